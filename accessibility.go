@@ -0,0 +1,171 @@
+package playwright
+
+import "fmt"
+
+// AccessibilityNode is a node of the accessibility tree returned by
+// Accessibility.Snapshot(). Properties which do not apply to the node have
+// their zero value.
+type AccessibilityNode struct {
+	// The role, e.g. "button" or "heading".
+	Role string
+	// A human readable name of the node.
+	Name string
+	// The current value of the node, a string or a float64.
+	Value interface{}
+	// An additional human readable description of the node.
+	Description string
+	// Keyboard shortcuts associated with the node.
+	KeyShortcuts string
+	// A human readable alternative to the role.
+	RoleDescription string
+	// A description of the current value.
+	ValueText string
+	Disabled  bool
+	// Whether the node is expanded or collapsed, nil when it can not be expanded.
+	Expanded        *bool
+	Focused         bool
+	Modal           bool
+	Multiline       bool
+	Multiselectable bool
+	Readonly        bool
+	Required        bool
+	Selected        bool
+	// Whether the checkbox is checked, MixedStateOn, MixedStateOff or
+	// MixedStateMixed, nil for nodes which can not be checked.
+	Checked *MixedState
+	// Whether the toggle button is pressed, MixedStateOn, MixedStateOff or
+	// MixedStateMixed, nil for nodes which can not be pressed.
+	Pressed *MixedState
+	// The level of a heading.
+	Level int
+	// The minimum and maximum value of a range, e.g. of a slider.
+	ValueMin float64
+	ValueMax float64
+	// What kind of autocomplete is supported by a control.
+	AutoComplete string
+	// What kind of popup is currently being shown for a node.
+	HasPopup string
+	// Whether and in what way the node's value is invalid.
+	Invalid string
+	// Whether the node is oriented horizontally or vertically.
+	Orientation string
+	Children    []*AccessibilityNode
+}
+
+type accessibilityImpl struct {
+	channel *channel
+}
+
+func newAccessibility(channel *channel) *accessibilityImpl {
+	return &accessibilityImpl{
+		channel: channel,
+	}
+}
+
+func (a *accessibilityImpl) Snapshot(options ...AccessibilitySnapshotOptions) (*AccessibilityNode, error) {
+	params := map[string]interface{}{}
+	if len(options) == 1 {
+		if options[0].InterestingOnly != nil {
+			params["interestingOnly"] = *options[0].InterestingOnly
+		}
+		if options[0].Root != nil {
+			params["root"] = options[0].Root.(*elementHandleImpl).channel
+		}
+	}
+	result, err := a.channel.Send("accessibilitySnapshot", params)
+	if err != nil {
+		return nil, fmt.Errorf("could not get accessibility snapshot: %w", err)
+	}
+	node, _ := result.(map[string]interface{})
+	if node == nil {
+		return nil, nil
+	}
+	return parseAccessibilityNode(node), nil
+}
+
+func parseAccessibilityNode(node map[string]interface{}) *AccessibilityNode {
+	str := func(key string) string {
+		value, _ := node[key].(string)
+		return value
+	}
+	flag := func(key string) bool {
+		value, _ := node[key].(bool)
+		return value
+	}
+	number := func(key string) float64 {
+		value, _ := node[key].(float64)
+		return value
+	}
+	result := &AccessibilityNode{
+		Role:            str("role"),
+		Name:            str("name"),
+		Description:     str("description"),
+		KeyShortcuts:    str("keyshortcuts"),
+		RoleDescription: str("roledescription"),
+		ValueText:       str("valuetext"),
+		Disabled:        flag("disabled"),
+		Focused:         flag("focused"),
+		Modal:           flag("modal"),
+		Multiline:       flag("multiline"),
+		Multiselectable: flag("multiselectable"),
+		Readonly:        flag("readonly"),
+		Required:        flag("required"),
+		Selected:        flag("selected"),
+		Checked:         parseAccessibilityMixedState(str("checked"), "checked", "unchecked"),
+		Pressed:         parseAccessibilityMixedState(str("pressed"), "pressed", "released"),
+		Level:           int(number("level")),
+		ValueMin:        number("valuemin"),
+		ValueMax:        number("valuemax"),
+		AutoComplete:    str("autocomplete"),
+		HasPopup:        str("haspopup"),
+		Invalid:         str("invalid"),
+		Orientation:     str("orientation"),
+	}
+	if value, ok := node["valueString"]; ok {
+		result.Value = value
+	} else if value, ok := node["valueNumber"]; ok {
+		result.Value = value
+	}
+	if expanded, ok := node["expanded"].(bool); ok {
+		result.Expanded = Bool(expanded)
+	}
+	if children, ok := node["children"].([]interface{}); ok {
+		for _, child := range children {
+			if child, ok := child.(map[string]interface{}); ok {
+				result.Children = append(result.Children, parseAccessibilityNode(child))
+			}
+		}
+	}
+	return result
+}
+
+// parseAccessibilityMixedState converts the tri-state of the checked and
+// pressed properties.
+func parseAccessibilityMixedState(state, on, off string) *MixedState {
+	switch state {
+	case on:
+		return MixedStateOn
+	case off:
+		return MixedStateOff
+	case "mixed":
+		return MixedStateMixed
+	}
+	return nil
+}
+
+// Find returns the first node of the tree, in depth-first order, for which
+// match returns true, or nil.
+func (n *AccessibilityNode) Find(match func(node *AccessibilityNode) bool) *AccessibilityNode {
+	if n == nil {
+		return nil
+	}
+	if match(n) {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessibilityNode(t *testing.T) {
+	node := parseAccessibilityNode(map[string]interface{}{
+		"role": "WebArea",
+		"name": "Accessibility",
+		"children": []interface{}{
+			map[string]interface{}{"role": "heading", "name": "Title", "level": float64(2)},
+			map[string]interface{}{"role": "checkbox", "name": "Agree", "checked": "mixed"},
+			map[string]interface{}{"role": "button", "name": "Menu", "pressed": "released", "expanded": false},
+			map[string]interface{}{"role": "slider", "valueNumber": float64(5), "valuemin": float64(0), "valuemax": float64(10)},
+			map[string]interface{}{"role": "textbox", "valueString": "hello", "focused": true},
+		},
+	})
+	require.Equal(t, "WebArea", node.Role)
+	require.Len(t, node.Children, 5)
+	require.Equal(t, 2, node.Children[0].Level)
+	require.Equal(t, MixedStateMixed, node.Children[1].Checked)
+	require.Nil(t, node.Children[1].Pressed)
+	require.Equal(t, MixedStateOff, node.Children[2].Pressed)
+	require.Equal(t, Bool(false), node.Children[2].Expanded)
+	require.Nil(t, node.Children[0].Expanded)
+	require.Equal(t, float64(5), node.Children[3].Value)
+	require.Equal(t, float64(10), node.Children[3].ValueMax)
+	require.Equal(t, "hello", node.Children[4].Value)
+	require.True(t, node.Children[4].Focused)
+
+	found := node.Find(func(node *AccessibilityNode) bool {
+		return node.Role == "checkbox"
+	})
+	require.Equal(t, "Agree", found.Name)
+	require.Nil(t, node.Find(func(node *AccessibilityNode) bool {
+		return node.Role == "link"
+	}))
+}
@@ -0,0 +1,707 @@
+package playwright
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxRedirects is the number of redirects a request follows when
+// MaxRedirects is not set.
+const defaultMaxRedirects = 20
+
+type apiRequestImpl struct{}
+
+func (r *apiRequestImpl) NewContext(options ...APIRequestNewContextOptions) (APIRequestContext, error) {
+	option := APIRequestNewContextOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	storageState := option.StorageState
+	if option.StorageStatePath != nil {
+		content, err := ioutil.ReadFile(*option.StorageStatePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read storage state file: %w", err)
+		}
+		storageState = &StorageState{}
+		if err := json.Unmarshal(content, storageState); err != nil {
+			return nil, fmt.Errorf("could not parse storage state file: %w", err)
+		}
+	}
+	jar := &apiCookieJar{}
+	var origins []OriginsState
+	if storageState != nil {
+		if err := jar.add(storageState.Cookies); err != nil {
+			return nil, err
+		}
+		origins = storageState.Origins
+	}
+	headers := option.ExtraHttpHeaders
+	timeout := float64(defaultTimeout)
+	if option.Timeout != nil {
+		timeout = *option.Timeout
+	}
+	request := newAPIRequestContext(jar, option.IgnoreHttpsErrors != nil && *option.IgnoreHttpsErrors)
+	request.baseURL = option.BaseURL
+	request.userAgent = option.UserAgent
+	if option.HttpCredentials != nil {
+		request.username = option.HttpCredentials.Username
+		request.password = option.HttpCredentials.Password
+	}
+	request.headers = func(string) map[string]string {
+		return headers
+	}
+	request.timeout = func() float64 {
+		return timeout
+	}
+	request.storageState = func() (*StorageState, error) {
+		return &StorageState{
+			Cookies: jar.all(),
+			Origins: origins,
+		}, nil
+	}
+	return request, nil
+}
+
+// Request returns the request context of the browser context, its requests
+// use and update the cookies of the browser context.
+func (b *browserContextImpl) Request() APIRequestContext {
+	b.Lock()
+	defer b.Unlock()
+	if b.request != nil {
+		return b.request
+	}
+	options := b.options
+	if options == nil {
+		options = &BrowserNewContextOptions{}
+	}
+	request := newAPIRequestContext(&contextCookieJar{context: b}, options.IgnoreHttpsErrors != nil && *options.IgnoreHttpsErrors)
+	request.baseURL = options.BaseURL
+	request.userAgent = options.UserAgent
+	if options.HttpCredentials != nil {
+		request.username = options.HttpCredentials.Username
+		request.password = options.HttpCredentials.Password
+	}
+	request.headers = func(url string) map[string]string {
+		b.Lock()
+		defer b.Unlock()
+		headers := b.extraHTTPHeaders
+		if headers == nil {
+			headers = options.ExtraHttpHeaders
+		}
+		return mergeHeaders(headers, scopedHeadersForURL(b.scopedHeaders, url))
+	}
+	request.timeout = b.timeoutSettings.Timeout
+	request.storageState = func() (*StorageState, error) {
+		return b.StorageState()
+	}
+	b.request = request
+	return request
+}
+
+func (p *pageImpl) Request() APIRequestContext {
+	return p.browserContext.Request()
+}
+
+type apiRequestContextImpl struct {
+	sync.Mutex
+	client       *http.Client
+	baseURL      *string
+	userAgent    *string
+	username     *string
+	password     *string
+	headers      func(url string) map[string]string
+	timeout      func() float64
+	storageState func() (*StorageState, error)
+	disposed     bool
+}
+
+func newAPIRequestContext(jar http.CookieJar, ignoreHTTPSErrors bool) *apiRequestContextImpl {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if ignoreHTTPSErrors {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &apiRequestContextImpl{
+		client: &http.Client{
+			Transport: transport,
+			Jar:       jar,
+		},
+	}
+}
+
+func (r *apiRequestContextImpl) Delete(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodDelete, url, options)
+}
+
+func (r *apiRequestContextImpl) Get(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodGet, url, options)
+}
+
+func (r *apiRequestContextImpl) Head(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodHead, url, options)
+}
+
+func (r *apiRequestContextImpl) Patch(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodPatch, url, options)
+}
+
+func (r *apiRequestContextImpl) Post(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodPost, url, options)
+}
+
+func (r *apiRequestContextImpl) Put(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	return r.fetchWithMethod(http.MethodPut, url, options)
+}
+
+func (r *apiRequestContextImpl) fetchWithMethod(method string, url string, options []APIRequestContextFetchOptions) (APIResponse, error) {
+	option := APIRequestContextFetchOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	option.Method = String(method)
+	return r.Fetch(url, option)
+}
+
+func (r *apiRequestContextImpl) Fetch(url string, options ...APIRequestContextFetchOptions) (APIResponse, error) {
+	option := APIRequestContextFetchOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	r.Lock()
+	disposed := r.disposed
+	r.Unlock()
+	if disposed {
+		return nil, errors.New("request context has been disposed")
+	}
+	requestURL, err := resolveAPIRequestURL(r.baseURL, url, option.Params)
+	if err != nil {
+		return nil, err
+	}
+	method := http.MethodGet
+	if option.Method != nil {
+		method = strings.ToUpper(*option.Method)
+	}
+	body, contentType, err := serializeAPIRequestBody(option)
+	if err != nil {
+		return nil, err
+	}
+	timeout := r.timeout()
+	if option.Timeout != nil {
+		timeout = *option.Timeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Duration(timeout)*time.Millisecond)
+	}
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	headers := r.headers(requestURL)
+	if contentType != "" {
+		headers = mergeHeaders(map[string]string{"Content-Type": contentType}, headers)
+	}
+	if r.userAgent != nil {
+		headers = mergeHeaders(headers, map[string]string{"User-Agent": *r.userAgent})
+	}
+	for name, value := range mergeHeaders(headers, option.Headers) {
+		request.Header.Set(name, value)
+	}
+	if r.username != nil {
+		password := ""
+		if r.password != nil {
+			password = *r.password
+		}
+		request.SetBasicAuth(*r.username, password)
+	}
+	maxRedirects := defaultMaxRedirects
+	if option.MaxRedirects != nil {
+		maxRedirects = *option.MaxRedirects
+	}
+	client := *r.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if maxRedirects == 0 {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > maxRedirects {
+			return errors.New("max redirect count exceeded")
+		}
+		return nil
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("request to %s failed: Timeout %.0fms exceeded", requestURL, timeout)
+		}
+		return nil, fmt.Errorf("request to %s failed: %w", requestURL, err)
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+	result := newAPIResponse(response, responseBody)
+	if option.FailOnStatusCode != nil && *option.FailOnStatusCode && result.Status() >= 400 {
+		return nil, fmt.Errorf("%d %s", result.Status(), result.StatusText())
+	}
+	return result, nil
+}
+
+func (r *apiRequestContextImpl) Dispose() error {
+	r.Lock()
+	defer r.Unlock()
+	r.disposed = true
+	r.client.CloseIdleConnections()
+	return nil
+}
+
+func (r *apiRequestContextImpl) StorageState(path ...string) (*StorageState, error) {
+	storageState, err := r.storageState()
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 1 {
+		if err := writeStorageState(path[0], storageState); err != nil {
+			return nil, err
+		}
+	}
+	return storageState, nil
+}
+
+// resolveAPIRequestURL resolves in against the base URL and adds the query
+// parameters.
+func resolveAPIRequestURL(baseURL *string, in string, params map[string]interface{}) (string, error) {
+	parsed, err := url.Parse(in)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if baseURL != nil {
+		base, err := url.Parse(*baseURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid baseURL: %w", err)
+		}
+		parsed = base.ResolveReference(parsed)
+	}
+	if !parsed.IsAbs() {
+		return "", fmt.Errorf("invalid URL '%s': relative URLs require a baseURL", in)
+	}
+	if len(params) > 0 {
+		query := parsed.Query()
+		for name, values := range formValues(params) {
+			query[name] = values
+		}
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String(), nil
+}
+
+// serializeAPIRequestBody returns the request body and its default content
+// type. Strings and bytes get sent as they are, other data gets encoded as
+// JSON.
+func serializeAPIRequestBody(option APIRequestContextFetchOptions) ([]byte, string, error) {
+	bodies := 0
+	for _, set := range []bool{option.Data != nil, option.Form != nil, option.Multipart != nil} {
+		if set {
+			bodies++
+		}
+	}
+	if bodies > 1 {
+		return nil, "", errors.New("only one of Data, Form or Multipart can be specified")
+	}
+	switch {
+	case option.Form != nil:
+		return []byte(formValues(option.Form).Encode()), "application/x-www-form-urlencoded", nil
+	case option.Multipart != nil:
+		return serializeMultipart(option.Multipart)
+	}
+	switch data := option.Data.(type) {
+	case nil:
+		return nil, "", nil
+	case string:
+		return []byte(data), "", nil
+	case []byte:
+		return data, "", nil
+	default:
+		body, err := json.Marshal(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not serialize data: %w", err)
+		}
+		return body, "application/json", nil
+	}
+}
+
+// formValues converts query parameters or form fields to url.Values, slices
+// add a value per element.
+func formValues(fields map[string]interface{}) url.Values {
+	values := url.Values{}
+	for name, value := range fields {
+		switch v := value.(type) {
+		case []string:
+			values[name] = append([]string{}, v...)
+		case []interface{}:
+			for _, element := range v {
+				values.Add(name, fmt.Sprint(element))
+			}
+		default:
+			values.Set(name, fmt.Sprint(v))
+		}
+	}
+	return values
+}
+
+// serializeMultipart encodes the fields as multipart/form-data. Files can be
+// given as InputFile, as *os.File which gets read to the end or as a slice
+// of InputFile for multiple files of the same field, other values get
+// formatted as text fields.
+func serializeMultipart(fields map[string]interface{}) ([]byte, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var files []InputFile
+		switch field := fields[name].(type) {
+		case InputFile:
+			files = []InputFile{field}
+		case []InputFile:
+			files = field
+		case *os.File:
+			content, err := ioutil.ReadAll(field)
+			if err != nil {
+				return nil, "", fmt.Errorf("could not read multipart file '%s': %w", name, err)
+			}
+			files = []InputFile{{Name: filepath.Base(field.Name()), Buffer: content}}
+		case nil:
+			return nil, "", fmt.Errorf("multipart field '%s' must not be nil", name)
+		default:
+			if err := writer.WriteField(name, fmt.Sprint(field)); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		for _, file := range files {
+			if err := writeMultipartFile(writer, name, file); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+func writeMultipartFile(writer *multipart.Writer, name string, file InputFile) error {
+	mimeType := file.MimeType
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(file.Name))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(name), escapeQuotes(file.Name)))
+	header.Set("Content-Type", mimeType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, bytes.NewReader(file.Buffer))
+	return err
+}
+
+func escapeQuotes(s string) string {
+	return strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace(s)
+}
+
+type apiResponseImpl struct {
+	url        string
+	status     int
+	statusText string
+	headers    map[string]string
+	body       []byte
+}
+
+func newAPIResponse(response *http.Response, body []byte) *apiResponseImpl {
+	headers := make(map[string]string, len(response.Header))
+	for name, values := range response.Header {
+		separator := ", "
+		if strings.EqualFold(name, "set-cookie") {
+			separator = "\n"
+		}
+		headers[strings.ToLower(name)] = strings.Join(values, separator)
+	}
+	return &apiResponseImpl{
+		url:        response.Request.URL.String(),
+		status:     response.StatusCode,
+		statusText: strings.TrimPrefix(response.Status, strconv.Itoa(response.StatusCode)+" "),
+		headers:    headers,
+		body:       body,
+	}
+}
+
+func (r *apiResponseImpl) Body() ([]byte, error) {
+	return r.body, nil
+}
+
+func (r *apiResponseImpl) Headers() map[string]string {
+	return r.headers
+}
+
+func (r *apiResponseImpl) JSON(v interface{}) error {
+	return json.Unmarshal(r.body, v)
+}
+
+func (r *apiResponseImpl) Ok() bool {
+	return r.status >= 200 && r.status <= 299
+}
+
+func (r *apiResponseImpl) Status() int {
+	return r.status
+}
+
+func (r *apiResponseImpl) StatusText() string {
+	return r.statusText
+}
+
+func (r *apiResponseImpl) Text() (string, error) {
+	return string(r.body), nil
+}
+
+func (r *apiResponseImpl) URL() string {
+	return r.url
+}
+
+// apiCookieJar stores the cookies of a request context which is not bound to
+// a browser context. Unlike cookiejar.Jar it can list its cookies, which is
+// needed for the storage state.
+type apiCookieJar struct {
+	sync.Mutex
+	cookies []Cookie
+}
+
+func (j *apiCookieJar) add(cookies []Cookie) error {
+	j.Lock()
+	defer j.Unlock()
+	for _, cookie := range cookies {
+		if cookie.URL != "" {
+			parsed, err := url.Parse(cookie.URL)
+			if err != nil {
+				return fmt.Errorf("invalid cookie URL: %w", err)
+			}
+			if cookie.Domain == "" {
+				cookie.Domain = parsed.Hostname()
+			}
+			if cookie.Path == "" {
+				cookie.Path = "/"
+			}
+			cookie.Secure = cookie.Secure || parsed.Scheme == "https"
+			cookie.URL = ""
+		}
+		if cookie.Domain == "" || cookie.Path == "" {
+			return fmt.Errorf("cookie '%s' should have a url or a domain and a path", cookie.Name)
+		}
+		j.set(cookie)
+	}
+	return nil
+}
+
+// set replaces the cookie with the same name, domain and path, expired
+// cookies only get removed.
+func (j *apiCookieJar) set(cookie Cookie) {
+	cookies := j.cookies[:0]
+	for _, existing := range j.cookies {
+		if existing.Name != cookie.Name || existing.Domain != cookie.Domain || existing.Path != cookie.Path {
+			cookies = append(cookies, existing)
+		}
+	}
+	j.cookies = cookies
+	if !cookieExpired(cookie) {
+		j.cookies = append(j.cookies, cookie)
+	}
+}
+
+func (j *apiCookieJar) all() []Cookie {
+	j.Lock()
+	defer j.Unlock()
+	cookies := make([]Cookie, 0, len(j.cookies))
+	for _, cookie := range j.cookies {
+		if !cookieExpired(cookie) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	return cookies
+}
+
+func (j *apiCookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.Lock()
+	defer j.Unlock()
+	host := strings.ToLower(u.Hostname())
+	for _, cookie := range cookies {
+		stored := Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   host,
+			Path:     cookie.Path,
+			Expires:  -1,
+			HttpOnly: cookie.HttpOnly,
+			Secure:   cookie.Secure,
+			SameSite: sameSiteName(cookie.SameSite),
+		}
+		if cookie.Domain != "" {
+			domain := strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+			if host != domain && !strings.HasSuffix(host, "."+domain) {
+				continue
+			}
+			stored.Domain = "." + domain
+		}
+		if stored.Path == "" || !strings.HasPrefix(stored.Path, "/") {
+			stored.Path = defaultCookiePath(u.Path)
+		}
+		switch {
+		case cookie.MaxAge < 0:
+			stored.Expires = 0
+		case cookie.MaxAge > 0:
+			stored.Expires = float64(time.Now().Unix() + int64(cookie.MaxAge))
+		case !cookie.Expires.IsZero():
+			stored.Expires = float64(cookie.Expires.Unix())
+		}
+		j.set(stored)
+	}
+}
+
+func (j *apiCookieJar) Cookies(u *url.URL) []*http.Cookie {
+	j.Lock()
+	defer j.Unlock()
+	var cookies []*http.Cookie
+	for _, cookie := range j.cookies {
+		if !cookieExpired(cookie) && cookieMatchesURL(cookie, u) {
+			cookies = append(cookies, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
+	return cookies
+}
+
+func cookieExpired(cookie Cookie) bool {
+	return cookie.Expires != -1 && cookie.Expires <= float64(time.Now().Unix())
+}
+
+func cookieMatchesURL(cookie Cookie, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	if strings.HasPrefix(cookie.Domain, ".") {
+		if host != cookie.Domain[1:] && !strings.HasSuffix(host, cookie.Domain) {
+			return false
+		}
+	} else if host != cookie.Domain {
+		return false
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	if path != cookie.Path && !strings.HasPrefix(path, strings.TrimSuffix(cookie.Path, "/")+"/") {
+		return false
+	}
+	return !cookie.Secure || u.Scheme == "https"
+}
+
+// defaultCookiePath returns the directory of the request path, see RFC 6265
+// section 5.1.4.
+func defaultCookiePath(path string) string {
+	index := strings.LastIndex(path, "/")
+	if index <= 0 {
+		return "/"
+	}
+	return path[:index]
+}
+
+func sameSiteName(sameSite http.SameSite) string {
+	switch sameSite {
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return "Lax"
+	}
+}
+
+// contextCookieJar reads and writes the cookies of a browser context, so that
+// its request context and its pages share the same session.
+type contextCookieJar struct {
+	context *browserContextImpl
+}
+
+func (j *contextCookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	params := make([]SetNetworkCookieParam, 0, len(cookies))
+	for _, cookie := range cookies {
+		domain := u.Hostname()
+		if cookie.Domain != "" {
+			domain = "." + strings.TrimPrefix(cookie.Domain, ".")
+		}
+		path := cookie.Path
+		if path == "" || !strings.HasPrefix(path, "/") {
+			path = defaultCookiePath(u.Path)
+		}
+		param := SetNetworkCookieParam{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   String(domain),
+			Path:     String(path),
+			HttpOnly: Bool(cookie.HttpOnly),
+			Secure:   Bool(cookie.Secure),
+			SameSite: String(sameSiteName(cookie.SameSite)),
+		}
+		switch {
+		case cookie.MaxAge < 0:
+			// An expiry in the past makes the browser delete the cookie.
+			param.Expires = Int(1)
+		case cookie.MaxAge > 0:
+			param.Expires = Int(int(time.Now().Unix()) + cookie.MaxAge)
+		case !cookie.Expires.IsZero():
+			param.Expires = Int(int(cookie.Expires.Unix()))
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return
+	}
+	if err := j.context.AddCookies(params...); err != nil {
+		log.Printf("could not store cookies of %s in the browser context: %v", u, err)
+	}
+}
+
+func (j *contextCookieJar) Cookies(u *url.URL) []*http.Cookie {
+	cookies, err := j.context.Cookies(u.String())
+	if err != nil {
+		log.Printf("could not read cookies for %s from the browser context: %v", u, err)
+		return nil
+	}
+	result := make([]*http.Cookie, 0, len(cookies))
+	for _, cookie := range cookies {
+		result = append(result, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	return result
+}
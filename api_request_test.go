@@ -0,0 +1,160 @@
+package playwright
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAPIRequestURL(t *testing.T) {
+	resolved, err := resolveAPIRequestURL(String("http://localhost:8080/api/"), "users", map[string]interface{}{"page": 2})
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080/api/users?page=2", resolved)
+	resolved, err = resolveAPIRequestURL(nil, "https://example.com/a?b=c&d=e", map[string]interface{}{"b": []string{"x", "y"}})
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/a?b=x&b=y&d=e", resolved)
+	_, err = resolveAPIRequestURL(nil, "/users", nil)
+	require.EqualError(t, err, "invalid URL '/users': relative URLs require a baseURL")
+}
+
+func TestSerializeAPIRequestBody(t *testing.T) {
+	body, contentType, err := serializeAPIRequestBody(APIRequestContextFetchOptions{Data: map[string]interface{}{"name": "foo"}})
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"foo"}`, string(body))
+	require.Equal(t, "application/json", contentType)
+	body, contentType, err = serializeAPIRequestBody(APIRequestContextFetchOptions{Data: "raw"})
+	require.NoError(t, err)
+	require.Equal(t, "raw", string(body))
+	require.Equal(t, "", contentType)
+	body, contentType, err = serializeAPIRequestBody(APIRequestContextFetchOptions{Form: map[string]interface{}{"a": "b c", "n": 1, "tags": []string{"x", "y"}}})
+	require.NoError(t, err)
+	require.Equal(t, "a=b+c&n=1&tags=x&tags=y", string(body))
+	require.Equal(t, "application/x-www-form-urlencoded", contentType)
+	_, _, err = serializeAPIRequestBody(APIRequestContextFetchOptions{Data: "raw", Form: map[string]interface{}{}})
+	require.EqualError(t, err, "only one of Data, Form or Multipart can be specified")
+	_, _, err = serializeAPIRequestBody(APIRequestContextFetchOptions{Multipart: map[string]interface{}{"a": nil}})
+	require.EqualError(t, err, "multipart field 'a' must not be nil")
+}
+
+func TestSerializeMultipart(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "upload-*.txt")
+	require.NoError(t, err)
+	_, err = file.WriteString("from disk")
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	defer file.Close()
+
+	body, contentType, err := serializeAPIRequestBody(APIRequestContextFetchOptions{Multipart: map[string]interface{}{
+		"name":   "foo",
+		"count":  3,
+		"avatar": InputFile{Name: "avatar.png", Buffer: []byte("png")},
+		"docs":   []InputFile{{Name: "a.json", MimeType: "text/plain", Buffer: []byte("a")}, {Name: "b", Buffer: []byte("b")}},
+		"file":   file,
+	}})
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.Equal(t, "multipart/form-data", mediaType)
+	form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"name": {"foo"}, "count": {"3"}}, form.Value)
+	read := func(header *multipart.FileHeader) string {
+		f, err := header.Open()
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		return string(content)
+	}
+	require.Equal(t, "avatar.png", form.File["avatar"][0].Filename)
+	require.Equal(t, "image/png", form.File["avatar"][0].Header.Get("Content-Type"))
+	require.Len(t, form.File["docs"], 2)
+	require.Equal(t, "text/plain", form.File["docs"][0].Header.Get("Content-Type"))
+	require.Equal(t, "application/octet-stream", form.File["docs"][1].Header.Get("Content-Type"))
+	require.Equal(t, "b", read(form.File["docs"][1]))
+	require.Equal(t, filepath.Base(file.Name()), form.File["file"][0].Filename)
+	require.Equal(t, "from disk", read(form.File["file"][0]))
+}
+
+func TestAPICookieJar(t *testing.T) {
+	jar := &apiCookieJar{}
+	require.NoError(t, jar.add([]Cookie{{Name: "seeded", Value: "1", URL: "http://example.com", Expires: -1}}))
+	u, err := url.Parse("http://api.example.com/users/42")
+	require.NoError(t, err)
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "abc", Domain: "example.com", Path: "/"},
+		{Name: "scoped", Value: "def"},
+		{Name: "foreign", Value: "ghi", Domain: "other.com"},
+		{Name: "secure", Value: "jkl", Secure: true},
+	})
+	names := func(raw string) []string {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		var result []string
+		for _, cookie := range jar.Cookies(u) {
+			result = append(result, cookie.Name)
+		}
+		return result
+	}
+	require.ElementsMatch(t, []string{"session", "scoped"}, names("http://api.example.com/users/43"))
+	require.ElementsMatch(t, []string{"session", "scoped", "secure"}, names("https://api.example.com/users"))
+	require.ElementsMatch(t, []string{"seeded", "session"}, names("http://example.com/"))
+	require.Equal(t, []string{"session"}, names("http://api.example.com/other"))
+
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Domain: "example.com", Path: "/", MaxAge: -1}})
+	require.Equal(t, []string{"seeded"}, names("http://example.com/"))
+	require.Len(t, jar.all(), 3)
+}
+
+func TestAPIRequestStorageStateFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret", Path: "/"})
+			return
+		}
+		if cookie, err := r.Cookie("session"); err == nil {
+			_, _ = w.Write([]byte(cookie.Value))
+		}
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), ".auth", "state.json")
+	origins := []OriginsState{{Origin: server.URL, LocalStorage: []LocalStorageEntry{{Name: "a", Value: "b"}}}}
+
+	request, err := (&apiRequestImpl{}).NewContext(APIRequestNewContextOptions{
+		StorageState: &StorageState{Origins: origins},
+	})
+	require.NoError(t, err)
+	_, err = request.Get(server.URL + "/login")
+	require.NoError(t, err)
+	state, err := request.StorageState(path)
+	require.NoError(t, err)
+	require.Len(t, state.Cookies, 1)
+	require.Equal(t, origins, state.Origins)
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), `"url"`)
+
+	reused, err := (&apiRequestImpl{}).NewContext(APIRequestNewContextOptions{
+		BaseURL:          String(server.URL),
+		StorageStatePath: String(path),
+	})
+	require.NoError(t, err)
+	response, err := reused.Get("/whoami")
+	require.NoError(t, err)
+	text, err := response.Text()
+	require.NoError(t, err)
+	require.Equal(t, "secret", text)
+	state, err = reused.StorageState()
+	require.NoError(t, err)
+	require.Equal(t, origins, state.Origins)
+}
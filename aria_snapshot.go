@@ -0,0 +1,404 @@
+package playwright
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ariaNode is a node of an ARIA snapshot, either of the accessibility tree
+// of an element or of an expected template.
+type ariaNode struct {
+	role string
+	name string
+	// namePattern matches the name of a template node, e.g. `/item \d+/`.
+	namePattern *regexp.Regexp
+	hasName     bool
+	attributes  map[string]string
+	// text is the inline text, the value of a textbox or the content of a text
+	// node.
+	text        string
+	textPattern *regexp.Regexp
+	children    []*ariaNode
+}
+
+// ariaAttributes are the attributes of a snapshot in the order they are
+// rendered in.
+var ariaAttributes = []string{"checked", "disabled", "expanded", "level", "pressed", "selected"}
+
+// ariaHoistedRoles are the roles of the containers which are left out of the
+// snapshot, their children take their place.
+var ariaHoistedRoles = map[string]bool{
+	"":                 true,
+	"generic":          true,
+	"GenericContainer": true,
+	"none":             true,
+	"presentation":     true,
+	"Section":          true,
+	"WebArea":          true,
+	"RootWebArea":      true,
+	"Iframe":           true,
+}
+
+// ariaNodes converts the accessibility tree of an element into the nodes of
+// its snapshot.
+func ariaNodes(node *AccessibilityNode) []*ariaNode {
+	if node == nil {
+		return nil
+	}
+	switch node.Role {
+	case "InlineTextBox", "LineBreak":
+		return nil
+	case "text", "StaticText":
+		text := normalizeWhiteSpace(node.Name)
+		if text == "" {
+			return nil
+		}
+		return []*ariaNode{{role: "text", text: text}}
+	}
+	children := []*ariaNode{}
+	for _, child := range node.Children {
+		for _, converted := range ariaNodes(child) {
+			// merge the text of adjacent text nodes
+			if last := len(children) - 1; last >= 0 && converted.role == "text" && children[last].role == "text" {
+				children[last].text += " " + converted.text
+				continue
+			}
+			children = append(children, converted)
+		}
+	}
+	if ariaHoistedRoles[node.Role] && node.Name == "" {
+		return children
+	}
+	result := &ariaNode{
+		role:       node.Role,
+		name:       normalizeWhiteSpace(node.Name),
+		hasName:    node.Name != "",
+		attributes: map[string]string{},
+	}
+	setMixed := func(name string, state *MixedState) {
+		switch state {
+		case MixedStateOn:
+			result.attributes[name] = "true"
+		case MixedStateMixed:
+			result.attributes[name] = "mixed"
+		}
+	}
+	setMixed("checked", node.Checked)
+	setMixed("pressed", node.Pressed)
+	if node.Disabled {
+		result.attributes["disabled"] = "true"
+	}
+	if node.Expanded != nil {
+		result.attributes["expanded"] = strconv.FormatBool(*node.Expanded)
+	}
+	if node.Level > 0 {
+		result.attributes["level"] = strconv.Itoa(node.Level)
+	}
+	if node.Selected {
+		result.attributes["selected"] = "true"
+	}
+	if value, ok := node.Value.(string); ok && value != "" {
+		result.text = normalizeWhiteSpace(value)
+	}
+	// text which only repeats the name, e.g. of a button, is left out
+	if len(children) == 1 && children[0].role == "text" && children[0].text == result.name {
+		children = nil
+	}
+	if len(children) == 1 && children[0].role == "text" && result.text == "" {
+		result.text = children[0].text
+		children = nil
+	}
+	result.children = children
+	return []*ariaNode{result}
+}
+
+func normalizeWhiteSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// renderAriaSnapshot renders nodes as a YAML list with a line per node, e.g.
+// `- heading "Title" [level=1]`. The line of a node with children ends with a
+// colon and its children follow indented by two spaces.
+func renderAriaSnapshot(nodes []*ariaNode) string {
+	lines := []string{}
+	var render func(nodes []*ariaNode, indent string)
+	render = func(nodes []*ariaNode, indent string) {
+		for _, node := range nodes {
+			line := indent + "- " + node.role
+			if node.hasName {
+				line += " " + strconv.Quote(node.name)
+			}
+			for _, attribute := range ariaAttributes {
+				value, ok := node.attributes[attribute]
+				if !ok {
+					continue
+				}
+				if value == "true" {
+					line += " [" + attribute + "]"
+				} else {
+					line += " [" + attribute + "=" + value + "]"
+				}
+			}
+			if len(node.children) > 0 {
+				lines = append(lines, line+":")
+				render(node.children, indent+"  ")
+				continue
+			}
+			if node.text != "" {
+				line += ": " + quoteAriaText(node.text)
+			}
+			lines = append(lines, line)
+		}
+	}
+	render(nodes, "")
+	return strings.Join(lines, "\n")
+}
+
+// quoteAriaText quotes texts which YAML would not read as plain strings.
+func quoteAriaText(text string) string {
+	if strings.ContainsAny(text, ":#\"'`{}[]|>&*!%@") || strings.HasPrefix(text, "-") || strings.HasPrefix(text, "/") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+var ariaTemplateLine = regexp.MustCompile(`^(\s*)- (.*)$`)
+
+// parseAriaTemplate parses the YAML list of an expected snapshot. Names and
+// texts can be regular expressions like `/item \d+/`, only the attributes
+// of the template get compared.
+func parseAriaTemplate(template string) ([]*ariaNode, error) {
+	type level struct {
+		indent int
+		nodes  *[]*ariaNode
+	}
+	root := []*ariaNode{}
+	stack := []level{{indent: -1, nodes: &root}}
+	var last *ariaNode
+	lastIndent := -1
+	for number, line := range strings.Split(template, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		match := ariaTemplateLine.FindStringSubmatch(strings.ReplaceAll(line, "\t", "  "))
+		if match == nil {
+			return nil, fmt.Errorf("invalid ARIA template line %d: expected '- role \"name\"', got %q", number+1, strings.TrimSpace(line))
+		}
+		indent := len(match[1])
+		node, err := parseAriaTemplateNode(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ARIA template line %d: %w", number+1, err)
+		}
+		if last != nil && indent > lastIndent {
+			stack = append(stack, level{indent: lastIndent, nodes: &last.children})
+		}
+		for len(stack) > 1 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].nodes
+		*parent = append(*parent, node)
+		last, lastIndent = node, indent
+	}
+	return root, nil
+}
+
+func parseAriaTemplateNode(line string) (*ariaNode, error) {
+	node := &ariaNode{attributes: map[string]string{}}
+	rest := strings.TrimSpace(line)
+	end := strings.IndexAny(rest, " :[")
+	if end == -1 {
+		end = len(rest)
+	}
+	node.role, rest = rest[:end], strings.TrimSpace(rest[end:])
+	if node.role == "" {
+		return nil, fmt.Errorf("missing role in %q", line)
+	}
+	if strings.HasPrefix(rest, `"`) || strings.HasPrefix(rest, "/") {
+		value, pattern, remaining, err := parseAriaValue(rest)
+		if err != nil {
+			return nil, err
+		}
+		node.hasName, node.name, node.namePattern = true, value, pattern
+		rest = strings.TrimSpace(remaining)
+	}
+	for strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated attribute in %q", line)
+		}
+		attribute := rest[1:end]
+		value := "true"
+		if i := strings.Index(attribute, "="); i != -1 {
+			attribute, value = attribute[:i], attribute[i+1:]
+		}
+		node.attributes[strings.TrimSpace(attribute)] = strings.TrimSpace(value)
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	if strings.HasPrefix(rest, ":") {
+		rest = strings.TrimSpace(rest[1:])
+		if rest != "" {
+			if strings.HasPrefix(rest, `"`) || strings.HasPrefix(rest, "/") {
+				value, pattern, _, err := parseAriaValue(rest)
+				if err != nil {
+					return nil, err
+				}
+				node.text, node.textPattern = value, pattern
+			} else {
+				node.text = normalizeWhiteSpace(rest)
+			}
+		}
+		rest = ""
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q", rest)
+	}
+	return node, nil
+}
+
+// parseAriaValue parses a quoted string or a /regular expression/ at the
+// start of s and returns the rest of it.
+func parseAriaValue(s string) (string, *regexp.Regexp, string, error) {
+	if strings.HasPrefix(s, "/") {
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+				continue
+			}
+			if s[i] == '/' {
+				pattern, err := regexp.Compile(s[1:i])
+				if err != nil {
+					return "", nil, "", err
+				}
+				return "", pattern, s[i+1:], nil
+			}
+		}
+		return "", nil, "", fmt.Errorf("unterminated regular expression %q", s)
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == '"' {
+			value, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", nil, "", err
+			}
+			return value, nil, s[i+1:], nil
+		}
+	}
+	return "", nil, "", fmt.Errorf("unterminated string %q", s)
+}
+
+// matchAriaNodes reports whether the template nodes match nodes in order,
+// nodes which are not part of the template are skipped.
+func matchAriaNodes(nodes, template []*ariaNode) bool {
+	i := 0
+	for _, expected := range template {
+		for i < len(nodes) && !matchAriaNode(nodes[i], expected) {
+			i++
+		}
+		if i == len(nodes) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func matchAriaNode(node, expected *ariaNode) bool {
+	if node.role != expected.role {
+		return false
+	}
+	if expected.namePattern != nil && !expected.namePattern.MatchString(node.name) {
+		return false
+	}
+	if expected.namePattern == nil && expected.hasName && node.name != normalizeWhiteSpace(expected.name) {
+		return false
+	}
+	for attribute, value := range expected.attributes {
+		actual, ok := node.attributes[attribute]
+		if !ok {
+			actual = "false"
+		}
+		if actual != value {
+			return false
+		}
+	}
+	if expected.text != "" || expected.textPattern != nil {
+		text := node.text
+		if text == "" {
+			texts := []string{}
+			for _, child := range node.children {
+				if child.role == "text" {
+					texts = append(texts, child.text)
+				}
+			}
+			text = strings.Join(texts, " ")
+		}
+		if expected.textPattern != nil && !expected.textPattern.MatchString(text) {
+			return false
+		}
+		if expected.textPattern == nil && text != normalizeWhiteSpace(expected.text) {
+			return false
+		}
+	}
+	return matchAriaNodes(node.children, expected.children)
+}
+
+// ariaSnapshotNodes returns the snapshot nodes of the element, including
+// the element itself.
+func ariaSnapshotNodes(element ElementHandle) ([]*ariaNode, error) {
+	frame, err := element.OwnerFrame()
+	if err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return nil, fmt.Errorf("could not get ARIA snapshot: element is not attached to a frame")
+	}
+	root, err := frame.Page().Accessibility().Snapshot(AccessibilitySnapshotOptions{
+		InterestingOnly: Bool(false),
+		Root:            element,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ariaNodes(root), nil
+}
+
+func (l *locatorImpl) AriaSnapshot(options ...LocatorAriaSnapshotOptions) (string, error) {
+	option := LocatorAriaSnapshotOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var snapshot string
+	err := l.withElement(func(element ElementHandle) error {
+		nodes, err := ariaSnapshotNodes(element)
+		if err != nil {
+			return err
+		}
+		snapshot = renderAriaSnapshot(nodes)
+		return nil
+	}, option.Timeout)
+	return snapshot, err
+}
+
+func (la *locatorAssertionsImpl) ToMatchAriaSnapshot(expected string, options ...LocatorAssertionsToMatchAriaSnapshotOptions) error {
+	option := LocatorAssertionsToMatchAriaSnapshotOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	template, err := parseAriaTemplate(expected)
+	if err != nil {
+		return err
+	}
+	return la.expect("to match ARIA snapshot", expected, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		nodes, err := ariaSnapshotNodes(element)
+		if err != nil {
+			return nil, false, err
+		}
+		return renderAriaSnapshot(nodes), matchAriaNodes(nodes, template), nil
+	})
+}
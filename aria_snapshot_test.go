@@ -0,0 +1,81 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ariaTestTree() *AccessibilityNode {
+	return &AccessibilityNode{
+		Role: "generic",
+		Children: []*AccessibilityNode{
+			{Role: "heading", Name: "Todo  list", Level: 1, Children: []*AccessibilityNode{
+				{Role: "StaticText", Name: "Todo list", Children: []*AccessibilityNode{{Role: "InlineTextBox", Name: "Todo list"}}},
+			}},
+			{Role: "list", Children: []*AccessibilityNode{
+				{Role: "listitem", Children: []*AccessibilityNode{{Role: "StaticText", Name: "Item 1"}}},
+				{Role: "listitem", Children: []*AccessibilityNode{
+					{Role: "generic", Children: []*AccessibilityNode{{Role: "StaticText", Name: "Item"}}},
+					{Role: "StaticText", Name: "2"},
+				}},
+			}},
+			{Role: "checkbox", Name: "Done", Checked: MixedStateOn},
+			{Role: "textbox", Name: "New item", Value: "milk: 2"},
+			{Role: "button", Name: "Add", Expanded: Bool(false)},
+		},
+	}
+}
+
+func TestRenderAriaSnapshot(t *testing.T) {
+	require.Equal(t, `- heading "Todo list" [level=1]
+- list:
+  - listitem: Item 1
+  - listitem: Item 2
+- checkbox "Done" [checked]
+- textbox "New item": "milk: 2"
+- button "Add" [expanded=false]`, renderAriaSnapshot(ariaNodes(ariaTestTree())))
+}
+
+func TestMatchAriaSnapshot(t *testing.T) {
+	nodes := ariaNodes(ariaTestTree())
+	for _, template := range []string{
+		renderAriaSnapshot(nodes),
+		`- heading "Todo list"`,
+		`
+		- list:
+		  - listitem: /Item \d/
+		  - listitem: Item 2
+		- button /Add|Save/`,
+		`- checkbox [checked=true]
+- button [expanded=false]`,
+		`- heading [level=1]
+- textbox "New item": "milk: 2"`,
+	} {
+		parsed, err := parseAriaTemplate(template)
+		require.NoError(t, err, template)
+		require.True(t, matchAriaNodes(nodes, parsed), template)
+	}
+	for _, template := range []string{
+		`- heading "Todo"`,
+		`- heading [level=2]`,
+		`- button "Add"
+- checkbox "Done"`,
+		`- list:
+  - listitem: Item 3`,
+		`- checkbox "Done" [checked=false]`,
+	} {
+		parsed, err := parseAriaTemplate(template)
+		require.NoError(t, err, template)
+		require.False(t, matchAriaNodes(nodes, parsed), template)
+	}
+}
+
+func TestParseAriaTemplateErrors(t *testing.T) {
+	_, err := parseAriaTemplate(`heading "Title"`)
+	require.EqualError(t, err, `invalid ARIA template line 1: expected '- role "name"', got "heading \"Title\""`)
+	_, err = parseAriaTemplate(`- heading "Title`)
+	require.Error(t, err)
+	_, err = parseAriaTemplate(`- heading /(/`)
+	require.Error(t, err)
+}
@@ -0,0 +1,784 @@
+package playwright
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// assertionsDefaultTimeout is the default time in milliseconds an assertion
+// retries until it fails.
+const assertionsDefaultTimeout = 5000
+
+// assertionsPollInterval is the interval in which an assertion re-reads the
+// state of the element.
+const assertionsPollInterval = 100 * time.Millisecond
+
+type playwrightAssertionsImpl struct {
+	defaultTimeout float64
+}
+
+// NewPlaywrightAssertions creates assertions which retry for `timeout`
+// milliseconds until they fail, defaults to 5 seconds.
+func NewPlaywrightAssertions(timeout ...float64) PlaywrightAssertions {
+	defaultTimeout := float64(assertionsDefaultTimeout)
+	if len(timeout) == 1 {
+		defaultTimeout = timeout[0]
+	}
+	return &playwrightAssertionsImpl{defaultTimeout}
+}
+
+func (pa *playwrightAssertionsImpl) Locator(locator Locator) LocatorAssertions {
+	return &locatorAssertionsImpl{
+		locator:        locator.(*locatorImpl),
+		defaultTimeout: pa.defaultTimeout,
+	}
+}
+
+func (pa *playwrightAssertionsImpl) Page(page Page) PageAssertions {
+	return &pageAssertionsImpl{
+		page:           page.(*pageImpl),
+		defaultTimeout: pa.defaultTimeout,
+	}
+}
+
+type locatorAssertionsImpl struct {
+	locator        *locatorImpl
+	defaultTimeout float64
+	isNot          bool
+}
+
+func (la *locatorAssertionsImpl) Not() LocatorAssertions {
+	return &locatorAssertionsImpl{
+		locator:        la.locator,
+		defaultTimeout: la.defaultTimeout,
+		isNot:          !la.isNot,
+	}
+}
+
+func (la *locatorAssertionsImpl) ToHaveAccessibleName(name interface{}, options ...LocatorAssertionsToHaveAccessibleNameOptions) error {
+	option := LocatorAssertionsToHaveAccessibleNameOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectAccessibility("accessible name", name, option.IgnoreCase, option.Timeout, func(node map[string]interface{}) string {
+		return accessibilityProperty(node, "name")
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveAccessibleDescription(description interface{}, options ...LocatorAssertionsToHaveAccessibleDescriptionOptions) error {
+	option := LocatorAssertionsToHaveAccessibleDescriptionOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectAccessibility("accessible description", description, option.IgnoreCase, option.Timeout, func(node map[string]interface{}) string {
+		return accessibilityProperty(node, "description")
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveRole(role string, options ...LocatorAssertionsToHaveRoleOptions) error {
+	option := LocatorAssertionsToHaveRoleOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectAccessibility("role", role, nil, option.Timeout, func(node map[string]interface{}) string {
+		return accessibilityProperty(node, "role")
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveCSS(name string, value interface{}, options ...LocatorAssertionsToHaveCSSOptions) error {
+	option := LocatorAssertionsToHaveCSSOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(value, false)
+	if err != nil {
+		return err
+	}
+	return la.expect(fmt.Sprintf("to have CSS property '%s'", name), value, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		actual, err := element.Evaluate("(element, name) => window.getComputedStyle(element).getPropertyValue(name)", name)
+		if err != nil {
+			return nil, false, err
+		}
+		return actual, matches(actual.(string)), nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveClass(expected interface{}, options ...LocatorAssertionsToHaveClassOptions) error {
+	option := LocatorAssertionsToHaveClassOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(expected, false)
+	if err != nil {
+		return err
+	}
+	return la.expect("to have class", expected, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		actual, err := element.Evaluate("element => element.className")
+		if err != nil {
+			return nil, false, err
+		}
+		class, _ := actual.(string)
+		return class, matches(class), nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveValue(value interface{}, options ...LocatorAssertionsToHaveValueOptions) error {
+	option := LocatorAssertionsToHaveValueOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(value, false)
+	if err != nil {
+		return err
+	}
+	return la.expect("to have value", value, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		actual, err := element.InputValue()
+		if err != nil {
+			return nil, false, err
+		}
+		return actual, matches(actual), nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveValues(values []interface{}, options ...LocatorAssertionsToHaveValuesOptions) error {
+	option := LocatorAssertionsToHaveValuesOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matchers := make([]func(actual string) bool, len(values))
+	for i, value := range values {
+		matches, err := newTextMatcher(value, false)
+		if err != nil {
+			return err
+		}
+		matchers[i] = matches
+	}
+	return la.expect("to have values", values, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		result, err := element.Evaluate(`element => {
+			if (element.nodeName !== 'SELECT' || !element.multiple)
+				throw new Error('Not a select element with a multiple attribute');
+			return [...element.selectedOptions].map(option => option.value);
+		}`)
+		if err != nil {
+			return nil, false, err
+		}
+		actual := transformToStringList(result)
+		if len(actual) != len(matchers) {
+			return actual, false, nil
+		}
+		for i, matches := range matchers {
+			if !matches(actual[i]) {
+				return actual, false, nil
+			}
+		}
+		return actual, true, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeInViewport(options ...LocatorAssertionsToBeInViewportOptions) error {
+	option := LocatorAssertionsToBeInViewportOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var expected interface{} = "ratio > 0"
+	if option.Ratio != nil {
+		expected = fmt.Sprintf("ratio >= %v", *option.Ratio)
+	}
+	return la.expect("to be in viewport with", expected, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		result, err := element.Evaluate(`element => new Promise(resolve => {
+			const observer = new IntersectionObserver(entries => {
+				resolve(entries[0].intersectionRatio);
+				observer.disconnect();
+			});
+			observer.observe(element);
+			requestAnimationFrame(() => {});
+		})`)
+		if err != nil {
+			return nil, false, err
+		}
+		var ratio float64
+		switch v := result.(type) {
+		case int:
+			ratio = float64(v)
+		case float64:
+			ratio = v
+		}
+		actual := fmt.Sprintf("ratio %v", ratio)
+		if option.Ratio != nil {
+			return actual, ratio > 0 && ratio > *option.Ratio-1e-9, nil
+		}
+		return actual, ratio > 0, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeAttached(options ...LocatorAssertionsToBeAttachedOptions) error {
+	option := LocatorAssertionsToBeAttachedOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectLocator("to be attached", true, option.Timeout, func(remaining float64) (interface{}, bool, error) {
+		count, err := la.locator.Count()
+		if err != nil {
+			return nil, false, err
+		}
+		return count > 0, count > 0, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeChecked(options ...LocatorAssertionsToBeCheckedOptions) error {
+	option := LocatorAssertionsToBeCheckedOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	expected := option.Checked == nil || *option.Checked
+	return la.expect("to be checked", expected, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		checked, err := element.IsChecked()
+		if err != nil {
+			return nil, false, err
+		}
+		return checked, checked == expected, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeDisabled(options ...LocatorAssertionsToBeDisabledOptions) error {
+	option := LocatorAssertionsToBeDisabledOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expect("to be disabled", true, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		disabled, err := element.IsDisabled()
+		return disabled, disabled, err
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeEditable(options ...LocatorAssertionsToBeEditableOptions) error {
+	option := LocatorAssertionsToBeEditableOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expect("to be editable", true, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		editable, err := element.IsEditable()
+		return editable, editable, err
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeEmpty(options ...LocatorAssertionsToBeEmptyOptions) error {
+	option := LocatorAssertionsToBeEmptyOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expect("to be empty", true, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		result, err := element.Evaluate(`element => {
+			if (element.nodeName === 'INPUT' || element.nodeName === 'TEXTAREA')
+				return !element.value;
+			return !element.textContent.trim();
+		}`)
+		if err != nil {
+			return nil, false, err
+		}
+		empty, _ := result.(bool)
+		return empty, empty, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeEnabled(options ...LocatorAssertionsToBeEnabledOptions) error {
+	option := LocatorAssertionsToBeEnabledOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expect("to be enabled", true, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		enabled, err := element.IsEnabled()
+		return enabled, enabled, err
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeFocused(options ...LocatorAssertionsToBeFocusedOptions) error {
+	option := LocatorAssertionsToBeFocusedOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expect("to be focused", true, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		result, err := element.Evaluate("element => element.getRootNode().activeElement === element")
+		if err != nil {
+			return nil, false, err
+		}
+		focused, _ := result.(bool)
+		return focused, focused, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeHidden(options ...LocatorAssertionsToBeHiddenOptions) error {
+	option := LocatorAssertionsToBeHiddenOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	// A locator which does not match any element is hidden as well, so
+	// there is no element to wait for.
+	return la.expectLocator("to be hidden", true, option.Timeout, func(remaining float64) (interface{}, bool, error) {
+		hidden, err := la.locator.IsHidden()
+		return hidden, hidden, err
+	})
+}
+
+func (la *locatorAssertionsImpl) ToBeVisible(options ...LocatorAssertionsToBeVisibleOptions) error {
+	option := LocatorAssertionsToBeVisibleOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectLocator("to be visible", true, option.Timeout, func(remaining float64) (interface{}, bool, error) {
+		visible, err := la.locator.IsVisible()
+		return visible, visible, err
+	})
+}
+
+func (la *locatorAssertionsImpl) ToContainText(expected interface{}, options ...LocatorAssertionsToContainTextOptions) error {
+	option := LocatorAssertionsToContainTextOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectText("to contain text", expected, true, option.IgnoreCase, option.UseInnerText, option.Timeout)
+}
+
+func (la *locatorAssertionsImpl) ToHaveAttribute(name string, value interface{}, options ...LocatorAssertionsToHaveAttributeOptions) error {
+	option := LocatorAssertionsToHaveAttributeOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(value, false)
+	if err != nil {
+		return err
+	}
+	return la.expect(fmt.Sprintf("to have attribute '%s'", name), value, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		result, err := element.Evaluate("(element, name) => element.getAttribute(name)", name)
+		if err != nil {
+			return nil, false, err
+		}
+		actual, ok := result.(string)
+		if !ok {
+			return nil, false, nil
+		}
+		return actual, matches(actual), nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveCount(count int, options ...LocatorAssertionsToHaveCountOptions) error {
+	option := LocatorAssertionsToHaveCountOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectLocator("to have count", count, option.Timeout, func(remaining float64) (interface{}, bool, error) {
+		actual, err := la.locator.Count()
+		if err != nil {
+			return nil, false, err
+		}
+		return actual, actual == count, nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveId(id interface{}, options ...LocatorAssertionsToHaveIdOptions) error {
+	option := LocatorAssertionsToHaveIdOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(id, false)
+	if err != nil {
+		return err
+	}
+	return la.expect("to have id", id, option.Timeout, func(element ElementHandle) (interface{}, bool, error) {
+		actual, err := element.Evaluate("element => element.id")
+		if err != nil {
+			return nil, false, err
+		}
+		value, _ := actual.(string)
+		return value, matches(value), nil
+	})
+}
+
+func (la *locatorAssertionsImpl) ToHaveText(expected interface{}, options ...LocatorAssertionsToHaveTextOptions) error {
+	option := LocatorAssertionsToHaveTextOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return la.expectText("to have text", expected, false, option.IgnoreCase, option.UseInnerText, option.Timeout)
+}
+
+// expectAccessibility polls the accessibility node of the locator until the
+// property returned by get matches expected or the timeout is exceeded.
+func (la *locatorAssertionsImpl) expectAccessibility(property string, expected interface{}, ignoreCase *bool, timeout *float64, get func(node map[string]interface{}) string) error {
+	matches, err := newTextMatcher(expected, ignoreCase != nil && *ignoreCase)
+	if err != nil {
+		return err
+	}
+	return la.expect("to have "+property, expected, timeout, func(element ElementHandle) (interface{}, bool, error) {
+		node, err := la.accessibilityNode(element)
+		if err != nil {
+			return nil, false, err
+		}
+		actual := get(node)
+		return actual, matches(actual), nil
+	})
+}
+
+// expect resolves the locator and runs check with the element until its
+// result is the expected one or the timeout is exceeded.
+func (la *locatorAssertionsImpl) expect(description string, expected interface{}, timeout *float64, check func(element ElementHandle) (interface{}, bool, error)) error {
+	if timeout == nil {
+		timeout = Float(la.defaultTimeout)
+	}
+	return pollAssertion(la.locator.String(), la.isNot, description, expected, *timeout, func(remaining float64) (interface{}, bool, error) {
+		var actual interface{}
+		var matched bool
+		err := la.locator.withElement(func(element ElementHandle) error {
+			var err error
+			actual, matched, err = check(element)
+			return err
+		}, Float(remaining))
+		return actual, matched, err
+	})
+}
+
+// expectText polls the text of the locator until it matches expected, which
+// is a string, a *regexp.Regexp or a []interface{} of those to match the
+// texts of all elements in order. Strings are compared with normalized
+// whitespace.
+func (la *locatorAssertionsImpl) expectText(description string, expected interface{}, contains bool, ignoreCase, useInnerText *bool, timeout *float64) error {
+	if list, ok := expected.([]interface{}); ok {
+		matchers := make([]func(actual string) bool, len(list))
+		for i, value := range list {
+			matches, err := newNormalizedTextMatcher(value, contains, ignoreCase != nil && *ignoreCase)
+			if err != nil {
+				return err
+			}
+			matchers[i] = matches
+		}
+		return la.expectLocator(description, expected, timeout, func(remaining float64) (interface{}, bool, error) {
+			var actual []string
+			var err error
+			if useInnerText != nil && *useInnerText {
+				actual, err = la.locator.AllInnerTexts()
+			} else {
+				actual, err = la.locator.AllTextContents()
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			if len(actual) != len(matchers) {
+				return actual, false, nil
+			}
+			for i, matches := range matchers {
+				if !matches(actual[i]) {
+					return actual, false, nil
+				}
+			}
+			return actual, true, nil
+		})
+	}
+	matches, err := newNormalizedTextMatcher(expected, contains, ignoreCase != nil && *ignoreCase)
+	if err != nil {
+		return err
+	}
+	return la.expect(description, expected, timeout, func(element ElementHandle) (interface{}, bool, error) {
+		var actual string
+		var err error
+		if useInnerText != nil && *useInnerText {
+			actual, err = element.InnerText()
+		} else {
+			actual, err = element.TextContent()
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return actual, matches(actual), nil
+	})
+}
+
+// expectLocator runs check until its result is the expected one or the
+// timeout is exceeded, for assertions which do not need a single element.
+func (la *locatorAssertionsImpl) expectLocator(description string, expected interface{}, timeout *float64, check func(remaining float64) (interface{}, bool, error)) error {
+	if timeout == nil {
+		timeout = Float(la.defaultTimeout)
+	}
+	return pollAssertion(la.locator.String(), la.isNot, description, expected, *timeout, check)
+}
+
+// pollAssertion runs check until its result is the expected one or timeout
+// milliseconds are exceeded. check gets the remaining time in milliseconds and
+// returns the actual value, which is only used for the error message, and
+// whether it matched.
+func pollAssertion(subject string, isNot bool, description string, expected interface{}, timeout float64, check func(remaining float64) (interface{}, bool, error)) error {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+	var actual interface{}
+	var lastErr error
+	for {
+		var matched bool
+		remaining := float64(time.Until(deadline) / time.Millisecond)
+		actual, matched, lastErr = check(math.Max(remaining, 1))
+		if lastErr == nil && matched != isNot {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(assertionsPollInterval)
+	}
+	not := ""
+	if isNot {
+		not = "not "
+	}
+	if lastErr != nil {
+		return fmt.Errorf("%s expected %s%s '%v': %w", subject, not, description, expected, lastErr)
+	}
+	return fmt.Errorf("%s expected %s%s '%v', got '%v'", subject, not, description, expected, actual)
+}
+
+type pageAssertionsImpl struct {
+	page           *pageImpl
+	defaultTimeout float64
+	isNot          bool
+}
+
+func (pa *pageAssertionsImpl) Not() PageAssertions {
+	return &pageAssertionsImpl{
+		page:           pa.page,
+		defaultTimeout: pa.defaultTimeout,
+		isNot:          !pa.isNot,
+	}
+}
+
+func (pa *pageAssertionsImpl) ToHaveURL(urlOrPredicate interface{}, options ...PageAssertionsToHaveURLOptions) error {
+	option := PageAssertionsToHaveURLOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var matches func(actual string) bool
+	switch v := urlOrPredicate.(type) {
+	case func(url string) bool:
+		matches = v
+	case string:
+		expected, err := pa.resolveURL(v)
+		if err != nil {
+			return err
+		}
+		urlOrPredicate = expected
+		matches, err = newTextMatcher(expected, option.IgnoreCase != nil && *option.IgnoreCase)
+		if err != nil {
+			return err
+		}
+	default:
+		var err error
+		matches, err = newTextMatcher(urlOrPredicate, false)
+		if err != nil {
+			return err
+		}
+	}
+	return pa.expect("to have URL", urlOrPredicate, option.Timeout, func() (interface{}, bool, error) {
+		actual := pa.page.URL()
+		return actual, matches(actual), nil
+	})
+}
+
+func (pa *pageAssertionsImpl) ToHaveTitle(title interface{}, options ...PageAssertionsToHaveTitleOptions) error {
+	option := PageAssertionsToHaveTitleOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matches, err := newTextMatcher(title, false)
+	if err != nil {
+		return err
+	}
+	return pa.expect("to have title", title, option.Timeout, func() (interface{}, bool, error) {
+		actual, err := pa.page.Title()
+		if err != nil {
+			return nil, false, err
+		}
+		return actual, matches(actual), nil
+	})
+}
+
+func (pa *pageAssertionsImpl) expect(description string, expected interface{}, timeout *float64, check func() (interface{}, bool, error)) error {
+	if timeout == nil {
+		timeout = Float(pa.defaultTimeout)
+	}
+	return pollAssertion("Page", pa.isNot, description, expected, *timeout, func(float64) (interface{}, bool, error) {
+		return check()
+	})
+}
+
+// resolveURL resolves a relative URL against the baseURL of the browser
+// context, like Page.Goto() does.
+func (pa *pageAssertionsImpl) resolveURL(in string) (string, error) {
+	options := pa.page.browserContext.options
+	if options == nil || options.BaseURL == nil {
+		return in, nil
+	}
+	base, err := url.Parse(*options.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid baseURL: %w", err)
+	}
+	ref, err := url.Parse(in)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+type apiResponseAssertionsImpl struct {
+	response APIResponse
+	isNot    bool
+}
+
+func (pa *playwrightAssertionsImpl) APIResponse(response APIResponse) APIResponseAssertions {
+	return &apiResponseAssertionsImpl{
+		response: response,
+	}
+}
+
+func (ra *apiResponseAssertionsImpl) Not() APIResponseAssertions {
+	return &apiResponseAssertionsImpl{
+		response: ra.response,
+		isNot:    !ra.isNot,
+	}
+}
+
+func (ra *apiResponseAssertionsImpl) ToBeOK() error {
+	return ra.expect("to be OK", "200-299", func() (interface{}, bool, error) {
+		return ra.response.Status(), ra.response.Ok(), nil
+	})
+}
+
+func (ra *apiResponseAssertionsImpl) ToHaveStatus(status int) error {
+	return ra.expect("to have status", status, func() (interface{}, bool, error) {
+		return ra.response.Status(), ra.response.Status() == status, nil
+	})
+}
+
+func (ra *apiResponseAssertionsImpl) ToHaveHeader(name string, value interface{}) error {
+	matches, err := newTextMatcher(value, false)
+	if err != nil {
+		return err
+	}
+	return ra.expect(fmt.Sprintf("to have header '%s'", name), value, func() (interface{}, bool, error) {
+		for header, actual := range ra.response.Headers() {
+			if strings.EqualFold(header, name) {
+				return actual, matches(actual), nil
+			}
+		}
+		return nil, false, nil
+	})
+}
+
+func (ra *apiResponseAssertionsImpl) ToHaveBody(expected interface{}) error {
+	matches, err := newTextMatcher(expected, false)
+	if err != nil {
+		return err
+	}
+	return ra.expect("to have body", expected, func() (interface{}, bool, error) {
+		body, err := ra.response.Text()
+		if err != nil {
+			return nil, false, err
+		}
+		return body, matches(body), nil
+	})
+}
+
+func (ra *apiResponseAssertionsImpl) ToHaveJSON(expected interface{}) error {
+	// Round-trip expected through JSON so that structs and maps compare like
+	// the decoded body, e.g. with float64 numbers.
+	serialized, err := json.Marshal(expected)
+	if err != nil {
+		return fmt.Errorf("could not serialize expected JSON: %w", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(serialized, &normalized); err != nil {
+		return fmt.Errorf("could not parse expected JSON: %w", err)
+	}
+	return ra.expect("to have JSON", string(serialized), func() (interface{}, bool, error) {
+		var actual interface{}
+		if err := ra.response.JSON(&actual); err != nil {
+			return nil, false, err
+		}
+		return actual, reflect.DeepEqual(actual, normalized), nil
+	})
+}
+
+// expect checks the response once, it does not change anymore.
+func (ra *apiResponseAssertionsImpl) expect(description string, expected interface{}, check func() (interface{}, bool, error)) error {
+	return pollAssertion("Response "+ra.response.URL(), ra.isNot, description, expected, 0, func(float64) (interface{}, bool, error) {
+		return check()
+	})
+}
+
+// accessibilityNode returns the accessibility node of the element, it includes
+// nodes which are not interesting to assistive technologies.
+func (la *locatorAssertionsImpl) accessibilityNode(element ElementHandle) (map[string]interface{}, error) {
+	page := la.locator.frame.Page().(*pageImpl)
+	result, err := page.channel.Send("accessibilitySnapshot", map[string]interface{}{
+		"interestingOnly": false,
+		"root":            element.(*elementHandleImpl).channel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get accessibility snapshot: %w", err)
+	}
+	node, _ := result.(map[string]interface{})
+	if node == nil {
+		return nil, errors.New("element is not part of the accessibility tree")
+	}
+	return node, nil
+}
+
+func accessibilityProperty(node map[string]interface{}, name string) string {
+	if value, ok := node[name].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// newTextMatcher returns a function which matches a string against expected,
+// which is either a string or a *regexp.Regexp.
+func newTextMatcher(expected interface{}, ignoreCase bool) (func(actual string) bool, error) {
+	switch v := expected.(type) {
+	case *regexp.Regexp:
+		return v.MatchString, nil
+	case string:
+		if ignoreCase {
+			return func(actual string) bool {
+				return strings.EqualFold(v, actual)
+			}, nil
+		}
+		return func(actual string) bool {
+			return v == actual
+		}, nil
+	}
+	return nil, fmt.Errorf("expected value must be a string or a *regexp.Regexp, got %T", expected)
+}
+
+// newNormalizedTextMatcher returns a function which matches a text against
+// expected, which is either a string or a *regexp.Regexp. Whitespace of
+// strings gets normalized, with contains they match substrings.
+func newNormalizedTextMatcher(expected interface{}, contains bool, ignoreCase bool) (func(actual string) bool, error) {
+	text, ok := expected.(string)
+	if !ok {
+		return newTextMatcher(expected, ignoreCase)
+	}
+	normalize := func(s string) string {
+		s = strings.Join(strings.Fields(s), " ")
+		if ignoreCase {
+			s = strings.ToLower(s)
+		}
+		return s
+	}
+	text = normalize(text)
+	return func(actual string) bool {
+		if contains {
+			return strings.Contains(normalize(actual), text)
+		}
+		return normalize(actual) == text
+	}, nil
+}
@@ -0,0 +1,37 @@
+package playwright
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTextMatcher(t *testing.T) {
+	matches, err := newTextMatcher("Submit", false)
+	require.NoError(t, err)
+	require.True(t, matches("Submit"))
+	require.False(t, matches("submit"))
+	matches, err = newTextMatcher("Submit", true)
+	require.NoError(t, err)
+	require.True(t, matches("submit"))
+	matches, err = newTextMatcher(regexp.MustCompile(`^Sub`), false)
+	require.NoError(t, err)
+	require.True(t, matches("Submit order"))
+	require.False(t, matches("Cancel"))
+	_, err = newTextMatcher(1, false)
+	require.Error(t, err)
+}
+
+func TestNewNormalizedTextMatcher(t *testing.T) {
+	matches, err := newNormalizedTextMatcher("Hello world", false, false)
+	require.NoError(t, err)
+	require.True(t, matches("  Hello\n  world "))
+	require.False(t, matches("Hello world!"))
+	matches, err = newNormalizedTextMatcher("WORLD", true, true)
+	require.NoError(t, err)
+	require.True(t, matches("Hello world!"))
+	matches, err = newNormalizedTextMatcher(regexp.MustCompile(`wor`), false, false)
+	require.NoError(t, err)
+	require.True(t, matches("Hello world"))
+}
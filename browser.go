@@ -12,6 +12,8 @@ type browserImpl struct {
 	isClosedOrClosing        bool
 	isConnectedOverWebSocket bool
 	contexts                 []BrowserContext
+	events                   browserEventBus
+	proxy                    *proxySettings
 }
 
 func (b *browserImpl) IsConnected() bool {
@@ -20,13 +22,78 @@ func (b *browserImpl) IsConnected() bool {
 	return b.isConnected
 }
 
+// name returns the name of the browser type, e.g. `chromium`.
+func (b *browserImpl) name() string {
+	if name, ok := b.initializer["name"].(string); ok {
+		return name
+	}
+	return "the browser"
+}
+
 func (b *browserImpl) NewContext(options ...BrowserNewContextOptions) (BrowserContext, error) {
 	overrides := map[string]interface{}{"sdkLanguage": "javascript"}
+	var originalOptions BrowserNewContextOptions
+	var deterministic *DeterministicRenderingOptions
+	var limits *ContextLimits
+	var demoMode *DemoModeOptions
+	var metadata map[string]string
+	var preset *ContextPreset
+	var har *harRecorder
+	var err error
 	if len(options) == 1 {
+		originalOptions = options[0]
+		if proxy := options[0].Proxy; proxy != nil {
+			if err := validateProxy(b.name(), proxy.Server, proxy.Username, proxy.Password); err != nil {
+				return nil, err
+			}
+		}
+		if options[0].Device != nil {
+			deviceOverrides, err := b.connection.playwright.deviceOverrides(*options[0].Device)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range deviceOverrides {
+				overrides[key] = value
+			}
+			options[0].Device = nil
+		}
+		if options[0].Preset != nil {
+			if preset, err = b.connection.playwright.preset(*options[0].Preset); err != nil {
+				return nil, err
+			}
+			preset.applyDefaults(&options[0].Locale, &options[0].TimezoneId, &options[0].Permissions)
+			if preset.Geolocation != nil && options[0].Geolocation == nil {
+				overrides["geolocation"] = preset.Geolocation
+			}
+			options[0].ExtraHttpHeaders = preset.extraHTTPHeaders(options[0].ExtraHttpHeaders)
+			options[0].Preset = nil
+		}
 		if options[0].ExtraHttpHeaders != nil {
 			overrides["extraHTTPHeaders"] = serializeMapToNameAndValue(options[0].ExtraHttpHeaders)
 			options[0].ExtraHttpHeaders = nil
 		}
+		metadata = options[0].Metadata
+		options[0].Metadata = nil
+		if options[0].RecordHarPath != nil {
+			var recordHar map[string]interface{}
+			if har, recordHar, err = newHarRecorder(expandArtifactPath(*options[0].RecordHarPath, metadata), options[0].RecordHarOmitContent, options[0].RecordHarContent, options[0].RecordHarMode, options[0].RecordHarUrlFilter); err != nil {
+				return nil, err
+			}
+			overrides["recordHar"] = recordHar
+		}
+		options[0].RecordHarPath = nil
+		options[0].RecordHarOmitContent = nil
+		options[0].RecordHarContent = nil
+		options[0].RecordHarMode = nil
+		options[0].RecordHarUrlFilter = nil
+		if deterministic = options[0].Deterministic; deterministic != nil {
+			applyDeterministicDefaults(&options[0].TimezoneId, &options[0].Locale, &options[0].ReducedMotion)
+			options[0].Deterministic = nil
+		}
+		limits = options[0].Limits
+		options[0].Limits = nil
+		demoMode = options[0].DemoMode
+		options[0].DemoMode = nil
 		if options[0].StorageStatePath != nil {
 			var storageState *BrowserNewContextOptionsStorageState
 			storageString, err := ioutil.ReadFile(*options[0].StorageStatePath)
@@ -48,11 +115,44 @@ func (b *browserImpl) NewContext(options ...BrowserNewContextOptions) (BrowserCo
 	context := fromChannel(channel).(*browserContextImpl)
 	if len(options) == 1 {
 		context.options = &options[0]
+		context.acceptDownloads = options[0].AcceptDownloads != nil && *options[0].AcceptDownloads
 	}
+	context.originalOptions = originalOptions
 	context.browser = b
+	context.proxy = b.proxy
+	if len(options) == 1 && options[0].Proxy != nil {
+		context.proxy = newProxySettings(options[0].Proxy.Server, options[0].Proxy.Bypass, options[0].Proxy.Username, options[0].Proxy.Password)
+	}
 	b.Lock()
 	b.contexts = append(b.contexts, context)
 	b.Unlock()
+	b.events.contextCreated(context)
+	context.har = har
+	if metadata != nil {
+		context.metadata = copyMetadata(metadata)
+	}
+	if preset != nil {
+		if err := context.applyPreset(preset); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if deterministic != nil {
+		if err := context.installDeterministicRendering(deterministic); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if demoMode != nil {
+		if err := context.installDemoMode(demoMode); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if limits != nil {
+		context.applyLimits(*limits)
+	}
+	b.connection.playwright.hooks.onContextCreated(context)
 	return context, nil
 }
 
@@ -71,6 +171,9 @@ func (b *browserImpl) NewPage(options ...BrowserNewContextOptions) (Page, error)
 }
 
 func (b *browserImpl) NewBrowserCDPSession() (CDPSession, error) {
+	if name := b.name(); !capabilitiesOf(name).cdp {
+		return nil, &NotSupportedError{Feature: "CDP sessions", Browser: name}
+	}
 	channel, err := b.channel.Send("newBrowserCDPSession", map[string]interface{}{
 		"sdkLanguage": "javascript",
 	})
@@ -90,14 +193,36 @@ func (b *browserImpl) Contexts() []BrowserContext {
 }
 
 func (b *browserImpl) Close() error {
+	hookErr := b.runBeforeCloseHooks()
 	_, err := b.channel.Send("close")
 	if err != nil {
 		return fmt.Errorf("could not send message: %w", err)
 	}
 	if b.isConnectedOverWebSocket {
-		return b.connection.Stop()
+		if err := b.connection.Stop(); err != nil {
+			return err
+		}
+	}
+	return hookErr
+}
+
+// runBeforeCloseHooks runs the before close hooks of the contexts which are
+// still open, as they get closed along with the browser.
+func (b *browserImpl) runBeforeCloseHooks() error {
+	var firstErr error
+	for _, context := range b.Contexts() {
+		context := context.(*browserContextImpl)
+		context.Lock()
+		closing := context.isClosedOrClosing
+		context.Unlock()
+		if closing {
+			continue
+		}
+		if err := context.runBeforeCloseHooks(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
 }
 
 func (b *browserImpl) Version() string {
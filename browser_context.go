@@ -4,23 +4,51 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
 )
 
 type browserContextImpl struct {
 	channelOwner
-	timeoutSettings   *timeoutSettings
-	isClosedOrClosing bool
-	options           *BrowserNewContextOptions
-	pages             []Page
-	routes            []*routeHandlerEntry
-	ownedPage         Page
-	browser           *browserImpl
-	serviceWorkers    []*workerImpl
-	bindings          map[string]BindingCallFunction
-	tracing           *tracingImpl
+	timeoutSettings          *timeoutSettings
+	isClosedOrClosing        bool
+	options                  *BrowserNewContextOptions
+	originalOptions          BrowserNewContextOptions
+	initScripts              []string
+	pages                    []Page
+	activePage               Page
+	routes                   []*routeHandlerEntry
+	blocker                  *requestBlocker
+	networkStats             *networkStatsCounter
+	httpCacheDisabled        bool
+	extraHTTPHeaders         map[string]string
+	scopedHeaders            []*scopedHeaders
+	request                  *apiRequestContextImpl
+	ownedPage                Page
+	browser                  *browserImpl
+	backgroundPages          []BackgroundPage
+	serviceWorkers           []*workerImpl
+	bindings                 map[string]BindingCallFunction
+	tracing                  *tracingImpl
+	har                      *harRecorder
+	harRouters               []*harRouter
+	permissionPromptsEnabled bool
+	permissionPromptsLock    sync.Mutex
+	acceptDownloads          bool
+	webSocketRouter          webSocketRouter
+	clock                    *clockImpl
+	limiter                  *contextLimiter
+	metadata                 map[string]string
+	beforeCloseHooks         []func(context BrowserContext) error
+	selectorSuggestions      bool
+	navigationRetries        int
+	proxy                    *proxySettings
+	routeClient              *http.Client
 }
 
 func (b *browserContextImpl) SetDefaultNavigationTimeout(timeout float64) {
@@ -40,7 +68,92 @@ func (b *browserContextImpl) SetDefaultTimeout(timeout float64) {
 func (b *browserContextImpl) Pages() []Page {
 	b.Lock()
 	defer b.Unlock()
-	return b.pages
+	pages := make([]Page, len(b.pages))
+	copy(pages, b.pages)
+	return pages
+}
+
+func (b *browserContextImpl) ActivePage() Page {
+	b.Lock()
+	defer b.Unlock()
+	return b.activePage
+}
+
+func (b *browserContextImpl) WaitForPage(options ...BrowserContextWaitForPageOptions) (Page, error) {
+	option := BrowserContextWaitForPageOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Timeout == nil {
+		option.Timeout = Float(b.timeoutSettings.Timeout())
+	}
+	matches := func(page Page) bool {
+		return option.Predicate == nil || option.Predicate(page)
+	}
+	pages := make(chan Page, 1)
+	onPage := func(page Page) {
+		if !matches(page) {
+			return
+		}
+		select {
+		case pages <- page:
+		default:
+		}
+	}
+	// The listener gets registered before looking at the open pages, so that
+	// a page which gets opened in between does not get lost.
+	b.On("page", onPage)
+	defer b.RemoveListener("page", onPage)
+	for _, page := range b.Pages() {
+		if matches(page) {
+			return page, nil
+		}
+	}
+	// A timeout of 0 disables the timeout.
+	var expired <-chan time.Time
+	if *option.Timeout > 0 {
+		expired = time.After(time.Duration(*option.Timeout) * time.Millisecond)
+	}
+	select {
+	case page := <-pages:
+		return page, nil
+	case <-expired:
+		return nil, fmt.Errorf("Timeout %.2fms exceeded while waiting for page.", *option.Timeout)
+	}
+}
+
+func (b *browserContextImpl) CloseAllPages() error {
+	var firstErr error
+	for _, page := range b.Pages() {
+		if err := page.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("could not close page %s: %w", page.URL(), err)
+		}
+	}
+	return firstErr
+}
+
+// setActivePage marks page as the one which the user interacts with, it gets
+// called when a page gets opened or brought to front.
+func (b *browserContextImpl) setActivePage(page Page) {
+	b.Lock()
+	defer b.Unlock()
+	b.activePage = page
+}
+
+func (b *browserContextImpl) BackgroundPages() []BackgroundPage {
+	b.Lock()
+	defer b.Unlock()
+	return b.backgroundPages
+}
+
+func (b *browserContextImpl) ServiceWorkers() []Worker {
+	b.Lock()
+	defer b.Unlock()
+	workers := make([]Worker, 0, len(b.serviceWorkers))
+	for _, worker := range b.serviceWorkers {
+		workers = append(workers, worker)
+	}
+	return workers
 }
 
 func (b *browserContextImpl) Browser() Browser {
@@ -51,6 +164,9 @@ func (b *browserContextImpl) Tracing() Tracing {
 }
 
 func (b *browserContextImpl) NewCDPSession(page Page) (CDPSession, error) {
+	if err := b.requireCDP(); err != nil {
+		return nil, err
+	}
 	channel, err := b.channel.Send("newCDPSession", map[string]interface{}{
 		"sdkLanguage": "javascript",
 		"page":        page.(*pageImpl).channel,
@@ -68,11 +184,23 @@ func (b *browserContextImpl) NewPage(options ...BrowserNewPageOptions) (Page, er
 	if b.ownedPage != nil {
 		return nil, errors.New("Please use browser.NewContext()")
 	}
-	channel, err := b.channel.Send("newPage", options)
+	if err := b.contextLimiter().checkNewPage(len(b.Pages())); err != nil {
+		return nil, err
+	}
+	channel, err := b.channel.Send("newPage")
 	if err != nil {
 		return nil, fmt.Errorf("could not send message: %w", err)
 	}
-	return fromChannel(channel).(*pageImpl), nil
+	page := fromChannel(channel).(*pageImpl)
+	if len(options) == 1 {
+		if err := page.applyOptions(options[0]); err != nil {
+			if closeErr := page.Close(); closeErr != nil {
+				log.Printf("could not close page: %v", closeErr)
+			}
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
 // NetworkCookie is the return structure of BrowserContext.Cookies()
@@ -127,6 +255,10 @@ func (b *browserContextImpl) ClearCookies() error {
 	return err
 }
 
+func (b *browserContextImpl) Clock() Clock {
+	return b.clock
+}
+
 func (b *browserContextImpl) GrantPermissions(permissions []string, options ...BrowserContextGrantPermissionsOptions) error {
 	_, err := b.channel.Send("grantPermissions", map[string]interface{}{
 		"permissions": permissions,
@@ -141,9 +273,9 @@ func (b *browserContextImpl) ClearPermissions() error {
 
 // SetGeolocationOptions represents the options for BrowserContext.SetGeolocation()
 type SetGeolocationOptions struct {
-	Longitude int  `json:"longitude"`
-	Latitude  int  `json:"latitude"`
-	Accuracy  *int `json:"accuracy"`
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+	Accuracy  *int    `json:"accuracy"`
 }
 
 func (b *browserContextImpl) SetGeolocation(gelocation *SetGeolocationOptions) error {
@@ -158,13 +290,6 @@ func (b *browserContextImpl) ResetGeolocation() error {
 	return err
 }
 
-func (b *browserContextImpl) SetExtraHTTPHeaders(headers map[string]string) error {
-	_, err := b.channel.Send("setExtraHTTPHeaders", map[string]interface{}{
-		"headers": serializeMapToNameAndValue(headers),
-	})
-	return err
-}
-
 func (b *browserContextImpl) SetOffline(offline bool) error {
 	_, err := b.channel.Send("setOffline", map[string]interface{}{
 		"offline": offline,
@@ -178,16 +303,32 @@ func (b *browserContextImpl) AddInitScript(options BrowserContextAddInitScriptOp
 		source = *options.Script
 	}
 	if options.Path != nil {
-		content, err := ioutil.ReadFile(*options.Path)
+		content, err := readAsset(options.FS, *options.Path)
 		if err != nil {
 			return err
 		}
 		source = string(content)
 	}
-	_, err := b.channel.Send("addInitScript", map[string]interface{}{
+	source = scopeInitScript(source, options.Origins, options.MainFrameOnly != nil && *options.MainFrameOnly)
+	if _, err := b.channel.Send("addInitScript", map[string]interface{}{
 		"source": source,
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+	b.Lock()
+	b.initScripts = append(b.initScripts, source)
+	b.Unlock()
+	return nil
+}
+
+// addInitFunction calls fn with arg in the current and future documents of
+// the context.
+func (b *browserContextImpl) addInitFunction(fn string, arg interface{}) error {
+	return addInitFunction(func(script string) error {
+		return b.AddInitScript(BrowserContextAddInitScriptOptions{
+			Script: String(script),
+		})
+	}, b.Pages(), fn, arg)
 }
 
 func (b *browserContextImpl) ExposeBinding(name string, binding BindingCallFunction, handle ...bool) error {
@@ -217,30 +358,55 @@ func (b *browserContextImpl) ExposeFunction(name string, binding ExposedFunction
 	})
 }
 
-func (b *browserContextImpl) Route(url interface{}, handler routeHandler) error {
-	b.routes = append(b.routes, newRouteHandlerEntry(newURLMatcher(url), handler))
-	if len(b.routes) == 1 {
-		_, err := b.channel.Send("setNetworkInterceptionEnabled", map[string]interface{}{
-			"enabled": true,
-		})
-		if err != nil {
-			return err
-		}
+func (b *browserContextImpl) Route(url interface{}, handler func(Route, Request), options ...BrowserContextRouteOptions) error {
+	var times *int
+	if len(options) == 1 {
+		times = options[0].Times
 	}
-	return nil
+	return b.updateInterception(func() {
+		b.routes = append(b.routes, newRouteHandlerEntry(newURLMatcher(url), handler, times))
+	})
 }
 
-func (b *browserContextImpl) Unroute(url interface{}, handlers ...routeHandler) error {
-	b.Lock()
-	defer b.Unlock()
+func (b *browserContextImpl) Unroute(url interface{}, handlers ...func(Route, Request)) error {
+	return b.updateInterception(func() {
+		b.routes = filterRoutes(b.routes, url, handlers...)
+	})
+}
 
-	routes, err := unroute(b.channel, b.routes, url, handlers...)
-	if err != nil {
-		return err
+func (b *browserContextImpl) UnrouteAll(options ...BrowserContextUnrouteAllOptions) error {
+	var routes []*routeHandlerEntry
+	err := b.updateInterception(func() {
+		routes = b.routes
+		b.routes = make([]*routeHandlerEntry, 0)
+	})
+	var behavior *UnrouteBehavior
+	if len(options) == 1 {
+		behavior = options[0].Behavior
 	}
-	b.routes = routes
+	unrouteAll(routes, behavior)
+	return err
+}
 
-	return nil
+// updateInterception runs update with the lock held and toggles the network
+// interception when update changed whether it is needed.
+func (b *browserContextImpl) updateInterception(update func()) error {
+	b.Lock()
+	wasIntercepting := b.needsInterception()
+	update()
+	intercepting := b.needsInterception()
+	b.Unlock()
+	if wasIntercepting == intercepting {
+		return nil
+	}
+	_, err := b.channel.Send("setNetworkInterceptionEnabled", map[string]interface{}{
+		"enabled": intercepting,
+	})
+	return err
+}
+
+func (b *browserContextImpl) needsInterception() bool {
+	return len(b.routes) > 0 || b.blocker != nil || b.httpCacheDisabled || len(b.scopedHeaders) > 0
 }
 
 func (b *browserContextImpl) WaitForEvent(event string, predicate ...interface{}) interface{} {
@@ -258,8 +424,29 @@ func (b *browserContextImpl) Close() error {
 	b.Lock()
 	b.isClosedOrClosing = true
 	b.Unlock()
-	_, err := b.channel.Send("close")
-	return err
+	hookErr := b.runBeforeCloseHooks()
+	b.Lock()
+	routers := b.harRouters
+	for _, page := range b.pages {
+		page := page.(*pageImpl)
+		page.Lock()
+		routers = append(routers, page.harRouters...)
+		page.harRouters = nil
+		page.Unlock()
+	}
+	b.Unlock()
+	if err := saveHars(routers); err != nil {
+		return err
+	}
+	if _, err := b.channel.Send("close"); err != nil {
+		return err
+	}
+	if b.har != nil {
+		if err := b.har.finish(); err != nil {
+			return fmt.Errorf("could not save HAR: %w", err)
+		}
+	}
+	return hookErr
 }
 
 type StorageState struct {
@@ -270,7 +457,7 @@ type StorageState struct {
 type Cookie struct {
 	Name     string  `json:"name"`
 	Value    string  `json:"value"`
-	URL      string  `json:"url"`
+	URL      string  `json:"url,omitempty"`
 	Domain   string  `json:"domain"`
 	Path     string  `json:"path"`
 	Expires  float64 `json:"expires"`
@@ -294,14 +481,7 @@ func (b *browserContextImpl) StorageState(paths ...string) (*StorageState, error
 		return nil, err
 	}
 	if len(paths) == 1 {
-		file, err := os.Create(paths[0])
-		if err != nil {
-			return nil, err
-		}
-		if err := json.NewEncoder(file).Encode(result); err != nil {
-			return nil, err
-		}
-		if err := file.Close(); err != nil {
+		if err := writeStorageState(paths[0], result); err != nil {
 			return nil, err
 		}
 	}
@@ -310,6 +490,116 @@ func (b *browserContextImpl) StorageState(paths ...string) (*StorageState, error
 	return &storageState, nil
 }
 
+// writeStorageState saves a storage state as JSON, the file can be passed as
+// StorageStatePath to Browser.NewContext() and APIRequest.NewContext(). Missing
+// parent directories get created, e.g. for a ".auth/user.json" file.
+func writeStorageState(path string, storageState interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create storage state directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(storageState); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (b *browserContextImpl) Clone(options ...BrowserNewContextOptions) (BrowserContext, error) {
+	if b.browser == nil {
+		return nil, errors.New("persistent browser contexts can not be cloned")
+	}
+	cloneOptions := b.originalOptions
+	if len(options) == 1 {
+		mergeContextOptions(&cloneOptions, options[0])
+	}
+	if len(options) == 0 || (options[0].StorageState == nil && options[0].StorageStatePath == nil) {
+		storageState, err := b.channel.SendReturnAsDict("storageState")
+		if err != nil {
+			return nil, fmt.Errorf("could not get storage state: %w", err)
+		}
+		serialized, err := json.Marshal(storageState)
+		if err != nil {
+			return nil, fmt.Errorf("could not serialize storage state: %w", err)
+		}
+		cloneOptions.StorageState = &BrowserNewContextOptionsStorageState{}
+		if err := json.Unmarshal(serialized, cloneOptions.StorageState); err != nil {
+			return nil, fmt.Errorf("could not parse storage state: %w", err)
+		}
+		cloneOptions.StorageStatePath = nil
+	}
+	clone, err := b.browser.NewContext(cloneOptions)
+	if err != nil {
+		return nil, err
+	}
+	b.Lock()
+	routes := make([]*routeHandlerEntry, len(b.routes))
+	copy(routes, b.routes)
+	initScripts := make([]string, len(b.initScripts))
+	copy(initScripts, b.initScripts)
+	blocker := b.blocker
+	httpCacheDisabled := b.httpCacheDisabled
+	extraHTTPHeaders := b.extraHTTPHeaders
+	scopedHeaders := make([]*scopedHeaders, len(b.scopedHeaders))
+	copy(scopedHeaders, b.scopedHeaders)
+	b.Unlock()
+	if blocker != nil {
+		if err := clone.BlockRequests(blocker.options); err != nil {
+			return nil, fmt.Errorf("could not block requests: %w", err)
+		}
+	}
+	if httpCacheDisabled {
+		if err := clone.SetHTTPCacheDisabled(true); err != nil {
+			return nil, fmt.Errorf("could not disable http cache: %w", err)
+		}
+	}
+	if extraHTTPHeaders != nil {
+		if err := clone.SetExtraHTTPHeaders(extraHTTPHeaders); err != nil {
+			return nil, fmt.Errorf("could not set extra HTTP headers: %w", err)
+		}
+	}
+	for _, entry := range scopedHeaders {
+		if err := clone.SetExtraHTTPHeaders(entry.headers, BrowserContextSetExtraHTTPHeadersOptions{
+			URL: entry.matcher.urlOrPredicate,
+		}); err != nil {
+			return nil, fmt.Errorf("could not set extra HTTP headers: %w", err)
+		}
+	}
+	for _, script := range initScripts {
+		if err := clone.AddInitScript(BrowserContextAddInitScriptOptions{
+			Script: String(script),
+		}); err != nil {
+			return nil, fmt.Errorf("could not add init script: %w", err)
+		}
+	}
+	cloned := clone.(*browserContextImpl)
+	if err := cloned.updateInterception(func() {
+		for _, route := range routes {
+			if _, ok := route.remainingTimes(); ok {
+				cloned.routes = append(cloned.routes, route.clone())
+			}
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("could not add routes: %w", err)
+	}
+	return clone, nil
+}
+
+// mergeContextOptions sets all fields of dest to the ones of overrides which
+// are not nil.
+func mergeContextOptions(dest *BrowserNewContextOptions, overrides BrowserNewContextOptions) {
+	destValue := reflect.ValueOf(dest).Elem()
+	overridesValue := reflect.ValueOf(overrides)
+	for i := 0; i < overridesValue.NumField(); i++ {
+		if field := overridesValue.Field(i); !field.IsNil() {
+			destValue.Field(i).Set(field)
+		}
+	}
+}
+
 func (b *browserContextImpl) onBinding(binding *bindingCallImpl) {
 	function := b.bindings[binding.initializer["name"].(string)]
 	if function == nil {
@@ -320,6 +610,7 @@ func (b *browserContextImpl) onBinding(binding *bindingCallImpl) {
 
 func (b *browserContextImpl) onClose() {
 	b.isClosedOrClosing = true
+	b.contextLimiter().stop()
 	if b.browser != nil {
 		contexts := make([]BrowserContext, 0)
 		b.browser.Lock()
@@ -338,22 +629,66 @@ func (b *browserContextImpl) onPage(page *pageImpl) {
 	page.setBrowserContext(b)
 	b.Lock()
 	b.pages = append(b.pages, page)
+	b.activePage = page
+	pages, limiter := len(b.pages), b.limiter
 	b.Unlock()
+	limiter.checkPages(pages)
 	b.Emit("page", page)
 	opener, _ := page.Opener()
-	if opener != nil && !opener.IsClosed() {
+	if opener == nil || opener.IsClosed() {
+		return
+	}
+	if !opener.(*pageImpl).inheritsToPopups() {
 		opener.Emit("popup", page)
+		return
 	}
+	// Applying the inheritance needs round trips to the driver, which can not
+	// happen while its messages get dispatched.
+	go func() {
+		if err := opener.(*pageImpl).applyToPopup(page); err != nil {
+			log.Printf("could not apply inheritance to popup: %v", err)
+		}
+		opener.Emit("popup", page)
+	}()
+}
+
+func (b *browserContextImpl) onBackgroundPage(page *pageImpl) {
+	page.setBrowserContext(b)
+	backgroundPage := newBackgroundPage(page)
+	b.Lock()
+	b.backgroundPages = append(b.backgroundPages, backgroundPage)
+	b.Unlock()
+	b.Emit("backgroundpage", backgroundPage)
+}
+
+func (b *browserContextImpl) onServiceWorker(worker *workerImpl) {
+	worker.context = b
+	b.Lock()
+	b.serviceWorkers = append(b.serviceWorkers, worker)
+	b.Unlock()
+	b.Emit("serviceworker", worker)
 }
 
 func (b *browserContextImpl) onRoute(route *routeImpl, request *requestImpl) {
 	go func() {
-		for _, handlerEntry := range b.routes {
-			if handlerEntry.matcher.Matches(request.URL()) {
-				handlerEntry.handler(route, request)
-				return
+		if b.abortIfBlocked(route, request) {
+			return
+		}
+		route.extraHeaders = b.scopedHeadersFor(request)
+		b.Lock()
+		routes := append([]*routeHandlerEntry{}, b.routes...)
+		b.Unlock()
+		handled, expired := dispatchRoute(routes, route, request)
+		if expired != nil {
+			if err := b.updateInterception(func() {
+				b.routes = removeRouteHandlerEntry(b.routes, expired)
+			}); err != nil {
+				log.Printf("could not update network interception: %v", err)
 			}
 		}
+		if handled {
+			return
+		}
 		if err := route.Continue(); err != nil {
 			log.Printf("could not continue request: %v", err)
 		}
@@ -368,19 +703,25 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 	bt := &browserContextImpl{
 		timeoutSettings: newTimeoutSettings(nil),
 		pages:           make([]Page, 0),
+		backgroundPages: make([]BackgroundPage, 0),
+		serviceWorkers:  make([]*workerImpl, 0),
 		routes:          make([]*routeHandlerEntry, 0),
 		bindings:        make(map[string]BindingCallFunction),
+		networkStats:    newNetworkStatsCounter(),
 	}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
 	bt.tracing = newTracing(bt)
+	bt.clock = newContextClock(bt)
 	bt.channel.On("bindingCall", func(params map[string]interface{}) {
 		bt.onBinding(fromChannel(params["binding"]).(*bindingCallImpl))
 	})
 	bt.channel.On("request", func(ev map[string]interface{}) {
 		request := fromChannel(ev["request"]).(*requestImpl)
 		page := fromNullableChannel(ev["page"])
+		bt.networkStats.onRequest(request)
 		bt.Emit("request", request)
 		if page != nil {
+			page.(*pageImpl).networkStats.onRequest(request)
 			page.(*pageImpl).Emit("request", request)
 		}
 	})
@@ -391,8 +732,10 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 		if request.timing != nil {
 			request.timing.ResponseEnd = ev["responseEndTiming"].(float64)
 		}
+		bt.networkStats.onRequestFailed()
 		bt.Emit("requestfailed", request)
 		if page != nil {
+			page.(*pageImpl).networkStats.onRequestFailed()
 			page.(*pageImpl).Emit("requestfailed", request)
 		}
 	})
@@ -411,8 +754,11 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 	bt.channel.On("response", func(ev map[string]interface{}) {
 		response := fromChannel(ev["response"]).(*responseImpl)
 		page := fromNullableChannel(ev["page"])
+		bt.networkStats.onResponse(response)
+		bt.contextLimiter().onResponse(response)
 		bt.Emit("response", response)
 		if page != nil {
+			page.(*pageImpl).networkStats.onResponse(response)
 			page.(*pageImpl).Emit("response", response)
 		}
 	})
@@ -420,6 +766,12 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 	bt.channel.On("page", func(payload map[string]interface{}) {
 		bt.onPage(fromChannel(payload["page"]).(*pageImpl))
 	})
+	bt.channel.On("backgroundPage", func(payload map[string]interface{}) {
+		bt.onBackgroundPage(fromChannel(payload["page"]).(*pageImpl))
+	})
+	bt.channel.On("serviceWorker", func(payload map[string]interface{}) {
+		bt.onServiceWorker(fromChannel(payload["worker"]).(*workerImpl))
+	})
 	bt.channel.On("route", func(params map[string]interface{}) {
 		bt.onRoute(fromChannel(params["route"]).(*routeImpl), fromChannel(params["request"]).(*requestImpl))
 	})
@@ -0,0 +1,193 @@
+package playwright
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// browserEventNames are the page events which get forwarded to the
+// subscriptions of Browser.SubscribeEvents().
+var browserEventNames = []string{
+	"console",
+	"crash",
+	"close",
+	"pageerror",
+	"request",
+	"requestfailed",
+	"requestfinished",
+	"response",
+}
+
+// BrowserEvent is an event of a page of one of the contexts of a browser,
+// tagged with the IDs of its context and page.
+type BrowserEvent struct {
+	// Name of the page event, e.g. `console`, `pageerror` or `request`. `page`
+	// gets sent when a page got created.
+	Name string
+	// ID of the browser context, stable for the lifetime of the context.
+	ContextID string
+	// ID of the page, stable for the lifetime of the page.
+	PageID  string
+	Context BrowserContext
+	Page    Page
+	// Payload of the event: a ConsoleMessage for `console`, an error for
+	// `pageerror`, a Request for the request events, a Response for
+	// `response` and nil for the other events.
+	Payload interface{}
+	// Time at which the event got received.
+	Time time.Time
+}
+
+// BrowserEventSubscription delivers the events of all contexts of a browser
+// on C. Events get dropped instead of blocking the connection when the buffer
+// of C is full, see Dropped().
+type BrowserEventSubscription struct {
+	// dropped is accessed atomically and comes first to be 64-bit aligned.
+	dropped int64
+	C       <-chan *BrowserEvent
+	events  chan *BrowserEvent
+	names   map[string]bool
+	bus     *browserEventBus
+	closed  bool
+}
+
+// Dropped returns the number of events which got dropped because C was full.
+func (s *BrowserEventSubscription) Dropped() int {
+	return int(atomic.LoadInt64(&s.dropped))
+}
+
+// Close stops the subscription and closes C.
+func (s *BrowserEventSubscription) Close() {
+	s.bus.Lock()
+	defer s.bus.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(s.bus.subscriptions, s)
+	close(s.events)
+}
+
+// browserEventBus forwards the page events of all contexts to the
+// subscriptions. Listeners get registered with the first subscription, so
+// browsers without subscriptions do not pay for it.
+type browserEventBus struct {
+	sync.Mutex
+	subscriptions map[*BrowserEventSubscription]bool
+	watching      bool
+	pages         map[*pageImpl]bool
+}
+
+func (b *browserImpl) SubscribeEvents(options ...BrowserSubscribeEventsOptions) *BrowserEventSubscription {
+	option := BrowserSubscribeEventsOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	bufferSize := 1000
+	if option.BufferSize != nil {
+		bufferSize = *option.BufferSize
+	}
+	events := make(chan *BrowserEvent, bufferSize)
+	subscription := &BrowserEventSubscription{
+		C:      events,
+		events: events,
+		bus:    &b.events,
+	}
+	if len(option.Events) > 0 {
+		subscription.names = make(map[string]bool, len(option.Events))
+		for _, name := range option.Events {
+			subscription.names[name] = true
+		}
+	}
+	b.events.Lock()
+	if b.events.subscriptions == nil {
+		b.events.subscriptions = make(map[*BrowserEventSubscription]bool)
+		b.events.pages = make(map[*pageImpl]bool)
+	}
+	b.events.subscriptions[subscription] = true
+	watching := b.events.watching
+	b.events.watching = true
+	b.events.Unlock()
+	if !watching {
+		for _, context := range b.Contexts() {
+			b.events.watchContext(context.(*browserContextImpl))
+		}
+	}
+	return subscription
+}
+
+// contextCreated starts forwarding the events of a new context if there are
+// subscriptions.
+func (e *browserEventBus) contextCreated(context *browserContextImpl) {
+	e.Lock()
+	watching := e.watching
+	e.Unlock()
+	if watching {
+		e.watchContext(context)
+	}
+}
+
+func (e *browserEventBus) watchContext(context *browserContextImpl) {
+	context.On("page", func(page *pageImpl) {
+		e.watchPage(context, page)
+	})
+	for _, page := range context.Pages() {
+		e.watchPage(context, page.(*pageImpl))
+	}
+}
+
+func (e *browserEventBus) watchPage(context *browserContextImpl, page *pageImpl) {
+	e.Lock()
+	if e.pages[page] {
+		e.Unlock()
+		return
+	}
+	e.pages[page] = true
+	e.Unlock()
+	e.publish("page", context, page, nil)
+	for _, name := range browserEventNames {
+		name := name
+		page.On(name, func(payload ...interface{}) {
+			var value interface{}
+			if len(payload) > 0 {
+				value = payload[0]
+			}
+			e.publish(name, context, page, value)
+		})
+	}
+	page.Once("close", func() {
+		e.Lock()
+		defer e.Unlock()
+		delete(e.pages, page)
+	})
+}
+
+// publish sends the event to the subscriptions without blocking, it runs on
+// the goroutine which dispatches the messages of the connection.
+func (e *browserEventBus) publish(name string, context *browserContextImpl, page *pageImpl, payload interface{}) {
+	e.Lock()
+	defer e.Unlock()
+	if len(e.subscriptions) == 0 {
+		return
+	}
+	event := &BrowserEvent{
+		Name:      name,
+		ContextID: context.guid,
+		PageID:    page.guid,
+		Context:   context,
+		Page:      page,
+		Payload:   payload,
+		Time:      time.Now(),
+	}
+	for subscription := range e.subscriptions {
+		if subscription.names != nil && !subscription.names[name] {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			atomic.AddInt64(&subscription.dropped, 1)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserEventBusPublish(t *testing.T) {
+	browser := &browserImpl{}
+	all := browser.SubscribeEvents(BrowserSubscribeEventsOptions{BufferSize: Int(1)})
+	console := browser.SubscribeEvents(BrowserSubscribeEventsOptions{Events: []string{"console"}})
+	context := &browserContextImpl{}
+	context.guid = "browser-context@1"
+	page := &pageImpl{}
+	page.guid = "page@1"
+
+	browser.events.publish("console", context, page, "message")
+	event := <-all.C
+	require.Equal(t, "console", event.Name)
+	require.Equal(t, "browser-context@1", event.ContextID)
+	require.Equal(t, "page@1", event.PageID)
+	require.Equal(t, "message", event.Payload)
+	require.Equal(t, event, <-console.C)
+
+	browser.events.publish("request", context, page, nil)
+	browser.events.publish("response", context, page, nil)
+	require.Equal(t, "request", (<-all.C).Name)
+	require.Equal(t, 1, all.Dropped())
+	require.Len(t, console.C, 0)
+
+	console.Close()
+	console.Close()
+	_, ok := <-console.C
+	require.False(t, ok)
+	browser.events.publish("console", context, page, nil)
+	require.Equal(t, "console", (<-all.C).Name)
+}
@@ -3,6 +3,7 @@ package playwright
 import (
 	"fmt"
 	"log"
+	"time"
 )
 
 type browserTypeImpl struct {
@@ -14,20 +15,51 @@ func (b *browserTypeImpl) Name() string {
 }
 
 func (b *browserTypeImpl) ExecutablePath() string {
-	return b.initializer["executablePath"].(string)
+	executablePath, _ := b.initializer["executablePath"].(string)
+	return executablePath
+}
+
+func (b *browserTypeImpl) SystemBrowsers() []SystemBrowser {
+	return findSystemBrowsers(b.Name())
 }
 
 func (b *browserTypeImpl) Launch(options ...BrowserTypeLaunchOptions) (Browser, error) {
 	overrides := map[string]interface{}{}
-	if len(options) == 1 && options[0].Env != nil {
-		overrides["env"] = serializeMapToNameAndValue(options[0].Env)
-		options[0].Env = nil
+	retries, backoff := retryOptions(nil, nil)
+	if len(options) == 1 {
+		if err := validateLaunchTarget(b.Name(), options[0].ExecutablePath); err != nil {
+			return nil, err
+		}
+		if proxy := options[0].Proxy; proxy != nil {
+			if err := validateProxy(b.Name(), proxy.Server, proxy.Username, proxy.Password); err != nil {
+				return nil, err
+			}
+		}
+		if options[0].Env != nil {
+			overrides["env"] = serializeMapToNameAndValue(options[0].Env)
+			options[0].Env = nil
+		}
+		retries, backoff = retryOptions(options[0].Retries, options[0].RetryBackoff)
+		options[0].Retries = nil
+		options[0].RetryBackoff = nil
 	}
-	channel, err := b.channel.Send("launch", overrides, options)
+	var browser *browserImpl
+	err := retryWithBackoff(retries, backoff, func() error {
+		channel, err := b.channel.Send("launch", overrides, options)
+		if err != nil {
+			return fmt.Errorf("could not send message: %w", err)
+		}
+		browser = fromChannel(channel).(*browserImpl)
+		if len(options) == 1 && options[0].Proxy != nil {
+			browser.proxy = newProxySettings(options[0].Proxy.Server, options[0].Proxy.Bypass, options[0].Proxy.Username, options[0].Proxy.Password)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("could not send message: %w", err)
+		return nil, err
 	}
-	return fromChannel(channel).(*browserImpl), nil
+	b.connection.playwright.hooks.onBrowserLaunched(browser)
+	return browser, nil
 }
 
 func (b *browserTypeImpl) LaunchPersistentContext(userDataDir string, options ...BrowserTypeLaunchPersistentContextOptions) (BrowserContext, error) {
@@ -35,35 +67,150 @@ func (b *browserTypeImpl) LaunchPersistentContext(userDataDir string, options ..
 		"userDataDir": userDataDir,
 		"sdkLanguage": "javascript",
 	}
+	var deterministic *DeterministicRenderingOptions
+	var limits *ContextLimits
+	var demoMode *DemoModeOptions
+	var metadata map[string]string
+	var preset *ContextPreset
+	var har *harRecorder
+	var err error
 	if len(options) == 1 {
+		if err := validateLaunchTarget(b.Name(), options[0].ExecutablePath); err != nil {
+			return nil, err
+		}
+		if proxy := options[0].Proxy; proxy != nil {
+			if err := validateProxy(b.Name(), proxy.Server, proxy.Username, proxy.Password); err != nil {
+				return nil, err
+			}
+		}
+		if len(options[0].Extensions) > 0 {
+			if options[0].Args, err = extensionArgs(b.Name(), options[0].Extensions, options[0].Args); err != nil {
+				return nil, err
+			}
+			if options[0].Headless == nil {
+				options[0].Headless = Bool(false)
+			}
+		}
+		options[0].Extensions = nil
+		if options[0].Device != nil {
+			deviceOverrides, err := b.connection.playwright.deviceOverrides(*options[0].Device)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range deviceOverrides {
+				overrides[key] = value
+			}
+			options[0].Device = nil
+		}
+		if options[0].Preset != nil {
+			if preset, err = b.connection.playwright.preset(*options[0].Preset); err != nil {
+				return nil, err
+			}
+			preset.applyDefaults(&options[0].Locale, &options[0].TimezoneId, &options[0].Permissions)
+			if preset.Geolocation != nil && options[0].Geolocation == nil {
+				overrides["geolocation"] = preset.Geolocation
+			}
+			options[0].ExtraHttpHeaders = preset.extraHTTPHeaders(options[0].ExtraHttpHeaders)
+			options[0].Preset = nil
+		}
 		if options[0].ExtraHttpHeaders != nil {
 			overrides["extraHTTPHeaders"] = serializeMapToNameAndValue(options[0].ExtraHttpHeaders)
+			options[0].ExtraHttpHeaders = nil
 		}
 		if options[0].Env != nil {
 			overrides["env"] = serializeMapToNameAndValue(options[0].Env)
 			options[0].Env = nil
 		}
+		metadata = options[0].Metadata
+		options[0].Metadata = nil
+		if options[0].RecordHarPath != nil {
+			var recordHar map[string]interface{}
+			if har, recordHar, err = newHarRecorder(expandArtifactPath(*options[0].RecordHarPath, metadata), options[0].RecordHarOmitContent, options[0].RecordHarContent, options[0].RecordHarMode, options[0].RecordHarUrlFilter); err != nil {
+				return nil, err
+			}
+			overrides["recordHar"] = recordHar
+		}
+		options[0].RecordHarPath = nil
+		options[0].RecordHarOmitContent = nil
+		options[0].RecordHarContent = nil
+		options[0].RecordHarMode = nil
+		options[0].RecordHarUrlFilter = nil
+		if deterministic = options[0].Deterministic; deterministic != nil {
+			applyDeterministicDefaults(&options[0].TimezoneId, &options[0].Locale, &options[0].ReducedMotion)
+			options[0].Deterministic = nil
+		}
+		limits = options[0].Limits
+		options[0].Limits = nil
+		demoMode = options[0].DemoMode
+		options[0].DemoMode = nil
 	}
 	channel, err := b.channel.Send("launchPersistentContext", overrides, options)
 	if err != nil {
 		return nil, fmt.Errorf("could not send message: %w", err)
 	}
-	return fromChannel(channel).(*browserContextImpl), nil
-}
-func (b *browserTypeImpl) Connect(url string) (Browser, error) {
-	transport := newWebSocketTransport(url)
-	connection := newConnection(transport, transport.Stop)
-	go func() {
-		err := connection.Start()
-		if err != nil {
-			log.Fatalf("could not start connection: %v", err)
+	context := fromChannel(channel).(*browserContextImpl)
+	if len(options) == 1 {
+		context.acceptDownloads = options[0].AcceptDownloads != nil && *options[0].AcceptDownloads
+		if options[0].Proxy != nil {
+			context.proxy = newProxySettings(options[0].Proxy.Server, options[0].Proxy.Bypass, options[0].Proxy.Username, options[0].Proxy.Password)
 		}
-	}()
-	obj, err := connection.CallOnObjectWithKnownName("Playwright")
+	}
+	context.har = har
+	if metadata != nil {
+		context.metadata = copyMetadata(metadata)
+	}
+	if preset != nil {
+		if err := context.applyPreset(preset); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if deterministic != nil {
+		if err := context.installDeterministicRendering(deterministic); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if demoMode != nil {
+		if err := context.installDemoMode(demoMode); err != nil {
+			context.Close()
+			return nil, err
+		}
+	}
+	if limits != nil {
+		context.applyLimits(*limits)
+	}
+	b.connection.playwright.hooks.onContextCreated(context)
+	return context, nil
+}
+func (b *browserTypeImpl) Connect(url string, options ...BrowserTypeConnectOptions) (Browser, error) {
+	option := BrowserTypeConnectOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	timeout := defaultTimeout * time.Millisecond
+	if option.Timeout != nil {
+		timeout = time.Duration(*option.Timeout * float64(time.Millisecond))
+	}
+	retries, backoff := retryOptions(option.Retries, option.RetryBackoff)
+	var transport *webSocketTransport
+	var playwright *Playwright
+	err := retryWithBackoff(retries, backoff, func() error {
+		var err error
+		transport, playwright, err = b.connect(url, timeout)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("could not call object: %w", err)
+		return nil, err
+	}
+	playwright.Devices = b.connection.playwright.Devices
+	playwright.Presets = b.connection.playwright.Presets
+	playwright.hooks = b.connection.playwright.hooks
+	if playwright.Selectors != nil {
+		if err := registerGetBySelectorEngines(playwright.Selectors); err != nil {
+			return nil, err
+		}
 	}
-	playwright := obj.(*Playwright)
 	browser := fromChannel(playwright.initializer["preLaunchedBrowser"]).(*browserImpl)
 	browser.isConnectedOverWebSocket = true
 	close_handler := func() {
@@ -76,10 +223,60 @@ func (b *browserTypeImpl) Connect(url string) (Browser, error) {
 		}
 		browser.onClose()
 	}
-	transport.(*webSocketTransport).Once("close", close_handler)
+	transport.Once("close", close_handler)
+	playwright.hooks.onBrowserLaunched(browser)
 	return browser, nil
 }
 
+// connect establishes a connection to the Playwright server at url and waits
+// for its Playwright object. A timeout of 0 disables the timeout.
+func (b *browserTypeImpl) connect(url string, timeout time.Duration) (*webSocketTransport, *Playwright, error) {
+	transport := newWebSocketTransport(url).(*webSocketTransport)
+	if err := transport.Dial(timeout); err != nil {
+		return nil, nil, err
+	}
+	connection := newConnection(transport, transport.Stop)
+	go func() {
+		if err := connection.Start(); err != nil {
+			log.Printf("could not start connection: %v", err)
+		}
+	}()
+	type connectResult struct {
+		playwright *Playwright
+		err        error
+	}
+	connected := make(chan connectResult, 1)
+	stop := make(chan struct{})
+	go func() {
+		obj, err := connection.waitForObjectWithKnownName("Playwright", stop)
+		if err != nil {
+			connected <- connectResult{err: err}
+			return
+		}
+		connected <- connectResult{playwright: obj.(*Playwright)}
+	}()
+	var timeoutChan <-chan time.Time
+	if timeout > 0 {
+		timeoutChan = time.After(timeout)
+	}
+	select {
+	case result := <-connected:
+		if result.err != nil {
+			if err := transport.Stop(); err != nil {
+				log.Printf("could not close connection: %v", err)
+			}
+			return nil, nil, fmt.Errorf("could not connect to %s: %w", url, result.err)
+		}
+		return transport, result.playwright, nil
+	case <-timeoutChan:
+		close(stop)
+		if err := transport.Stop(); err != nil {
+			log.Printf("could not close connection: %v", err)
+		}
+		return nil, nil, fmt.Errorf("Timeout %.2fms exceeded while connecting to %s.", float64(timeout)/float64(time.Millisecond), url)
+	}
+}
+
 func newBrowserType(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *browserTypeImpl {
 	bt := &browserTypeImpl{}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
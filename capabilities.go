@@ -0,0 +1,89 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotSupported matches every *NotSupportedError with errors.Is().
+var ErrNotSupported = errors.New("not supported")
+
+// NotSupportedError is returned for features which the browser does not
+// support, before they get sent to the driver. BrowserType.SupportsVideo(),
+// BrowserType.SupportsPDF(), BrowserType.SupportsCDP() and
+// BrowserType.SupportsExtensions() tell in advance.
+type NotSupportedError struct {
+	// The feature, e.g. "PDF generation".
+	Feature string
+	// Name of the browser type, e.g. "webkit".
+	Browser string
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s is not supported in %s", e.Feature, e.Browser)
+}
+
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// browserCapabilities are the features which are not available in all
+// browser types.
+type browserCapabilities struct {
+	video      bool
+	pdf        bool
+	cdp        bool
+	extensions bool
+}
+
+var capabilitiesByBrowser = map[string]browserCapabilities{
+	"chromium": {video: true, pdf: true, cdp: true, extensions: true},
+	"firefox":  {video: true},
+	"webkit":   {video: true},
+}
+
+// capabilitiesOf returns the capabilities of a browser type, unknown browser
+// types are assumed to support everything, so the driver decides.
+func capabilitiesOf(browserName string) browserCapabilities {
+	if capabilities, ok := capabilitiesByBrowser[browserName]; ok {
+		return capabilities
+	}
+	return browserCapabilities{video: true, pdf: true, cdp: true, extensions: true}
+}
+
+func (b *browserTypeImpl) SupportsVideo() bool {
+	return capabilitiesOf(b.Name()).video
+}
+
+func (b *browserTypeImpl) SupportsPDF() bool {
+	return capabilitiesOf(b.Name()).pdf
+}
+
+func (b *browserTypeImpl) SupportsCDP() bool {
+	return capabilitiesOf(b.Name()).cdp
+}
+
+func (b *browserTypeImpl) SupportsExtensions() bool {
+	return capabilitiesOf(b.Name()).extensions
+}
+
+// browserName returns the name of the browser type of the context. The
+// parent of a persistent context is its browser type.
+func (b *browserContextImpl) browserName() string {
+	if b.browser != nil {
+		return b.browser.name()
+	}
+	if b.parent != nil {
+		if name, ok := b.parent.initializer["name"].(string); ok {
+			return name
+		}
+	}
+	return "the browser"
+}
+
+func (b *browserContextImpl) requireCDP() error {
+	if name := b.browserName(); !capabilitiesOf(name).cdp {
+		return &NotSupportedError{Feature: "CDP sessions", Browser: name}
+	}
+	return nil
+}
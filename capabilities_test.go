@@ -0,0 +1,23 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotSupportedError(t *testing.T) {
+	err := fmt.Errorf("could not print: %w", &NotSupportedError{Feature: "PDF generation", Browser: "webkit"})
+	require.True(t, errors.Is(err, ErrNotSupported))
+	require.EqualError(t, err, "could not print: PDF generation is not supported in webkit")
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	require.Equal(t, browserCapabilities{video: true, pdf: true, cdp: true, extensions: true}, capabilitiesOf("chromium"))
+	require.Equal(t, browserCapabilities{video: true}, capabilitiesOf("webkit"))
+	require.Equal(t, browserCapabilities{video: true}, capabilitiesOf("firefox"))
+	require.True(t, capabilitiesOf("electron").cdp)
+	require.True(t, capabilitiesOf("electron").extensions)
+}
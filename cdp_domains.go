@@ -0,0 +1,447 @@
+package playwright
+
+import "fmt"
+
+// sendCDPCommand sends a CDP command with typed params and remaps the result
+// into the given result struct.
+func sendCDPCommand(session CDPSession, method string, params interface{}, result interface{}) error {
+	var paramsMap map[string]interface{}
+	if params != nil {
+		paramsMap = transformStructIntoMapIfNeeded(params)
+	}
+	response, err := session.Send(method, paramsMap)
+	if err != nil {
+		return fmt.Errorf("could not send %s: %w", method, err)
+	}
+	if result != nil && response != nil {
+		remapMapToStruct(response, result)
+	}
+	return nil
+}
+
+// onCDPEvent subscribes to a CDP event and remaps its params into a new value
+// of the type the handler accepts.
+func onCDPEvent(session CDPSession, event string, newEvent func() interface{}, handler func(interface{})) {
+	session.On(event, func(params map[string]interface{}) {
+		ev := newEvent()
+		if params != nil {
+			remapMapToStruct(params, ev)
+		}
+		handler(ev)
+	})
+}
+
+// CDPNetwork provides typed access to the [Network](https://chromedevtools.github.io/devtools-protocol/tot/Network/)
+// domain of the Chrome DevTools Protocol.
+type CDPNetwork struct {
+	session CDPSession
+}
+
+// Enable enables network tracking, network events will now be delivered to the client.
+func (n *CDPNetwork) Enable() error {
+	return sendCDPCommand(n.session, "Network.enable", nil, nil)
+}
+
+// Disable disables network tracking, prevents network events from being sent to the client.
+func (n *CDPNetwork) Disable() error {
+	return sendCDPCommand(n.session, "Network.disable", nil, nil)
+}
+
+// SetCacheDisabled toggles ignoring cache for each request. If `true`, cache will not be used.
+func (n *CDPNetwork) SetCacheDisabled(cacheDisabled bool) error {
+	return sendCDPCommand(n.session, "Network.setCacheDisabled", map[string]interface{}{
+		"cacheDisabled": cacheDisabled,
+	}, nil)
+}
+
+// ClearBrowserCache clears the browser cache.
+func (n *CDPNetwork) ClearBrowserCache() error {
+	return sendCDPCommand(n.session, "Network.clearBrowserCache", nil, nil)
+}
+
+// ClearBrowserCookies clears the browser cookies.
+func (n *CDPNetwork) ClearBrowserCookies() error {
+	return sendCDPCommand(n.session, "Network.clearBrowserCookies", nil, nil)
+}
+
+// SetBlockedURLs blocks URLs from loading. URL patterns can contain wildcards ('*').
+func (n *CDPNetwork) SetBlockedURLs(urls []string) error {
+	return sendCDPCommand(n.session, "Network.setBlockedURLs", map[string]interface{}{
+		"urls": urls,
+	}, nil)
+}
+
+// SetExtraHTTPHeaders specifies whether to always send extra HTTP headers with the requests from this page.
+func (n *CDPNetwork) SetExtraHTTPHeaders(headers map[string]string) error {
+	return sendCDPCommand(n.session, "Network.setExtraHTTPHeaders", map[string]interface{}{
+		"headers": headers,
+	}, nil)
+}
+
+// CDPNetworkConditions are the params of CDPNetwork.EmulateNetworkConditions()
+type CDPNetworkConditions struct {
+	// True to emulate internet disconnection.
+	Offline bool `json:"offline"`
+	// Minimum latency from request sent to response headers received (ms).
+	Latency float64 `json:"latency"`
+	// Maximal aggregated download throughput (bytes/sec). -1 disables download throttling.
+	DownloadThroughput float64 `json:"downloadThroughput"`
+	// Maximal aggregated upload throughput (bytes/sec). -1 disables upload throttling.
+	UploadThroughput float64 `json:"uploadThroughput"`
+	// Connection type if known, e.g. `cellular3g` or `wifi`.
+	ConnectionType *string `json:"connectionType"`
+}
+
+// EmulateNetworkConditions activates emulation of network conditions.
+func (n *CDPNetwork) EmulateNetworkConditions(conditions CDPNetworkConditions) error {
+	return sendCDPCommand(n.session, "Network.emulateNetworkConditions", conditions, nil)
+}
+
+// CDPNetworkResponseBody is the result of CDPNetwork.GetResponseBody()
+type CDPNetworkResponseBody struct {
+	// Response body.
+	Body string `json:"body"`
+	// True, if content was sent as base64.
+	Base64Encoded bool `json:"base64Encoded"`
+}
+
+// GetResponseBody returns content served for the given request.
+func (n *CDPNetwork) GetResponseBody(requestID string) (*CDPNetworkResponseBody, error) {
+	result := &CDPNetworkResponseBody{}
+	if err := sendCDPCommand(n.session, "Network.getResponseBody", map[string]interface{}{
+		"requestId": requestID,
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CDPNetworkRequest represents HTTP request data.
+type CDPNetworkRequest struct {
+	URL      string            `json:"url"`
+	Method   string            `json:"method"`
+	Headers  map[string]string `json:"headers"`
+	PostData string            `json:"postData"`
+}
+
+// CDPNetworkResponse represents HTTP response data.
+type CDPNetworkResponse struct {
+	URL               string            `json:"url"`
+	Status            int               `json:"status"`
+	StatusText        string            `json:"statusText"`
+	Headers           map[string]string `json:"headers"`
+	MimeType          string            `json:"mimeType"`
+	RemoteIPAddress   string            `json:"remoteIPAddress"`
+	FromDiskCache     bool              `json:"fromDiskCache"`
+	FromServiceWorker bool              `json:"fromServiceWorker"`
+	EncodedDataLength float64           `json:"encodedDataLength"`
+	Protocol          string            `json:"protocol"`
+}
+
+// CDPNetworkRequestWillBeSentEvent is fired when page is about to send HTTP request.
+type CDPNetworkRequestWillBeSentEvent struct {
+	RequestID   string            `json:"requestId"`
+	LoaderID    string            `json:"loaderId"`
+	DocumentURL string            `json:"documentURL"`
+	Request     CDPNetworkRequest `json:"request"`
+	Timestamp   float64           `json:"timestamp"`
+	Type        string            `json:"type"`
+	FrameID     string            `json:"frameId"`
+}
+
+// CDPNetworkResponseReceivedEvent is fired when HTTP response is available.
+type CDPNetworkResponseReceivedEvent struct {
+	RequestID string             `json:"requestId"`
+	LoaderID  string             `json:"loaderId"`
+	Timestamp float64            `json:"timestamp"`
+	Type      string             `json:"type"`
+	Response  CDPNetworkResponse `json:"response"`
+	FrameID   string             `json:"frameId"`
+}
+
+// CDPNetworkLoadingFinishedEvent is fired when HTTP request has finished loading.
+type CDPNetworkLoadingFinishedEvent struct {
+	RequestID         string  `json:"requestId"`
+	Timestamp         float64 `json:"timestamp"`
+	EncodedDataLength float64 `json:"encodedDataLength"`
+}
+
+// CDPNetworkLoadingFailedEvent is fired when HTTP request has failed to load.
+type CDPNetworkLoadingFailedEvent struct {
+	RequestID string  `json:"requestId"`
+	Timestamp float64 `json:"timestamp"`
+	Type      string  `json:"type"`
+	ErrorText string  `json:"errorText"`
+	Canceled  bool    `json:"canceled"`
+}
+
+// OnRequestWillBeSent subscribes to the `Network.requestWillBeSent` event.
+func (n *CDPNetwork) OnRequestWillBeSent(handler func(*CDPNetworkRequestWillBeSentEvent)) {
+	onCDPEvent(n.session, "Network.requestWillBeSent", func() interface{} {
+		return &CDPNetworkRequestWillBeSentEvent{}
+	}, func(ev interface{}) {
+		handler(ev.(*CDPNetworkRequestWillBeSentEvent))
+	})
+}
+
+// OnResponseReceived subscribes to the `Network.responseReceived` event.
+func (n *CDPNetwork) OnResponseReceived(handler func(*CDPNetworkResponseReceivedEvent)) {
+	onCDPEvent(n.session, "Network.responseReceived", func() interface{} {
+		return &CDPNetworkResponseReceivedEvent{}
+	}, func(ev interface{}) {
+		handler(ev.(*CDPNetworkResponseReceivedEvent))
+	})
+}
+
+// OnLoadingFinished subscribes to the `Network.loadingFinished` event.
+func (n *CDPNetwork) OnLoadingFinished(handler func(*CDPNetworkLoadingFinishedEvent)) {
+	onCDPEvent(n.session, "Network.loadingFinished", func() interface{} {
+		return &CDPNetworkLoadingFinishedEvent{}
+	}, func(ev interface{}) {
+		handler(ev.(*CDPNetworkLoadingFinishedEvent))
+	})
+}
+
+// OnLoadingFailed subscribes to the `Network.loadingFailed` event.
+func (n *CDPNetwork) OnLoadingFailed(handler func(*CDPNetworkLoadingFailedEvent)) {
+	onCDPEvent(n.session, "Network.loadingFailed", func() interface{} {
+		return &CDPNetworkLoadingFailedEvent{}
+	}, func(ev interface{}) {
+		handler(ev.(*CDPNetworkLoadingFailedEvent))
+	})
+}
+
+// CDPEmulation provides typed access to the [Emulation](https://chromedevtools.github.io/devtools-protocol/tot/Emulation/)
+// domain of the Chrome DevTools Protocol.
+type CDPEmulation struct {
+	session CDPSession
+}
+
+// CDPEmulationDeviceMetrics are the params of CDPEmulation.SetDeviceMetricsOverride()
+type CDPEmulationDeviceMetrics struct {
+	// Overriding width value in pixels. 0 disables the override.
+	Width int `json:"width"`
+	// Overriding height value in pixels. 0 disables the override.
+	Height int `json:"height"`
+	// Overriding device scale factor value. 0 disables the override.
+	DeviceScaleFactor float64 `json:"deviceScaleFactor"`
+	// Whether to emulate mobile device.
+	Mobile bool `json:"mobile"`
+	// Screen orientation override.
+	ScreenOrientation *CDPEmulationScreenOrientation `json:"screenOrientation"`
+}
+
+// CDPEmulationScreenOrientation is the screen orientation of CDPEmulationDeviceMetrics
+type CDPEmulationScreenOrientation struct {
+	// Orientation type, one of `portraitPrimary`, `portraitSecondary`, `landscapePrimary` or `landscapeSecondary`.
+	Type string `json:"type"`
+	// Orientation angle.
+	Angle int `json:"angle"`
+}
+
+// SetDeviceMetricsOverride overrides the values of device screen dimensions.
+func (e *CDPEmulation) SetDeviceMetricsOverride(metrics CDPEmulationDeviceMetrics) error {
+	return sendCDPCommand(e.session, "Emulation.setDeviceMetricsOverride", metrics, nil)
+}
+
+// ClearDeviceMetricsOverride clears the overridden device metrics.
+func (e *CDPEmulation) ClearDeviceMetricsOverride() error {
+	return sendCDPCommand(e.session, "Emulation.clearDeviceMetricsOverride", nil, nil)
+}
+
+// SetCPUThrottlingRate enables CPU throttling to emulate slow CPUs. 1 is no throttle, 2 is 2x slowdown, etc.
+func (e *CDPEmulation) SetCPUThrottlingRate(rate float64) error {
+	return sendCDPCommand(e.session, "Emulation.setCPUThrottlingRate", map[string]interface{}{
+		"rate": rate,
+	}, nil)
+}
+
+// SetTimezoneOverride overrides default host system timezone with the specified one, e.g. `Europe/Berlin`.
+func (e *CDPEmulation) SetTimezoneOverride(timezoneID string) error {
+	return sendCDPCommand(e.session, "Emulation.setTimezoneOverride", map[string]interface{}{
+		"timezoneId": timezoneID,
+	}, nil)
+}
+
+// SetGeolocationOverride overrides the Geolocation Position or Error.
+func (e *CDPEmulation) SetGeolocationOverride(latitude, longitude, accuracy float64) error {
+	return sendCDPCommand(e.session, "Emulation.setGeolocationOverride", map[string]interface{}{
+		"latitude":  latitude,
+		"longitude": longitude,
+		"accuracy":  accuracy,
+	}, nil)
+}
+
+// ClearGeolocationOverride clears the overridden Geolocation Position and Error.
+func (e *CDPEmulation) ClearGeolocationOverride() error {
+	return sendCDPCommand(e.session, "Emulation.clearGeolocationOverride", nil, nil)
+}
+
+// UserAgentMetadata is the [User-Agent Client Hints](https://wicg.github.io/ua-client-hints/) data which gets
+// returned by `navigator.userAgentData` and sent via the `Sec-CH-UA-*` headers.
+type UserAgentMetadata struct {
+	Brands          []UserAgentBrandVersion `json:"brands"`
+	FullVersion     *string                 `json:"fullVersion"`
+	Platform        string                  `json:"platform"`
+	PlatformVersion string                  `json:"platformVersion"`
+	Architecture    string                  `json:"architecture"`
+	Model           string                  `json:"model"`
+	Mobile          bool                    `json:"mobile"`
+}
+
+// UserAgentBrandVersion is a brand of UserAgentMetadata
+type UserAgentBrandVersion struct {
+	Brand   string `json:"brand"`
+	Version string `json:"version"`
+}
+
+// SetUserAgentOverride allows overriding user agent with the given string and optionally the client hints metadata.
+func (e *CDPEmulation) SetUserAgentOverride(userAgent string, metadata ...UserAgentMetadata) error {
+	params := map[string]interface{}{
+		"userAgent": userAgent,
+	}
+	if len(metadata) == 1 {
+		params["userAgentMetadata"] = transformStructIntoMapIfNeeded(metadata[0])
+	}
+	return sendCDPCommand(e.session, "Emulation.setUserAgentOverride", params, nil)
+}
+
+// SetEmulatedMedia emulates the given media type (e.g. `print`) and CSS media features
+// (e.g. `prefers-reduced-motion`).
+func (e *CDPEmulation) SetEmulatedMedia(media string, features map[string]string) error {
+	serializedFeatures := make([]map[string]string, 0)
+	for name, value := range features {
+		serializedFeatures = append(serializedFeatures, map[string]string{
+			"name":  name,
+			"value": value,
+		})
+	}
+	return sendCDPCommand(e.session, "Emulation.setEmulatedMedia", map[string]interface{}{
+		"media":    media,
+		"features": serializedFeatures,
+	}, nil)
+}
+
+// CDPPerformance provides typed access to the [Performance](https://chromedevtools.github.io/devtools-protocol/tot/Performance/)
+// domain of the Chrome DevTools Protocol.
+type CDPPerformance struct {
+	session CDPSession
+}
+
+// CDPPerformanceMetric is a single run-time execution metric.
+type CDPPerformanceMetric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// Enable enables collecting and reporting metrics.
+func (p *CDPPerformance) Enable() error {
+	return sendCDPCommand(p.session, "Performance.enable", nil, nil)
+}
+
+// Disable disables collecting and reporting metrics.
+func (p *CDPPerformance) Disable() error {
+	return sendCDPCommand(p.session, "Performance.disable", nil, nil)
+}
+
+// GetMetrics retrieves current values of run-time metrics.
+func (p *CDPPerformance) GetMetrics() ([]CDPPerformanceMetric, error) {
+	result := &struct {
+		Metrics []CDPPerformanceMetric `json:"metrics"`
+	}{}
+	if err := sendCDPCommand(p.session, "Performance.getMetrics", nil, result); err != nil {
+		return nil, err
+	}
+	return result.Metrics, nil
+}
+
+// CDPStorage provides typed access to the [Storage](https://chromedevtools.github.io/devtools-protocol/tot/Storage/)
+// domain of the Chrome DevTools Protocol.
+type CDPStorage struct {
+	session CDPSession
+}
+
+// ClearDataForOrigin clears storage for origin. `storageTypes` is a comma separated list of storage types to clear,
+// e.g. `cookies,local_storage,indexeddb` or `all`.
+func (s *CDPStorage) ClearDataForOrigin(origin, storageTypes string) error {
+	return sendCDPCommand(s.session, "Storage.clearDataForOrigin", map[string]interface{}{
+		"origin":       origin,
+		"storageTypes": storageTypes,
+	}, nil)
+}
+
+// CDPStorageUsageAndQuota is the result of CDPStorage.GetUsageAndQuota()
+type CDPStorageUsageAndQuota struct {
+	// Storage usage (bytes).
+	Usage float64 `json:"usage"`
+	// Storage quota (bytes).
+	Quota float64 `json:"quota"`
+	// Whether or not the origin has an active storage quota override.
+	OverrideActive bool `json:"overrideActive"`
+}
+
+// GetUsageAndQuota returns usage and quota in bytes.
+func (s *CDPStorage) GetUsageAndQuota(origin string) (*CDPStorageUsageAndQuota, error) {
+	result := &CDPStorageUsageAndQuota{}
+	if err := sendCDPCommand(s.session, "Storage.getUsageAndQuota", map[string]interface{}{
+		"origin": origin,
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// OverrideQuotaForOrigin overrides quota for the specified origin. Pass 0 to remove the override.
+func (s *CDPStorage) OverrideQuotaForOrigin(origin string, quotaSize float64) error {
+	params := map[string]interface{}{
+		"origin": origin,
+	}
+	if quotaSize > 0 {
+		params["quotaSize"] = quotaSize
+	}
+	return sendCDPCommand(s.session, "Storage.overrideQuotaForOrigin", params, nil)
+}
+
+// CDPBrowser provides typed access to the [Browser](https://chromedevtools.github.io/devtools-protocol/tot/Browser/)
+// domain of the Chrome DevTools Protocol.
+type CDPBrowser struct {
+	session CDPSession
+}
+
+// CDPBrowserWindow is the result of CDPBrowser.GetWindowForTarget()
+type CDPBrowserWindow struct {
+	// Browser window id.
+	WindowID int `json:"windowId"`
+	// Bounds information of the window.
+	Bounds WindowBounds `json:"bounds"`
+}
+
+// GetWindowForTarget returns the browser window which contains the target of the session.
+func (b *CDPBrowser) GetWindowForTarget() (*CDPBrowserWindow, error) {
+	result := &CDPBrowserWindow{}
+	if err := sendCDPCommand(b.session, "Browser.getWindowForTarget", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetWindowBounds returns the position and size of the browser window.
+func (b *CDPBrowser) GetWindowBounds(windowID int) (*WindowBounds, error) {
+	result := &struct {
+		Bounds WindowBounds `json:"bounds"`
+	}{}
+	if err := sendCDPCommand(b.session, "Browser.getWindowBounds", map[string]interface{}{
+		"windowId": windowID,
+	}, result); err != nil {
+		return nil, err
+	}
+	return &result.Bounds, nil
+}
+
+// SetWindowBounds sets the position and/or size of the browser window. Leaves unspecified fields unchanged.
+func (b *CDPBrowser) SetWindowBounds(windowID int, bounds WindowBounds) error {
+	return sendCDPCommand(b.session, "Browser.setWindowBounds", map[string]interface{}{
+		"windowId": windowID,
+		"bounds":   transformStructIntoMapIfNeeded(bounds),
+	}, nil)
+}
@@ -21,6 +21,26 @@ func (c *cdpSessionImpl) Send(method string, params map[string]interface{}) (int
 	return result, err
 }
 
+func (c *cdpSessionImpl) Network() *CDPNetwork {
+	return &CDPNetwork{session: c}
+}
+
+func (c *cdpSessionImpl) Emulation() *CDPEmulation {
+	return &CDPEmulation{session: c}
+}
+
+func (c *cdpSessionImpl) Performance() *CDPPerformance {
+	return &CDPPerformance{session: c}
+}
+
+func (c *cdpSessionImpl) Storage() *CDPStorage {
+	return &CDPStorage{session: c}
+}
+
+func (c *cdpSessionImpl) Browser() *CDPBrowser {
+	return &CDPBrowser{session: c}
+}
+
 func (c *cdpSessionImpl) onEvent(params map[string]interface{}) {
 	c.Emit(params["method"].(string), params["params"])
 }
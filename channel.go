@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
@@ -14,16 +15,44 @@ type channel struct {
 }
 
 func (c *channel) Send(method string, options ...interface{}) (interface{}, error) {
-	return c.innerSend(method, false, options...)
+	return c.innerSend(contextFromOptions(options...), method, false, options...)
+}
+
+// SendContext is like Send, but uses the given context instead of the
+// Context option of the call.
+func (c *channel) SendContext(ctx context.Context, method string, options ...interface{}) (interface{}, error) {
+	return c.innerSend(ctx, method, false, options...)
 }
 
 func (c *channel) SendReturnAsDict(method string, options ...interface{}) (interface{}, error) {
-	return c.innerSend(method, true, options...)
+	return c.innerSend(contextFromOptions(options...), method, true, options...)
 }
 
-func (c *channel) innerSend(method string, returnAsDict bool, options ...interface{}) (interface{}, error) {
+func (c *channel) innerSend(ctx context.Context, method string, returnAsDict bool, options ...interface{}) (interface{}, error) {
+	for _, option := range options {
+		if err := validateOptions(option); err != nil {
+			return nil, err
+		}
+	}
 	params := transformOptions(options...)
-	result, err := c.connection.SendMessageToServer(c.guid, method, params)
+	if ctx == nil {
+		ctx = context.Background()
+	} else {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("could not send message to server: %w", err)
+		}
+		c.boundTimeout(ctx, method, params, options...)
+	}
+	result, err := c.connection.SendMessageToServerContext(ctx, c.guid, method, params)
+	if err != nil && ctx.Err() != nil {
+		c.stopNavigation(method)
+	} else if err != nil {
+		if frame, ok := c.object.(*frameImpl); ok {
+			if result, err = frame.retryAfterNavigation(method, params, err); err != nil {
+				err = frame.suggestSelectors(method, params, err)
+			}
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not send message to server: %w", err)
 	}
@@ -45,6 +74,72 @@ func (c *channel) innerSend(method string, returnAsDict bool, options ...interfa
 	return result, nil
 }
 
+// navigationMethods are the methods whose timeout defaults to the navigation
+// timeout.
+var navigationMethods = map[string]bool{
+	"goto":      true,
+	"reload":    true,
+	"goBack":    true,
+	"goForward": true,
+}
+
+// boundTimeout makes a call which has a Timeout option time out in the driver
+// at the deadline of ctx at the latest.
+func (c *channel) boundTimeout(ctx context.Context, method string, params map[string]interface{}, options ...interface{}) {
+	if _, ok := ctx.Deadline(); !ok {
+		return
+	}
+	if _, ok := optionsField("Timeout", options...); !ok {
+		return
+	}
+	var settings *timeoutSettings
+	switch owner := c.object.(type) {
+	case *frameImpl:
+		if owner.page != nil {
+			settings = owner.page.timeoutSettings
+		}
+	case *pageImpl:
+		settings = owner.timeoutSettings
+	}
+	timeout := 0.0
+	switch v := params["timeout"].(type) {
+	case *float64:
+		timeout = *v
+	case float64:
+		timeout = v
+	case int:
+		timeout = float64(v)
+	default:
+		if settings != nil && navigationMethods[method] {
+			timeout = settings.NavigationTimeout()
+		} else if settings != nil {
+			timeout = settings.Timeout()
+		}
+	}
+	params["timeout"] = boundTimeout(ctx, timeout)
+}
+
+// stopNavigation stops the navigation of a navigating call whose context got
+// done, so that it does not keep loading in the background.
+func (c *channel) stopNavigation(method string) {
+	if !navigationMethods[method] {
+		return
+	}
+	var frame *frameImpl
+	switch owner := c.object.(type) {
+	case *frameImpl:
+		frame = owner
+	case *pageImpl:
+		frame = owner.mainFrame.(*frameImpl)
+	}
+	if frame == nil {
+		return
+	}
+	go func() {
+		_, _ = frame.Evaluate("() => window.stop()")
+	}()
+}
+
 func (c *channel) SendNoReply(method string, options ...interface{}) {
 	params := transformOptions(options...)
 	_, err := c.connection.SendMessageToServer(c.guid, method, params)
@@ -0,0 +1,52 @@
+package playwright
+
+import (
+	"fmt"
+)
+
+type clipboardImpl struct {
+	page *pageImpl
+}
+
+func (c *clipboardImpl) ReadText() (string, error) {
+	if err := c.grantPermissions(); err != nil {
+		return "", err
+	}
+	text, err := c.page.Evaluate("() => navigator.clipboard.readText()")
+	if err != nil {
+		return "", fmt.Errorf("could not read clipboard: %w", err)
+	}
+	return text.(string), nil
+}
+
+func (c *clipboardImpl) WriteText(text string) error {
+	if err := c.grantPermissions(); err != nil {
+		return err
+	}
+	if _, err := c.page.Evaluate("text => navigator.clipboard.writeText(text)", text); err != nil {
+		return fmt.Errorf("could not write clipboard: %w", err)
+	}
+	return nil
+}
+
+// grantPermissions allows the current origin of the page to access the
+// clipboard, pages with an opaque origin like about:blank get the permissions
+// for all origins.
+func (c *clipboardImpl) grantPermissions() error {
+	origin, err := c.page.Evaluate("() => location.origin")
+	if err != nil {
+		return fmt.Errorf("could not get origin: %w", err)
+	}
+	options := BrowserContextGrantPermissionsOptions{}
+	if origin, ok := origin.(string); ok && origin != "null" {
+		options.Origin = String(origin)
+	}
+	if err := c.page.browserContext.GrantPermissions([]string{"clipboard-read", "clipboard-write"}, options); err != nil {
+		return fmt.Errorf("could not grant clipboard permissions: %w", err)
+	}
+	return nil
+}
+
+func newClipboard(page *pageImpl) *clipboardImpl {
+	return &clipboardImpl{page}
+}
@@ -0,0 +1,360 @@
+package playwright
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// clockFrameInterval is the interval in milliseconds in which the fake clock
+// runs animation frames, which matches a display with 60Hz.
+const clockFrameInterval = 16
+
+// clockScript installs the clock of the page. With fake timers, the timer
+// functions get replaced with fake ones which only advance when they get
+// driven from the Clock, CSS and Web Animations get paused and are moved
+// forward together with the clock. Without, only the time returned by `Date`
+// can be fixed. Installing the fake timers later upgrades the clock.
+const clockScript = `([startTime, fakeTimers]) => {
+  if (window.__playwrightClock) {
+    if (fakeTimers)
+      window.__playwrightClock.installTimers(startTime);
+    return;
+  }
+  const frameInterval = %d;
+  const NativeDate = window.Date;
+  const nativeSetTimeout = window.setTimeout.bind(window);
+  const nativeClearTimeout = window.clearTimeout.bind(window);
+  let origin = startTime;
+  let now = startTime;
+  let fixedTime;
+  let timersInstalled = false;
+  let ticker;
+  let lastId = 0;
+  const timers = new Map();
+  const frames = new Map();
+
+  const toCallback = callback => typeof callback === 'function' ? callback : new Function(String(callback));
+  const addTimer = (callback, delay, args, isInterval) => {
+    const id = ++lastId;
+    delay = Math.max(0, Number(delay) || 0);
+    if (isInterval)
+      delay = Math.max(1, delay);
+    timers.set(id, { id, callback: toCallback(callback), args, delay, callAt: now + delay, isInterval });
+    return id;
+  };
+  const nextTimer = () => {
+    let result;
+    for (const timer of timers.values()) {
+      if (!result || timer.callAt < result.callAt)
+        result = timer;
+    }
+    return result;
+  };
+  const nextFrameAt = () => origin + (Math.floor((now - origin) / frameInterval) + 1) * frameInterval;
+  const syncAnimations = delta => {
+    if (!document.getAnimations)
+      return;
+    for (const animation of document.getAnimations()) {
+      if (animation.playState !== 'paused')
+        animation.pause();
+      animation.currentTime = (animation.currentTime || 0) + delta;
+    }
+  };
+  const advanceTo = time => {
+    if (time > now) {
+      syncAnimations(time - now);
+      now = time;
+    }
+  };
+  const runTimer = timer => {
+    advanceTo(timer.callAt);
+    if (timer.isInterval)
+      timer.callAt += timer.delay;
+    else
+      timers.delete(timer.id);
+    timer.callback.apply(window, timer.args);
+  };
+  const runFrame = () => {
+    const callbacks = [...frames.values()];
+    frames.clear();
+    const timestamp = now - origin;
+    for (const callback of callbacks)
+      callback(timestamp);
+  };
+  const currentTime = () => fixedTime !== undefined ? fixedTime : now;
+  const requireTimers = () => {
+    if (!timersInstalled)
+      throw new Error('Clock is not installed, call Clock.Install() first');
+  };
+
+  class FakeDate extends NativeDate {
+    constructor(...args) {
+      if (args.length)
+        super(...args);
+      else
+        super(currentTime());
+    }
+    static now() {
+      return currentTime();
+    }
+  }
+  window.Date = FakeDate;
+
+  window.__playwrightClock = {
+    installTimers(time) {
+      if (timersInstalled)
+        return;
+      timersInstalled = true;
+      origin = time;
+      now = time;
+      window.setTimeout = (callback, delay, ...args) => addTimer(callback, delay, args, false);
+      window.setInterval = (callback, delay, ...args) => addTimer(callback, delay, args, true);
+      window.clearTimeout = id => timers.delete(id);
+      window.clearInterval = id => timers.delete(id);
+      window.requestAnimationFrame = callback => {
+        const id = ++lastId;
+        frames.set(id, callback);
+        return id;
+      };
+      window.cancelAnimationFrame = id => frames.delete(id);
+      window.performance.now = () => now - origin;
+      syncAnimations(0);
+    },
+    setFixedTime(time) {
+      fixedTime = time;
+    },
+    runFor(ms) {
+      requireTimers();
+      const target = now + ms;
+      while (true) {
+        const timer = nextTimer();
+        const frameAt = frames.size ? nextFrameAt() : Infinity;
+        const timerAt = timer ? timer.callAt : Infinity;
+        if (Math.min(timerAt, frameAt) > target)
+          break;
+        if (timerAt <= frameAt) {
+          runTimer(timer);
+        } else {
+          advanceTo(frameAt);
+          runFrame();
+        }
+      }
+      advanceTo(target);
+      return now;
+    },
+    runFrames(count) {
+      requireTimers();
+      for (let i = 0; i < count; i++)
+        this.runFor(nextFrameAt() - now);
+      return now;
+    },
+    flushTimers(limit) {
+      requireTimers();
+      for (let i = 0; i < limit; i++) {
+        const timer = nextTimer();
+        if (!timer)
+          return now;
+        runTimer(timer);
+      }
+      throw new Error('Aborting after running ' + limit + ' timers, assuming an infinite loop!');
+    },
+    fastForward(ms) {
+      requireTimers();
+      const target = now + ms;
+      const due = [...timers.values()].filter(timer => timer.callAt <= target).sort((a, b) => a.callAt - b.callAt);
+      advanceTo(target);
+      for (const timer of due) {
+        if (timer.isInterval)
+          timer.callAt = now + timer.delay;
+        else
+          timers.delete(timer.id);
+        timer.callback.apply(window, timer.args);
+      }
+      if (frames.size)
+        runFrame();
+      return now;
+    },
+    pauseAt(time) {
+      requireTimers();
+      this.pause();
+      if (time < now)
+        throw new Error('Cannot pause at ' + new NativeDate(time).toISOString() + ', the clock is at ' + new NativeDate(now).toISOString() + ' already');
+      return this.runFor(time - now);
+    },
+    pause() {
+      if (ticker !== undefined)
+        nativeClearTimeout(ticker);
+      ticker = undefined;
+    },
+    resume() {
+      requireTimers();
+      if (ticker !== undefined)
+        return;
+      let last = NativeDate.now();
+      const tick = () => {
+        const current = NativeDate.now();
+        ticker = nativeSetTimeout(tick, frameInterval);
+        this.runFor(current - last);
+        last = current;
+      };
+      ticker = nativeSetTimeout(tick, frameInterval);
+    },
+  };
+  if (fakeTimers)
+    window.__playwrightClock.installTimers(startTime);
+}`
+
+// clockFunction returns the function which installs the clock, it gets
+// called with the start time and whether to install the fake timers.
+func clockFunction() string {
+	return fmt.Sprintf(clockScript, clockFrameInterval)
+}
+
+func clockMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// clockFixedTimeScript fixes the time of a document, which got installed with
+// clockScript before. Later changes of the fixed time are fetched from the
+// binding whose name is passed in.
+const clockFixedTimeScript = `([time, binding]) => {
+  const clock = window.__playwrightClock;
+  clock.setFixedTime(time);
+  if (window[binding])
+    window[binding]().then(time => clock.setFixedTime(time)).catch(() => {});
+}`
+
+const clockFixedTimeBinding = "__playwrightClockFixedTime"
+
+var clockFixedTimeCounter int32
+
+// clockFlushTimersLimit is the maximum amount of timers which FlushTimers
+// runs before it assumes an infinite loop.
+const clockFlushTimersLimit = 10000
+
+// clockImpl is the clock of a page or, applying to all of its pages, of a
+// browser context.
+type clockImpl struct {
+	// fixedTime is read by the binding of the documents which get created
+	// after SetFixedTime() got called again, so that the init script which
+	// fixes the time only gets added once. It comes first to be 64-bit
+	// aligned for the atomic operations.
+	fixedTime int64
+	sync.Mutex
+	addInitScript    func(script string) error
+	exposeBinding    func(name string, binding BindingCallFunction) error
+	pages            func() []Page
+	fixedTimeBinding string
+}
+
+func (c *clockImpl) Install(options ...ClockInstallOptions) error {
+	startTime := time.Now()
+	if len(options) == 1 && options[0].Time != nil {
+		startTime = *options[0].Time
+	}
+	if err := addInitFunction(c.addInitScript, c.pages(), clockFunction(), []interface{}{clockMillis(startTime), true}); err != nil {
+		return fmt.Errorf("could not install clock: %w", err)
+	}
+	return nil
+}
+
+func (c *clockImpl) SetFixedTime(t time.Time) error {
+	if err := c.setFixedTime(clockMillis(t)); err != nil {
+		return fmt.Errorf("could not set fixed time: %w", err)
+	}
+	return nil
+}
+
+func (c *clockImpl) setFixedTime(time int64) error {
+	c.Lock()
+	defer c.Unlock()
+	atomic.StoreInt64(&c.fixedTime, time)
+	if c.fixedTimeBinding != "" {
+		return evaluateInFrames(c.pages(), "time => window.__playwrightClock.setFixedTime(time)", time)
+	}
+	binding := fmt.Sprintf("%s%d", clockFixedTimeBinding, atomic.AddInt32(&clockFixedTimeCounter, 1))
+	if err := c.exposeBinding(binding, func(source *BindingSource, args ...interface{}) interface{} {
+		return atomic.LoadInt64(&c.fixedTime)
+	}); err != nil {
+		return err
+	}
+	pages := c.pages()
+	if err := addInitFunction(c.addInitScript, pages, clockFunction(), []interface{}{time, false}); err != nil {
+		return err
+	}
+	if err := addInitFunction(c.addInitScript, pages, clockFixedTimeScript, []interface{}{time, binding}); err != nil {
+		return err
+	}
+	c.fixedTimeBinding = binding
+	return nil
+}
+
+func (c *clockImpl) RunFor(duration time.Duration) error {
+	return c.run("(clock, ms) => clock.runFor(ms)", clockDuration(duration))
+}
+
+func (c *clockImpl) FastForward(duration time.Duration) error {
+	return c.run("(clock, ms) => clock.fastForward(ms)", clockDuration(duration))
+}
+
+func (c *clockImpl) PauseAt(t time.Time) error {
+	return c.run("(clock, time) => clock.pauseAt(time)", clockMillis(t))
+}
+
+func (c *clockImpl) Resume() error {
+	return c.run("clock => clock.resume()", nil)
+}
+
+func (c *clockImpl) AdvanceAnimationFrames(count int) error {
+	return c.run("(clock, count) => clock.runFrames(count)", count)
+}
+
+func (c *clockImpl) FlushTimers() error {
+	return c.run("(clock, limit) => clock.flushTimers(limit)", clockFlushTimersLimit)
+}
+
+func (c *clockImpl) run(fn string, arg interface{}) error {
+	for _, page := range c.pages() {
+		if _, err := page.Evaluate(fmt.Sprintf(`arg => {
+  const clock = window.__playwrightClock;
+  if (!clock)
+    throw new Error('Clock is not installed, call Clock.Install() first');
+  return (%s)(clock, arg);
+}`, fn), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func clockDuration(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+func newClock(page *pageImpl) *clockImpl {
+	return &clockImpl{
+		addInitScript: func(script string) error {
+			return page.AddInitScript(PageAddInitScriptOptions{Script: String(script)})
+		},
+		exposeBinding: func(name string, binding BindingCallFunction) error {
+			return page.ExposeBinding(name, binding)
+		},
+		pages: func() []Page {
+			return []Page{page}
+		},
+	}
+}
+
+func newContextClock(context *browserContextImpl) *clockImpl {
+	return &clockImpl{
+		addInitScript: func(script string) error {
+			return context.AddInitScript(BrowserContextAddInitScriptOptions{Script: String(script)})
+		},
+		exposeBinding: func(name string, binding BindingCallFunction) error {
+			return context.ExposeBinding(name, binding)
+		},
+		pages: context.Pages,
+	}
+}
@@ -0,0 +1,64 @@
+// Command hargen generates Go route handler stubs from a HAR recording.
+//
+//	go run github.com/neilspage/playwright-go/cmd/hargen -har session.har -out fixtures/stubs.go
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/neilspage/playwright-go/hargen"
+)
+
+func main() {
+	harPath := flag.String("har", "", "path of the HAR file")
+	out := flag.String("out", "", "path of the generated Go file, defaults to stdout")
+	pkg := flag.String("package", "", "package name of the generated file, defaults to the name of the output directory or fixtures")
+	filter := flag.String("filter", "", "only generate stubs for URLs matching this regular expression")
+	flag.Parse()
+	if *harPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	archive, err := hargen.Load(*harPath)
+	if err != nil {
+		log.Fatalf("could not load HAR: %v", err)
+	}
+	options := hargen.Options{
+		Package: *pkg,
+		Source:  filepath.Base(*harPath),
+	}
+	if options.Package == "" && *out != "" {
+		dir, err := filepath.Abs(filepath.Dir(*out))
+		if err != nil {
+			log.Fatalf("could not resolve output directory: %v", err)
+		}
+		options.Package = filepath.Base(dir)
+	}
+	if *filter != "" {
+		pattern, err := regexp.Compile(*filter)
+		if err != nil {
+			log.Fatalf("invalid filter: %v", err)
+		}
+		options.Include = func(entry *hargen.Entry) bool {
+			return pattern.MatchString(entry.Request.URL)
+		}
+	}
+	source, err := hargen.Generate(archive, options)
+	if err != nil {
+		log.Fatalf("could not generate stubs: %v", err)
+	}
+	if *out == "" {
+		if _, err := os.Stdout.Write(source); err != nil {
+			log.Fatalf("could not write stubs: %v", err)
+		}
+		return
+	}
+	if err := ioutil.WriteFile(*out, source, 0644); err != nil {
+		log.Fatalf("could not write stubs: %v", err)
+	}
+}
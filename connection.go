@@ -1,9 +1,11 @@
 package playwright
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 )
 
 type callback struct {
@@ -19,8 +21,21 @@ type connection struct {
 	lastID                      int
 	lastIDLock                  sync.Mutex
 	rootObject                  *channelOwner
+	playwright                  *Playwright
 	callbacks                   sync.Map
 	stopDriver                  func() error
+	middlewaresLock             sync.RWMutex
+	middlewares                 []ProtocolMiddleware
+	sentHandlers                []func(message ProtocolMessage)
+	receivedHandlers            []func(message ProtocolMessage)
+	healthLock                  sync.RWMutex
+	healthErr                   error
+	lastMessage                 time.Time
+	unhealthyHandlers           []func(err error)
+	stopHealthCheck             chan bool
+	pendingPing                 *pendingPing
+	sourceTracings              int32
+	tracingGroups               int32
 }
 
 func (c *connection) Start() error {
@@ -28,6 +43,12 @@ func (c *connection) Start() error {
 }
 
 func (c *connection) Stop() error {
+	c.healthLock.Lock()
+	if c.stopHealthCheck != nil {
+		close(c.stopHealthCheck)
+		c.stopHealthCheck = nil
+	}
+	c.healthLock.Unlock()
 	if err := c.transport.Stop(); err != nil {
 		return fmt.Errorf("could not stop transport: %w", err)
 	}
@@ -35,15 +56,44 @@ func (c *connection) Stop() error {
 }
 
 func (c *connection) CallOnObjectWithKnownName(name string) (interface{}, error) {
-	if _, ok := c.waitingForRemoteObjects[name]; !ok {
+	return c.waitForObjectWithKnownName(name, nil)
+}
+
+// waitForObjectWithKnownName waits for the object with the name until stop
+// gets closed.
+func (c *connection) waitForObjectWithKnownName(name string, stop <-chan struct{}) (interface{}, error) {
+	c.waitingForRemoteObjectsLock.Lock()
+	waiting, ok := c.waitingForRemoteObjects[name]
+	if !ok {
+		// buffered, so that creating the object does not block when the
+		// waiting stopped meanwhile
+		waiting = make(chan interface{}, 1)
+		c.waitingForRemoteObjects[name] = waiting
+	}
+	c.waitingForRemoteObjectsLock.Unlock()
+	select {
+	case object := <-waiting:
+		return object, nil
+	case <-stop:
 		c.waitingForRemoteObjectsLock.Lock()
-		c.waitingForRemoteObjects[name] = make(chan interface{})
+		delete(c.waitingForRemoteObjects, name)
 		c.waitingForRemoteObjectsLock.Unlock()
+		return nil, fmt.Errorf("stopped waiting for %s", name)
 	}
-	return <-c.waitingForRemoteObjects[name], nil
 }
 
 func (c *connection) Dispatch(msg *message) {
+	c.touch()
+	id := msg.ID
+	if msg = c.receive(msg); msg == nil {
+		// the call would wait forever for its dropped response otherwise
+		if cb, ok := c.callbacks.Load(id); ok && id != 0 {
+			cb.(chan callback) <- callback{
+				Error: errMessageDropped,
+			}
+		}
+		return
+	}
 	method := msg.Method
 	if msg.ID != 0 {
 		cb, _ := c.callbacks.Load(msg.ID)
@@ -139,6 +189,12 @@ func (c *connection) replaceGuidsWithChannels(payload interface{}) interface{} {
 }
 
 func (c *connection) SendMessageToServer(guid string, method string, params interface{}) (interface{}, error) {
+	return c.SendMessageToServerContext(context.Background(), guid, method, params)
+}
+
+// SendMessageToServerContext sends the message and returns the error of ctx
+// as soon as it is done. The response of the driver is discarded then.
+func (c *connection) SendMessageToServerContext(ctx context.Context, guid string, method string, params interface{}) (interface{}, error) {
 	c.lastIDLock.Lock()
 	c.lastID++
 	id := c.lastID
@@ -149,11 +205,25 @@ func (c *connection) SendMessageToServer(guid string, method string, params inte
 		"method": method,
 		"params": c.replaceChannelsWithGuids(params),
 	}
+	if metadata := c.callMetadata(guid, method); metadata != nil {
+		message["metadata"] = metadata
+	}
 	cb, _ := c.callbacks.LoadOrStore(id, make(chan callback))
-	if err := c.transport.Send(message); err != nil {
+	if err := c.send(message); err != nil {
+		c.callbacks.Delete(id)
 		return nil, fmt.Errorf("could not send message: %w", err)
 	}
-	result := <-cb.(chan callback)
+	var result callback
+	select {
+	case result = <-cb.(chan callback):
+	case <-ctx.Done():
+		// the callback must stay registered until the driver responded
+		go func() {
+			<-cb.(chan callback)
+			c.callbacks.Delete(id)
+		}()
+		return nil, ctx.Err()
+	}
 	c.callbacks.Delete(id)
 	if result.Error != nil {
 		return nil, result.Error
@@ -0,0 +1,143 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultHealthCheckTimeout is the time after which the driver is considered
+// unresponsive if it did not answer a health check.
+const defaultHealthCheckTimeout = 30 * time.Second
+
+// DriverUnresponsiveError is returned by Connection.Ping() and passed to the
+// Connection.OnUnhealthy() handlers when the driver did not answer a health
+// check in time.
+type DriverUnresponsiveError struct {
+	// Time which was waited for the answer of the driver.
+	Timeout time.Duration
+	// Time at which the last message was received from the driver.
+	LastMessage time.Time
+}
+
+func (e *DriverUnresponsiveError) Error() string {
+	return fmt.Sprintf("driver did not respond within %s, last message was received at %s", e.Timeout, e.LastMessage.Format(time.RFC3339))
+}
+
+// pendingPing is a health check which waits for the answer of the driver.
+// Only one is sent at a time, so that an unresponsive driver does not pile up
+// a blocked goroutine for each health check.
+type pendingPing struct {
+	done chan struct{}
+	err  error
+}
+
+func (c *connection) Ping(timeout time.Duration) error {
+	if c.playwright == nil {
+		return errors.New("connection is not initialized")
+	}
+	ping := c.ping()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ping.done:
+		c.setHealth(ping.err)
+		return ping.err
+	case <-timer.C:
+		err := &DriverUnresponsiveError{
+			Timeout:     timeout,
+			LastMessage: c.lastMessageTime(),
+		}
+		c.setHealth(err)
+		return err
+	}
+}
+
+// ping returns the pending ping or sends a new one if there is none.
+func (c *connection) ping() *pendingPing {
+	c.healthLock.Lock()
+	defer c.healthLock.Unlock()
+	if c.pendingPing != nil {
+		return c.pendingPing
+	}
+	ping := &pendingPing{
+		done: make(chan struct{}),
+	}
+	c.pendingPing = ping
+	go func() {
+		// The driver does not know the method and answers with an error,
+		// which is enough to know that it is still processing messages.
+		_, err := c.SendMessageToServer(c.playwright.guid, "__ping__", nil)
+		var playwrightErr *Error
+		if err != nil && errors.As(err, &playwrightErr) {
+			err = nil
+		}
+		ping.err = err
+		c.healthLock.Lock()
+		c.pendingPing = nil
+		c.healthLock.Unlock()
+		close(ping.done)
+	}()
+	return ping
+}
+
+func (c *connection) Healthy() bool {
+	c.healthLock.RLock()
+	defer c.healthLock.RUnlock()
+	return c.healthErr == nil
+}
+
+func (c *connection) OnUnhealthy(handler func(err error)) {
+	c.healthLock.Lock()
+	defer c.healthLock.Unlock()
+	c.unhealthyHandlers = append(c.unhealthyHandlers, handler)
+}
+
+func (c *connection) setHealth(err error) {
+	c.healthLock.Lock()
+	wasHealthy := c.healthErr == nil
+	c.healthErr = err
+	handlers := c.unhealthyHandlers
+	c.healthLock.Unlock()
+	if err != nil && wasHealthy {
+		for _, handler := range handlers {
+			handler(err)
+		}
+	}
+}
+
+func (c *connection) lastMessageTime() time.Time {
+	c.healthLock.RLock()
+	defer c.healthLock.RUnlock()
+	return c.lastMessage
+}
+
+func (c *connection) touch() {
+	c.healthLock.Lock()
+	defer c.healthLock.Unlock()
+	c.lastMessage = time.Now()
+}
+
+// startHealthCheck pings the driver every interval until the connection gets
+// stopped.
+func (c *connection) startHealthCheck(interval, timeout time.Duration) {
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	c.healthLock.Lock()
+	c.stopHealthCheck = make(chan bool)
+	stop := c.stopHealthCheck
+	c.healthLock.Unlock()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_ = c.Ping(timeout)
+			}
+		}
+	}()
+}
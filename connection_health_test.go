@@ -0,0 +1,66 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionPingShouldBeHealthyWhenDriverAnswers(t *testing.T) {
+	connection, transport := newFakeConnection()
+	connection.playwright = &Playwright{}
+	go func() {
+		sent := <-transport.sent
+		require.Equal(t, "__ping__", sent["method"])
+		transport.dispatch(&message{
+			ID: sent["id"].(int),
+			Error: &struct {
+				Error errorPayload `json:"error"`
+			}{
+				Error: errorPayload{Name: "Error", Message: "unknown method"},
+			},
+		})
+	}()
+	require.NoError(t, connection.Ping(time.Second))
+	require.True(t, connection.Healthy())
+}
+
+func TestConnectionPingShouldDetectUnresponsiveDriver(t *testing.T) {
+	connection, _ := newFakeConnection()
+	connection.playwright = &Playwright{}
+	unhealthy := make(chan error, 1)
+	connection.OnUnhealthy(func(err error) {
+		unhealthy <- err
+	})
+	err := connection.Ping(50 * time.Millisecond)
+	var unresponsiveErr *DriverUnresponsiveError
+	require.True(t, errors.As(err, &unresponsiveErr))
+	require.Equal(t, 50*time.Millisecond, unresponsiveErr.Timeout)
+	require.False(t, connection.Healthy())
+	require.Equal(t, err, <-unhealthy)
+}
+
+func TestConnectionPingShouldNotPileUpWhileDriverIsUnresponsive(t *testing.T) {
+	connection, transport := newFakeConnection()
+	connection.playwright = &Playwright{}
+	for i := 0; i < 3; i++ {
+		require.Error(t, connection.Ping(10*time.Millisecond))
+	}
+	require.Len(t, transport.sent, 1)
+	go func() {
+		for sent := range transport.sent {
+			transport.dispatch(&message{
+				ID: sent["id"].(int),
+				Error: &struct {
+					Error errorPayload `json:"error"`
+				}{
+					Error: errorPayload{Name: "Error", Message: "unknown method"},
+				},
+			})
+		}
+	}()
+	require.NoError(t, connection.Ping(time.Second))
+	require.True(t, connection.Healthy())
+}
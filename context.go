@@ -0,0 +1,120 @@
+package playwright
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"time"
+)
+
+// WithContext runs fn, which calls one or more blocking Playwright methods,
+// and returns as soon as fn returned or ctx is done, whichever happens
+// first. When ctx is done first, its error gets returned, so
+// errors.Is(err, context.DeadlineExceeded) and errors.Is(err, context.Canceled)
+// work as usual.
+//
+// WithContext only stops waiting for fn, the calls of fn keep running in the
+// background until they finish or their Playwright timeout elapses. To abort
+// a call when ctx is done, pass ctx as its Context option instead:
+//
+//	_, err := page.Goto(url, playwright.PageGotoOptions{
+//		Context: ctx,
+//	})
+func WithContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TimeoutFromContext returns the time in milliseconds which is left until
+// the deadline of ctx, to be used as the Timeout option of a call. Returns
+// nil when ctx has no deadline, so the default timeout applies.
+func TimeoutFromContext(ctx context.Context) *float64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	remaining := float64(time.Until(deadline)) / float64(time.Millisecond)
+	// A timeout of 0 disables the timeout in Playwright.
+	if remaining < 1 {
+		remaining = 1
+	}
+	return Float(remaining)
+}
+
+// contextDone returns the done channel of ctx, or nil for a nil ctx so that
+// selecting on it blocks forever.
+func contextDone(ctx context.Context) <-chan struct{} {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Done()
+}
+
+// optionsField returns the field with the given name of the options struct
+// of a call. The options are passed like to channel.Send(), e.g. as a slice
+// of option structs next to a map of parameters.
+func optionsField(name string, options ...interface{}) (reflect.Value, bool) {
+	for _, option := range options {
+		v := reflect.ValueOf(option)
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || v.Kind() == reflect.Slice {
+			if v.Kind() == reflect.Slice {
+				if v.Len() == 0 {
+					break
+				}
+				v = v.Index(0)
+			} else {
+				if v.IsNil() {
+					break
+				}
+				v = v.Elem()
+			}
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		if field := v.FieldByName(name); field.IsValid() {
+			return field, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// contextFromOptions returns the Context option of a call, or nil when it
+// has none. The call returns the error of the context as soon as it is done,
+// the driver is not waited for then, and the deadline of the context bounds
+// the Timeout of the call. Navigations get stopped when the context gets
+// canceled, since the driver can not cancel them.
+func contextFromOptions(options ...interface{}) context.Context {
+	field, ok := optionsField("Context", options...)
+	if !ok || field.IsNil() {
+		return nil
+	}
+	ctx, _ := field.Interface().(context.Context)
+	return ctx
+}
+
+// boundTimeout returns the timeout in milliseconds of a call which must
+// finish before the deadline of ctx. A timeout of 0 means no timeout, like in
+// Playwright.
+func boundTimeout(ctx context.Context, timeout float64) float64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+	remaining := math.Max(1, float64(time.Until(deadline))/float64(time.Millisecond))
+	if timeout == 0 || remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
@@ -0,0 +1,80 @@
+package playwright
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContext(t *testing.T) {
+	require.NoError(t, WithContext(context.Background(), func() error {
+		return nil
+	}))
+	fnErr := errors.New("failed")
+	require.Equal(t, fnErr, WithContext(context.Background(), func() error {
+		return fnErr
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+	err := WithContext(ctx, func() error {
+		<-release
+		return nil
+	})
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	err = WithContext(canceled, func() error {
+		called = true
+		return nil
+	})
+	require.True(t, errors.Is(err, context.Canceled))
+	require.False(t, called)
+}
+
+func TestTimeoutFromContext(t *testing.T) {
+	require.Nil(t, TimeoutFromContext(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	timeout := TimeoutFromContext(ctx)
+	require.NotNil(t, timeout)
+	require.InDelta(t, 1000, *timeout, 100)
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	require.Equal(t, float64(1), *TimeoutFromContext(expired))
+}
+
+func TestContextFromOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.Nil(t, contextFromOptions())
+	require.Nil(t, contextFromOptions(map[string]interface{}{"url": "about:blank"}, []PageGotoOptions{}))
+	require.Nil(t, contextFromOptions([]PageGotoOptions{{}}))
+	require.Equal(t, ctx, contextFromOptions(map[string]interface{}{"url": "about:blank"}, []PageGotoOptions{{Context: ctx}}))
+	require.Equal(t, ctx, contextFromOptions(&PageClickOptions{Context: ctx}))
+
+	params := transformOptions(map[string]interface{}{"url": "about:blank"}, []PageGotoOptions{{Context: ctx, Timeout: Float(10)}})
+	require.Len(t, params, 2)
+	require.Equal(t, "about:blank", params["url"])
+	require.Equal(t, Float(10), params["timeout"])
+}
+
+func TestBoundTimeout(t *testing.T) {
+	require.Equal(t, float64(500), boundTimeout(context.Background(), 500))
+	require.Equal(t, float64(0), boundTimeout(context.Background(), 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.Equal(t, float64(500), boundTimeout(ctx, 500))
+	require.InDelta(t, 1000, boundTimeout(ctx, 0), 100)
+	require.InDelta(t, 1000, boundTimeout(ctx, 30000), 100)
+}
@@ -0,0 +1,108 @@
+package playwright
+
+import "fmt"
+
+// DemoModeOptions renders the input of the automation into the pages of a
+// browser context, so recorded videos and screencasts can double as demo
+// footage. It gets enabled with the `DemoMode` option of Browser.NewContext()
+// and BrowserType.LaunchPersistentContext(). The overlay lives in the top
+// frame and does not receive pointer events.
+type DemoModeOptions struct {
+	// Whether to render a cursor which follows the mouse. Defaults to true.
+	Cursor *bool
+	// Whether to render a ripple where the mouse gets pressed. Defaults to true.
+	Clicks *bool
+	// Whether to render a caption with the text of the field which is being
+	// typed into. The text of password fields gets masked. Defaults to true.
+	Captions *bool
+}
+
+// demoModeScript adds the overlay in a closed shadow root, so neither the
+// styles of the page nor selectors reach into it.
+const demoModeScript = `options => {
+  if (window !== window.top || window.__playwrightDemoMode)
+    return;
+  window.__playwrightDemoMode = true;
+  const setTimeout = window.setTimeout.bind(window);
+  const clearTimeout = window.clearTimeout.bind(window);
+  const host = document.createElement('x-pw-demo');
+  host.setAttribute('style', 'position: fixed; inset: 0; pointer-events: none; z-index: 2147483647;');
+  const root = host.attachShadow({ mode: 'closed' });
+  const style = document.createElement('style');
+  style.textContent =
+    '.cursor { position: fixed; left: 0; top: 0; width: 20px; height: 20px; margin: -12px 0 0 -12px; border-radius: 50%; ' +
+    'background: rgba(0, 0, 0, 0.35); border: 2px solid white; box-shadow: 0 0 4px rgba(0, 0, 0, 0.6); display: none; ' +
+    'transition: transform 80ms linear; } ' +
+    '.ripple { position: fixed; width: 40px; height: 40px; margin: -23px 0 0 -23px; border-radius: 50%; ' +
+    'border: 3px solid rgba(255, 64, 64, 0.9); animation: ripple 500ms ease-out forwards; } ' +
+    '@keyframes ripple { from { transform: scale(0.2); opacity: 1; } to { transform: scale(1.5); opacity: 0; } } ' +
+    '.caption { position: fixed; left: 50%; bottom: 32px; transform: translateX(-50%); max-width: 80%; padding: 8px 16px; ' +
+    'border-radius: 6px; background: rgba(0, 0, 0, 0.75); color: white; font: 20px sans-serif; white-space: pre-wrap; ' +
+    'overflow-wrap: anywhere; display: none; }';
+  const cursor = document.createElement('div');
+  cursor.className = 'cursor';
+  const caption = document.createElement('div');
+  caption.className = 'caption';
+  root.append(style, cursor, caption);
+  const attach = () => {
+    if (!host.isConnected && document.documentElement)
+      document.documentElement.appendChild(host);
+  };
+  document.addEventListener('DOMContentLoaded', attach);
+  attach();
+
+  const move = event => {
+    attach();
+    cursor.style.display = 'block';
+    cursor.style.transform = 'translate(' + event.clientX + 'px, ' + event.clientY + 'px)';
+  };
+  if (options.cursor)
+    window.addEventListener('mousemove', move, true);
+  if (options.clicks) {
+    window.addEventListener('mousedown', event => {
+      if (options.cursor)
+        move(event);
+      const ripple = document.createElement('div');
+      ripple.className = 'ripple';
+      ripple.style.left = event.clientX + 'px';
+      ripple.style.top = event.clientY + 'px';
+      root.appendChild(ripple);
+      setTimeout(() => ripple.remove(), 600);
+    }, true);
+  }
+  if (options.captions) {
+    let hideTimer;
+    window.addEventListener('input', event => {
+      const target = event.target;
+      let text;
+      if (target instanceof HTMLInputElement || target instanceof HTMLTextAreaElement)
+        text = target.type === 'password' ? '•'.repeat(target.value.length) : target.value;
+      else if (target instanceof HTMLElement && target.isContentEditable)
+        text = target.innerText;
+      else
+        return;
+      attach();
+      caption.textContent = text;
+      caption.style.display = text ? 'block' : 'none';
+      clearTimeout(hideTimer);
+      hideTimer = setTimeout(() => caption.style.display = 'none', 1500);
+    }, true);
+  }
+}`
+
+// installDemoMode adds the overlay of the demo mode to the context and its
+// existing pages, e.g. the initial page of a persistent context.
+func (b *browserContextImpl) installDemoMode(options *DemoModeOptions) error {
+	enabled := func(option *bool) bool {
+		return option == nil || *option
+	}
+	config := map[string]interface{}{
+		"cursor":   enabled(options.Cursor),
+		"clicks":   enabled(options.Clicks),
+		"captions": enabled(options.Captions),
+	}
+	if err := b.addInitFunction(demoModeScript, config); err != nil {
+		return fmt.Errorf("could not enable demo mode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package playwright
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeterministicRenderingOptions makes the pages of a browser context render the
+// same way in every run, e.g. for reproducible screenshots. It gets enabled
+// with the `Deterministic` option of Browser.NewContext() and
+// BrowserType.LaunchPersistentContext():
+//   - the clock of the pages is frozen, it only advances when driven by Page.Clock()
+//   - Math.random returns the same sequence in every document
+//   - CSS animations and transitions finish immediately and smooth scrolling is disabled
+//   - the text caret is hidden
+//   - the timezone, locale and reduced motion preference are fixed, unless they are set explicitly
+type DeterministicRenderingOptions struct {
+	// Time the clock of the pages is frozen at. Defaults to 2020-01-01T00:00:00Z.
+	Time *time.Time
+	// Seed of Math.random. Defaults to 1.
+	Seed *int
+}
+
+var (
+	deterministicTime     = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	deterministicTimezone = "UTC"
+	deterministicLocale   = "en-US"
+)
+
+// deterministicScript seeds Math.random with mulberry32 and injects a style
+// sheet which disables animations, transitions, smooth scrolling and the
+// caret. Smooth scrolling requested from scripts gets replaced as well.
+const deterministicScript = `seed => {
+  if (window.__playwrightDeterministic)
+    return;
+  window.__playwrightDeterministic = true;
+  let state = seed >>> 0;
+  Math.random = () => {
+    state = (state + 0x6D2B79F5) >>> 0;
+    let t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+  };
+  const css = '*, *::before, *::after { animation-duration: 0s !important; animation-delay: 0s !important; ' +
+    'transition-duration: 0s !important; transition-delay: 0s !important; scroll-behavior: auto !important; ' +
+    'caret-color: transparent !important; }';
+  const inject = () => {
+    const style = document.createElement('style');
+    style.textContent = css;
+    (document.head || document.documentElement).appendChild(style);
+  };
+  if (document.readyState === 'loading')
+    document.addEventListener('DOMContentLoaded', inject);
+  else
+    inject();
+  const instant = options => options && typeof options === 'object' && options.behavior === 'smooth' ? { ...options, behavior: 'auto' } : options;
+  for (const target of [window, Element.prototype]) {
+    for (const name of ['scroll', 'scrollTo', 'scrollBy']) {
+      const original = target[name];
+      if (original)
+        target[name] = function(...args) { return original.apply(this, args.map(instant)); };
+    }
+  }
+  const scrollIntoView = Element.prototype.scrollIntoView;
+  Element.prototype.scrollIntoView = function(options) {
+    return scrollIntoView.call(this, instant(options));
+  };
+}`
+
+// applyDeterministicDefaults fixes the context options which influence the
+// rendering, explicitly set ones are kept.
+func applyDeterministicDefaults(timezoneID, locale **string, reducedMotion **ReducedMotion) {
+	if *timezoneID == nil {
+		*timezoneID = String(deterministicTimezone)
+	}
+	if *locale == nil {
+		*locale = String(deterministicLocale)
+	}
+	if *reducedMotion == nil {
+		*reducedMotion = ReducedMotionReduce
+	}
+}
+
+// installDeterministicRendering adds the init scripts of the deterministic
+// rendering mode to the context and runs them in its existing pages, e.g. the
+// initial page of a persistent context.
+func (b *browserContextImpl) installDeterministicRendering(options *DeterministicRenderingOptions) error {
+	startTime := deterministicTime
+	if options.Time != nil {
+		startTime = *options.Time
+	}
+	seed := 1
+	if options.Seed != nil {
+		seed = *options.Seed
+	}
+	if err := b.addInitFunction(clockFunction(), []interface{}{clockMillis(startTime), true}); err != nil {
+		return fmt.Errorf("could not enable deterministic rendering: %w", err)
+	}
+	if err := b.addInitFunction(deterministicScript, seed); err != nil {
+		return fmt.Errorf("could not enable deterministic rendering: %w", err)
+	}
+	return nil
+}
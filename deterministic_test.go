@@ -0,0 +1,15 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDeterministicDefaults(t *testing.T) {
+	options := BrowserNewContextOptions{Locale: String("de-DE")}
+	applyDeterministicDefaults(&options.TimezoneId, &options.Locale, &options.ReducedMotion)
+	require.Equal(t, "UTC", *options.TimezoneId)
+	require.Equal(t, "de-DE", *options.Locale)
+	require.Equal(t, ReducedMotionReduce, options.ReducedMotion)
+}
@@ -0,0 +1,351 @@
+package playwright
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DOMNode is an element or a text node of a DOMSnapshot.
+type DOMNode struct {
+	// Lower-cased tag name of elements, "#text" for text nodes.
+	Name string `json:"name"`
+	// Attributes of elements, the tokens of the class attribute are sorted.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Text of text nodes with collapsed whitespace. Adjacent text nodes get merged.
+	Text string `json:"text,omitempty"`
+	// Computed styles of elements which got requested with the Styles option.
+	Styles   map[string]string `json:"styles,omitempty"`
+	Children []*DOMNode        `json:"children,omitempty"`
+}
+
+// DOMSnapshot is a normalized serialization of a DOM tree which got taken
+// with Page.DOMSnapshot(). Scripts, styles, comments and whitespace-only text
+// are left out, so snapshots of the same structure compare equal.
+type DOMSnapshot struct {
+	URL  string   `json:"url"`
+	Root *DOMNode `json:"root"`
+}
+
+// DOMChangeType is the kind of a DOMChange.
+type DOMChangeType string
+
+const (
+	// DOMChangeAdded is a node which only exists in the second snapshot.
+	DOMChangeAdded DOMChangeType = "added"
+	// DOMChangeRemoved is a node which only exists in the first snapshot.
+	DOMChangeRemoved DOMChangeType = "removed"
+	// DOMChangeText is a text node whose text changed.
+	DOMChangeText DOMChangeType = "text"
+	// DOMChangeAttribute is an attribute which got added, removed or changed.
+	DOMChangeAttribute DOMChangeType = "attribute"
+	// DOMChangeStyle is a computed style which changed.
+	DOMChangeStyle DOMChangeType = "style"
+)
+
+// DOMChange is a difference between two snapshots found by DiffDOMSnapshots().
+type DOMChange struct {
+	Type DOMChangeType
+	// Path of the node, e.g. "html > body > div[2] > p", the index is only
+	// added for siblings with the same name.
+	Path string
+	// Name of the changed attribute or style property.
+	Name string
+	// Before and After are the serialized nodes for added and removed nodes,
+	// the texts or the attribute and style values otherwise. Missing
+	// attributes are empty.
+	Before string
+	After  string
+}
+
+func (c DOMChange) String() string {
+	switch c.Type {
+	case DOMChangeAdded:
+		return fmt.Sprintf("%s: added %s", c.Path, c.After)
+	case DOMChangeRemoved:
+		return fmt.Sprintf("%s: removed %s", c.Path, c.Before)
+	case DOMChangeText:
+		return fmt.Sprintf("%s: text changed from %q to %q", c.Path, c.Before, c.After)
+	}
+	return fmt.Sprintf("%s: %s %s changed from %q to %q", c.Path, c.Type, c.Name, c.Before, c.After)
+}
+
+const domSnapshotScript = `({ selector, styles, ignoreAttributes, ignoreSelectors }) => {
+  const root = selector ? document.querySelector(selector) : document.documentElement;
+  if (!root)
+    throw new Error('No element matches selector ' + selector);
+  const SKIP = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE']);
+  const ignored = new Set(ignoreAttributes);
+  const visit = node => {
+    if (node.nodeType === Node.TEXT_NODE || node.nodeType === Node.CDATA_SECTION_NODE) {
+      const text = node.textContent.replace(/\s+/g, ' ').trim();
+      return text ? { name: '#text', text } : null;
+    }
+    if (node.nodeType !== Node.ELEMENT_NODE || SKIP.has(node.nodeName.toUpperCase()))
+      return null;
+    if (ignoreSelectors.some(s => node.matches(s)))
+      return null;
+    const result = { name: node.localName };
+    const attributes = {};
+    for (const attribute of node.attributes) {
+      if (ignored.has(attribute.name))
+        continue;
+      attributes[attribute.name] = attribute.name === 'class' ? attribute.value.split(/\s+/).filter(Boolean).sort().join(' ') : attribute.value;
+    }
+    if (Object.keys(attributes).length)
+      result.attributes = attributes;
+    if (styles.length) {
+      const computed = getComputedStyle(node);
+      result.styles = {};
+      for (const property of styles)
+        result.styles[property] = computed.getPropertyValue(property);
+    }
+    const children = [];
+    for (const child of node.childNodes) {
+      const serialized = visit(child);
+      if (!serialized)
+        continue;
+      const last = children[children.length - 1];
+      if (last && last.name === '#text' && serialized.name === '#text')
+        last.text += ' ' + serialized.text;
+      else
+        children.push(serialized);
+    }
+    if (children.length)
+      result.children = children;
+    return result;
+  };
+  return visit(root);
+}`
+
+func (p *pageImpl) DOMSnapshot(options ...PageDOMSnapshotOptions) (*DOMSnapshot, error) {
+	option := PageDOMSnapshotOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	selector := ""
+	if option.Selector != nil {
+		selector = *option.Selector
+	}
+	result, err := p.mainFrame.Evaluate(domSnapshotScript, map[string]interface{}{
+		"selector":         selector,
+		"styles":           nonNilStrings(option.Styles),
+		"ignoreAttributes": nonNilStrings(option.IgnoreAttributes),
+		"ignoreSelectors":  nonNilStrings(option.IgnoreSelectors),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not take DOM snapshot: %w", err)
+	}
+	return &DOMSnapshot{
+		URL:  p.URL(),
+		Root: parseDOMNode(result),
+	}, nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func parseDOMNode(in interface{}) *DOMNode {
+	serialized, ok := in.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	node := &DOMNode{}
+	node.Name, _ = serialized["name"].(string)
+	node.Text, _ = serialized["text"].(string)
+	if attributes, ok := serialized["attributes"]; ok {
+		node.Attributes = toStringMap(attributes)
+	}
+	if styles, ok := serialized["styles"]; ok {
+		node.Styles = toStringMap(styles)
+	}
+	children, _ := serialized["children"].([]interface{})
+	for _, child := range children {
+		if parsed := parseDOMNode(child); parsed != nil {
+			node.Children = append(node.Children, parsed)
+		}
+	}
+	return node
+}
+
+// String serializes the snapshot as indented markup with sorted attributes,
+// which is stable enough to be stored as a golden file.
+func (s *DOMSnapshot) String() string {
+	out := &strings.Builder{}
+	if s.Root != nil {
+		writeDOMNode(out, s.Root, 0)
+	}
+	return out.String()
+}
+
+func writeDOMNode(out *strings.Builder, node *DOMNode, depth int) {
+	out.WriteString(strings.Repeat("  ", depth))
+	out.WriteString(node.describe())
+	out.WriteString("\n")
+	for _, child := range node.Children {
+		writeDOMNode(out, child, depth+1)
+	}
+}
+
+// describe returns the node without its children, e.g.
+// `<a class="link" href="/"> {color: red}` or `"text"`.
+func (n *DOMNode) describe() string {
+	if n.Name == "#text" {
+		return strconv.Quote(n.Text)
+	}
+	out := &strings.Builder{}
+	out.WriteString("<" + n.Name)
+	for _, name := range sortedKeys(n.Attributes) {
+		fmt.Fprintf(out, " %s=%q", name, n.Attributes[name])
+	}
+	out.WriteString(">")
+	if len(n.Styles) > 0 {
+		styles := make([]string, 0, len(n.Styles))
+		for _, name := range sortedKeys(n.Styles) {
+			styles = append(styles, name+": "+n.Styles[name])
+		}
+		out.WriteString(" {" + strings.Join(styles, "; ") + "}")
+	}
+	return out.String()
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiffDOMSnapshots returns the changes between two snapshots, in document
+// order. Children get aligned by their name and id, so inserting an element
+// reports a single added node instead of changes to all its next siblings.
+func DiffDOMSnapshots(before, after *DOMSnapshot) []DOMChange {
+	var changes []DOMChange
+	switch {
+	case before.Root == nil && after.Root == nil:
+	case before.Root == nil:
+		changes = append(changes, DOMChange{Type: DOMChangeAdded, Path: after.Root.Name, After: after.Root.describe()})
+	case after.Root == nil:
+		changes = append(changes, DOMChange{Type: DOMChangeRemoved, Path: before.Root.Name, Before: before.Root.describe()})
+	case domNodeKey(before.Root) != domNodeKey(after.Root):
+		changes = append(changes,
+			DOMChange{Type: DOMChangeRemoved, Path: before.Root.Name, Before: before.Root.describe()},
+			DOMChange{Type: DOMChangeAdded, Path: after.Root.Name, After: after.Root.describe()})
+	default:
+		diffDOMNodes(&changes, before.Root.Name, before.Root, after.Root)
+	}
+	return changes
+}
+
+// domNodeKey identifies the nodes which are compared with each other.
+func domNodeKey(node *DOMNode) string {
+	return node.Name + "#" + node.Attributes["id"]
+}
+
+func diffDOMNodes(changes *[]DOMChange, path string, before, after *DOMNode) {
+	if before.Text != after.Text {
+		*changes = append(*changes, DOMChange{Type: DOMChangeText, Path: path, Before: before.Text, After: after.Text})
+	}
+	diffStringMaps(changes, DOMChangeAttribute, path, before.Attributes, after.Attributes)
+	diffStringMaps(changes, DOMChangeStyle, path, before.Styles, after.Styles)
+	beforePaths := childPaths(path, before.Children)
+	afterPaths := childPaths(path, after.Children)
+	i, j := 0, 0
+	for _, pair := range alignDOMNodes(before.Children, after.Children) {
+		for ; i < pair[0]; i++ {
+			*changes = append(*changes, DOMChange{Type: DOMChangeRemoved, Path: beforePaths[i], Before: before.Children[i].describe()})
+		}
+		for ; j < pair[1]; j++ {
+			*changes = append(*changes, DOMChange{Type: DOMChangeAdded, Path: afterPaths[j], After: after.Children[j].describe()})
+		}
+		diffDOMNodes(changes, afterPaths[j], before.Children[i], after.Children[j])
+		i++
+		j++
+	}
+	for ; i < len(before.Children); i++ {
+		*changes = append(*changes, DOMChange{Type: DOMChangeRemoved, Path: beforePaths[i], Before: before.Children[i].describe()})
+	}
+	for ; j < len(after.Children); j++ {
+		*changes = append(*changes, DOMChange{Type: DOMChangeAdded, Path: afterPaths[j], After: after.Children[j].describe()})
+	}
+}
+
+func diffStringMaps(changes *[]DOMChange, changeType DOMChangeType, path string, before, after map[string]string) {
+	names := map[string]bool{}
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		if before[name] != after[name] {
+			*changes = append(*changes, DOMChange{Type: changeType, Path: path, Name: name, Before: before[name], After: after[name]})
+		}
+	}
+}
+
+// childPaths returns the paths of the children, siblings with the same name
+// get a 1-based index.
+func childPaths(parent string, children []*DOMNode) []string {
+	counts := map[string]int{}
+	for _, child := range children {
+		counts[child.Name]++
+	}
+	seen := map[string]int{}
+	paths := make([]string, len(children))
+	for i, child := range children {
+		seen[child.Name]++
+		paths[i] = parent + " > " + child.Name
+		if counts[child.Name] > 1 {
+			paths[i] += fmt.Sprintf("[%d]", seen[child.Name])
+		}
+	}
+	return paths
+}
+
+// alignDOMNodes returns the index pairs of the longest common subsequence of
+// the node keys.
+func alignDOMNodes(before, after []*DOMNode) [][2]int {
+	lengths := make([][]int, len(before)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if domNodeKey(before[i]) == domNodeKey(after[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	var pairs [][2]int
+	for i, j := 0, 0; i < len(before) && j < len(after); {
+		switch {
+		case domNodeKey(before[i]) == domNodeKey(after[j]):
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
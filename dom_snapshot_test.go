@@ -0,0 +1,47 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDOMSnapshotString(t *testing.T) {
+	snapshot := &DOMSnapshot{Root: &DOMNode{
+		Name:       "ul",
+		Attributes: map[string]string{"id": "list", "class": "a b"},
+		Styles:     map[string]string{"display": "block", "color": "red"},
+		Children: []*DOMNode{
+			{Name: "li", Children: []*DOMNode{{Name: "#text", Text: `say "hi"`}}},
+		},
+	}}
+	require.Equal(t, "<ul class=\"a b\" id=\"list\"> {color: red; display: block}\n  <li>\n    \"say \\\"hi\\\"\"\n", snapshot.String())
+}
+
+func TestDiffDOMSnapshots(t *testing.T) {
+	text := func(s string) *DOMNode {
+		return &DOMNode{Name: "#text", Text: s}
+	}
+	before := &DOMSnapshot{Root: &DOMNode{Name: "body", Children: []*DOMNode{
+		{Name: "h1", Children: []*DOMNode{text("Title")}},
+		{Name: "p", Attributes: map[string]string{"class": "intro"}, Children: []*DOMNode{text("one")}},
+		{Name: "p", Children: []*DOMNode{text("two")}},
+		{Name: "footer"},
+	}}}
+	after := &DOMSnapshot{Root: &DOMNode{Name: "body", Children: []*DOMNode{
+		{Name: "div", Attributes: map[string]string{"id": "banner"}},
+		{Name: "h1", Children: []*DOMNode{text("New title")}},
+		{Name: "p", Children: []*DOMNode{text("one")}},
+		{Name: "p", Children: []*DOMNode{text("two")}},
+	}}}
+	changes := DiffDOMSnapshots(before, after)
+	require.Equal(t, []DOMChange{
+		{Type: DOMChangeAdded, Path: "body > div", After: `<div id="banner">`},
+		{Type: DOMChangeText, Path: "body > h1 > #text", Before: "Title", After: "New title"},
+		{Type: DOMChangeAttribute, Path: "body > p[1]", Name: "class", Before: "intro"},
+		{Type: DOMChangeRemoved, Path: "body > footer", Before: "<footer>"},
+	}, changes)
+	require.Equal(t, `body > p[1]: attribute class changed from "intro" to ""`, changes[2].String())
+	require.Equal(t, `body > div: added <div id="banner">`, changes[0].String())
+	require.Empty(t, DiffDOMSnapshots(before, before))
+}
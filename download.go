@@ -1,5 +1,18 @@
 package playwright
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
 type downloadImpl struct {
 	page              *pageImpl
 	url               string
@@ -55,3 +68,125 @@ func newDownload(page *pageImpl, url string, suggestedFilename string, artifact
 		artifact:          artifact,
 	}
 }
+
+// DownloadMatch is the result of Page.ExpectDownloadMatching().
+type DownloadMatch struct {
+	Download Download
+	// MIME type of the download, taken from the `Content-Type` header of the response when the page saw it, from the
+	// extension of the suggested filename otherwise.
+	MimeType string
+	// Size of the downloaded file in bytes.
+	Size int64
+	// Hex encoded SHA-256 hash of the content of the download.
+	SHA256 string
+}
+
+func (p *pageImpl) ExpectDownloadMatching(cb func() error, options ...PageExpectDownloadMatchingOptions) (*DownloadMatch, error) {
+	option := PageExpectDownloadMatchingOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matchesFilename := func(string) bool { return true }
+	if option.SuggestedFilename != nil {
+		var err error
+		if matchesFilename, err = newTextMatcher(option.SuggestedFilename, false); err != nil {
+			return nil, err
+		}
+	}
+	var contentTypesLock sync.Mutex
+	contentTypes := make(map[string]string)
+	onResponse := func(response Response) {
+		contentTypesLock.Lock()
+		defer contentTypesLock.Unlock()
+		contentTypes[response.URL()] = response.Headers()["content-type"]
+	}
+	p.On("response", onResponse)
+	defer p.RemoveListener("response", onResponse)
+	download, err := p.ExpectDownload(cb, PageExpectDownloadOptions{
+		Predicate: func(download Download) bool {
+			return matchesFilename(download.SuggestedFilename())
+		},
+		Timeout: option.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	failure, err := download.Failure()
+	if err != nil {
+		return nil, err
+	}
+	if failure != "" {
+		return nil, fmt.Errorf("download of %s failed: %s", download.URL(), failure)
+	}
+	match := &DownloadMatch{Download: download}
+	contentTypesLock.Lock()
+	contentType := contentTypes[download.URL()]
+	contentTypesLock.Unlock()
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(download.SuggestedFilename()))
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		match.MimeType = mediaType
+	}
+	match.Size, match.SHA256, err = hashDownload(download)
+	if err != nil {
+		return nil, err
+	}
+	if option.MimeType != nil && !strings.EqualFold(match.MimeType, *option.MimeType) {
+		return match, fmt.Errorf("download %s has MIME type %q, expected %q", download.SuggestedFilename(), match.MimeType, *option.MimeType)
+	}
+	if option.MinSize != nil && match.Size < int64(*option.MinSize) {
+		return match, fmt.Errorf("download %s has %d bytes, expected at least %d", download.SuggestedFilename(), match.Size, *option.MinSize)
+	}
+	if option.SHA256 != nil && !strings.EqualFold(match.SHA256, *option.SHA256) {
+		return match, fmt.Errorf("download %s has SHA-256 %s, expected %s", download.SuggestedFilename(), match.SHA256, *option.SHA256)
+	}
+	return match, nil
+}
+
+// hashDownload streams the content of a finished download through SHA-256.
+// Remote downloads have no local path, they get saved to a temporary file.
+func hashDownload(download Download) (int64, string, error) {
+	path, err := download.Path()
+	if err != nil {
+		dir, err := ioutil.TempDir("", "playwright-download-")
+		if err != nil {
+			return 0, "", fmt.Errorf("could not create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, "download")
+		if err := download.SaveAs(path); err != nil {
+			return 0, "", err
+		}
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("could not open download: %w", err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", fmt.Errorf("could not read download: %w", err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+const (
+	// DownloadBlockedByPage is the reason of downloads which got blocked
+	// because of Page.SetAcceptDownloads(false).
+	DownloadBlockedByPage = "page"
+	// DownloadBlockedByContext is the reason of downloads which got blocked
+	// because the browser context got created without `acceptDownloads`.
+	DownloadBlockedByContext = "context"
+)
+
+// DownloadBlockedEvent is passed as the second argument of the
+// `downloadblocked` event of the Page, next to the Download itself.
+type DownloadBlockedEvent struct {
+	// The download which got canceled.
+	Download Download
+	// Why the download got blocked, either DownloadBlockedByPage or
+	// DownloadBlockedByContext.
+	Reason string
+}
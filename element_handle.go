@@ -2,6 +2,7 @@ package playwright
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
 )
@@ -200,16 +201,71 @@ type Rect struct {
 	Y      int `json:"y"`
 }
 
-func (e *elementHandleImpl) BoundingBox() (*Rect, error) {
+func (e *elementHandleImpl) BoundingBox(options ...ElementHandleBoundingBoxOptions) (*Rect, error) {
+	space := BoundingBoxSpaceViewport
+	if len(options) == 1 && options[0].Space != nil {
+		space = options[0].Space
+	}
+	if *space == *BoundingBoxSpaceFrame {
+		result, err := e.Evaluate(`element => {
+			const rect = element.getBoundingClientRect();
+			if (!rect.width && !rect.height && !element.getClientRects().length)
+				return null;
+			return { x: rect.x, y: rect.y, width: rect.width, height: rect.height };
+		}`)
+		if err != nil {
+			return nil, fmt.Errorf("could not get bounding box: %w", err)
+		}
+		if result == nil {
+			return nil, nil
+		}
+		return rectFromMap(result.(map[string]interface{})), nil
+	}
 	boundingBox, err := e.channel.Send("boundingBox")
 	if err != nil {
 		return nil, err
 	}
+	if boundingBox == nil {
+		return nil, nil
+	}
 	out := &Rect{}
 	remapMapToStruct(boundingBox, out)
+	if *space == *BoundingBoxSpacePage {
+		frame, err := e.OwnerFrame()
+		if err != nil {
+			return nil, fmt.Errorf("could not get owner frame: %w", err)
+		}
+		scroll, err := frame.Page().MainFrame().Evaluate("() => ({ x: window.scrollX, y: window.scrollY })")
+		if err != nil {
+			return nil, fmt.Errorf("could not get scroll offset: %w", err)
+		}
+		offset := rectFromMap(scroll.(map[string]interface{}))
+		out.X += offset.X
+		out.Y += offset.Y
+	}
 	return out, nil
 }
 
+// rectFromMap converts the result of an evaluation into a Rect, the values
+// can either be ints or float64s.
+func rectFromMap(in map[string]interface{}) *Rect {
+	value := func(key string) int {
+		switch v := in[key].(type) {
+		case int:
+			return v
+		case float64:
+			return int(v)
+		}
+		return 0
+	}
+	return &Rect{
+		X:      value("x"),
+		Y:      value("y"),
+		Width:  value("width"),
+		Height: value("height"),
+	}
+}
+
 func (e *elementHandleImpl) Check(options ...ElementHandleCheckOptions) error {
 	_, err := e.channel.Send("check", options)
 	return err
@@ -252,11 +308,29 @@ func (e *elementHandleImpl) SelectText(options ...ElementHandleSelectTextOptions
 }
 
 func (e *elementHandleImpl) Screenshot(options ...ElementHandleScreenshotOptions) ([]byte, error) {
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
 	var path *string
 	if len(options) > 0 {
 		path = options[0].Path
+		if options[0].Stabilize != nil {
+			frame, err := e.OwnerFrame()
+			if err != nil {
+				return nil, err
+			}
+			if frame == nil {
+				return nil, errors.New("could not stabilize page: element is not attached to a frame")
+			}
+			timeout := screenshotTimeout(options[0].Timeout, frame.(*frameImpl).page.timeoutSettings.Timeout())
+			if err := stabilizeFrame(frame, *options[0].Stabilize, timeout); err != nil {
+				return nil, err
+			}
+		}
 	}
-	data, err := e.channel.Send("screenshot", options)
+	params := transformOptions(options)
+	delete(params, "stabilize")
+	data, err := e.channel.Send("screenshot", params)
 	if err != nil {
 		return nil, fmt.Errorf("could not send message :%w", err)
 	}
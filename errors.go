@@ -18,7 +18,11 @@ func (e *TimeoutError) Error() string {
 	return e.Message
 }
 
+// parseError converts an error of the driver, masking the secrets registered
+// with RedactValue().
 func parseError(err errorPayload) error {
+	err.Message = redactions.redactString(err.Message)
+	err.Stack = redactions.redactString(err.Stack)
 	if err.Name == "TimeoutError" {
 		return &TimeoutError{
 			Name:    "TimeoutError",
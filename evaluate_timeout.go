@@ -0,0 +1,123 @@
+package playwright
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// evaluateTimeoutGrace is the time the in-page timer of an evaluation gets
+// to reject it, before the evaluation is considered to block the page.
+const evaluateTimeoutGrace = 250 * time.Millisecond
+
+// evaluateTimeoutScript races the expression against a timer in the page, so
+// pending promises get rejected.
+const evaluateTimeoutScript = `async ({ arg, timeout, message }) => {
+  const fn = %s;
+  let timer;
+  const expired = new Promise((_, reject) => {
+    timer = setTimeout(() => reject(new Error(message)), timeout);
+  });
+  try {
+    return await Promise.race([Promise.resolve().then(() => fn(arg)), expired]);
+  } finally {
+    clearTimeout(timer);
+  }
+}`
+
+// parseEvaluateArguments returns the argument, whether the expression is no
+// function and the EvaluateOptions of the variadic options of Evaluate().
+func parseEvaluateArguments(expression string, options []interface{}) (interface{}, bool, EvaluateOptions) {
+	var option EvaluateOptions
+	if len(options) > 0 {
+		switch last := options[len(options)-1].(type) {
+		case EvaluateOptions:
+			option = last
+			options = options[:len(options)-1]
+		case *EvaluateOptions:
+			if last != nil {
+				option = *last
+			}
+			options = options[:len(options)-1]
+		}
+	}
+	var arg interface{}
+	forceExpression := !isFunctionBody(expression)
+	if len(options) == 1 {
+		arg = options[0]
+	} else if len(options) == 2 {
+		arg = options[0]
+		forceExpression = options[1].(bool)
+	}
+	return arg, forceExpression, option
+}
+
+func evaluateTimeoutMessage(timeout float64) string {
+	return fmt.Sprintf("Timeout %vms exceeded while evaluating.", timeout)
+}
+
+// wrapEvaluateTimeout returns the function which evaluates expression with
+// the in-page timeout and its argument.
+func wrapEvaluateTimeout(expression string, forceExpression bool, arg interface{}, timeout float64) (string, interface{}, error) {
+	fn := expression
+	if forceExpression {
+		source, err := json.Marshal(expression)
+		if err != nil {
+			return "", nil, err
+		}
+		fn = fmt.Sprintf("() => (0, eval)(%s)", source)
+	}
+	return fmt.Sprintf(evaluateTimeoutScript, fn), map[string]interface{}{
+		"arg":     arg,
+		"timeout": int(timeout),
+		"message": evaluateTimeoutMessage(timeout),
+	}, nil
+}
+
+// sendWithTimeout sends the evaluating message and returns a *TimeoutError
+// once timeout and the grace period passed, terminating the script which
+// blocks the page.
+func (f *frameImpl) sendWithTimeout(ctx context.Context, method string, params map[string]interface{}, timeout float64) (interface{}, error) {
+	type evaluation struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan evaluation, 1)
+	go func() {
+		result, err := f.channel.SendContext(ctx, method, params)
+		done <- evaluation{result, err}
+	}()
+	timer := time.NewTimer(time.Duration(timeout*float64(time.Millisecond)) + evaluateTimeoutGrace)
+	defer timer.Stop()
+	message := evaluateTimeoutMessage(timeout)
+	select {
+	case evaluation := <-done:
+		if evaluation.err != nil && strings.Contains(evaluation.err.Error(), message) {
+			return nil, &TimeoutError{Name: "TimeoutError", Message: message}
+		}
+		return evaluation.result, evaluation.err
+	case <-contextDone(ctx):
+		return nil, fmt.Errorf("could not send message to server: %w", ctx.Err())
+	case <-timer.C:
+	}
+	f.terminateExecution()
+	return nil, &TimeoutError{Name: "TimeoutError", Message: message}
+}
+
+// terminateExecution aborts the script which is running in the page, this is
+// only supported by Chromium.
+func (f *frameImpl) terminateExecution() {
+	if f.page == nil || f.page.browserContext == nil {
+		return
+	}
+	session, err := f.page.browserContext.NewCDPSession(f.page)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = session.Detach()
+	}()
+	_, _ = session.Send("Runtime.terminateExecution", nil)
+}
@@ -0,0 +1,39 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvaluateArguments(t *testing.T) {
+	arg, forceExpression, option := parseEvaluateArguments("1 + 2", nil)
+	require.Nil(t, arg)
+	require.True(t, forceExpression)
+	require.Nil(t, option.Timeout)
+
+	arg, forceExpression, option = parseEvaluateArguments("a => a", []interface{}{2, EvaluateOptions{Timeout: Float(100)}})
+	require.Equal(t, 2, arg)
+	require.False(t, forceExpression)
+	require.Equal(t, 100.0, *option.Timeout)
+
+	arg, forceExpression, option = parseEvaluateArguments("a => a", []interface{}{"x", true, &EvaluateOptions{Timeout: Float(50)}})
+	require.Equal(t, "x", arg)
+	require.True(t, forceExpression)
+	require.Equal(t, 50.0, *option.Timeout)
+}
+
+func TestWrapEvaluateTimeout(t *testing.T) {
+	script, arg, err := wrapEvaluateTimeout("a => a * 2", false, 21, 1000)
+	require.NoError(t, err)
+	require.Contains(t, script, "const fn = a => a * 2;")
+	require.Equal(t, map[string]interface{}{
+		"arg":     21,
+		"timeout": 1000,
+		"message": "Timeout 1000ms exceeded while evaluating.",
+	}, arg)
+
+	script, _, err = wrapEvaluateTimeout(`document.title + "!"`, true, nil, 1000)
+	require.NoError(t, err)
+	require.Contains(t, script, `const fn = () => (0, eval)("document.title + \"!\"");`)
+}
@@ -0,0 +1,215 @@
+package playwright
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const extensionURLScheme = "chrome-extension://"
+
+var extensionArgPrefixes = []string{"--disable-extensions-except=", "--load-extension="}
+
+// extensionArgs returns args with the Chromium arguments which load the
+// unpacked extensions at paths. Extensions which args load already are kept.
+func extensionArgs(browserName string, paths []string, args []string) ([]string, error) {
+	if !capabilitiesOf(browserName).extensions {
+		return nil, &NotSupportedError{Feature: "Loading extensions", Browser: browserName}
+	}
+	extensions := []string{}
+	result := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, extensionArgPrefixes[1]) {
+			extensions = append(extensions, strings.Split(strings.TrimPrefix(arg, extensionArgPrefixes[1]), ",")...)
+		}
+		if !strings.HasPrefix(arg, extensionArgPrefixes[0]) && !strings.HasPrefix(arg, extensionArgPrefixes[1]) {
+			result = append(result, arg)
+		}
+	}
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve extension path: %w", err)
+		}
+		if _, err := os.Stat(filepath.Join(path, "manifest.json")); err != nil {
+			return nil, fmt.Errorf("extension %s does not have a manifest.json: %w", path, err)
+		}
+		extensions = append(extensions, path)
+	}
+	list := strings.Join(extensions, ",")
+	return append(result, extensionArgPrefixes[0]+list, extensionArgPrefixes[1]+list), nil
+}
+
+type extensionImpl struct {
+	context        *browserContextImpl
+	id             string
+	backgroundPage BackgroundPage
+	serviceWorker  Worker
+}
+
+func (e *extensionImpl) ID() string {
+	return e.id
+}
+
+func (e *extensionImpl) URL(path string) string {
+	return extensionURLScheme + e.id + "/" + strings.TrimPrefix(path, "/")
+}
+
+func (e *extensionImpl) BackgroundPage() BackgroundPage {
+	return e.backgroundPage
+}
+
+func (e *extensionImpl) ServiceWorker() Worker {
+	return e.serviceWorker
+}
+
+func (e *extensionImpl) Evaluate(expression string, options ...interface{}) (interface{}, error) {
+	if e.backgroundPage != nil {
+		return e.backgroundPage.Evaluate(expression, options...)
+	}
+	return e.serviceWorker.Evaluate(expression, options...)
+}
+
+func (e *extensionImpl) Manifest() (map[string]interface{}, error) {
+	manifest, err := e.Evaluate("() => chrome.runtime.getManifest()")
+	if err != nil {
+		return nil, fmt.Errorf("could not get extension manifest: %w", err)
+	}
+	return manifest.(map[string]interface{}), nil
+}
+
+func (e *extensionImpl) OpenPopup() (Page, error) {
+	manifest, err := e.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"action", "browser_action", "page_action"} {
+		if action, ok := manifest[key].(map[string]interface{}); ok {
+			if popup, ok := action["default_popup"].(string); ok {
+				return e.openPage(popup)
+			}
+		}
+	}
+	return nil, fmt.Errorf("extension %s does not have a popup page", e.id)
+}
+
+func (e *extensionImpl) OpenOptions() (Page, error) {
+	manifest, err := e.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	if optionsUI, ok := manifest["options_ui"].(map[string]interface{}); ok {
+		if options, ok := optionsUI["page"].(string); ok {
+			return e.openPage(options)
+		}
+	}
+	if options, ok := manifest["options_page"].(string); ok {
+		return e.openPage(options)
+	}
+	return nil, fmt.Errorf("extension %s does not have an options page", e.id)
+}
+
+func (e *extensionImpl) SendMessage(message interface{}) (interface{}, error) {
+	var page Page
+	for _, p := range e.context.Pages() {
+		if strings.HasPrefix(p.URL(), e.URL("")) {
+			page = p
+			break
+		}
+	}
+	if page == nil {
+		var err error
+		if page, err = e.OpenPopup(); err != nil {
+			if page, err = e.OpenOptions(); err != nil {
+				return nil, fmt.Errorf("could not open an extension page to send the message from: %w", err)
+			}
+		}
+		defer page.Close()
+	}
+	return page.Evaluate("message => chrome.runtime.sendMessage(message)", message)
+}
+
+func (e *extensionImpl) openPage(path string) (Page, error) {
+	page, err := e.context.NewPage()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := page.Goto(e.URL(path)); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (b *browserContextImpl) Extensions() []Extension {
+	extensions := make([]Extension, 0)
+	for _, backgroundPage := range b.BackgroundPages() {
+		if extension := newExtension(b, backgroundPage.URL(), backgroundPage, nil); extension != nil {
+			extensions = append(extensions, extension)
+		}
+	}
+	for _, worker := range b.ServiceWorkers() {
+		if extension := newExtension(b, worker.URL(), nil, worker); extension != nil {
+			extensions = append(extensions, extension)
+		}
+	}
+	return extensions
+}
+
+func (b *browserContextImpl) WaitForExtension(options ...BrowserContextWaitForExtensionOptions) (Extension, error) {
+	option := BrowserContextWaitForExtensionOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Timeout == nil {
+		option.Timeout = Float(b.timeoutSettings.Timeout())
+	}
+	matches := func(extension *extensionImpl) bool {
+		return extension != nil && (option.ID == nil || extension.ID() == *option.ID)
+	}
+	found := make(chan Extension, 1)
+	onBackgroundPage := func(page BackgroundPage) {
+		if extension := newExtension(b, page.URL(), page, nil); matches(extension) {
+			select {
+			case found <- extension:
+			default:
+			}
+		}
+	}
+	onServiceWorker := func(worker Worker) {
+		if extension := newExtension(b, worker.URL(), nil, worker); matches(extension) {
+			select {
+			case found <- extension:
+			default:
+			}
+		}
+	}
+	b.On("backgroundpage", onBackgroundPage)
+	b.On("serviceworker", onServiceWorker)
+	defer b.RemoveListener("backgroundpage", onBackgroundPage)
+	defer b.RemoveListener("serviceworker", onServiceWorker)
+	for _, extension := range b.Extensions() {
+		if matches(extension.(*extensionImpl)) {
+			return extension, nil
+		}
+	}
+	select {
+	case extension := <-found:
+		return extension, nil
+	case <-time.After(time.Duration(*option.Timeout) * time.Millisecond):
+		return nil, fmt.Errorf("Timeout %.2fms exceeded while waiting for extension.", *option.Timeout)
+	}
+}
+
+func newExtension(context *browserContextImpl, url string, backgroundPage BackgroundPage, serviceWorker Worker) *extensionImpl {
+	if !strings.HasPrefix(url, extensionURLScheme) {
+		return nil
+	}
+	return &extensionImpl{
+		context:        context,
+		id:             strings.Split(strings.TrimPrefix(url, extensionURLScheme), "/")[0],
+		backgroundPage: backgroundPage,
+		serviceWorker:  serviceWorker,
+	}
+}
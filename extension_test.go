@@ -0,0 +1,34 @@
+package playwright
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtensionArgs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte("{}"), 0644))
+	args, err := extensionArgs("chromium", []string{dir}, []string{
+		"--mute-audio",
+		"--disable-extensions-except=/existing",
+		"--load-extension=/existing",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"--mute-audio",
+		"--disable-extensions-except=/existing," + dir,
+		"--load-extension=/existing," + dir,
+	}, args)
+
+	_, err = extensionArgs("chromium", []string{t.TempDir()}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not have a manifest.json")
+
+	_, err = extensionArgs("firefox", []string{dir}, nil)
+	require.True(t, errors.Is(err, ErrNotSupported))
+	require.EqualError(t, err, "Loading extensions is not supported in firefox")
+}
@@ -0,0 +1,130 @@
+package playwright
+
+import (
+	"strings"
+)
+
+// scopedHeaders are extra HTTP headers which only get sent with requests to
+// URLs matching url. They get added when the requests are routed.
+type scopedHeaders struct {
+	matcher *urlMatcher
+	headers map[string]string
+}
+
+// mergeHeaders returns a copy of base with the headers of overrides added.
+// Header names are case-insensitive, so an override replaces a header with
+// the same name in any case.
+func mergeHeaders(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for name, value := range base {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		for existing := range merged {
+			if strings.EqualFold(existing, name) {
+				delete(merged, existing)
+			}
+		}
+		merged[name] = value
+	}
+	return merged
+}
+
+// setScopedHeaders sets the headers for url, they replace or, with merge,
+// extend the ones which are already set for it. Empty headers remove the
+// entry.
+func setScopedHeaders(entries []*scopedHeaders, url interface{}, headers map[string]string, merge bool) []*scopedHeaders {
+	result := make([]*scopedHeaders, 0, len(entries)+1)
+	var previous map[string]string
+	for _, entry := range entries {
+		if entry.matcher.urlOrPredicate == url {
+			previous = entry.headers
+			continue
+		}
+		result = append(result, entry)
+	}
+	if merge {
+		headers = mergeHeaders(previous, headers)
+	}
+	if len(headers) == 0 {
+		return result
+	}
+	return append(result, &scopedHeaders{
+		matcher: newURLMatcher(url),
+		headers: headers,
+	})
+}
+
+// scopedHeadersForURL returns the headers of all entries matching url, later
+// entries take precedence.
+func scopedHeadersForURL(entries []*scopedHeaders, url string) map[string]string {
+	var headers map[string]string
+	for _, entry := range entries {
+		if entry.matcher.Matches(url) {
+			headers = mergeHeaders(headers, entry.headers)
+		}
+	}
+	return headers
+}
+
+func (b *browserContextImpl) SetExtraHTTPHeaders(headers map[string]string, options ...BrowserContextSetExtraHTTPHeadersOptions) error {
+	option := BrowserContextSetExtraHTTPHeadersOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	merge := option.Merge != nil && *option.Merge
+	if option.URL != nil {
+		return b.updateInterception(func() {
+			b.scopedHeaders = setScopedHeaders(b.scopedHeaders, option.URL, headers, merge)
+		})
+	}
+	b.Lock()
+	if merge {
+		current := b.extraHTTPHeaders
+		if current == nil && b.options != nil {
+			current = b.options.ExtraHttpHeaders
+		}
+		headers = mergeHeaders(current, headers)
+	}
+	b.extraHTTPHeaders = headers
+	b.Unlock()
+	_, err := b.channel.Send("setExtraHTTPHeaders", map[string]interface{}{
+		"headers": serializeMapToNameAndValue(headers),
+	})
+	return err
+}
+
+func (p *pageImpl) SetExtraHTTPHeaders(headers map[string]string, options ...PageSetExtraHTTPHeadersOptions) error {
+	option := PageSetExtraHTTPHeadersOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	merge := option.Merge != nil && *option.Merge
+	if option.URL != nil {
+		return p.updateInterception(func() {
+			p.scopedHeaders = setScopedHeaders(p.scopedHeaders, option.URL, headers, merge)
+		})
+	}
+	p.Lock()
+	if merge {
+		headers = mergeHeaders(p.extraHTTPHeaders, headers)
+	}
+	p.extraHTTPHeaders = headers
+	p.Unlock()
+	return p.sendExtraHTTPHeaders()
+}
+
+// scopedHeadersFor returns the URL scoped extra headers of the context and of
+// the page of the request, the ones of the page take precedence.
+func (b *browserContextImpl) scopedHeadersFor(request *requestImpl) map[string]string {
+	b.Lock()
+	headers := scopedHeadersForURL(b.scopedHeaders, request.URL())
+	b.Unlock()
+	frame, ok := fromNullableChannel(request.initializer["frame"]).(*frameImpl)
+	if !ok || frame.page == nil {
+		return headers
+	}
+	frame.page.RLock()
+	defer frame.page.RUnlock()
+	return mergeHeaders(headers, scopedHeadersForURL(frame.page.scopedHeaders, request.URL()))
+}
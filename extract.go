@@ -0,0 +1,364 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExtractField describes where the value of a struct field gets extracted
+// from, relative to an element matched by ExtractAll.
+type ExtractField struct {
+	// CSS selector of the child element, the matched element itself is used if empty.
+	Selector string
+	// Name of the attribute to read, the trimmed text content is read if empty.
+	Attribute string
+}
+
+// FieldMap maps the names of struct fields to the ExtractField which provides their value.
+type FieldMap map[string]ExtractField
+
+const extractAllScript = `(elements, fields) => elements.map(element => {
+  const result = {};
+  for (const [name, field] of Object.entries(fields)) {
+    const target = field.selector ? element.querySelector(field.selector) : element;
+    if (!target)
+      result[name] = null;
+    else if (field.attribute)
+      result[name] = target.getAttribute(field.attribute);
+    else
+      result[name] = (target.textContent || '').trim();
+  }
+  return result;
+})`
+
+func (f *frameImpl) ExtractAll(selector string, dest interface{}, fields FieldMap) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice || destValue.Elem().Type().Elem().Kind() != reflect.Struct {
+		return errors.New("dest must be a pointer to a slice of structs")
+	}
+	rowType := destValue.Elem().Type().Elem()
+	serializedFields := make(map[string]interface{})
+	for name, field := range fields {
+		if _, ok := rowType.FieldByName(name); !ok {
+			return fmt.Errorf("%s has no field %s", rowType, name)
+		}
+		serializedFields[name] = map[string]interface{}{
+			"selector":  field.Selector,
+			"attribute": field.Attribute,
+		}
+	}
+	result, err := f.EvalOnSelectorAll(selector, extractAllScript, serializedFields)
+	if err != nil {
+		return fmt.Errorf("could not extract: %w", err)
+	}
+	rows := result.([]interface{})
+	out := reflect.MakeSlice(destValue.Elem().Type(), len(rows), len(rows))
+	for i, row := range rows {
+		for name, value := range row.(map[string]interface{}) {
+			if value == nil {
+				continue
+			}
+			if err := setExtractedValue(out.Index(i).FieldByName(name), value.(string)); err != nil {
+				return fmt.Errorf("could not extract field %s of row %d: %w", name, i, err)
+			}
+		}
+	}
+	destValue.Elem().Set(out)
+	return nil
+}
+
+func (l *locatorImpl) ExtractAll(dest interface{}, fields FieldMap) error {
+	return l.frame.ExtractAll(l.selector, dest, fields)
+}
+
+// setExtractedValue converts the extracted text into the type of the field.
+// Pointer fields stay nil if the element or attribute was missing.
+func setExtractedValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(strings.ReplaceAll(value, ",", ""), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(strings.ReplaceAll(value, ",", ""), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// extractTextScript renders the visible content of root as lines of text.
+// Block elements start new lines, in Markdown headings, lists, links,
+// emphasis, code, quotes and tables keep their structure.
+const extractTextScript = `(root, markdown) => {
+  const SKIP = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE', 'HEAD', 'IFRAME', 'OBJECT', 'SVG', 'CANVAS']);
+  const PARAGRAPH = new Set(['P', 'H1', 'H2', 'H3', 'H4', 'H5', 'H6', 'UL', 'OL', 'PRE', 'TABLE', 'BLOCKQUOTE', 'HR', 'FIGURE', 'DL']);
+  const BLOCK = new Set(['ADDRESS', 'ARTICLE', 'ASIDE', 'BODY', 'DD', 'DETAILS', 'DIALOG', 'DIV', 'DT', 'FIELDSET', 'FIGCAPTION',
+    'FOOTER', 'FORM', 'HEADER', 'HTML', 'LI', 'MAIN', 'NAV', 'SECTION', 'SUMMARY', 'TR']);
+  const FENCE = '\x60\x60\x60';
+  const lines = [];
+  const prefixes = [];
+  let current = '';
+  const prefix = () => prefixes.map(p => {
+    const result = p.used ? p.rest : p.first;
+    p.used = true;
+    return result;
+  }).join('');
+  const push = line => lines.push(prefix() + line);
+  const flush = () => {
+    const text = current.replace(/\s+/g, ' ').trim();
+    current = '';
+    if (text)
+      push(text);
+  };
+  const paragraph = () => {
+    flush();
+    if (lines.length && lines[lines.length - 1] !== '')
+      lines.push('');
+  };
+  const hidden = element => {
+    if (element.hidden)
+      return true;
+    const style = getComputedStyle(element);
+    return style.display === 'none' || style.visibility === 'hidden';
+  };
+  const cellText = cell => {
+    const text = (cell.innerText || '').replace(/\s+/g, ' ').trim();
+    return markdown ? text.replace(/\|/g, '\\|') : text;
+  };
+  const children = node => {
+    for (const child of (node.shadowRoot || node).childNodes)
+      render(child);
+  };
+  const wrap = (node, marker) => {
+    if (!markdown || !node.textContent.trim()) {
+      children(node);
+      return;
+    }
+    current += marker;
+    children(node);
+    current += marker;
+  };
+  const render = node => {
+    if (node.nodeType === Node.TEXT_NODE) {
+      current += node.textContent;
+      return;
+    }
+    if (node.nodeType !== Node.ELEMENT_NODE)
+      return;
+    const name = node.nodeName.toUpperCase();
+    if (SKIP.has(name) || hidden(node))
+      return;
+    switch (name) {
+      case 'BR':
+        flush();
+        return;
+      case 'HR':
+        paragraph();
+        if (markdown)
+          push('---');
+        paragraph();
+        return;
+      case 'IMG':
+        if (markdown && node.alt)
+          current += '![' + node.alt + '](' + node.src + ')';
+        return;
+      case 'A':
+        if (!markdown || !node.href || !node.textContent.trim()) {
+          children(node);
+          return;
+        }
+        current += '[';
+        children(node);
+        current += '](' + node.href + ')';
+        return;
+      case 'STRONG':
+      case 'B':
+        wrap(node, '**');
+        return;
+      case 'EM':
+      case 'I':
+        wrap(node, '*');
+        return;
+      case 'CODE':
+        wrap(node, '\x60');
+        return;
+      case 'PRE':
+        paragraph();
+        if (markdown)
+          push(FENCE);
+        for (const line of node.textContent.replace(/\n$/, '').split('\n'))
+          push(line.replace(/\s+$/, ''));
+        if (markdown)
+          push(FENCE);
+        paragraph();
+        return;
+      case 'H1': case 'H2': case 'H3': case 'H4': case 'H5': case 'H6':
+        paragraph();
+        if (markdown && node.textContent.trim())
+          current = '#'.repeat(+name[1]) + ' ';
+        children(node);
+        paragraph();
+        return;
+      case 'UL':
+      case 'OL': {
+        const nested = prefixes.length > 0;
+        if (nested)
+          flush();
+        else
+          paragraph();
+        let index = node.nodeName === 'OL' && node.start ? node.start : 1;
+        for (const item of node.children) {
+          if (item.nodeName.toUpperCase() !== 'LI' || hidden(item)) {
+            render(item);
+            continue;
+          }
+          const marker = markdown ? (name === 'OL' ? (index++) + '. ' : '- ') : '';
+          prefixes.push({ first: marker, rest: ' '.repeat(marker.length), used: false });
+          children(item);
+          flush();
+          prefixes.pop();
+        }
+        if (!nested)
+          paragraph();
+        return;
+      }
+      case 'BLOCKQUOTE':
+        paragraph();
+        if (markdown)
+          prefixes.push({ first: '> ', rest: '> ', used: false });
+        children(node);
+        flush();
+        if (markdown)
+          prefixes.pop();
+        paragraph();
+        return;
+      case 'TABLE': {
+        paragraph();
+        const rows = [...node.rows].filter(row => !hidden(row));
+        rows.forEach((row, i) => {
+          const cells = [...row.cells].map(cellText);
+          if (!markdown) {
+            push(cells.join('\t'));
+            return;
+          }
+          push('| ' + cells.join(' | ') + ' |');
+          if (i === 0)
+            push('|' + cells.map(() => ' --- |').join(''));
+        });
+        paragraph();
+        return;
+      }
+    }
+    if (PARAGRAPH.has(name)) {
+      paragraph();
+      children(node);
+      paragraph();
+    } else if (BLOCK.has(name)) {
+      flush();
+      children(node);
+      flush();
+    } else {
+      children(node);
+    }
+  };
+  render(root);
+  flush();
+  return lines.join('\n');
+}`
+
+var extractedBlankLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+// normalizeExtractedText trims trailing whitespace of every line and
+// collapses consecutive blank lines.
+func normalizeExtractedText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(extractedBlankLinesRegex.ReplaceAllString(text, "\n\n"))
+}
+
+func (f *frameImpl) ExtractText(options ...FrameExtractTextOptions) (string, error) {
+	option := FrameExtractTextOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	markdown := option.Format != nil && *option.Format == *TextFormatMarkdown
+	var result interface{}
+	var err error
+	if option.Selector != nil {
+		result, err = f.EvalOnSelector(*option.Selector, extractTextScript, markdown)
+	} else {
+		result, err = f.Evaluate(fmt.Sprintf("markdown => (%s)(document.body || document.documentElement, markdown)", extractTextScript), markdown)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not extract text: %w", err)
+	}
+	text, _ := result.(string)
+	return normalizeExtractedText(text), nil
+}
+
+func (f *frameImpl) InnerTextAll() (string, error) {
+	text, err := f.ExtractText()
+	if err != nil {
+		return "", err
+	}
+	parts := []string{}
+	if text != "" {
+		parts = append(parts, text)
+	}
+	for _, child := range f.ChildFrames() {
+		if child.IsDetached() {
+			continue
+		}
+		childText, err := child.InnerTextAll()
+		if err != nil {
+			return "", err
+		}
+		if childText != "" {
+			parts = append(parts, childText)
+		}
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+func (p *pageImpl) ExtractText(options ...PageExtractTextOptions) (string, error) {
+	if len(options) == 1 {
+		return p.mainFrame.ExtractText(FrameExtractTextOptions(options[0]))
+	}
+	return p.mainFrame.ExtractText()
+}
+
+func (p *pageImpl) InnerTextAll() (string, error) {
+	return p.mainFrame.InnerTextAll()
+}
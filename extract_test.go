@@ -0,0 +1,34 @@
+package playwright
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExtractedValue(t *testing.T) {
+	var row struct {
+		Name    string
+		Count   int
+		Price   *float64
+		InStock bool
+		Tags    []string
+	}
+	value := reflect.ValueOf(&row).Elem()
+	require.NoError(t, setExtractedValue(value.FieldByName("Name"), "foo"))
+	require.NoError(t, setExtractedValue(value.FieldByName("Count"), "1,024"))
+	require.NoError(t, setExtractedValue(value.FieldByName("Price"), "9.99"))
+	require.NoError(t, setExtractedValue(value.FieldByName("InStock"), "true"))
+	require.Equal(t, "foo", row.Name)
+	require.Equal(t, 1024, row.Count)
+	require.Equal(t, 9.99, *row.Price)
+	require.True(t, row.InStock)
+	require.Error(t, setExtractedValue(value.FieldByName("Count"), "many"))
+	require.Error(t, setExtractedValue(value.FieldByName("Tags"), "a,b"))
+}
+
+func TestNormalizeExtractedText(t *testing.T) {
+	require.Equal(t, "# Title\n\nfoo\nbar\n\nbaz", normalizeExtractedText("\n\n# Title  \n\n\n\nfoo\t\nbar\n\n\nbaz\n\n"))
+	require.Equal(t, "", normalizeExtractedText("\n \n"))
+}
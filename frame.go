@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"sync"
@@ -84,6 +85,35 @@ func (f *frameImpl) Goto(url string, options ...PageGotoOptions) (Response, erro
 }
 
 func (f *frameImpl) AddScriptTag(options PageAddScriptTagOptions) (ElementHandle, error) {
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
+	bundle := (options.Bundle != nil && *options.Bundle) || (options.Path != nil && isTypeScriptFile(*options.Path))
+	options.Bundle = nil
+	if options.FS != nil && options.Path != nil {
+		file, err := readAsset(options.FS, *options.Path)
+		if err != nil {
+			return nil, err
+		}
+		options.Content = String(string(file))
+		options.Path = nil
+	}
+	options.FS = nil
+	if bundle {
+		var path, content string
+		if options.Path != nil {
+			path = *options.Path
+		} else if options.Content != nil {
+			content = *options.Content
+		}
+		script, err := bundleModule(path, content)
+		if err != nil {
+			return nil, err
+		}
+		options.Content = String(script)
+		options.Path = nil
+		options.Type = nil
+	}
 	if options.Path != nil {
 		file, err := ioutil.ReadFile(*options.Path)
 		if err != nil {
@@ -100,14 +130,18 @@ func (f *frameImpl) AddScriptTag(options PageAddScriptTagOptions) (ElementHandle
 }
 
 func (f *frameImpl) AddStyleTag(options PageAddStyleTagOptions) (ElementHandle, error) {
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
 	if options.Path != nil {
-		file, err := ioutil.ReadFile(*options.Path)
+		file, err := readAsset(options.FS, *options.Path)
 		if err != nil {
 			return nil, err
 		}
 		options.Content = String(string(file))
 		options.Path = nil
 	}
+	options.FS = nil
 	channel, err := f.channel.Send("addStyleTag", options)
 	if err != nil {
 		return nil, err
@@ -137,17 +171,36 @@ func (f *frameImpl) WaitForLoadState(given ...string) {
 	<-succeed
 }
 
-func (f *frameImpl) WaitForURL(url string, options ...FrameWaitForURLOptions) error {
-	if len(options) > 0 {
-		if _, err := f.WaitForNavigation(PageWaitForNavigationOptions{
-			URL:       url,
-			Timeout:   options[0].Timeout,
-			WaitUntil: options[0].WaitUntil,
-		}); err != nil {
-			return err
+func (f *frameImpl) WaitForURL(url interface{}, options ...FrameWaitForURLOptions) error {
+	option := FrameWaitForURLOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if newURLMatcher(url).Matches(f.URL()) {
+		if option.WaitUntil != nil {
+			f.WaitForLoadState(string(*option.WaitUntil))
 		}
+		return nil
 	}
-	return nil
+	_, err := f.WaitForNavigation(PageWaitForNavigationOptions{
+		Context:   option.Context,
+		URL:       url,
+		Timeout:   option.Timeout,
+		WaitUntil: option.WaitUntil,
+	})
+	return err
+}
+
+func (f *frameImpl) ExpectNavigation(cb func() error, options ...PageWaitForNavigationOptions) (Response, error) {
+	navigationOptions := make([]interface{}, 0)
+	for _, option := range options {
+		navigationOptions = append(navigationOptions, option)
+	}
+	response, err := newExpectWrapper(f.WaitForNavigation, navigationOptions, cb)
+	if response == nil {
+		return nil, err
+	}
+	return response.(*responseImpl), err
 }
 
 func (f *frameImpl) WaitForEvent(event string, predicate ...interface{}) interface{} {
@@ -180,6 +233,8 @@ func (f *frameImpl) WaitForNavigation(options ...PageWaitForNavigationOptions) (
 	select {
 	case <-deadline:
 		return nil, fmt.Errorf("Timeout %.2fms exceeded.", *option.Timeout)
+	case <-contextDone(option.Context):
+		return nil, fmt.Errorf("could not wait for navigation: %w", option.Context.Err())
 	case eventData := <-waitForEvent(f, "navigated", predicate):
 		event := eventData.(map[string]interface{})
 		if event["newDocument"] != nil && event["newDocument"].(map[string]interface{})["request"] != nil {
@@ -190,14 +245,37 @@ func (f *frameImpl) WaitForNavigation(options ...PageWaitForNavigationOptions) (
 	return nil, nil
 }
 
+// FrameNavigatedEvent is passed as the second argument of the
+// `framenavigated` event of the Page, next to the Frame itself.
+type FrameNavigatedEvent struct {
+	// The frame which navigated.
+	Frame Frame
+	// URL of the frame before the navigation.
+	OldURL string
+	// URL of the frame after the navigation.
+	NewURL string
+	// Whether the navigation created a new document, it is false for navigations within the same document like
+	// anchor navigations or usage of the History API.
+	NewDocument bool
+}
+
 func (f *frameImpl) onFrameNavigated(ev map[string]interface{}) {
 	f.Lock()
+	oldURL := f.url
 	f.url = ev["url"].(string)
 	f.name = ev["name"].(string)
 	f.Unlock()
 	f.Emit("navigated", ev)
+	if f.page != nil && f.parentFrame == nil && ev["newDocument"] != nil && f.page.browserContext != nil {
+		f.page.browserContext.contextLimiter().onNavigation()
+	}
 	if f.page != nil {
-		f.page.Emit("framenavigated", f)
+		f.page.Emit("framenavigated", f, &FrameNavigatedEvent{
+			Frame:       f,
+			OldURL:      oldURL,
+			NewURL:      ev["url"].(string),
+			NewDocument: ev["newDocument"] != nil,
+		})
 	}
 }
 
@@ -240,16 +318,21 @@ func (f *frameImpl) QuerySelectorAll(selector string) ([]ElementHandle, error) {
 }
 
 func (f *frameImpl) Evaluate(expression string, options ...interface{}) (interface{}, error) {
-	var arg interface{}
-	forceExpression := false
-	if !isFunctionBody(expression) {
-		forceExpression = true
-	}
-	if len(options) == 1 {
-		arg = options[0]
-	} else if len(options) == 2 {
-		arg = options[0]
-		forceExpression = options[1].(bool)
+	arg, forceExpression, option := parseEvaluateArguments(expression, options)
+	if option.Timeout != nil {
+		wrapped, wrappedArg, err := wrapEvaluateTimeout(expression, forceExpression, arg, *option.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		result, err := f.sendWithTimeout(context.Background(), "evaluateExpression", map[string]interface{}{
+			"expression": wrapped,
+			"isFunction": true,
+			"arg":        serializeArgument(wrappedArg),
+		}, *option.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		return parseResult(result), nil
 	}
 	result, err := f.channel.Send("evaluateExpression", map[string]interface{}{
 		"expression": expression,
@@ -443,6 +526,17 @@ func (f *frameImpl) Uncheck(selector string, options ...FrameUncheckOptions) err
 	return err
 }
 
+func (f *frameImpl) SetChecked(selector string, checked bool, options ...FrameSetCheckedOptions) error {
+	method := "uncheck"
+	if checked {
+		method = "check"
+	}
+	_, err := f.channel.Send(method, map[string]interface{}{
+		"selector": selector,
+	}, options)
+	return err
+}
+
 func (f *frameImpl) WaitForTimeout(timeout float64) {
 	time.Sleep(time.Duration(timeout) * time.Millisecond)
 }
@@ -456,21 +550,34 @@ func (f *frameImpl) WaitForFunction(expression string, arg interface{}, options
 	if !isFunctionBody(expression) {
 		forceExpression = true
 	}
-	result, err := f.channel.Send("evaluateExpression", map[string]interface{}{
+	params := map[string]interface{}{
 		"expression": expression,
 		"isFunction": !forceExpression,
 		"arg":        serializeArgument(arg),
-		"timeout":    option.Timeout,
-		"polling":    option.Polling,
-	})
+	}
+	timeout := f.page.timeoutSettings.Timeout()
+	if option.Timeout != nil {
+		timeout = *option.Timeout
+	}
+	params["timeout"] = timeout
+	if option.Polling != nil && option.Polling != "raf" {
+		params["pollingInterval"] = option.Polling
+	}
+	if option.Context != nil {
+		if err := option.Context.Err(); err != nil {
+			return nil, fmt.Errorf("could not wait for function: %w", err)
+		}
+		timeout = boundTimeout(option.Context, timeout)
+		params["timeout"] = timeout
+	}
+	result, err := f.channel.SendContext(option.Context, "waitForFunction", params)
 	if err != nil {
 		return nil, err
 	}
-	handle := result.(map[string]interface{})["handle"]
-	if handle == nil {
+	if result == nil {
 		return nil, nil
 	}
-	return handle.(*jsHandleImpl), nil
+	return fromChannel(result).(JSHandle), nil
 }
 
 func (f *frameImpl) Title() (string, error) {
@@ -613,6 +720,10 @@ func (f *frameImpl) IsVisible(selector string, options ...FrameIsVisibleOptions)
 	return visible.(bool), nil
 }
 
+func (f *frameImpl) Locator(selector string) Locator {
+	return newLocator(f, selector)
+}
+
 func (f *frameImpl) InputValue(selector string, options ...FrameInputValueOptions) (string, error) {
 
 	value, err := f.channel.Send("inputValue", map[string]interface{}{
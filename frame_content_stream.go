@@ -0,0 +1,157 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+// contentChunkSize is the maximum size of a single chunk of content which gets
+// transferred over the protocol by SetContentFromReader and ContentTo.
+const contentChunkSize = 1024 * 1024
+
+const waitForDocumentStateScript = `state => new Promise(resolve => {
+  const reached = state === 'domcontentloaded' ? document.readyState !== 'loading' : document.readyState === 'complete';
+  if (reached)
+    return resolve();
+  window.addEventListener(state === 'domcontentloaded' ? 'DOMContentLoaded' : 'load', () => resolve(), { once: true });
+})`
+
+const readContentChunkScript = `(content, { start, size }) => {
+  let end = Math.min(start + size, content.length);
+  if (end < content.length && /[\uD800-\uDBFF]/.test(content[end - 1]))
+    end--;
+  return content.substring(start, end);
+}`
+
+func (f *frameImpl) SetContentFromReader(r io.Reader, options ...PageSetContentOptions) error {
+	option := PageSetContentOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.WaitUntil == nil {
+		option.WaitUntil = WaitUntilStateLoad
+	}
+	if option.Timeout == nil {
+		option.Timeout = Float(f.page.timeoutSettings.NavigationTimeout())
+	}
+	if err := f.SetContent("", PageSetContentOptions{
+		Timeout:   option.Timeout,
+		WaitUntil: WaitUntilStateDomcontentloaded,
+	}); err != nil {
+		return err
+	}
+	if _, err := f.Evaluate("() => document.open()"); err != nil {
+		return fmt.Errorf("could not open document: %w", err)
+	}
+	buf := make([]byte, contentChunkSize)
+	pending := 0
+	for {
+		n, err := io.ReadFull(r, buf[pending:])
+		n += pending
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			return fmt.Errorf("could not read content: %w", err)
+		}
+		end := n
+		if !eof {
+			end = lastRuneBoundary(buf[:n])
+		}
+		if end > 0 {
+			if _, err := f.Evaluate("chunk => document.write(chunk)", string(buf[:end])); err != nil {
+				return fmt.Errorf("could not write content: %w", err)
+			}
+		}
+		pending = copy(buf, buf[end:n])
+		if eof {
+			break
+		}
+	}
+	if _, err := f.Evaluate("() => document.close()"); err != nil {
+		return fmt.Errorf("could not close document: %w", err)
+	}
+	// The networkidle state is not tracked for documents written by the client,
+	// it falls back to load.
+	state := "load"
+	if *option.WaitUntil == *WaitUntilStateDomcontentloaded {
+		state = "domcontentloaded"
+	}
+	result := make(chan error, 1)
+	go func() {
+		_, err := f.Evaluate(waitForDocumentStateScript, state)
+		result <- err
+	}()
+	var deadline <-chan time.Time
+	if *option.Timeout != 0 {
+		deadline = time.After(time.Duration(*option.Timeout) * time.Millisecond)
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-deadline:
+		return fmt.Errorf("Timeout %.2fms exceeded.", *option.Timeout)
+	}
+}
+
+func (f *frameImpl) ContentTo(w io.Writer) error {
+	content, err := f.EvaluateHandle(`() => {
+  let content = '';
+  if (document.doctype)
+    content = new XMLSerializer().serializeToString(document.doctype);
+  if (document.documentElement)
+    content += document.documentElement.outerHTML;
+  return content;
+}`)
+	if err != nil {
+		return fmt.Errorf("could not get content: %w", err)
+	}
+	defer content.Dispose()
+	start := 0
+	for {
+		chunk, err := content.Evaluate(readContentChunkScript, map[string]interface{}{
+			"start": start,
+			"size":  contentChunkSize,
+		})
+		if err != nil {
+			return fmt.Errorf("could not read content: %w", err)
+		}
+		text := chunk.(string)
+		if text == "" {
+			return nil
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return fmt.Errorf("could not write content: %w", err)
+		}
+		start += utf16Length(text)
+	}
+}
+
+// lastRuneBoundary returns the length of the prefix of buf which does not end
+// with an incomplete UTF-8 encoded rune.
+func lastRuneBoundary(buf []byte) int {
+	for i := len(buf) - 1; i >= 0 && i >= len(buf)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(buf[i]) {
+			if utf8.FullRune(buf[i:]) {
+				return len(buf)
+			}
+			return i
+		}
+	}
+	return len(buf)
+}
+
+// utf16Length returns the length of s in UTF-16 code units, which is how
+// JavaScript measures strings.
+func utf16Length(s string) int {
+	length := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			length += 2
+		} else {
+			length++
+		}
+	}
+	return length
+}
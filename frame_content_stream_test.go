@@ -0,0 +1,23 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastRuneBoundary(t *testing.T) {
+	require.Equal(t, 3, lastRuneBoundary([]byte("abc")))
+	emoji := []byte("a🎉")
+	require.Equal(t, 5, lastRuneBoundary(emoji))
+	for i := 2; i < 5; i++ {
+		require.Equal(t, 1, lastRuneBoundary(emoji[:i]))
+	}
+	require.Equal(t, 0, lastRuneBoundary(nil))
+}
+
+func TestUTF16Length(t *testing.T) {
+	require.Equal(t, 3, utf16Length("abc"))
+	require.Equal(t, 1, utf16Length("ä"))
+	require.Equal(t, 3, utf16Length("a🎉"))
+}
@@ -2,6 +2,7 @@ package playwright
 
 func getMixedState(in string) *MixedState {
 	v := MixedState(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -15,6 +16,7 @@ var (
 
 func getColorScheme(in string) *ColorScheme {
 	v := ColorScheme(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -28,6 +30,7 @@ var (
 
 func getReducedMotion(in string) *ReducedMotion {
 	v := ReducedMotion(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -40,6 +43,7 @@ var (
 
 func getMouseButton(in string) *MouseButton {
 	v := MouseButton(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -53,6 +57,7 @@ var (
 
 func getKeyboardModifier(in string) *KeyboardModifier {
 	v := KeyboardModifier(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -67,6 +72,7 @@ var (
 
 func getScreenshotType(in string) *ScreenshotType {
 	v := ScreenshotType(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -79,6 +85,7 @@ var (
 
 func getElementState(in string) *ElementState {
 	v := ElementState(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -95,6 +102,7 @@ var (
 
 func getWaitForSelectorState(in string) *WaitForSelectorState {
 	v := WaitForSelectorState(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -109,6 +117,7 @@ var (
 
 func getWaitUntilState(in string) *WaitUntilState {
 	v := WaitUntilState(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -122,6 +131,7 @@ var (
 
 func getLoadState(in string) *LoadState {
 	v := LoadState(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -135,6 +145,7 @@ var (
 
 func getMedia(in string) *Media {
 	v := Media(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -148,6 +159,7 @@ var (
 
 func getSameSiteAttribute(in string) *SameSiteAttribute {
 	v := SameSiteAttribute(in)
+	registerEnumValue(v)
 	return &v
 }
 
@@ -158,3 +170,99 @@ var (
 	SameSiteAttributeLax                       = getSameSiteAttribute("Lax")
 	SameSiteAttributeNone                      = getSameSiteAttribute("None")
 )
+
+func getBoundingBoxSpace(in string) *BoundingBoxSpace {
+	v := BoundingBoxSpace(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type BoundingBoxSpace string
+
+var (
+	BoundingBoxSpaceViewport *BoundingBoxSpace = getBoundingBoxSpace("viewport")
+	BoundingBoxSpacePage                       = getBoundingBoxSpace("page")
+	BoundingBoxSpaceFrame                      = getBoundingBoxSpace("frame")
+)
+
+func getTextFormat(in string) *TextFormat {
+	v := TextFormat(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type TextFormat string
+
+var (
+	TextFormatPlain    *TextFormat = getTextFormat("text")
+	TextFormatMarkdown             = getTextFormat("markdown")
+)
+
+func getHarContentPolicy(in string) *HarContentPolicy {
+	v := HarContentPolicy(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type HarContentPolicy string
+
+var (
+	HarContentPolicyOmit   *HarContentPolicy = getHarContentPolicy("omit")
+	HarContentPolicyEmbed                    = getHarContentPolicy("embed")
+	HarContentPolicyAttach                   = getHarContentPolicy("attach")
+)
+
+func getHarMode(in string) *HarMode {
+	v := HarMode(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type HarMode string
+
+var (
+	HarModeFull    *HarMode = getHarMode("full")
+	HarModeMinimal          = getHarMode("minimal")
+)
+
+func getHarNotFound(in string) *HarNotFound {
+	v := HarNotFound(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type HarNotFound string
+
+var (
+	HarNotFoundAbort    *HarNotFound = getHarNotFound("abort")
+	HarNotFoundFallback              = getHarNotFound("fallback")
+)
+
+func getUnrouteBehavior(in string) *UnrouteBehavior {
+	v := UnrouteBehavior(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type UnrouteBehavior string
+
+var (
+	UnrouteBehaviorDefault      *UnrouteBehavior = getUnrouteBehavior("default")
+	UnrouteBehaviorIgnoreErrors                  = getUnrouteBehavior("ignoreErrors")
+	UnrouteBehaviorWait                          = getUnrouteBehavior("wait")
+)
+
+func getContextLimit(in string) *ContextLimit {
+	v := ContextLimit(in)
+	registerEnumValue(v)
+	return &v
+}
+
+type ContextLimit string
+
+var (
+	ContextLimitPages         *ContextLimit = getContextLimit("pages")
+	ContextLimitNavigations                 = getContextLimit("navigations")
+	ContextLimitDuration                    = getContextLimit("duration")
+	ContextLimitBytesReceived               = getContextLimit("bytesReceived")
+)
@@ -1,5 +1,60 @@
 package playwright
 
+import (
+	"context"
+	"io/fs"
+	"time"
+)
+
+type APIRequestContextFetchOptions struct {
+	// Allows to set post data of the request. Strings and byte slices get sent as they are, other values get serialized as JSON and set the `content-type` header to `application/json` if it is not set explicitly.
+	Data interface{} `json:"data"`
+	// Whether to return an error for response codes other than 2xx and 3xx. By default a response is returned for all status codes.
+	FailOnStatusCode *bool `json:"failOnStatusCode"`
+	// Provides an object that will be serialized as html form using `application/x-www-form-urlencoded` encoding and sent as this request body. Values get formatted with fmt.Sprint, slices add a field per element.
+	Form map[string]interface{} `json:"form"`
+	// Allows to set HTTP headers. These headers take precedence over the extra HTTP headers of the request context.
+	Headers map[string]string `json:"headers"`
+	// Maximum number of request redirects that will be followed automatically. An error will be returned if the number is exceeded. Defaults to `20`. Pass `0` to not follow redirects.
+	MaxRedirects *int `json:"maxRedirects"`
+	// If set changes the fetch method (e.g. PUT or POST). If not specified, GET method is used. Ignored by APIRequestContext.Get(), APIRequestContext.Post() and the other method specific helpers.
+	Method *string `json:"method"`
+	// Provides an object that will be serialized as html form using `multipart/form-data` encoding and sent as this request body. File uploads can be given as InputFile, as a slice of InputFile for multiple files or as an *os.File which gets read to the end, other values get sent as text fields.
+	Multipart map[string]interface{} `json:"multipart"`
+	// Query parameters to be sent with the URL. They replace parameters of the same name in the URL, slices add a parameter per element.
+	Params map[string]interface{} `json:"params"`
+	// Request timeout in milliseconds. Defaults to the default timeout of the request context, pass `0` to disable timeout.
+	Timeout *float64 `json:"timeout"`
+}
+type APIRequestNewContextOptions struct {
+	// Methods like APIRequestContext.Get() take the base URL into consideration by using the [`URL()`](https://developer.mozilla.org/en-US/docs/Web/API/URL/URL) constructor for building the corresponding URL.
+	BaseURL *string `json:"baseURL"`
+	// An object containing additional HTTP headers to be sent with every request.
+	ExtraHttpHeaders map[string]string `json:"extraHTTPHeaders"`
+	// Credentials for [HTTP authentication](https://developer.mozilla.org/en-US/docs/Web/HTTP/Authentication), they get sent with every request.
+	HttpCredentials *APIRequestNewContextOptionsHttpCredentials `json:"httpCredentials"`
+	// Whether to ignore HTTPS errors when sending network requests. Defaults to `false`.
+	IgnoreHttpsErrors *bool `json:"ignoreHTTPSErrors"`
+	// Populates context with given storage state, e.g. the one returned by BrowserContext.StorageState(). Only the cookies get sent with the requests.
+	StorageState *StorageState `json:"storageState"`
+	// Populates context with given storage state. Path to the file with saved storage state.
+	StorageStatePath *string `json:"storageStatePath"`
+	// Maximum time in milliseconds to wait for the response. Defaults to `30000` (30 seconds). Pass `0` to disable timeout.
+	Timeout *float64 `json:"timeout"`
+	// Specific user agent to use in this context.
+	UserAgent *string `json:"userAgent"`
+}
+type APIRequestNewContextOptionsHttpCredentials struct {
+	Username *string `json:"username"`
+	Password *string `json:"password"`
+}
+
+type AccessibilitySnapshotOptions struct {
+	// Prune uninteresting nodes from the tree. Defaults to `true`.
+	InterestingOnly *bool `json:"interestingOnly"`
+	// The root DOM element for the snapshot. Defaults to the whole page.
+	Root ElementHandle `json:"-"`
+}
 type BrowserNewContextOptions struct {
 	// Whether to automatically download all the attachments. Defaults to `false` where all the downloads are canceled.
 	AcceptDownloads *bool `json:"acceptDownloads"`
@@ -11,6 +66,12 @@ type BrowserNewContextOptions struct {
 	BypassCSP *bool `json:"bypassCSP"`
 	// Emulates `'prefers-colors-scheme'` media feature, supported values are `'light'`, `'dark'`, `'no-preference'`. See Page.EmulateMedia() for more details. Defaults to `'light'`.
 	ColorScheme *ColorScheme `json:"colorScheme"`
+	// Name of a device descriptor from Playwright.Devices (including the ones added via Playwright.RegisterDevice()) to emulate. Explicitly passed options take precedence over the values of the descriptor.
+	Device *string `json:"device"`
+	// Renders a cursor, click ripples and captions of the typed text into the pages, so recorded videos can double as demo footage. See DemoModeOptions.
+	DemoMode *DemoModeOptions `json:"demoMode"`
+	// Makes the pages render the same way in every run: freezes the clock, seeds Math.random, disables animations, transitions and smooth scrolling, hides the caret and fixes the timezone to `UTC`, the locale to `en-US` and the reduced motion preference to `reduce` unless they are set explicitly. See DeterministicRenderingOptions.
+	Deterministic *DeterministicRenderingOptions `json:"deterministic"`
 	// Specify device scale factor (can be thought of as dpr). Defaults to `1`.
 	DeviceScaleFactor *float64 `json:"deviceScaleFactor"`
 	// An object containing additional HTTP headers to be sent with every request. All header values must be strings.
@@ -26,15 +87,31 @@ type BrowserNewContextOptions struct {
 	IsMobile *bool `json:"isMobile"`
 	// Whether or not to enable JavaScript in the context. Defaults to `true`.
 	JavaScriptEnabled *bool `json:"javaScriptEnabled"`
+	// Guardrails against runaway pages: the context gets closed when one of the limits is exceeded, see ContextLimits and BrowserContext.LimitError().
+	Limits *ContextLimits `json:"limits"`
 	// Specify user locale, for example `en-GB`, `de-DE`, etc. Locale will affect `navigator.language` value, `Accept-Language` request header value as well as number and date formatting rules.
 	Locale *string `json:"locale"`
+	// Metadata of the context, e.g. the name, owner and ticket of the test, see BrowserContext.Metadata(). The `{key}` placeholders of the HAR, trace and video paths get replaced with the values.
+	Metadata map[string]string `json:"metadata"`
 	// Whether to emulate network being offline. Defaults to `false`.
 	Offline *bool `json:"offline"`
 	// A list of permissions to grant to all pages in this context. See BrowserContext.GrantPermissions() for more details.
 	Permissions []string `json:"permissions"`
+	// Name of a context preset registered via Playwright.RegisterPreset() to apply. Explicitly passed options take precedence over the values of the preset, extra HTTP headers get merged.
+	Preset *string `json:"preset"`
 	// Network proxy settings to use with this context.
 	// For Chromium on Windows the browser needs to be launched with the global proxy for this option to work. If all contexts override the proxy, global proxy will be never used and can be any string, for example `launch({ proxy: { server: 'http://per-context' } })`.
 	Proxy *BrowserNewContextOptionsProxy `json:"proxy"`
+	// Optional setting to control resource content management. If `omit` is specified, content is not persisted. If `attach` is specified, resources are persisted as separate files and all of these files are archived along with the HAR file. Defaults to `embed`, which stores content inline the HAR file as per HAR specification, and to `attach` for `.zip` paths.
+	RecordHarContent *HarContentPolicy `json:"recordHarContent"`
+	// When set to `minimal`, only record information necessary for routing from HAR. This omits sizes, timing, page, cookies, security and other types of HAR information that are not used when replaying from HAR. Defaults to `full`.
+	RecordHarMode *HarMode `json:"recordHarMode"`
+	// Optional setting to control whether to omit request content from the HAR. Defaults to `false`. Deprecated, use `RecordHarContent` instead.
+	RecordHarOmitContent *bool `json:"recordHarOmitContent"`
+	// Enables [HAR](http://www.softwareishard.com/blog/har-12-spec) recording for all pages into the file at the given path, which gets written when the context is closed. Make sure to call BrowserContext.Close() for the HAR to be saved. If the path ends with `.zip`, the HAR and its attachments are archived together.
+	RecordHarPath *string `json:"recordHarPath"`
+	// A glob pattern, regular expression or predicate `func(url string) bool` the URLs of the requests need to match to be stored in the HAR. Defaults to all requests.
+	RecordHarUrlFilter interface{} `json:"recordHarUrlFilter"`
 	// Enables video recording for all pages into `recordVideo.dir` directory. If not specified videos are not recorded. Make sure to await BrowserContext.Close() for videos to be saved.
 	RecordVideo *BrowserNewContextOptionsRecordVideo `json:"recordVideo"`
 	// Emulates `'prefers-reduced-motion'` media feature, supported values are `'reduce'`, `'no-preference'`. See Page.EmulateMedia() for more details. Defaults to `'no-preference'`.
@@ -173,6 +250,18 @@ type BrowserContextAddInitScriptOptions struct {
 	Script *string `json:"script"`
 	// Optional Script path to be evaluated in all pages in the browser context.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
+	// Origins of the documents to evaluate the script in, e.g. `https://example.com`. Defaults to all origins.
+	Origins []string `json:"origins"`
+	// Whether to only evaluate the script in main frames, so it does not run in iframes. Defaults to `false`.
+	MainFrameOnly *bool `json:"mainFrameOnly"`
+}
+type BrowserContextBlockRequestsOptions struct {
+	// Resource types to abort, e.g. `image`, `font`, `stylesheet` or `media`. See Request.resourceType().
+	ResourceTypes []string `json:"resourceTypes"`
+	// Domains to abort requests to, subdomains get blocked as well.
+	Domains []string `json:"domains"`
 }
 
 // Result of calling <see cref="BrowserContext.Cookies" />.
@@ -202,6 +291,18 @@ type BrowserContextGrantPermissionsOptions struct {
 type BrowserContextRouteOptions struct {
 	// handler function to route the request.
 	Handler func(Route) `json:"handler"`
+	// How often a route should be used. By default it will be used every time.
+	Times *int `json:"times"`
+}
+type BrowserContextRouteFromHAROptions struct {
+	// If set to 'abort' any request not found in the HAR file will be aborted. If set to 'fallback' missing requests will be sent to the network. Defaults to abort.
+	NotFound *HarNotFound `json:"notFound"`
+	// If specified, updates the given HAR with the actual network information instead of serving from file. The file is written to disk when the page or the browser context is closed.
+	Update *bool `json:"update"`
+	// Optional setting to control resource content management in the update mode. If `attach` is specified, resources are persisted as separate files or entries in the ZIP archive. If `embed` is specified, content is stored inline the HAR file. Defaults to `attach` for `.zip` files and to `embed` otherwise.
+	UpdateContent *HarContentPolicy `json:"updateContent"`
+	// A glob pattern, regular expression or predicate to match the request URL. Only requests with URL matching the pattern will be served from the HAR file, respectively recorded into it. If not specified, all requests are served from the HAR file.
+	URL interface{} `json:"url"`
 }
 type BrowserContextGeolocation struct {
 	// Latitude between -90 and 90.
@@ -217,10 +318,24 @@ type BrowserContextStorageStateResult struct {
 	Cookies []BrowserContextStorageStateResultCookies `json:"cookies"`
 	Origins []BrowserContextStorageStateResultOrigins `json:"origins"`
 }
+type BrowserContextSetExtraHTTPHeadersOptions struct {
+	// Whether to merge the headers into the ones which are already set, instead of replacing them. Defaults to `false`.
+	Merge *bool `json:"merge"`
+	// Only send the headers with requests to URLs matching this glob pattern string or *regexp.Regexp, e.g. the origin of
+	// your API. Scoped headers get added by routing the requests of the context.
+	URL interface{} `json:"url"`
+}
 type BrowserContextStorageStateOptions struct {
 	// The file path to save the storage state to. If `path` is a relative path, then it is resolved relative to current working directory. If no path is provided, storage state is still returned, but won't be saved to the disk.
 	Path *string `json:"path"`
 }
+type BrowserContextUnrouteAllOptions struct {
+	// Specifies whether to wait for already running handlers and what to do if they throw errors:
+	// `'default'` - do not wait for current handler calls (if any) to finish.
+	// `'wait'` - wait for current handler calls (if any) to finish.
+	// `'ignoreErrors'` - do not wait for current handler calls (if any) to finish, errors of their route actions get ignored.
+	Behavior *UnrouteBehavior `json:"behavior"`
+}
 type BrowserContextUnrouteOptions struct {
 	// Optional handler function used to register a routing with BrowserContext.Route().
 	Handler func(Route, Request) `json:"handler"`
@@ -250,6 +365,10 @@ type BrowserTypeLaunchOptions struct {
 	Headless *bool `json:"headless"`
 	// Network proxy settings.
 	Proxy *BrowserTypeLaunchOptionsProxy `json:"proxy"`
+	// How often the launch is retried if it fails, e.g. because of a timeout. Defaults to `0`.
+	Retries *int `json:"retries"`
+	// Time in milliseconds to wait before the first retry, which gets doubled for every following retry. Defaults to `1000`.
+	RetryBackoff *float64 `json:"retryBackoff"`
 	// Slows down Playwright operations by the specified amount of milliseconds. Useful so that you can see what is going on.
 	SlowMo *float64 `json:"slowMo"`
 	// Maximum time in milliseconds to wait for the browser instance to start. Defaults to `30000` (30 seconds). Pass `0` to disable timeout.
@@ -257,6 +376,21 @@ type BrowserTypeLaunchOptions struct {
 	// If specified, traces are saved into this directory.
 	TracesDir *string `json:"tracesDir"`
 }
+type BrowserSubscribeEventsOptions struct {
+	// Capacity of the channel of the subscription, defaults to `1000`. Events which arrive while the channel is full get
+	// dropped.
+	BufferSize *int `json:"bufferSize"`
+	// Names of the events to deliver, e.g. `console` and `pageerror`. All events get delivered if empty.
+	Events []string `json:"events"`
+}
+type BrowserTypeConnectOptions struct {
+	// How often connecting is retried if it fails. Defaults to `0`.
+	Retries *int `json:"retries"`
+	// Time in milliseconds to wait before the first retry, which gets doubled for every following retry. Defaults to `1000`.
+	RetryBackoff *float64 `json:"retryBackoff"`
+	// Maximum time in milliseconds to wait for the connection to be established. Defaults to `30000` (30 seconds). Pass `0` to disable timeout.
+	Timeout *float64 `json:"timeout"`
+}
 type BrowserTypeProxy struct {
 	// Proxy to be used for all requests. HTTP and SOCKS proxies are supported, for example `http://myproxy.com:3128` or `socks5://myproxy.com:3128`. Short form `myproxy.com:3128` is considered an HTTP proxy.
 	Server *string `json:"server"`
@@ -284,6 +418,12 @@ type BrowserTypeLaunchPersistentContextOptions struct {
 	ChromiumSandbox *bool `json:"chromiumSandbox"`
 	// Emulates `'prefers-colors-scheme'` media feature, supported values are `'light'`, `'dark'`, `'no-preference'`. See Page.EmulateMedia() for more details. Defaults to `'light'`.
 	ColorScheme *ColorScheme `json:"colorScheme"`
+	// Name of a device descriptor from Playwright.Devices (including the ones added via Playwright.RegisterDevice()) to emulate. Explicitly passed options take precedence over the values of the descriptor.
+	Device *string `json:"device"`
+	// Renders a cursor, click ripples and captions of the typed text into the pages, so recorded videos can double as demo footage. See DemoModeOptions.
+	DemoMode *DemoModeOptions `json:"demoMode"`
+	// Makes the pages render the same way in every run: freezes the clock, seeds Math.random, disables animations, transitions and smooth scrolling, hides the caret and fixes the timezone to `UTC`, the locale to `en-US` and the reduced motion preference to `reduce` unless they are set explicitly. See DeterministicRenderingOptions.
+	Deterministic *DeterministicRenderingOptions `json:"deterministic"`
 	// Specify device scale factor (can be thought of as dpr). Defaults to `1`.
 	DeviceScaleFactor *float64 `json:"deviceScaleFactor"`
 	// **Chromium-only** Whether to auto-open a Developer Tools panel for each tab. If this option is `true`, the `headless` option will be set `false`.
@@ -294,6 +434,8 @@ type BrowserTypeLaunchPersistentContextOptions struct {
 	Env map[string]string `json:"env"`
 	// Path to a browser executable to run instead of the bundled one. If `executablePath` is a relative path, then it is resolved relative to the current working directory. Note that Playwright only works with the bundled Chromium, Firefox or WebKit, use at your own risk.
 	ExecutablePath *string `json:"executablePath"`
+	// Paths of unpacked extensions to load into the persistent context, only supported in Chromium. Relative paths are resolved against the current working directory. Extensions can only be loaded in headed mode, so `headless` defaults to `false` when extensions are given. The loaded extensions are available via BrowserContext.WaitForExtension().
+	Extensions []string `json:"extensions"`
 	// An object containing additional HTTP headers to be sent with every request. All header values must be strings.
 	ExtraHttpHeaders map[string]string                                     `json:"extraHTTPHeaders"`
 	Geolocation      *BrowserTypeLaunchPersistentContextOptionsGeolocation `json:"geolocation"`
@@ -315,14 +457,30 @@ type BrowserTypeLaunchPersistentContextOptions struct {
 	IsMobile *bool `json:"isMobile"`
 	// Whether or not to enable JavaScript in the context. Defaults to `true`.
 	JavaScriptEnabled *bool `json:"javaScriptEnabled"`
+	// Guardrails against runaway pages: the context gets closed when one of the limits is exceeded, see ContextLimits and BrowserContext.LimitError().
+	Limits *ContextLimits `json:"limits"`
 	// Specify user locale, for example `en-GB`, `de-DE`, etc. Locale will affect `navigator.language` value, `Accept-Language` request header value as well as number and date formatting rules.
 	Locale *string `json:"locale"`
+	// Metadata of the context, e.g. the name, owner and ticket of the test, see BrowserContext.Metadata(). The `{key}` placeholders of the HAR, trace and video paths get replaced with the values.
+	Metadata map[string]string `json:"metadata"`
 	// Whether to emulate network being offline. Defaults to `false`.
 	Offline *bool `json:"offline"`
 	// A list of permissions to grant to all pages in this context. See BrowserContext.GrantPermissions() for more details.
 	Permissions []string `json:"permissions"`
+	// Name of a context preset registered via Playwright.RegisterPreset() to apply. Explicitly passed options take precedence over the values of the preset, extra HTTP headers get merged.
+	Preset *string `json:"preset"`
 	// Network proxy settings.
 	Proxy *BrowserTypeLaunchPersistentContextOptionsProxy `json:"proxy"`
+	// Optional setting to control resource content management. If `omit` is specified, content is not persisted. If `attach` is specified, resources are persisted as separate files and all of these files are archived along with the HAR file. Defaults to `embed`, which stores content inline the HAR file as per HAR specification, and to `attach` for `.zip` paths.
+	RecordHarContent *HarContentPolicy `json:"recordHarContent"`
+	// When set to `minimal`, only record information necessary for routing from HAR. This omits sizes, timing, page, cookies, security and other types of HAR information that are not used when replaying from HAR. Defaults to `full`.
+	RecordHarMode *HarMode `json:"recordHarMode"`
+	// Optional setting to control whether to omit request content from the HAR. Defaults to `false`. Deprecated, use `RecordHarContent` instead.
+	RecordHarOmitContent *bool `json:"recordHarOmitContent"`
+	// Enables [HAR](http://www.softwareishard.com/blog/har-12-spec) recording for all pages into the file at the given path, which gets written when the context is closed. Make sure to call BrowserContext.Close() for the HAR to be saved. If the path ends with `.zip`, the HAR and its attachments are archived together.
+	RecordHarPath *string `json:"recordHarPath"`
+	// A glob pattern, regular expression or predicate `func(url string) bool` the URLs of the requests need to match to be stored in the HAR. Defaults to all requests.
+	RecordHarUrlFilter interface{} `json:"recordHarUrlFilter"`
 	// Enables video recording for all pages into `recordVideo.dir` directory. If not specified videos are not recorded. Make sure to await BrowserContext.Close() for videos to be saved.
 	RecordVideo *BrowserTypeLaunchPersistentContextOptionsRecordVideo `json:"recordVideo"`
 	// Emulates `'prefers-reduced-motion'` media feature, supported values are `'reduce'`, `'no-preference'`. See Page.EmulateMedia() for more details. Defaults to `'no-preference'`.
@@ -388,6 +546,10 @@ type ElementHandleBoundingBoxResult struct {
 	// the height of the element in pixels.
 	Height *float64 `json:"height"`
 }
+type ElementHandleBoundingBoxOptions struct {
+	// Coordinate space of the returned bounding box, defaults to BoundingBoxSpaceViewport.
+	Space *BoundingBoxSpace `json:"space"`
+}
 type ElementHandleCheckOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
 	Force *bool `json:"force"`
@@ -493,6 +655,8 @@ type ElementHandleScreenshotOptions struct {
 	Path *string `json:"path"`
 	// The quality of the image, between 0-100. Not applicable to `png` images.
 	Quality *int `json:"quality"`
+	// Waits for fonts, images and animations to settle before taking the screenshot, see Page.Stabilize().
+	Stabilize *StabilizeOptions `json:"stabilize"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
@@ -581,6 +745,10 @@ type FrameAddScriptTagOptions struct {
 	Content *string `json:"content"`
 	// Path to the JavaScript file to be injected into frame. If `path` is a relative path, then it is resolved relative to the current working directory.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
+	// Bundles the ES module in `content` or `path` together with its imports into a single classic script, TypeScript gets transpiled. Always done for `.ts`, `.tsx`, `.mts`, `.cts` and `.jsx` files. Requires the [esbuild](https://esbuild.github.io) executable on the `PATH` or in the `PLAYWRIGHT_ESBUILD_PATH` environment variable.
+	Bundle *bool `json:"bundle"`
 	// Script type. Use 'module' in order to load a Javascript ES6 module. See [script](https://developer.mozilla.org/en-US/docs/Web/HTML/Element/script) for more details.
 	Type *string `json:"type"`
 	// URL of a script to be added.
@@ -591,6 +759,8 @@ type FrameAddStyleTagOptions struct {
 	Content *string `json:"content"`
 	// Path to the CSS file to be injected into frame. If `path` is a relative path, then it is resolved relative to the current working directory.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
 	// URL of the `<link>` tag.
 	URL *string `json:"url"`
 }
@@ -607,6 +777,8 @@ type FrameCheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FramePosition struct {
 	X *float64 `json:"x"`
@@ -633,6 +805,8 @@ type FrameClickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameDblclickOptions struct {
 	// Defaults to `left`.
@@ -653,6 +827,8 @@ type FrameDblclickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameDispatchEventOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -700,6 +876,22 @@ type FrameEvaluateHandleOptions struct {
 	// Optional argument to pass to `expression`.
 	Arg interface{} `json:"arg"`
 }
+type FrameEvaluateModuleOptions struct {
+	// ES module or TypeScript source to evaluate. Relative imports get resolved against the current working directory.
+	Content *string `json:"content"`
+	// Path to the ES module or TypeScript file to evaluate. Relative imports get resolved against its directory.
+	Path *string `json:"path"`
+	// Name of the export to return, functions get called with `Arg` and awaited. Defaults to `default`.
+	Export *string `json:"export"`
+	// Optional argument to pass to the exported function.
+	Arg interface{} `json:"arg"`
+}
+type FrameExtractTextOptions struct {
+	// Output format, either TextFormatPlain or TextFormatMarkdown. Defaults to TextFormatPlain.
+	Format *TextFormat `json:"format"`
+	// Selector of the element to extract the text of. Defaults to the body of the document.
+	Selector *string `json:"selector"`
+}
 type FrameFillOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
 	Force *bool `json:"force"`
@@ -709,6 +901,16 @@ type FrameFillOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type FrameFillTimeOptions struct {
+	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
+	Force *bool `json:"force"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
 }
 type FrameFocusOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -732,6 +934,8 @@ type FrameGotoOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameHoverOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -746,6 +950,8 @@ type FrameHoverOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameInnerHTMLOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -810,6 +1016,8 @@ type FramePressOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameQuerySelectorOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -824,6 +1032,22 @@ type FrameSelectOptionOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type FrameSetCheckedOptions struct {
+	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
+	Force *bool `json:"force"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// A point to use relative to the top-left corner of element padding box. If not specified, uses some visible point of the element.
+	Position *FrameSetCheckedOptionsPosition `json:"position"`
+	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
+	Strict *bool `json:"strict"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
+	Trial *bool `json:"trial"`
 }
 type FrameSetContentOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -857,6 +1081,8 @@ type FrameTapOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameTextContentOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -873,6 +1099,8 @@ type FrameTypeOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameUncheckOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -887,12 +1115,16 @@ type FrameUncheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameWaitForFunctionOptions struct {
 	// If `polling` is `'raf'`, then `expression` is constantly executed in `requestAnimationFrame` callback. If `polling` is a number, then it is treated as an interval in milliseconds at which the function would be executed. Defaults to `raf`.
 	Polling interface{} `json:"polling"`
 	// maximum time to wait for in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout().
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameWaitForLoadStateOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -908,6 +1140,8 @@ type FrameWaitForNavigationOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameWaitForSelectorOptions struct {
 	// Defaults to `'visible'`. Can be either:
@@ -920,6 +1154,8 @@ type FrameWaitForSelectorOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type FrameWaitForURLOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -929,6 +1165,13 @@ type FrameWaitForURLOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type InputRecordingReplayOptions struct {
+	// Factor by which the replay is faster than the recording, e.g. `2` replays twice as fast. Pass `0` to replay the
+	// events without any delay. Defaults to `1`.
+	Speed *float64 `json:"speed"`
 }
 type JSHandleEvaluateOptions struct {
 	// Optional argument to pass to `expression`.
@@ -958,7 +1201,13 @@ type LocatorBoundingBoxResult struct {
 	// the height of the element in pixels.
 	Height *float64 `json:"height"`
 }
+type LocatorAriaSnapshotOptions struct {
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+}
 type LocatorBoundingBoxOptions struct {
+	// Coordinate space of the returned bounding box, defaults to BoundingBoxSpaceViewport.
+	Space *BoundingBoxSpace `json:"space"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 }
@@ -973,6 +1222,8 @@ type LocatorCheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorPosition struct {
 	X *float64 `json:"x"`
@@ -997,6 +1248,8 @@ type LocatorClickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorDblclickOptions struct {
 	// Defaults to `left`.
@@ -1015,6 +1268,8 @@ type LocatorDblclickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorDispatchEventOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
@@ -1043,6 +1298,16 @@ type LocatorFillOptions struct {
 	NoWaitAfter *bool `json:"noWaitAfter"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type LocatorFillTimeOptions struct {
+	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
+	Force *bool `json:"force"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
 }
 type LocatorFocusOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
@@ -1063,6 +1328,8 @@ type LocatorHoverOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorInnerHTMLOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
@@ -1107,6 +1374,16 @@ type LocatorPressOptions struct {
 	NoWaitAfter *bool `json:"noWaitAfter"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type LocatorPressSequentiallyOptions struct {
+	// Time to wait between key presses in milliseconds. Defaults to 0.
+	Delay *float64 `json:"delay"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
 }
 type LocatorScreenshotOptions struct {
 	// Hides default white background and allows capturing screenshots with transparency. Not applicable to `jpeg` images. Defaults to `false`.
@@ -1115,6 +1392,8 @@ type LocatorScreenshotOptions struct {
 	Path *string `json:"path"`
 	// The quality of the image, between 0-100. Not applicable to `png` images.
 	Quality *int `json:"quality"`
+	// Waits for fonts, images and animations to settle before taking the screenshot, see Page.Stabilize().
+	Stabilize *StabilizeOptions `json:"stabilize"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
@@ -1131,6 +1410,8 @@ type LocatorSelectOptionOptions struct {
 	NoWaitAfter *bool `json:"noWaitAfter"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorSelectTextOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -1138,6 +1419,18 @@ type LocatorSelectTextOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 }
+type LocatorSetCheckedOptions struct {
+	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
+	Force *bool `json:"force"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// A point to use relative to the top-left corner of element padding box. If not specified, uses some visible point of the element.
+	Position *LocatorSetCheckedOptionsPosition `json:"position"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
+	Trial *bool `json:"trial"`
+}
 type LocatorSetInputFilesOptions struct {
 	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
 	NoWaitAfter *bool `json:"noWaitAfter"`
@@ -1157,6 +1450,8 @@ type LocatorTapOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorTextContentOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
@@ -1169,6 +1464,8 @@ type LocatorTypeOptions struct {
 	NoWaitAfter *bool `json:"noWaitAfter"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type LocatorUncheckOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -1181,6 +1478,20 @@ type LocatorUncheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type LocatorWaitForOptions struct {
+	// Defaults to `'visible'`. Can be either:
+	// `'attached'` - wait for element to be present in DOM.
+	// `'detached'` - wait for element to not be present in DOM.
+	// `'visible'` - wait for element to have non-empty bounding box and no `visibility:hidden`. Note that element without any content or with `display:none` has an empty bounding box and is not considered visible.
+	// `'hidden'` - wait for element to be either detached from DOM, or have an empty bounding box or `visibility:hidden`. This is opposite to the `'visible'` option.
+	State *WaitForSelectorState `json:"state"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type MouseClickOptions struct {
 	// Defaults to `left`.
@@ -1217,12 +1528,22 @@ type PageAddInitScriptOptions struct {
 	Script *string `json:"script"`
 	// Optional Script path to be evaluated in all pages in the browser context.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
+	// Origins of the documents to evaluate the script in, e.g. `https://example.com`. Defaults to all origins.
+	Origins []string `json:"origins"`
+	// Whether to only evaluate the script in main frames, so it does not run in iframes. Defaults to `false`.
+	MainFrameOnly *bool `json:"mainFrameOnly"`
 }
 type PageAddScriptTagOptions struct {
 	// Raw JavaScript content to be injected into frame.
 	Content *string `json:"content"`
 	// Path to the JavaScript file to be injected into frame. If `path` is a relative path, then it is resolved relative to the current working directory.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
+	// Bundles the ES module in `content` or `path` together with its imports into a single classic script, TypeScript gets transpiled. Always done for `.ts`, `.tsx`, `.mts`, `.cts` and `.jsx` files. Requires the [esbuild](https://esbuild.github.io) executable on the `PATH` or in the `PLAYWRIGHT_ESBUILD_PATH` environment variable.
+	Bundle *bool `json:"bundle"`
 	// Script type. Use 'module' in order to load a Javascript ES6 module. See [script](https://developer.mozilla.org/en-US/docs/Web/HTML/Element/script) for more details.
 	Type *string `json:"type"`
 	// URL of a script to be added.
@@ -1233,9 +1554,21 @@ type PageAddStyleTagOptions struct {
 	Content *string `json:"content"`
 	// Path to the CSS file to be injected into frame. If `path` is a relative path, then it is resolved relative to the current working directory.
 	Path *string `json:"path"`
+	// File system to read `path` from instead of the working directory, e.g. an `embed.FS` with assets embedded via `go:embed`. The path then uses forward slashes and is relative to the root of the file system.
+	FS fs.FS `json:"-"`
 	// URL of the `<link>` tag.
 	URL *string `json:"url"`
 }
+type PageRouteFromHAROptions struct {
+	// If set to 'abort' any request not found in the HAR file will be aborted. If set to 'fallback' missing requests will be sent to the network. Defaults to abort.
+	NotFound *HarNotFound `json:"notFound"`
+	// If specified, updates the given HAR with the actual network information instead of serving from file. The file is written to disk when the page or the browser context is closed.
+	Update *bool `json:"update"`
+	// Optional setting to control resource content management in the update mode. If `attach` is specified, resources are persisted as separate files or entries in the ZIP archive. If `embed` is specified, content is stored inline the HAR file. Defaults to `attach` for `.zip` files and to `embed` otherwise.
+	UpdateContent *HarContentPolicy `json:"updateContent"`
+	// A glob pattern, regular expression or predicate to match the request URL. Only requests with URL matching the pattern will be served from the HAR file, respectively recorded into it. If not specified, all requests are served from the HAR file.
+	URL interface{} `json:"url"`
+}
 type PageCheckOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
 	Force *bool `json:"force"`
@@ -1249,6 +1582,8 @@ type PageCheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PagePosition struct {
 	X *float64 `json:"x"`
@@ -1275,6 +1610,8 @@ type PageClickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageCloseOptions struct {
 	// Defaults to `false`. Whether to run the [before unload](https://developer.mozilla.org/en-US/docs/Web/Events/beforeunload) page handlers.
@@ -1299,6 +1636,8 @@ type PageDblclickOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageDispatchEventOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -1306,6 +1645,13 @@ type PageDispatchEventOptions struct {
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 }
+type PageDumpIndexedDBOptions struct {
+	// Origin of the frame whose databases get dumped, defaults to the one of the main frame.
+	Origin *string `json:"origin"`
+	// Names of the databases to dump, defaults to all databases of the origin. Required in browsers which do not support
+	// `indexedDB.databases()`.
+	Databases []string `json:"databases"`
+}
 type PageDragAndDropOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
 	Force *bool `json:"force"`
@@ -1358,6 +1704,22 @@ type PageExposeBindingOptions struct {
 	// Whether to pass the argument as a handle, instead of passing by value. When passing a handle, only one argument is supported. When passing by value, multiple arguments are supported.
 	Handle *bool `json:"handle"`
 }
+type PageEvaluateModuleOptions struct {
+	// ES module or TypeScript source to evaluate. Relative imports get resolved against the current working directory.
+	Content *string `json:"content"`
+	// Path to the ES module or TypeScript file to evaluate. Relative imports get resolved against its directory.
+	Path *string `json:"path"`
+	// Name of the export to return, functions get called with `Arg` and awaited. Defaults to `default`.
+	Export *string `json:"export"`
+	// Optional argument to pass to the exported function.
+	Arg interface{} `json:"arg"`
+}
+type PageExtractTextOptions struct {
+	// Output format, either TextFormatPlain or TextFormatMarkdown. Defaults to TextFormatPlain.
+	Format *TextFormat `json:"format"`
+	// Selector of the element to extract the text of. Defaults to the body of the document.
+	Selector *string `json:"selector"`
+}
 type PageFillOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
 	Force *bool `json:"force"`
@@ -1367,6 +1729,8 @@ type PageFillOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageFocusOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -1388,6 +1752,8 @@ type PageGoBackOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageGoForwardOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -1397,6 +1763,8 @@ type PageGoForwardOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageGotoOptions struct {
 	// Referer header value. If provided it will take preference over the referer header value set by Page.SetExtraHttpHeaders().
@@ -1408,6 +1776,8 @@ type PageGotoOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageHoverOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -1422,6 +1792,8 @@ type PageHoverOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageInnerHTMLOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -1529,6 +1901,8 @@ type PagePressOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageQuerySelectorOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -1542,10 +1916,14 @@ type PageReloadOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageRouteOptions struct {
 	// handler function to route the request.
 	Handler func(Route, Request) `json:"handler"`
+	// How often a route should be used. By default it will be used every time.
+	Times *int `json:"times"`
 }
 type PageScreenshotOptions struct {
 	// An object which specifies clipping of the resulting image. Should have the following fields:
@@ -1558,6 +1936,8 @@ type PageScreenshotOptions struct {
 	Path *string `json:"path"`
 	// The quality of the image, between 0-100. Not applicable to `png` images.
 	Quality *int `json:"quality"`
+	// Waits for fonts, images and animations to settle before taking the screenshot, see Page.Stabilize().
+	Stabilize *StabilizeOptions `json:"stabilize"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
@@ -1582,6 +1962,8 @@ type PageSelectOptionOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageSetContentOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -1592,6 +1974,13 @@ type PageSetContentOptions struct {
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
 }
+type PageSetExtraHTTPHeadersOptions struct {
+	// Whether to merge the headers into the ones which are already set, instead of replacing them. Defaults to `false`.
+	Merge *bool `json:"merge"`
+	// Only send the headers with requests to URLs matching this glob pattern string or *regexp.Regexp, e.g. the origin of
+	// your API. Scoped headers get added by routing the requests of the page.
+	URL interface{} `json:"url"`
+}
 type PageSetInputFilesOptions struct {
 	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as navigating to inaccessible pages. Defaults to `false`.
 	NoWaitAfter *bool `json:"noWaitAfter"`
@@ -1615,6 +2004,8 @@ type PageTapOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageTextContentOptions struct {
 	// When true, the call requires selector to resolve to a single element. If given selector resolves to more then one element, the call throws an exception.
@@ -1631,6 +2022,8 @@ type PageTypeOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageUncheckOptions struct {
 	// Whether to bypass the [actionability](./actionability.md) checks. Defaults to `false`.
@@ -1645,6 +2038,15 @@ type PageUncheckOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// When set, this method only performs the [actionability](./actionability.md) checks and skips the action. Defaults to `false`. Useful to wait until the element is ready for the action without performing it.
 	Trial *bool `json:"trial"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageUnrouteAllOptions struct {
+	// Specifies whether to wait for already running handlers and what to do if they throw errors:
+	// `'default'` - do not wait for current handler calls (if any) to finish.
+	// `'wait'` - wait for current handler calls (if any) to finish.
+	// `'ignoreErrors'` - do not wait for current handler calls (if any) to finish, errors of their route actions get ignored.
+	Behavior *UnrouteBehavior `json:"behavior"`
 }
 type PageUnrouteOptions struct {
 	// Optional handler function to route the request.
@@ -1658,11 +2060,19 @@ type PageViewportSizeResult struct {
 	// page height in pixels.
 	Height *int `json:"height"`
 }
+type PageWaitForAssetsLoadedOptions struct {
+	// Whether to wait for the images outside of the viewport as well. Defaults to `false`.
+	AllImages *bool `json:"allImages"`
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+}
 type PageWaitForFunctionOptions struct {
 	// If `polling` is `'raf'`, then `expression` is constantly executed in `requestAnimationFrame` callback. If `polling` is a number, then it is treated as an interval in milliseconds at which the function would be executed. Defaults to `raf`.
 	Polling interface{} `json:"polling"`
 	// maximum time to wait for in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout().
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageWaitForLoadStateOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -1678,6 +2088,8 @@ type PageWaitForNavigationOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 type PageWaitForRequestOptions struct {
 	// Maximum wait time in milliseconds, defaults to 30 seconds, pass `0` to disable the timeout. The default value can be changed by using the Page.SetDefaultTimeout() method.
@@ -1698,6 +2110,23 @@ type PageWaitForSelectorOptions struct {
 	Strict *bool `json:"strict"`
 	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
 	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageSubscriptionOptions struct {
+	// Capacity of the channel of the subscription, defaults to `100`. Events which arrive while the channel is full get
+	// dropped, see `Dropped()`.
+	BufferSize *int `json:"bufferSize"`
+}
+type PageWaitForFrameOptions struct {
+	// Maximum time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by
+	// using the BrowserContext.SetDefaultTimeout() or Page.SetDefaultTimeout() methods.
+	Timeout *float64 `json:"timeout"`
+	// When to consider the frame loaded, defaults to `load`. Events can be either:
+	// `'domcontentloaded'` - consider the frame loaded when the `DOMContentLoaded` event is fired.
+	// `'load'` - consider the frame loaded when the `load` event is fired.
+	// `'networkidle'` - consider the frame loaded when there are no network connections for at least `500` ms.
+	WaitUntil *WaitUntilState `json:"waitUntil"`
 }
 type PageWaitForURLOptions struct {
 	// Maximum operation time in milliseconds, defaults to 30 seconds, pass `0` to disable timeout. The default value can be changed by using the BrowserContext.SetDefaultNavigationTimeout(), BrowserContext.SetDefaultTimeout(), Page.SetDefaultNavigationTimeout() or Page.SetDefaultTimeout() methods.
@@ -1707,6 +2136,8 @@ type PageWaitForURLOptions struct {
 	// `'load'` - consider operation to be finished when the `load` event is fired.
 	// `'networkidle'` - consider operation to be finished when there are no network connections for at least `500` ms.
 	WaitUntil *WaitUntilState `json:"waitUntil"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
 }
 
 // Result of calling <see cref="Request.Timing" />.
@@ -1779,6 +2210,36 @@ type RouteContinueOptions struct {
 	// If set changes the request URL. New URL must have same protocol as original one.
 	URL *string `json:"url"`
 }
+type RequestReplayOptions struct {
+	// If set changes the request HTTP headers. Header values will be converted to a string.
+	Headers map[string]string `json:"headers"`
+	// Maximum number of request redirects that will be followed automatically. An error will be returned if the number is
+	// exceeded. Defaults to `20`. Pass `0` to not follow redirects.
+	MaxRedirects *int `json:"maxRedirects"`
+	// If set changes the request method (e.g. GET or POST)
+	Method *string `json:"method"`
+	// If set changes the post data of request
+	PostData interface{} `json:"postData"`
+	// Request timeout in milliseconds. Defaults to the default timeout of the browser context, pass `0` to disable timeout.
+	Timeout *float64 `json:"timeout"`
+	// If set changes the request URL.
+	URL *string `json:"url"`
+}
+type RouteFetchOptions struct {
+	// If set changes the request HTTP headers. Header values will be converted to a string.
+	Headers map[string]string `json:"headers"`
+	// Maximum number of request redirects that will be followed automatically. An error will be returned if the number is
+	// exceeded. Defaults to `20`. Pass `0` to not follow redirects.
+	MaxRedirects *int `json:"maxRedirects"`
+	// If set changes the request method (e.g. GET or POST)
+	Method *string `json:"method"`
+	// If set changes the post data of request
+	PostData interface{} `json:"postData"`
+	// Request timeout in milliseconds. Defaults to the default timeout of the browser context, pass `0` to disable timeout.
+	Timeout *float64 `json:"timeout"`
+	// If set changes the request URL.
+	URL *string `json:"url"`
+}
 type RouteFulfillOptions struct {
 	// Response body.
 	Body interface{} `json:"body"`
@@ -1786,8 +2247,14 @@ type RouteFulfillOptions struct {
 	ContentType *string `json:"contentType"`
 	// Response headers. Header values will be converted to a string.
 	Headers map[string]string `json:"headers"`
+	// Value which gets serialized with json.Marshal as the response body. Sets the `content-type` header to
+	// `application/json` unless it is set explicitly. Can not be combined with Body or Path.
+	JSON interface{} `json:"-"`
 	// File path to respond with. The content type will be inferred from file extension. If `path` is a relative path, then it is resolved relative to the current working directory.
 	Path *string `json:"path"`
+	// APIResponse to fulfill the route's request with, e.g. the one returned by Route.Fetch(). Its status, headers and body
+	// are used unless they are set explicitly, the `content-length` header gets recalculated when the body is replaced.
+	Response APIResponse `json:"-"`
 	// Response status code, defaults to `200`.
 	Status *int `json:"status"`
 }
@@ -1802,11 +2269,23 @@ type TracingStartOptions struct {
 	Screenshots *bool `json:"screenshots"`
 	// Whether to capture DOM snapshot on every action.
 	Snapshots *bool `json:"snapshots"`
+	// Whether to include the source files of the actions for the source tab of the trace viewer.
+	Sources *bool `json:"sources"`
+	// Trace name to be shown in the trace viewer.
+	Title *string `json:"title"`
+}
+type TracingStartChunkOptions struct {
+	// Trace name to be shown in the trace viewer.
+	Title *string `json:"title"`
 }
 type TracingStopOptions struct {
 	// Export trace into the file with the given name.
 	Path *string `json:"path"`
 }
+type TracingStopChunkOptions struct {
+	// Export trace collected since the last Tracing.StartChunk() call into the file with the given path.
+	Path *string `json:"path"`
+}
 type FrameReceivedPayload struct {
 	// frame payload
 	Payload []byte `json:"payload"`
@@ -1815,6 +2294,12 @@ type FrameSentPayload struct {
 	// frame payload
 	Payload []byte `json:"payload"`
 }
+type WebSocketRouteCloseOptions struct {
+	// Close code, defaults to `1000`.
+	Code *int `json:"code"`
+	// Close reason.
+	Reason *string `json:"reason"`
+}
 type WorkerEvaluateOptions struct {
 	// Optional argument to pass to `expression`.
 	Arg interface{} `json:"arg"`
@@ -2062,6 +2547,10 @@ type FrameHoverOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
 }
+type FrameSetCheckedOptionsPosition struct {
+	X *float64 `json:"x"`
+	Y *float64 `json:"y"`
+}
 type FrameTapOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
@@ -2086,6 +2575,10 @@ type LocatorHoverOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
 }
+type LocatorSetCheckedOptionsPosition struct {
+	X *float64 `json:"x"`
+	Y *float64 `json:"y"`
+}
 type LocatorTapOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
@@ -2094,6 +2587,124 @@ type LocatorUncheckOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
 }
+type LocatorAssertionsToHaveAccessibleNameOptions struct {
+	// Whether to perform case-insensitive match. Ignored if `name` is a *regexp.Regexp.
+	IgnoreCase *bool `json:"ignoreCase"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveAccessibleDescriptionOptions struct {
+	// Whether to perform case-insensitive match. Ignored if `description` is a *regexp.Regexp.
+	IgnoreCase *bool `json:"ignoreCase"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToMatchAriaSnapshotOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveRoleOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveCSSOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveClassOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveValueOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveValuesOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeInViewportOptions struct {
+	// The minimal ratio of the element to intersect the viewport. Defaults to any positive ratio.
+	Ratio *float64 `json:"ratio"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeAttachedOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeCheckedOptions struct {
+	// The state the checkbox or radio button has to be in. Defaults to `true`.
+	Checked *bool `json:"checked"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeDisabledOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeEditableOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeEmptyOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeEnabledOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeFocusedOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeHiddenOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToBeVisibleOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToContainTextOptions struct {
+	// Whether to perform case-insensitive match. Ignored if the expected text is a *regexp.Regexp.
+	IgnoreCase *bool `json:"ignoreCase"`
+	// Whether to use `element.innerText` instead of `element.textContent`.
+	UseInnerText *bool `json:"useInnerText"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveAttributeOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveCountOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveIdOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type LocatorAssertionsToHaveTextOptions struct {
+	// Whether to perform case-insensitive match. Ignored if the expected text is a *regexp.Regexp.
+	IgnoreCase *bool `json:"ignoreCase"`
+	// Whether to use `element.innerText` instead of `element.textContent`.
+	UseInnerText *bool `json:"useInnerText"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type PageAssertionsToHaveURLOptions struct {
+	// Whether to perform case-insensitive match. Ignored if the URL is a *regexp.Regexp or a predicate.
+	IgnoreCase *bool `json:"ignoreCase"`
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
+type PageAssertionsToHaveTitleOptions struct {
+	// Time to retry the assertion for in milliseconds.
+	Timeout *float64 `json:"timeout"`
+}
 type PageCheckOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
@@ -2146,6 +2757,92 @@ type PageUncheckOptionsPosition struct {
 	X *float64 `json:"x"`
 	Y *float64 `json:"y"`
 }
+type PageDOMSnapshotOptions struct {
+	// Selector of the root element of the snapshot. Defaults to the document element.
+	Selector *string `json:"selector"`
+	// Computed style properties to include, e.g. "display" or "color". No styles get included by default.
+	Styles []string `json:"styles"`
+	// Attributes which get left out, e.g. generated ids.
+	IgnoreAttributes []string `json:"ignoreAttributes"`
+	// Selectors of elements which get left out together with their subtrees, e.g. ads or timestamps.
+	IgnoreSelectors []string `json:"ignoreSelectors"`
+}
+type PageExpectDownloadMatchingOptions struct {
+	// Suggested filename the download has to match, either a string or a *regexp.Regexp. Downloads with another filename
+	// get ignored.
+	SuggestedFilename interface{} `json:"suggestedFilename"`
+	// MIME type the download has to have, e.g. `text/csv`. Parameters like the charset are ignored.
+	MimeType *string `json:"mimeType"`
+	// Minimal size of the downloaded file in bytes.
+	MinSize *int `json:"minSize"`
+	// Hex encoded SHA-256 hash the content of the download has to have.
+	SHA256 *string `json:"sha256"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+}
+type PageSetViewportSizeOptions struct {
+	// Specify device scale factor (can be thought of as dpr). Only supported in Chromium.
+	DeviceScaleFactor *float64 `json:"deviceScaleFactor"`
+	// Screen orientation, either `portrait` or `landscape`. Only supported in Chromium.
+	Orientation *string `json:"orientation"`
+}
+type PageExpectDialogOptions struct {
+	// Only resolve with a dialog for which the predicate returns true.
+	Predicate func(dialog Dialog) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectDownloadOptions struct {
+	// Only resolve with a download for which the predicate returns true.
+	Predicate func(download Download) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectFileChooserOptions struct {
+	// Only resolve with a file chooser for which the predicate returns true.
+	Predicate func(fileChooser FileChooser) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectPopupOptions struct {
+	// Only resolve with a popup whose URL matches. Either a glob pattern string, a *regexp.Regexp or a
+	// func(url string) bool.
+	URL interface{} `json:"url"`
+	// Only resolve with a popup for which the predicate returns true.
+	Predicate func(popup Page) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectRequestFinishedOptions struct {
+	// Only resolve with a request for which the predicate returns true.
+	Predicate func(request Request) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectRequestAndResponseOptions struct {
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
+type PageExpectWorkerOptions struct {
+	// Only resolve with a worker for which the predicate returns true.
+	Predicate func(worker Worker) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+	// Context of the call, overrides Timeout when it has a deadline.
+	Context context.Context `json:"-"`
+}
 type BrowserNewContextOptionsRecordVideoSize struct {
 	// Video frame width.
 	Width *int `json:"width"`
@@ -2210,6 +2907,18 @@ type BrowserContextStorageStateResultOriginsLocalStorage struct {
 	Name  *string `json:"name"`
 	Value *string `json:"value"`
 }
+type BrowserContextWaitForPageOptions struct {
+	// Only resolve with a page for which the predicate returns true.
+	Predicate func(page Page) bool `json:"-"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the browser context.
+	Timeout *float64 `json:"timeout"`
+}
+type BrowserContextWaitForExtensionOptions struct {
+	// Extension ID to wait for. Defaults to the first extension which gets loaded.
+	ID *string `json:"id"`
+	// Maximum time in milliseconds. Defaults to the default timeout of the browser context.
+	Timeout *float64 `json:"timeout"`
+}
 type BrowserTypeLaunchPersistentContextOptionsRecordVideoSize struct {
 	// Video frame width.
 	Width *int `json:"width"`
@@ -2224,3 +2933,19 @@ type BrowserNewPageOptionsStorageStateOriginsLocalStorage struct {
 	Name  *string `json:"name"`
 	Value *string `json:"value"`
 }
+type ClockInstallOptions struct {
+	// Time to initialize the fake clock with. Defaults to the current time.
+	Time *time.Time `json:"time"`
+}
+
+// EvaluateOptions can be passed as the last argument of Frame.Evaluate() and
+// Page.Evaluate(), after the argument of the expression:
+//
+//	page.Evaluate(`() => new Promise(() => {})`, nil, playwright.EvaluateOptions{Timeout: playwright.Float(1000)})
+type EvaluateOptions struct {
+	// Maximum time in milliseconds the evaluation may take. When it is exceeded
+	// the evaluation gets aborted in the page and a *TimeoutError is returned.
+	// Pending promises get rejected in all browsers, scripts which block the
+	// page get terminated in Chromium only. Defaults to no timeout.
+	Timeout *float64 `json:"timeout"`
+}
@@ -1,5 +1,88 @@
 package playwright
 
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// APIRequest exposes the API that can be used for Web API testing, it is available as `Playwright.Request`. Each
+// request context created with APIRequest.NewContext() has its own cookie storage, use BrowserContext.Request() to
+// share the cookies with a browser context.
+type APIRequest interface {
+	// Creates a new instance of APIRequestContext.
+	NewContext(options ...APIRequestNewContextOptions) (APIRequestContext, error)
+}
+
+// APIRequestContext sends HTTP requests without a browser, e.g. to prepare the server state via a REST API before
+// driving the UI. Cookies received in responses get stored and sent with later requests.
+type APIRequestContext interface {
+	// Sends HTTP(S) [DELETE](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/DELETE) request and returns its
+	// response.
+	Delete(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// All further requests of the request context return an error. Requests of a browser context's request context do not
+	// affect the browser context.
+	Dispose() error
+	// Sends HTTP(S) request and returns its response. The method defaults to GET and can be set with the `Method`
+	// option. Relative URLs get resolved against the `BaseURL` option of the context.
+	Fetch(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Sends HTTP(S) [GET](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/GET) request and returns its response.
+	Get(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Sends HTTP(S) [HEAD](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/HEAD) request and returns its
+	// response.
+	Head(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Sends HTTP(S) [PATCH](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/PATCH) request and returns its
+	// response.
+	Patch(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Sends HTTP(S) [POST](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/POST) request and returns its
+	// response.
+	Post(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Sends HTTP(S) [PUT](https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/PUT) request and returns its response.
+	Put(url string, options ...APIRequestContextFetchOptions) (APIResponse, error)
+	// Returns storage state for this request context, contains current cookies and the local storage snapshot if it was
+	// passed to the constructor. For the request context of a browser context it is BrowserContext.StorageState().
+	StorageState(path ...string) (*StorageState, error)
+}
+
+// APIResponse represents responses returned by APIRequestContext.Get() and similar methods. The body is read
+// completely before the response is returned. A page's Response implements APIResponse as well.
+type APIResponse interface {
+	// Returns the buffer with response body.
+	Body() ([]byte, error)
+	// An object with all the response HTTP headers associated with this response. Header names are lower-cased.
+	Headers() map[string]string
+	// Unmarshals the JSON representation of response body into `v`.
+	JSON(v interface{}) error
+	// Contains a boolean stating whether the response was successful (status in the range 200-299) or not.
+	Ok() bool
+	// Contains the status code of the response (e.g., 200 for a success).
+	Status() int
+	// Contains the status text of the response (e.g. usually an "OK" for a success).
+	StatusText() string
+	// Returns the text representation of response body.
+	Text() (string, error)
+	// Contains the URL of the response.
+	URL() string
+}
+
+// The Accessibility class provides methods for inspecting Chromium's accessibility tree. The accessibility tree is used by
+// assistive technology such as [screen readers](https://en.wikipedia.org/wiki/Screen_reader) or
+// [switches](https://en.wikipedia.org/wiki/Switch_access).
+// Accessibility is a very platform-specific thing. On different platforms, there are different screen readers that
+// might have wildly different output.
+// Rendering engines of Chromium, Firefox and WebKit have a concept of "accessibility tree", which is then translated into
+// different platform-specific APIs. Accessibility namespace gives access to this Accessibility Tree.
+// Most of the accessibility tree gets filtered out when converting from internal browser AX Tree to Platform-specific
+// AX-Tree or by assistive technologies themselves. By default, Playwright tries to approximate this filtering, exposing
+// only the "interesting" nodes of the tree.
+type Accessibility interface {
+	// Captures the current state of the accessibility tree. The returned object represents the root accessible node of the
+	// page, it is nil when the root is not part of the tree.
+	// > NOTE: The Chromium accessibility tree contains nodes that go unused on most platforms and by most screen readers.
+	// Playwright will discard them as well for an easier to process tree, unless `interestingOnly` is set to `false`.
+	Snapshot(options ...AccessibilitySnapshotOptions) (*AccessibilityNode, error)
+}
+
 type BindingCall interface {
 	Call(f BindingCallFunction)
 }
@@ -27,6 +110,11 @@ type Browser interface {
 	// > NOTE: CDP Sessions are only supported on Chromium-based browsers.
 	// Returns the newly created browser session.
 	NewBrowserCDPSession() (CDPSession, error)
+	// Returns a subscription which delivers the `console`, `pageerror`, `crash`, `close`, request and response events of
+	// the pages of all contexts created with Browser.NewContext(), tagged with the IDs of their context and page, on a Go
+	// channel. Events get dropped instead of blocking when the channel is full. Call `Close()` on the subscription to stop
+	// it.
+	SubscribeEvents(options ...BrowserSubscribeEventsOptions) *BrowserEventSubscription
 	// Returns the browser version.
 	Version() string
 }
@@ -45,6 +133,16 @@ type CDPSession interface {
 	// send messages.
 	Detach() error
 	Send(method string, params map[string]interface{}) (interface{}, error)
+	// Returns typed bindings for the `Network` domain of the session.
+	Network() *CDPNetwork
+	// Returns typed bindings for the `Emulation` domain of the session.
+	Emulation() *CDPEmulation
+	// Returns typed bindings for the `Performance` domain of the session.
+	Performance() *CDPPerformance
+	// Returns typed bindings for the `Storage` domain of the session.
+	Storage() *CDPStorage
+	// Returns typed bindings for the `Browser` domain of the session.
+	Browser() *CDPBrowser
 }
 
 // BrowserContexts provide a way to operate multiple independent browser sessions.
@@ -54,6 +152,10 @@ type CDPSession interface {
 // contexts don't write any browsing data to disk.
 type BrowserContext interface {
 	EventEmitter
+	// Returns the page which was opened or brought to front via Page.bringToFront() most recently. When the active page
+	// gets closed, the most recently opened page which is still open becomes the active one. Returns `nil` if there are no
+	// open pages.
+	ActivePage() Page
 	// Adds cookies into this browser context. All pages within this context will have these cookies installed. Cookies can be
 	// obtained via BrowserContext.cookies().
 	AddCookies(cookies ...SetNetworkCookieParam) error
@@ -64,15 +166,30 @@ type BrowserContext interface {
 	// The script is evaluated after the document was created but before any of its scripts were run. This is useful to amend
 	// the JavaScript environment, e.g. to seed `Math.random`.
 	// An example of overriding `Math.random` before the page loads:
+	// Pass `origins` or `mainFrameOnly` to only evaluate the script in documents of these origins or in main frames, e.g. so
+	// a patched `window.fetch` does not leak into third-party iframes.
 	// > NOTE: The order of evaluation of multiple scripts installed via BrowserContext.addInitScript() and
 	// Page.addInitScript() is not defined.
 	AddInitScript(script BrowserContextAddInitScriptOptions) error
+	// Aborts all requests of the browser context which match one of the given resource types or domains, e.g. to speed up
+	// scraping runs by not loading images and fonts. The rules get applied before any route handlers, including the ones
+	// of Page.route(), and replace the ones of a previous call. Calling it with empty options stops blocking.
+	BlockRequests(options BrowserContextBlockRequestsOptions) error
 	// Returns the browser instance of the context. If it was launched as a persistent context null gets returned.
 	Browser() Browser
 	// Clears context cookies.
 	ClearCookies() error
+	// Returns the fake clock of the context which controls the timers and animations of all of its pages.
+	Clock() Clock
+	// Creates a new browser context with the options of this context, its current storage state (cookies and
+	// localStorage), routes and init scripts. Non-nil fields of `options` override the ones of this context. This is useful
+	// to share an expensive authenticated setup between parallel tests. Persistent contexts can not be cloned.
+	Clone(options ...BrowserNewContextOptions) (BrowserContext, error)
 	// Clears all permission overrides for the browser context.
 	ClearPermissions() error
+	// Closes all open pages of the browser context while keeping the context itself open, e.g. to reset the tabs between
+	// steps of a multi-tab flow. All pages are attempted to be closed even if closing one of them fails.
+	CloseAllPages() error
 	// Closes the browser context. All the pages that belong to the browser context will be closed.
 	// > NOTE: The default browser context cannot be closed.
 	Close() error
@@ -98,15 +215,41 @@ type BrowserContext interface {
 	// Grants specified permissions to the browser context. Only grants corresponding permissions to the given origin if
 	// specified.
 	GrantPermissions(permissions []string, options ...BrowserContextGrantPermissionsOptions) error
+	// Returns the *LimitExceededError the context got closed with because it exceeded one of the `Limits` it was created
+	// with, nil otherwise.
+	LimitError() error
+	// Returns a copy of the metadata of the context, set with the `Metadata` option or SetMetadata().
+	Metadata() map[string]string
 	// > NOTE: CDP sessions are only supported on Chromium-based browsers.
 	// Returns the newly created session.
 	NewCDPSession(page Page) (CDPSession, error)
-	// Creates a new page in the browser context.
+	// Creates a new page in the browser context. The `Viewport`, `UserAgent`, `ExtraHttpHeaders` and `ColorScheme` options
+	// allow pages in the same context to use different emulation settings than the browser context, the other options
+	// only apply to Browser.NewPage().
 	NewPage(options ...BrowserNewPageOptions) (Page, error)
-	// Returns all open pages in the context.
+	// Returns the counters of all requests and responses of the browser context since it got created, including the ones
+	// of pages which are closed already. The returned value is a copy.
+	NetworkStats() NetworkStats
+	// Registers a hook which gets called once before the context gets closed, by BrowserContext.Close() or
+	// Browser.Close(), while its pages can still be used, e.g. to flush artifacts. The context gets closed even if a hook
+	// returns an error, the first error is returned by the close call.
+	OnBeforeClose(hook func(context BrowserContext) error)
+	// Returns all open pages in the context in the order they were opened. The returned slice is a copy, so it does not
+	// change when pages get opened or closed afterwards.
 	Pages() []Page
 	// Returns a handle for all background pages (eg. extensions) within the browser context.
 	BackgroundPages() []BackgroundPage
+	// > NOTE: Service workers are only supported on Chromium-based browsers.
+	// All existing service workers in the context.
+	ServiceWorkers() []Worker
+	// Returns all the Chromium extensions which have a running background page or service worker in the browser context.
+	Extensions() []Extension
+	// Waits until the background page (Manifest V2) or service worker (Manifest V3) of an extension got started and returns
+	// the extension. Extensions can only be loaded into persistent contexts via the `--load-extension` argument.
+	WaitForExtension(options ...BrowserContextWaitForExtensionOptions) (Extension, error)
+	// Sets the metadata `key` of the context, e.g. the name, owner or ticket of the test. The `{key}` placeholders of the
+	// trace and video paths get replaced with the metadata values when the artifacts get saved.
+	SetMetadata(key, value string)
 	// This setting will change the default maximum navigation time for the following methods and related shortcuts:
 	// - Page.goBack()
 	// - Page.goForward()
@@ -124,13 +267,18 @@ type BrowserContext interface {
 	// The extra HTTP headers will be sent with every request initiated by any page in the context. These headers are merged
 	// with page-specific extra HTTP headers set with Page.setExtraHTTPHeaders(). If page overrides a particular
 	// header, page-specific header value will be used instead of the browser context header value.
+	// Pass `Merge` to add the headers to the current ones instead of replacing them, and `URL` to only send them with
+	// requests to matching URLs, so e.g. authorization headers do not leak to third-party origins.
 	// > NOTE: BrowserContext.setExtraHTTPHeaders() does not guarantee the order of headers in the outgoing requests.
-	SetExtraHTTPHeaders(headers map[string]string) error
+	SetExtraHTTPHeaders(headers map[string]string, options ...BrowserContextSetExtraHTTPHeadersOptions) error
 	// Sets the context's geolocation. Passing `null` or `undefined` emulates position unavailable.
 	// > NOTE: Consider using BrowserContext.grantPermissions() to grant permissions for the browser context pages to
 	// read its geolocation.
 	SetGeolocation(gelocation *SetGeolocationOptions) error
 	ResetGeolocation() error
+	// API testing helper associated with this context. Requests made with this API will use the cookies of the context and
+	// store the cookies they receive in it, so they share the session with the pages of the context.
+	Request() APIRequestContext
 	// Routing provides the capability to modify network requests that are made by any page in the browser context. Once route
 	// is enabled, every request matching the url pattern will stall unless it's continued, fulfilled or aborted.
 	// An example of a naive handler that aborts all image requests:
@@ -141,27 +289,152 @@ type BrowserContext interface {
 	// handlers.
 	// To remove a route with its handler you can use BrowserContext.unroute().
 	// > NOTE: Enabling routing disables http cache.
-	Route(url interface{}, handler routeHandler) error
+	// With the `Times` option the route gets removed after it handled the given number of requests.
+	Route(url interface{}, handler func(Route, Request), options ...BrowserContextRouteOptions) error
+	// Routes the WebSockets of the pages of the context whose URL matches `url` to `handler`, a routed WebSocket does
+	// not connect to the server unless the handler calls WebSocketRoute.ConnectToServer(). `url` is a glob pattern,
+	// regex pattern or predicate receiving the URL. Page routes (set up with Page.RouteWebSocket()) take precedence.
+	// The routing applies to documents which get loaded after the call.
+	RouteWebSocket(url interface{}, handler func(WebSocketRoute)) error
+	// If specified the network requests that are made in the context will be served from the HAR file, which can be
+	// recorded with the `RecordHarPath` option of Browser.NewContext() or with the `Update` option. Requests which are not
+	// in the HAR get aborted, or sent to the network with HarNotFoundFallback. HAR files ending with `.zip` are archives
+	// with the HAR and its attachments.
+	RouteFromHAR(har string, options ...BrowserContextRouteFromHAROptions) error
+	// Makes all pages of the browser context bypass the HTTP cache when `disabled` is `true`, e.g. to measure cold loads.
+	// The cache gets bypassed by enabling the request interception, just like BrowserContext.route() does.
+	SetHTTPCacheDisabled(disabled bool) error
 	SetOffline(offline bool) error
 	// Returns storage state for this browser context, contains current cookies and local storage snapshot.
 	StorageState(path ...string) (*StorageState, error)
 	// Removes a route created with BrowserContext.route(). When `handler` is not specified, removes all routes for
 	// the `url`.
-	Unroute(url interface{}, handler ...routeHandler) error
+	Unroute(url interface{}, handler ...func(Route, Request)) error
+	// Removes all routes created with BrowserContext.route() and BrowserContext.RouteFromHAR(). The `Behavior` option
+	// decides whether to wait for the handlers which are still running.
+	UnrouteAll(options ...BrowserContextUnrouteAllOptions) error
 	// Waits for event to fire and passes its value into the predicate function. Returns when the predicate returns truthy
 	// value. Will throw an error if the context closes before the event is fired. Returns the event data value.
 	WaitForEvent(event string, predicate ...interface{}) interface{}
 	Tracing() Tracing
+	// Reports the permission prompts of the pages in the browser context via the `permissionrequest` event of the
+	// `Page` and the `BrowserContext`. The handlers get called with a `PermissionRequest` which they can grant or deny.
+	// Requests which do not get answered fall back to the permissions of the browser context.
+	EnablePermissionPrompts() error
+	// Makes actions of the pages in the browser context, e.g. Locator.Click() or Page.Fill(), which time out because their
+	// selector does not match any element list the locators of up to three similar elements of the page in the
+	// `TimeoutError`, e.g. `GetByTestId("submit-button")` for a test ID which got renamed from `submit`.
+	EnableSelectorSuggestions()
+	// Makes actions of the pages in the browser context, e.g. Locator.Click() or Page.Fill(), retry up to `retries` times
+	// when they fail with "Execution context was destroyed" because the page navigated or reloaded while they were
+	// performed, e.g. when an app redirects after refreshing a token in the background. Each attempt gets the full
+	// timeout. Defaults to `0`.
+	SetNavigationRetries(retries int)
+	// Returns the first open page for which the `Predicate` option returns true, or waits for such a page to be opened.
+	// Without a predicate the first open page is returned.
+	WaitForPage(options ...BrowserContextWaitForPageOptions) (Page, error)
 }
 
-// API for collecting and saving Playwright traces. Playwright traces can be opened using the Playwright CLI after
-// Playwright script runs.
-// Start with specifying the folder traces will be stored in:
+// PermissionRequest is emitted via the `permissionrequest` event of the `Page` and the `BrowserContext` when a page
+// calls an API which prompts the user for a permission, e.g. `navigator.geolocation.getCurrentPosition()`,
+// `Notification.requestPermission()` or `navigator.mediaDevices.getUserMedia()`. The request has to be granted or denied
+// synchronously from the event handler. See BrowserContext.EnablePermissionPrompts().
+type PermissionRequest interface {
+	// Returns the name of the requested permission, e.g. `geolocation`, `notifications`, `camera`, `microphone` or
+	// `clipboard-read`.
+	Name() string
+	// Returns the origin of the frame which requested the permission.
+	Origin() string
+	// Returns the page which requested the permission.
+	Page() Page
+	// Returns the frame which requested the permission.
+	Frame() Frame
+	// Grants the permission to the origin in the browser context and lets the page continue with the original API call.
+	Grant() error
+	// Denies the permission. The page gets the same error as if the user had dismissed the prompt.
+	Deny()
+}
+
+// API for collecting and saving Playwright traces. Playwright traces can be opened in the trace viewer with
+// `playwright show-trace trace.zip` after Playwright script runs.
+// Start recording a trace with BrowserContext.Tracing().Start() before performing actions. At the end, stop tracing
+// with BrowserContext.Tracing().Stop() and save it to a file.
 type Tracing interface {
-	// Start tracing.
+	// Opens a group with the given name in the trace. The calls made until the matching Tracing.GroupEnd() show up
+	// in the trace viewer with the names of the open groups as a prefix, e.g. `login › page.fill`. Groups can be nested.
+	Group(name string) error
+	// Closes the last group opened by Tracing.Group().
+	GroupEnd() error
+	// Start tracing. With `sources` the Go source files of the calls get bundled into the trace, `title` names the
+	// trace in the trace viewer.
 	Start(options ...TracingStartOptions) error
-	// Stop tracing.
+	// Start a new trace chunk. If you'd like to record multiple traces on the same BrowserContext, use
+	// Tracing.Start() once, and then create multiple trace chunks with Tracing.StartChunk() and Tracing.StopChunk().
+	StartChunk(options ...TracingStartChunkOptions) error
+	// Stop tracing. If `path` is given, the trace is exported into the zip file at this path. The `{key}` placeholders of
+	// `path` get replaced with the values of BrowserContext.Metadata().
 	Stop(options ...TracingStopOptions) error
+	// Stop the trace chunk. If `path` is given, the actions recorded since the chunk started are exported into the zip
+	// file at this path. Tracing itself keeps running, the next chunk gets started with Tracing.StartChunk(). The `{key}`
+	// placeholders of `path` get replaced with the values of BrowserContext.Metadata().
+	StopChunk(options ...TracingStopChunkOptions) error
+}
+
+// Connection is the connection between the client and the Playwright driver. It allows to observe and intercept the
+// protocol messages, e.g. to log, filter or fuzz them or to build tooling like custom recorders.
+type Connection interface {
+	// Calls `handler` for every message which gets sent to the driver, after it passed the middlewares.
+	OnMessageSent(handler func(message ProtocolMessage))
+	// Calls `handler` for every message which gets received from the driver, after it passed the middlewares.
+	OnMessageReceived(handler func(message ProtocolMessage))
+	// Adds `middleware` to the end of the middleware chain, which every sent and received message passes through.
+	Use(middleware ProtocolMiddleware)
+	// Sends a health check to the driver and waits up to `timeout` for its answer. Returns a `DriverUnresponsiveError`
+	// if the driver did not answer in time.
+	Ping(timeout time.Duration) error
+	// Returns whether the last health check of the driver succeeded. Health checks run periodically if
+	// `HealthCheckInterval` is passed to Run().
+	Healthy() bool
+	// Calls `handler` when the driver becomes unhealthy, e.g. because it did not answer a health check in time.
+	OnUnhealthy(handler func(err error))
+}
+
+// Clipboard reads and writes the system clipboard from the page via the
+// [Clipboard API](https://developer.mozilla.org/en-US/docs/Web/API/Clipboard_API). The `clipboard-read` and
+// `clipboard-write` permissions get granted to the origin of the page on each call, which is only supported in
+// Chromium.
+type Clipboard interface {
+	// Returns the text which is currently stored in the clipboard.
+	ReadText() (string, error)
+	// Replaces the content of the clipboard with `text`.
+	WriteText(text string) error
+}
+
+// Clock replaces `Date`, `performance.now()`, the timer functions and `requestAnimationFrame` of a page with fake ones
+// which only advance when they get driven from the test. CSS and Web Animations get paused and move forward together with
+// the clock, so the state of an animation can be asserted deterministically. The clock survives navigations but gets
+// reset to its initial time on each new document. The clock of a browser context applies to all of its pages.
+type Clock interface {
+	// Installs the fake clock in the page. The clock gets installed in the current document and in all documents the page
+	// navigates to afterwards. The clock is paused until Resume() gets called.
+	Install(options ...ClockInstallOptions) error
+	// Makes `Date.now()` and `new Date()` return `t` at all times, the timers keep running. It does not require Install().
+	SetFixedTime(t time.Time) error
+	// Advances the clock by `duration` at once, firing the timers which are due only once, like a computer waking up
+	// from sleep.
+	FastForward(duration time.Duration) error
+	// Advances the clock to `t`, firing all timers and animation frames which are due on the way, and pauses it there.
+	// It fails if the clock is past `t` already.
+	PauseAt(t time.Time) error
+	// Lets the clock advance in real time, until it gets paused with PauseAt().
+	Resume() error
+	// Advances the clock by `duration`, firing all timers and animation frames which are due on the way.
+	RunFor(duration time.Duration) error
+	// Advances the clock by `count` animation frames of 16ms each, firing all timers which are due on the way.
+	AdvanceAnimationFrames(count int) error
+	// Runs all pending timers, including the ones which get scheduled by them, until there are none left. Animation frames
+	// are not run.
+	FlushTimers() error
 }
 
 // BrowserType provides methods to launch a specific browser instance or connect to an existing one. The following is a
@@ -192,8 +465,22 @@ type BrowserType interface {
 	LaunchPersistentContext(userDataDir string, options ...BrowserTypeLaunchPersistentContextOptions) (BrowserContext, error)
 	// Returns browser name. For example: `'chromium'`, `'webkit'` or `'firefox'`.
 	Name() string
+	// Returns whether the browser can record videos of its pages, see the `recordVideo` option of Browser.newContext().
+	SupportsVideo() bool
+	// Returns whether the browser can generate PDFs with Page.pdf(), which returns a *NotSupportedError otherwise.
+	// > NOTE: Generating a pdf is only supported in headless mode.
+	SupportsPDF() bool
+	// Returns whether the browser supports CDP sessions, BrowserContext.newCDPSession() and
+	// Browser.newBrowserCDPSession() return a *NotSupportedError otherwise.
+	SupportsCDP() bool
+	// Returns whether the browser can load extensions via the `extensions` option of
+	// BrowserType.launchPersistentContext(), which returns a *NotSupportedError otherwise.
+	SupportsExtensions() bool
+	// Returns the browser distributions (e.g. Google Chrome or Microsoft Edge) which are installed on the system and can be
+	// launched by passing their channel via the `channel` option.
+	SystemBrowsers() []SystemBrowser
 	// This methods attaches Playwright to an existing browser instance.
-	Connect(url string) (Browser, error)
+	Connect(url string, options ...BrowserTypeConnectOptions) (Browser, error)
 }
 
 // `ConsoleMessage` objects are dispatched by page via the [`event: Page.console`] event.
@@ -289,7 +576,9 @@ type ElementHandle interface {
 	// [Element.getBoundingClientRect](https://developer.mozilla.org/en-US/docs/Web/API/Element/getBoundingClientRect).
 	// Assuming the page is static, it is safe to use bounding box coordinates to perform input. For example, the following
 	// snippet should click the center of the element.
-	BoundingBox() (*Rect, error)
+	// Pass `space` to get the bounding box relative to the main frame document instead, which includes its scroll offset,
+	// or relative to the viewport of the frame which owns the element.
+	BoundingBox(options ...ElementHandleBoundingBoxOptions) (*Rect, error)
 	// This method checks the element by performing the following steps:
 	// 1. Ensure that element is a checkbox or a radio input. If not, this method throws. If the element is already checked,
 	// this method returns immediately.
@@ -561,6 +850,9 @@ type Frame interface {
 	Click(selector string, options ...PageClickOptions) error
 	// Gets the full HTML contents of the frame, including the doctype.
 	Content() (string, error)
+	// Writes the full HTML contents of the frame, including the doctype, to `w` in chunks. Unlike Frame.content() this
+	// does not transfer the whole document in a single protocol message.
+	ContentTo(w io.Writer) error
 	// This method double clicks an element matching `selector` by performing the following steps:
 	// 1. Find an element matching `selector`. If there is none, wait until a matching element is attached to the DOM.
 	// 1. Wait for [actionability](./actionability.md) checks on the matched element, unless `force` option is set. If the
@@ -596,7 +888,13 @@ type Frame interface {
 	// not serializable by `JSON`: `-0`, `NaN`, `Infinity`, `-Infinity`.
 	// A string can also be passed in instead of a function.
 	// `ElementHandle` instances can be passed as an argument to the Frame.evaluate():
+	// An EvaluateOptions can be passed after the argument to abort the evaluation in the frame when it exceeds its
+	// timeout.
 	Evaluate(expression string, options ...interface{}) (interface{}, error)
+	// Bundles the ES module in the `Path` file or in `Content` together with its imports, transpiles TypeScript and
+	// evaluates it in the frame. Returns the value of the `Export` export, functions get called with `Arg` and their
+	// result is awaited. Requires the [esbuild](https://esbuild.github.io) executable, the driver does not bundle modules.
+	EvaluateModule(options FrameEvaluateModuleOptions) (interface{}, error)
 	// Returns the return value of `expression` as a `JSHandle`.
 	// The only difference between Frame.evaluate`] and [`method: Frame.evaluateHandle() is that
 	// Frame.evaluateHandle() returns `JSHandle`.
@@ -620,6 +918,19 @@ type Frame interface {
 	// return its value.
 	// Examples:
 	EvalOnSelectorAll(selector string, expression string, options ...interface{}) (interface{}, error)
+	// Extracts the elements matching `selector` into `dest`, which must be a pointer to a slice of structs, in a single
+	// round trip. `fields` maps the names of the struct fields to a CSS selector relative to the matched element and an
+	// optional attribute; the trimmed text content is used without an attribute. Values get converted to the type of the
+	// struct field, pointer fields stay nil if the child element or attribute is missing.
+	ExtractAll(selector string, dest interface{}, fields FieldMap) error
+	// Returns the visible text of the frame's document, or of the element matching the `Selector` option, in a single round
+	// trip. Whitespace is normalized, block elements start new lines and hidden elements are skipped. With
+	// TextFormatMarkdown headings, lists, links, emphasis, code, quotes and tables are rendered as Markdown.
+	ExtractText(options ...FrameExtractTextOptions) (string, error)
+	// Waits for the frame to navigate while `cb` is executed and returns the main resource response, which is `nil` for
+	// navigations within the same document. This is useful for iframes which navigate on their own, e.g. payment
+	// challenges.
+	ExpectNavigation(cb func() error, options ...PageWaitForNavigationOptions) (Response, error)
 	// This method waits for an element matching `selector`, waits for [actionability](./actionability.md) checks, focuses the
 	// element, fills it and triggers an `input` event after filling. Note that you can pass an empty string to clear the input
 	// field.
@@ -629,6 +940,12 @@ type Frame interface {
 	// instead.
 	// To send fine-grained keyboard events, use Frame.type().
 	Fill(selector string, value string, options ...FrameFillOptions) error
+	// Fills a date, time, datetime-local, month or week input with `value`, in the format of the input type. The value
+	// gets converted into the timezone of the page, e.g. the one emulated via the `TimezoneId` option of the context, so
+	// the input shows the same instant regardless of the timezone of `value`. Seconds and milliseconds are only included
+	// for time and datetime-local inputs when they are set. Inputs which the browser does not support and treats as text
+	// inputs, e.g. month inputs in WebKit, get filled with the same value.
+	FillTime(selector string, value time.Time, options ...FrameFillTimeOptions) error
 	// This method fetches an element with `selector` and focuses it. If there's no element matching `selector`, the method
 	// waits until a matching element appears in the DOM.
 	Focus(selector string, options ...FrameFocusOptions) error
@@ -639,6 +956,27 @@ type Frame interface {
 	FrameElement() (ElementHandle, error)
 	// Returns element attribute value.
 	GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (string, error)
+	// Allows locating elements by their alt text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByAltText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by the text of the associated `<label>` or `aria-labelledby` element, or by the
+	// `aria-label` attribute. `text` is either a string or a *regexp.Regexp.
+	GetByLabel(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by their placeholder text, `text` is either a string or a *regexp.Regexp.
+	GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their [ARIA role](https://www.w3.org/TR/wai-aria-1.2/#roles), explicit or implicit,
+	// [ARIA attributes](https://www.w3.org/TR/wai-aria-1.2/#aria-attributes) and
+	// [accessible name](https://w3c.github.io/accname/#dfn-accessible-name). Elements which are hidden from the
+	// accessibility tree only match with the `IncludeHidden` option.
+	GetByRole(role string, options ...GetByRoleOptions) Locator
+	// Allows locating elements by their test id, `testId` is either a string or a *regexp.Regexp. Strings match the whole
+	// value of the attribute, which is `data-testid` unless changed with SetTestIdAttribute().
+	GetByTestId(testId interface{}) Locator
+	// Allows locating elements that contain the given text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their title attribute, `text` is either a string or a *regexp.Regexp.
+	GetByTitle(text interface{}, options ...GetByTextOptions) Locator
 	// Returns the main resource response. In case of multiple redirects, the navigation will resolve with the response of the
 	// last redirect.
 	// `frame.goto` will throw an error if:
@@ -669,6 +1007,9 @@ type Frame interface {
 	InnerHTML(selector string, options ...PageInnerHTMLOptions) (string, error)
 	// Returns `element.innerText`.
 	InnerText(selector string, options ...PageInnerTextOptions) (string, error)
+	// Returns the normalized plain text of the frame, see Frame.extractText(), followed by the texts of its child frames,
+	// separated by blank lines.
+	InnerTextAll() (string, error)
 	// Returns `true` if the frame has been detached, or `false` otherwise.
 	IsDetached() bool
 	// Returns whether the element is checked. Throws if the element is not a checkbox or radio input.
@@ -685,6 +1026,10 @@ type Frame interface {
 	// Returns whether the element is [visible](./actionability.md#visible). `selector` that does not match any elements is
 	// considered not visible.
 	IsVisible(selector string, options ...FrameIsVisibleOptions) (bool, error)
+	// The method returns an element locator that can be used to perform actions in the frame. Locator is resolved to the
+	// element immediately before performing an action, so a series of actions on the same locator can in fact be performed
+	// on different DOM elements.
+	Locator(selector string) Locator
 	// Returns frame's name attribute as specified in the tag.
 	// If the name is empty, returns the id attribute instead.
 	// > NOTE: This value is calculated once when the frame is created, and will not update if the attribute is changed later.
@@ -714,6 +1059,9 @@ type Frame interface {
 	// [Working with selectors](./selectors.md) for more details. If no elements match the selector, returns empty array.
 	QuerySelectorAll(selector string) ([]ElementHandle, error)
 	SetContent(content string, options ...PageSetContentOptions) error
+	// Streams the HTML markup read from `r` into the document in chunks, which avoids transferring multi-megabyte
+	// documents in a single protocol message. The `networkidle` state falls back to `load`.
+	SetContentFromReader(r io.Reader, options ...PageSetContentOptions) error
 	// This method waits for an element matching `selector`, waits for [actionability](./actionability.md) checks, waits until
 	// all specified options are present in the `<select>` element and selects these options.
 	// If the target element is not a `<select>` element, this method throws an error. However, if the element is inside the
@@ -722,6 +1070,10 @@ type Frame interface {
 	// Returns the array of option values that have been successfully selected.
 	// Triggers a `change` and `input` event once all the provided options have been selected.
 	SelectOption(selector string, values SelectOptionValues, options ...FrameSelectOptionOptions) ([]string, error)
+	// Checks or unchecks an element matching `selector`, depending on `checked`, like Frame.check() and
+	// Frame.uncheck(). Elements which are in the desired state already are left untouched. Throws when the element is no
+	// checkbox or radio input, or when it does not end up in the desired state.
+	SetChecked(selector string, checked bool, options ...FrameSetCheckedOptions) error
 	// This method expects `selector` to point to an
 	// [input element](https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input).
 	// Sets the value of the file input to these file paths or files. If some of the `filePaths` are relative paths, then they
@@ -765,6 +1117,7 @@ type Frame interface {
 	// Returns when the `expression` returns a truthy value, returns that value.
 	// The Frame.waitForFunction() can be used to observe viewport size change:
 	// To pass an argument to the predicate of `frame.waitForFunction` function:
+	// A predicate which blocks the frame past the timeout gets terminated in Chromium.
 	WaitForFunction(expression string, arg interface{}, options ...FrameWaitForFunctionOptions) (JSHandle, error)
 	// Waits for the required load state to be reached.
 	// This returns when the frame reaches a required load state, `load` by default. The navigation must have been committed
@@ -778,8 +1131,9 @@ type Frame interface {
 	// > NOTE: Usage of the [History API](https://developer.mozilla.org/en-US/docs/Web/API/History_API) to change the URL is
 	// considered a navigation.
 	WaitForNavigation(options ...PageWaitForNavigationOptions) (Response, error)
-	// Waits for the frame to navigate to the given URL.
-	WaitForURL(url string, options ...FrameWaitForURLOptions) error
+	// Waits for the frame to navigate to the given URL, resolves immediately if the frame is already at it. `url` can be a
+	// glob pattern string, a *regexp.Regexp or a `func(url string) bool`.
+	WaitForURL(url interface{}, options ...FrameWaitForURLOptions) error
 	// Returns when element specified by selector satisfies `state` option. Returns `null` if waiting for `hidden` or
 	// `detached`.
 	// Wait for the `selector` to satisfy `state` option (either appear/disappear from dom, or become visible/hidden). If at
@@ -882,6 +1236,328 @@ type Keyboard interface {
 	Up(key string) error
 }
 
+// Locator represents a view to the element(s) on the page. It captures the logic sufficient to retrieve the element at
+// any given moment. Locator can be created with the Page.locator() method.
+// The difference between the Locator and ElementHandle is that the latter points to a particular element, while Locator
+// captures the logic of how to retrieve that element. Locators are strict, they throw if the selector resolves to more
+// than one element.
+// State getters like Locator.inputValue() wait for the element the same way as actions do and retry when the element gets
+// detached before it could be read.
+type Locator interface {
+	// Returns an array of `node.innerText` values for all matching nodes.
+	AllInnerTexts() ([]string, error)
+	// Returns an array of `node.textContent` values for all matching nodes.
+	AllTextContents() ([]string, error)
+	// Returns the ARIA snapshot of the element, a YAML representation of its accessibility tree which can be compared with
+	// LocatorAssertions.toMatchAriaSnapshot():
+	//   - heading "Title" [level=1]
+	//   - list:
+	//     - listitem: One
+	// Containers without a role or name are left out and their children take their place.
+	AriaSnapshot(options ...LocatorAriaSnapshotOptions) (string, error)
+	// This method returns the bounding box of the element, or `null` if the element is not visible. The bounding box is
+	// calculated relative to the main frame viewport - which is usually the same as the browser window. Pass `space` to get
+	// it relative to the main frame document or to the viewport of the frame which owns the element.
+	BoundingBox(options ...LocatorBoundingBoxOptions) (*Rect, error)
+	// This method checks the element by performing the following steps:
+	// 1. Ensure that element is a checkbox or a radio input. If not, this method throws.
+	// 1. If the element is already checked, this method returns immediately.
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.mouse`] to click in the center of the element.
+	// 1. Wait for initiated navigations to either succeed or fail, unless `noWaitAfter` option is set.
+	// 1. Ensure that the element is now checked. If not, this method throws.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	Check(options ...LocatorCheckOptions) error
+	// This method clicks the element by performing the following steps:
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.mouse`] to click in the center of the element, or the specified `position`.
+	// 1. Wait for initiated navigations to either succeed or fail, unless `noWaitAfter` option is set.
+	// If the element is detached from the DOM at any moment during the action, this method throws.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	Click(options ...LocatorClickOptions) error
+	// Returns the number of elements matching given selector.
+	Count() (int, error)
+	// This method double clicks the element by performing the following steps:
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.mouse`] to double click in the center of the element, or the specified `position`.
+	// 1. Wait for initiated navigations to either succeed or fail, unless `noWaitAfter` option is set. Note that if the
+	// first click of the `dblclick()` triggers a navigation event, this method will throw.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	// > NOTE: `element.dblclick()` dispatches two `click` events and a single `dblclick` event.
+	Dblclick(options ...LocatorDblclickOptions) error
+	// The snippet below dispatches the `click` event on the element. Regardless of the visibility state of the element,
+	// `click` is dispatched. This is equivalent to calling
+	// [element.click()](https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/click).
+	// Under the hood, it creates an instance of an event based on the given `type`, initializes it with `eventInit`
+	// properties and dispatches it on the element. Events are `composed`, `cancelable` and bubble by default.
+	DispatchEvent(typ string, eventInit interface{}, options ...LocatorDispatchEventOptions) error
+	// Resolves given locator to the first matching DOM element. If no elements matching the query are visible, waits for
+	// them up to a given timeout. If multiple elements match the selector, throws.
+	ElementHandle(options ...LocatorElementHandleOptions) (ElementHandle, error)
+	// Resolves given locator to all matching DOM elements.
+	ElementHandles() ([]ElementHandle, error)
+	// Returns the return value of `expression`, which gets the matching element as its first argument and `arg` as its
+	// second one. If `expression` returns a [Promise], this method waits for the promise to resolve and returns its value.
+	Evaluate(expression string, arg interface{}, options ...LocatorEvaluateOptions) (interface{}, error)
+	// The method finds all elements matching the specified locator and passes an array of matched elements as a first
+	// argument to `expression`. Returns the result of `expression` invocation.
+	EvaluateAll(expression string, options ...interface{}) (interface{}, error)
+	// Returns the return value of `expression` as a JSHandle, `expression` gets the matching element as its first argument
+	// and `arg` as its second one.
+	EvaluateHandle(expression string, arg interface{}, options ...LocatorEvaluateHandleOptions) (JSHandle, error)
+	// Extracts the elements matching the locator into `dest`, which must be a pointer to a slice of structs, in a single
+	// round trip. `fields` maps the names of the struct fields to a CSS selector relative to the matched element and an
+	// optional attribute, like Page.ExtractAll() does.
+	ExtractAll(dest interface{}, fields FieldMap) error
+	// This method waits for [actionability](./actionability.md) checks, focuses the element, fills it and triggers an `input`
+	// event after filling. Note that you can pass an empty string to clear the input field.
+	// If the target element is not an `<input>`, `<textarea>` or `[contenteditable]` element, this method throws an error.
+	Fill(value string, options ...LocatorFillOptions) error
+	// Fills a date, time, datetime-local, month or week input with `value`, in the format of the input type. The value
+	// gets converted into the timezone of the page, e.g. the one emulated via the `TimezoneId` option of the context, so
+	// the input shows the same instant regardless of the timezone of `value`. Seconds and milliseconds are only included
+	// for time and datetime-local inputs when they are set. Inputs which the browser does not support and treats as text
+	// inputs, e.g. month inputs in WebKit, get filled with the same value.
+	FillTime(value time.Time, options ...LocatorFillTimeOptions) error
+	// Returns locator to the first matching element.
+	First() Locator
+	// Calls [focus](https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/focus) on the element.
+	Focus(options ...LocatorFocusOptions) error
+	// Returns element attribute value.
+	GetAttribute(name string, options ...LocatorGetAttributeOptions) (string, error)
+	// Allows locating elements by their alt text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByAltText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by the text of the associated `<label>` or `aria-labelledby` element, or by the
+	// `aria-label` attribute. `text` is either a string or a *regexp.Regexp.
+	GetByLabel(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by their placeholder text, `text` is either a string or a *regexp.Regexp.
+	GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their [ARIA role](https://www.w3.org/TR/wai-aria-1.2/#roles), explicit or implicit,
+	// [ARIA attributes](https://www.w3.org/TR/wai-aria-1.2/#aria-attributes) and
+	// [accessible name](https://w3c.github.io/accname/#dfn-accessible-name) inside of the locator's subtree. Elements which are hidden from the
+	// accessibility tree only match with the `IncludeHidden` option.
+	GetByRole(role string, options ...GetByRoleOptions) Locator
+	// Allows locating elements by their test id, `testId` is either a string or a *regexp.Regexp. Strings match the whole
+	// value of the attribute, which is `data-testid` unless changed with SetTestIdAttribute().
+	GetByTestId(testId interface{}) Locator
+	// Allows locating elements that contain the given text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their title attribute, `text` is either a string or a *regexp.Regexp.
+	GetByTitle(text interface{}, options ...GetByTextOptions) Locator
+	// This method hovers over the element by performing the following steps:
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.mouse`] to hover over the center of the element, or the specified `position`.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	Hover(options ...LocatorHoverOptions) error
+	// Returns the `element.innerHTML`.
+	InnerHTML(options ...LocatorInnerHTMLOptions) (string, error)
+	// Returns the `element.innerText`.
+	InnerText(options ...LocatorInnerTextOptions) (string, error)
+	// Returns `input.value` for `<input>` or `<textarea>` or `<select>` element. Throws for non-input elements.
+	InputValue(options ...LocatorInputValueOptions) (string, error)
+	// Returns whether the element is checked. Throws if the element is not a checkbox or radio input.
+	IsChecked(options ...LocatorIsCheckedOptions) (bool, error)
+	// Returns whether the element is disabled, the opposite of [enabled](./actionability.md#enabled).
+	IsDisabled(options ...LocatorIsDisabledOptions) (bool, error)
+	// Returns whether the element is [editable](./actionability.md#editable).
+	IsEditable(options ...LocatorIsEditableOptions) (bool, error)
+	// Returns whether the element is [enabled](./actionability.md#enabled).
+	IsEnabled(options ...LocatorIsEnabledOptions) (bool, error)
+	// Returns whether the element is hidden, the opposite of [visible](./actionability.md#visible). A locator which does not
+	// match any elements is considered hidden.
+	IsHidden(options ...LocatorIsHiddenOptions) (bool, error)
+	// Returns whether the element is [visible](./actionability.md#visible). A locator which does not match any elements is
+	// considered not visible.
+	IsVisible(options ...LocatorIsVisibleOptions) (bool, error)
+	// Returns locator to the last matching element.
+	Last() Locator
+	// The method finds an element matching the specified selector in the locator's subtree.
+	Locator(selector string) Locator
+	// Returns locator to the n-th matching element. It's zero based, `nth(0)` selects the first element and `nth(-1)` the
+	// last one.
+	Nth(index int) Locator
+	// Returns the page the locator belongs to.
+	Page() Page
+	// Focuses the element, and then uses Keyboard.down() and Keyboard.up().
+	// `key` can specify the intended [keyboardEvent.key](https://developer.mozilla.org/en-US/docs/Web/API/KeyboardEvent/key)
+	// value or a single character to generate the text for. Shortcuts such as `key: "Control+o"` or `key: "Control+Shift+T"`
+	// are supported as well.
+	Press(key string, options ...LocatorPressOptions) error
+	// Focuses the element, and then sends a `keydown`, `keypress`/`input`, and `keyup` event for each character in the text.
+	// Pass `delay` to wait between key presses, which is useful for inputs with key-by-key masking or autocomplete logic.
+	// This is the replacement for ElementHandle.type(), to fill the value at once use ElementHandle.fill() instead.
+	PressSequentially(text string, options ...LocatorPressSequentiallyOptions) error
+	// Returns the buffer with the captured screenshot. This method waits for the [actionability](./actionability.md) checks,
+	// then scrolls element into view before taking a screenshot.
+	Screenshot(options ...LocatorScreenshotOptions) ([]byte, error)
+	// This method waits for [actionability](./actionability.md) checks, then tries to scroll element into view, unless it is
+	// completely visible as defined by
+	// [IntersectionObserver](https://developer.mozilla.org/en-US/docs/Web/API/Intersection_Observer_API)'s `ratio`.
+	ScrollIntoViewIfNeeded(options ...LocatorScrollIntoViewIfNeededOptions) error
+	// Returns the array of option values that have been successfully selected.
+	// Triggers a `change` and `input` event once all the provided options have been selected. If element is not a `<select>`
+	// element, the method throws an error.
+	SelectOption(values SelectOptionValues, options ...LocatorSelectOptionOptions) ([]string, error)
+	// This method waits for [actionability](./actionability.md) checks, then focuses the element and selects all its text
+	// content.
+	SelectText(options ...LocatorSelectTextOptions) error
+	// Checks or unchecks the element, depending on `checked`, like Locator.check() and Locator.uncheck(). An element
+	// which is in the desired state already is left untouched. Throws when the element is no checkbox or radio input, or
+	// when it does not end up in the desired state.
+	SetChecked(checked bool, options ...LocatorSetCheckedOptions) error
+	// This method expects the element to point to an
+	// [input element](https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input).
+	// Sets the value of the file input to these file paths or files. If some of the `filePaths` are relative paths, then they
+	// are resolved relative to the the current working directory. For empty array, clears the selected files.
+	SetInputFiles(files []InputFile, options ...LocatorSetInputFilesOptions) error
+	// This method taps the element by performing the following steps:
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.touchscreen`] to tap the center of the element, or the specified `position`.
+	// 1. Wait for initiated navigations to either succeed or fail, unless `noWaitAfter` option is set.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	// > NOTE: `element.tap()` requires that the `hasTouch` option of the browser context be set to true.
+	Tap(options ...LocatorTapOptions) error
+	// Returns the `node.textContent`.
+	TextContent(options ...LocatorTextContentOptions) (string, error)
+	// Focuses the element, and then sends a `keydown`, `keypress`/`input`, and `keyup` event for each character in the text.
+	// To press a special key, like `Control` or `ArrowDown`, use Locator.press().
+	Type(text string, options ...LocatorTypeOptions) error
+	// This method unchecks the element by performing the following steps:
+	// 1. Ensure that element is a checkbox or a radio input. If not, this method throws. If the element is already
+	// unchecked, this method returns immediately.
+	// 1. Wait for [actionability](./actionability.md) checks on the element, unless `force` option is set.
+	// 1. Scroll the element into view if needed.
+	// 1. Use [`property: Page.mouse`] to click in the center of the element.
+	// 1. Wait for initiated navigations to either succeed or fail, unless `noWaitAfter` option is set.
+	// 1. Ensure that the element is now unchecked. If not, this method throws.
+	// When all steps combined have not finished during the specified `timeout`, this method throws a `TimeoutError`. Passing
+	// zero timeout disables this.
+	Uncheck(options ...LocatorUncheckOptions) error
+	// Returns when element specified by locator satisfies the `state` option, which defaults to `visible`. Waiting for the
+	// `hidden` or `detached` state also succeeds when the locator does not match any elements.
+	WaitFor(options ...LocatorWaitForOptions) error
+}
+
+// LocatorAssertions provides assertions which retry until the expected condition is met or the timeout is exceeded.
+// Assertions return an error describing the mismatch instead of failing the test themselves.
+type LocatorAssertions interface {
+	// Makes the assertion check for the opposite condition.
+	Not() LocatorAssertions
+	// Ensures the locator points to at least one element which is attached to the DOM.
+	ToBeAttached(options ...LocatorAssertionsToBeAttachedOptions) error
+	// Ensures the element is a checked checkbox or radio button. Pass `Checked: playwright.Bool(false)` to ensure it is
+	// unchecked.
+	ToBeChecked(options ...LocatorAssertionsToBeCheckedOptions) error
+	// Ensures the element is disabled, see Locator.isDisabled().
+	ToBeDisabled(options ...LocatorAssertionsToBeDisabledOptions) error
+	// Ensures the element is editable, see Locator.isEditable().
+	ToBeEditable(options ...LocatorAssertionsToBeEditableOptions) error
+	// Ensures the `<input>` or `<textarea>` element has no value, or the element has no text content.
+	ToBeEmpty(options ...LocatorAssertionsToBeEmptyOptions) error
+	// Ensures the element is enabled, see Locator.isEnabled().
+	ToBeEnabled(options ...LocatorAssertionsToBeEnabledOptions) error
+	// Ensures the element is the active element of its document or shadow root.
+	ToBeFocused(options ...LocatorAssertionsToBeFocusedOptions) error
+	// Ensures the locator points to a hidden element or to no element at all.
+	ToBeHidden(options ...LocatorAssertionsToBeHiddenOptions) error
+	// Ensures the element intersects the viewport, as reported by the
+	// [Intersection Observer API](https://developer.mozilla.org/en-US/docs/Web/API/Intersection_Observer_API). Pass `ratio`
+	// to require a minimal part of the element to be visible, e.g. `0.5` for at least half of it.
+	ToBeInViewport(options ...LocatorAssertionsToBeInViewportOptions) error
+	// Ensures the locator points to a visible element.
+	ToBeVisible(options ...LocatorAssertionsToBeVisibleOptions) error
+	// Ensures the text of the element contains `expected`, which can be a string or a *regexp.Regexp. Pass a []interface{}
+	// to match the texts of all elements the locator points to, in order. Whitespace of strings gets normalized.
+	ToContainText(expected interface{}, options ...LocatorAssertionsToContainTextOptions) error
+	// Ensures the element has the given [accessible description](https://w3c.github.io/accname/#dfn-accessible-description).
+	// `description` can be a string or a *regexp.Regexp.
+	ToHaveAccessibleDescription(description interface{}, options ...LocatorAssertionsToHaveAccessibleDescriptionOptions) error
+	// Ensures the element has the given [accessible name](https://w3c.github.io/accname/#dfn-accessible-name). `name` can be
+	// a string or a *regexp.Regexp.
+	ToHaveAccessibleName(name interface{}, options ...LocatorAssertionsToHaveAccessibleNameOptions) error
+	// Ensures the element has the attribute `name` with the given value, which can be a string or a *regexp.Regexp.
+	ToHaveAttribute(name string, value interface{}, options ...LocatorAssertionsToHaveAttributeOptions) error
+	// Ensures the element has the given computed CSS property, e.g. `display` with `flex`. `value` can be a string or a
+	// *regexp.Regexp.
+	ToHaveCSS(name string, value interface{}, options ...LocatorAssertionsToHaveCSSOptions) error
+	// Ensures the `class` attribute of the element equals `expected`, which can be a string or a *regexp.Regexp. Use a
+	// *regexp.Regexp to match a single class out of several ones.
+	ToHaveClass(expected interface{}, options ...LocatorAssertionsToHaveClassOptions) error
+	// Ensures the locator resolves to exactly `count` elements.
+	ToHaveCount(count int, options ...LocatorAssertionsToHaveCountOptions) error
+	// Ensures the element has the given id, which can be a string or a *regexp.Regexp.
+	ToHaveId(id interface{}, options ...LocatorAssertionsToHaveIdOptions) error
+	// Ensures the element has the given [ARIA role](https://www.w3.org/TR/wai-aria-1.2/#roles), either an explicit one or
+	// the implicit role of the element, e.g. `button` for a `<button>`.
+	ToHaveRole(role string, options ...LocatorAssertionsToHaveRoleOptions) error
+	// Ensures the text of the element equals `expected`, which can be a string or a *regexp.Regexp. Pass a []interface{} to
+	// match the texts of all elements the locator points to, in order. Whitespace of strings gets normalized.
+	ToHaveText(expected interface{}, options ...LocatorAssertionsToHaveTextOptions) error
+	// Ensures the `<input>`, `<textarea>` or `<select>` element has the given value. `value` can be a string or a
+	// *regexp.Regexp.
+	ToHaveValue(value interface{}, options ...LocatorAssertionsToHaveValueOptions) error
+	// Ensures a `<select multiple>` element has exactly the given options selected, in order. Each value can be a string or
+	// a *regexp.Regexp.
+	ToHaveValues(values []interface{}, options ...LocatorAssertionsToHaveValuesOptions) error
+	// Ensures the ARIA snapshot of the element, see Locator.ariaSnapshot(), matches the `expected` template. The template
+	// is a YAML list of the same format, nodes which are left out of it are ignored, so it only needs to contain the
+	// relevant part of the tree. Names and texts can be regular expressions like `/Item \d+/`.
+	ToMatchAriaSnapshot(expected string, options ...LocatorAssertionsToMatchAriaSnapshotOptions) error
+}
+
+// PageAssertions provides assertions for the state of a page which retry until the expected condition is met or the
+// timeout is exceeded, e.g. after navigations which happen asynchronously.
+type PageAssertions interface {
+	// Makes the assertion check for the opposite condition.
+	Not() PageAssertions
+	// Ensures the page has the given title. `title` can be a string or a *regexp.Regexp.
+	ToHaveTitle(title interface{}, options ...PageAssertionsToHaveTitleOptions) error
+	// Ensures the page is navigated to the given URL. `urlOrPredicate` can be a string, a *regexp.Regexp or a
+	// `func(url string) bool`. A relative string gets resolved against the `baseURL` of the browser context.
+	ToHaveURL(urlOrPredicate interface{}, options ...PageAssertionsToHaveURLOptions) error
+}
+
+// APIResponseAssertions provides assertions for HTTP responses, e.g. of APIRequestContext.Get() or Page.goto(). Responses
+// do not change anymore, so these assertions check them once instead of retrying.
+type APIResponseAssertions interface {
+	// Makes the assertion check for the opposite condition.
+	Not() APIResponseAssertions
+	// Ensures the response status code is within `200..299` range.
+	ToBeOK() error
+	// Ensures the response body equals `expected`, which can be a string or a *regexp.Regexp.
+	ToHaveBody(expected interface{}) error
+	// Ensures the response has the header `name`, matched case-insensitively, with the given value, which can be a string
+	// or a *regexp.Regexp.
+	ToHaveHeader(name string, value interface{}) error
+	// Ensures the response body is JSON which equals `expected` after both got decoded, `expected` can be anything that
+	// encodes to JSON, e.g. a map or a struct with JSON tags.
+	ToHaveJSON(expected interface{}) error
+	// Ensures the response has the given status code.
+	ToHaveStatus(status int) error
+}
+
+// PlaywrightAssertions creates assertions for locators, pages and responses, it gets created with NewPlaywrightAssertions().
+type PlaywrightAssertions interface {
+	// Creates assertions for the given response.
+	APIResponse(response APIResponse) APIResponseAssertions
+	// Creates assertions for the given locator.
+	Locator(locator Locator) LocatorAssertions
+	// Creates assertions for the given page.
+	Page(page Page) PageAssertions
+}
+
 // The Mouse class operates in main-frame CSS pixels relative to the top-left corner of the viewport.
 // Every `page` object has its own Mouse, accessible with [`property: Page.mouse`].
 type Mouse interface {
@@ -909,9 +1585,50 @@ type Mouse interface {
 // To unsubscribe from events use the `removeListener` method:
 type Page interface {
 	EventEmitter
+	Accessibility() Accessibility
 	Mouse() Mouse
 	Keyboard() Keyboard
 	Touchscreen() Touchscreen
+	// Returns the fake clock of the page which can be used to control timers and animations.
+	Clock() Clock
+	// Returns the clipboard of the page which can be used to read and write text.
+	Clipboard() Clipboard
+	// Returns whether the page accepts downloads. Defaults to the `acceptDownloads` option of the browser context.
+	AcceptDownloads() bool
+	// Overrides whether the page accepts downloads. Downloads of a page which does not accept them get canceled and are
+	// emitted via the `downloadblocked` event instead of the `download` event. Downloads can only be enabled for a page if
+	// the browser context got created with `acceptDownloads`. The `downloadblocked` event gets a *DownloadBlockedEvent
+	// with the reason as its second argument. It is also emitted for the downloads which the browser context blocks, which
+	// keep getting emitted via the `download` event as failed downloads.
+	SetAcceptDownloads(accept bool) error
+	// Makes popups opened by the page inherit its routes, init scripts and exposed bindings, so that popups do not escape
+	// network mocks. Popups inherit this setting themselves. The inheritance gets applied asynchronously once the browser
+	// reported the popup and before the `popup` event is emitted, the routes of the page keep their remaining `Times`.
+	// The initial document of the popup, e.g. the one of `window.open(url)`, is not guaranteed to be covered, as its
+	// requests can happen before; only the routes of the browser context are guaranteed to apply to it. Navigations of the
+	// popup after the `popup` event are covered.
+	SetInheritToPopups(inherit bool)
+	// Takes a snapshot of the URL, the localStorage and sessionStorage of the main frame and all cookies of the browser
+	// context, which can be restored later with Page.restoreSnapshot().
+	Snapshot() (*PageSnapshot, error)
+	// Restores a snapshot taken with Page.snapshot(), also in a page of another browser context. The cookies get added to
+	// the browser context of the page and the storage gets written before the scripts of the snapshot URL run, afterwards
+	// the page navigates to the snapshot URL.
+	RestoreSnapshot(snapshot *PageSnapshot) error
+	// Serializes the DOM of the main frame, or the subtree of the element matching the `Selector` option, into a normalized
+	// tree without scripts, styles, comments and whitespace-only text, optionally with computed styles. Compare two
+	// snapshots with DiffDOMSnapshots() to assert structural changes without visual diffing.
+	DOMSnapshot(options ...PageDOMSnapshotOptions) (*DOMSnapshot, error)
+	// Returns the bounds and the state of the browser window which contains the page. Only supported in Chromium.
+	WindowBounds() (*WindowBounds, error)
+	// Changes the bounds or the state of the browser window which contains the page, e.g. to maximize it or to enter
+	// fullscreen. The state can not be combined with the bounds unless it is `normal`. Only supported in Chromium.
+	SetWindowBounds(bounds WindowBounds) error
+	// Overrides the user agent of the page. It is sent with the requests of the page and returned by
+	// `navigator.userAgent`. In Chromium the override is applied via CDP, which also supports the client hints `metadata`.
+	// In the other browsers the `User-Agent` header and `navigator.userAgent` get overridden and the `metadata` is
+	// ignored.
+	SetUserAgent(userAgent string, metadata ...UserAgentMetadata) error
 	// Adds a script which would be evaluated in one of the following scenarios:
 	// - Whenever the page is navigated.
 	// - Whenever the child frame is attached or navigated. In this case, the script is evaluated in the context of the newly
@@ -919,6 +1636,8 @@ type Page interface {
 	// The script is evaluated after the document was created but before any of its scripts were run. This is useful to amend
 	// the JavaScript environment, e.g. to seed `Math.random`.
 	// An example of overriding `Math.random` before the page loads:
+	// Pass `origins` or `mainFrameOnly` to only evaluate the script in documents of these origins or in main frames, e.g. so
+	// a patched `window.fetch` does not leak into third-party iframes.
 	// > NOTE: The order of evaluation of multiple scripts installed via BrowserContext.addInitScript() and
 	// Page.addInitScript() is not defined.
 	AddInitScript(script PageAddInitScriptOptions) error
@@ -957,6 +1676,14 @@ type Page interface {
 	// zero timeout disables this.
 	// Shortcut for main frame's Frame.click().
 	Click(selector string, options ...PageClickOptions) error
+	// Clears the HTTP cache of the browser, so the next loads of the page are cold ones.
+	// > NOTE: Clearing the browser cache is only supported in Chromium.
+	ClearBrowserCache() error
+	// Clears the storage of `origin`, e.g. `https://example.com`. `storageTypes` are the ones of the
+	// [Storage.clearDataForOrigin](https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearDataForOrigin)
+	// CDP command, e.g. `cookies`, `local_storage`, `indexeddb` or `cache_storage`, and default to all of them.
+	// > NOTE: Clearing the storage is only supported in Chromium.
+	ClearStorageForOrigin(origin string, storageTypes ...string) error
 	// If `runBeforeUnload` is `false`, does not run any unload handlers and waits for the page to be closed. If
 	// `runBeforeUnload` is `true` the method will run unload handlers, but will **not** wait for the page to close.
 	// By default, `page.close()` **does not** run `beforeunload` handlers.
@@ -965,6 +1692,9 @@ type Page interface {
 	Close(options ...PageCloseOptions) error
 	// Gets the full HTML contents of the page, including the doctype.
 	Content() (string, error)
+	// Writes the full HTML contents of the page, including the doctype, to `w` in chunks. Shortcut for main frame's
+	// Frame.contentTo().
+	ContentTo(w io.Writer) error
 	// Get the browser context that the page belongs to.
 	Context() BrowserContext
 	// This method double clicks an element matching `selector` by performing the following steps:
@@ -995,6 +1725,9 @@ type Page interface {
 	// - [Event](https://developer.mozilla.org/en-US/docs/Web/API/Event/Event)
 	// You can also specify `JSHandle` as the property value if you want live objects to be passed into the event:
 	DispatchEvent(selector string, typ string, options ...PageDispatchEventOptions) error
+	// Returns the content of the IndexedDB databases of the main frame or of the first frame with the given `origin`,
+	// including all records of their object stores. Databases which do not exist are skipped.
+	DumpIndexedDB(options ...PageDumpIndexedDBOptions) ([]IndexedDBDatabase, error)
 	// The method adds a function called `name` on the `window` object of every frame in this page. When called, the function
 	// executes `callback` and returns a [Promise] which resolves to the return value of `callback`. If the `callback` returns
 	// a [Promise], it will be awaited.
@@ -1015,6 +1748,10 @@ type Page interface {
 	// This method changes the `CSS media type` through the `media` argument, and/or the `'prefers-colors-scheme'` media
 	// feature, using the `colorScheme` argument.
 	EmulateMedia(options ...PageEmulateMediaOptions) error
+	// Emulates arbitrary CSS media features, e.g. `prefers-reduced-data` or `forced-colors`, which have no dedicated option
+	// in Page.emulateMedia() yet. Each call replaces the features of the previous one, pass an empty slice to stop the
+	// emulation. Only supported in Chromium.
+	EmulateMediaFeatures(features []MediaFeature) error
 	// Returns the value of the `expression` invocation.
 	// If the function passed to the Page.evaluate`] returns a [Promise], then [`method: Page.evaluate() would wait
 	// for the promise to resolve and return its value.
@@ -1024,8 +1761,17 @@ type Page interface {
 	// Passing argument to `expression`:
 	// A string can also be passed in instead of a function:
 	// `ElementHandle` instances can be passed as an argument to the Page.evaluate():
+	// An EvaluateOptions can be passed after the argument to abort the evaluation in the page when it exceeds its
+	// timeout.
 	// Shortcut for main frame's Frame.evaluate().
 	Evaluate(expression string, options ...interface{}) (interface{}, error)
+	// Bundles the ES module in the `Path` file or in `Content` together with its imports, transpiles TypeScript and
+	// evaluates it in the page. Returns the value of the `Export` export, functions get called with `Arg` and their
+	// result is awaited. Requires the [esbuild](https://esbuild.github.io) executable, the driver does not bundle modules.
+	EvaluateModule(options PageEvaluateModuleOptions) (interface{}, error)
+	// Evaluates `expression` in all frames of the page whose document has the given `origin`, e.g. `https://example.com`,
+	// and returns the results in the order of Page.frames(). Frames of other origins are left untouched.
+	EvaluateInOrigin(origin string, expression string, options ...interface{}) ([]interface{}, error)
 	// Returns the value of the `expression` invocation as a `JSHandle`.
 	// The only difference between Page.evaluate`] and [`method: Page.evaluateHandle() is that
 	// Page.evaluateHandle() returns `JSHandle`.
@@ -1047,16 +1793,44 @@ type Page interface {
 	// return its value.
 	// Examples:
 	EvalOnSelectorAll(selector string, expression string, options ...interface{}) (interface{}, error)
+	// Extracts the elements matching `selector` into `dest`, which must be a pointer to a slice of structs, in a single
+	// round trip. Shortcut for main frame's Frame.extractAll().
+	ExtractAll(selector string, dest interface{}, fields FieldMap) error
+	// Returns the visible text of the page, see Frame.extractText(). Shortcut for main frame's Frame.extractText().
+	ExtractText(options ...PageExtractTextOptions) (string, error)
 	ExpectConsoleMessage(cb func() error) (ConsoleMessage, error)
-	ExpectDownload(cb func() error) (Download, error)
+	// Waits for a dialog to be opened by the page while `cb` is executed and returns it. If multiple dialogs get opened, the
+	// first one which matches the `Predicate` option is returned.
+	ExpectDialog(cb func() error, options ...PageExpectDialogOptions) (Dialog, error)
+	// Waits for a download to be started by the page while `cb` is executed and returns it. If multiple downloads get
+	// started, the first one which matches the `Predicate` option is returned.
+	ExpectDownload(cb func() error, options ...PageExpectDownloadOptions) (Download, error)
+	// Waits for a download matching the `SuggestedFilename` option while `cb` is executed, waits for it to finish and checks its
+	// MIME type, size and content against the options. Returns an error describing the first mismatch, the returned
+	// DownloadMatch holds the actual values including the SHA-256 hash of the content for fixture comparison.
+	ExpectDownloadMatching(cb func() error, options ...PageExpectDownloadMatchingOptions) (*DownloadMatch, error)
 	ExpectEvent(event string, cb func() error, predicates ...interface{}) (interface{}, error)
-	ExpectFileChooser(cb func() error) (FileChooser, error)
+	// Waits for a file chooser to be opened by the page while `cb` is executed and returns it. If multiple file choosers get
+	// opened, the first one which matches the `Predicate` option is returned.
+	ExpectFileChooser(cb func() error, options ...PageExpectFileChooserOptions) (FileChooser, error)
 	ExpectLoadState(state string, cb func() error) error
 	ExpectNavigation(cb func() error, options ...PageWaitForNavigationOptions) (Response, error)
-	ExpectPopup(cb func() error) (Page, error)
+	// Waits for a popup to be opened by the page while `cb` is executed and returns it. If multiple popups get opened, the
+	// first one which matches the `URL` and `Predicate` options is returned.
+	ExpectPopup(cb func() error, options ...PageExpectPopupOptions) (Page, error)
 	ExpectRequest(url interface{}, cb func() error, options ...interface{}) (Request, error)
+	// Waits for a request matching `url` to receive its response while `cb` is executed and returns both. `url` can be a
+	// glob pattern string, a *regexp.Regexp or a `func(url string) bool`. The listener gets registered before `cb` runs, so
+	// fast responses are not missed.
+	ExpectRequestAndResponse(url interface{}, cb func() error, options ...PageExpectRequestAndResponseOptions) (Request, Response, error)
+	// Waits for a request to finish loading its response body while `cb` is executed and returns it. If multiple requests
+	// finish, the first one which matches the `Predicate` option is returned.
+	ExpectRequestFinished(cb func() error, options ...PageExpectRequestFinishedOptions) (Request, error)
 	ExpectResponse(url interface{}, cb func() error, options ...interface{}) (Response, error)
-	ExpectWorker(cb func() error) (Worker, error)
+	// Waits for a worker to be spawned by the page while `cb` is executed and returns it. If multiple workers get spawned,
+	// the first one which matches the `Predicate` option is returned.
+	ExpectWorker(cb func() error, options ...PageExpectWorkerOptions) (Worker, error)
+	// Deprecated: Use Page.ExpectDialog() instead.
 	ExpectedDialog(cb func() error) (Dialog, error)
 	// This method waits for an element matching `selector`, waits for [actionability](./actionability.md) checks, focuses the
 	// element, fills it and triggers an `input` event after filling. Note that you can pass an empty string to clear the input
@@ -1068,6 +1842,13 @@ type Page interface {
 	// To send fine-grained keyboard events, use Page.type().
 	// Shortcut for main frame's Frame.fill().
 	Fill(selector, text string, options ...FrameFillOptions) error
+	// Fills a date, time, datetime-local, month or week input with `value`, in the format of the input type. The value
+	// gets converted into the timezone of the page, e.g. the one emulated via the `TimezoneId` option of the context, so
+	// the input shows the same instant regardless of the timezone of `value`. Seconds and milliseconds are only included
+	// for time and datetime-local inputs when they are set. Inputs which the browser does not support and treats as text
+	// inputs, e.g. month inputs in WebKit, get filled with the same value.
+	// Shortcut for main frame's Frame.FillTime().
+	FillTime(selector string, value time.Time, options ...FrameFillTimeOptions) error
 	// This method fetches an element with `selector` and focuses it. If there's no element matching `selector`, the method
 	// waits until a matching element appears in the DOM.
 	// Shortcut for main frame's Frame.focus().
@@ -1076,8 +1857,42 @@ type Page interface {
 	Frame(options PageFrameOptions) Frame
 	// An array of all frames attached to the page.
 	Frames() []Frame
+	// Returns a subscription which delivers the console messages of the page on a channel, as an alternative to the
+	// `console` event which fits into `select` loops. The channel gets closed by `Close()` or when the page closes.
+	ConsoleMessages(options ...PageSubscriptionOptions) *ConsoleMessageSubscription
+	// Returns a subscription which delivers the uncaught exceptions of the page on a channel, see ConsoleMessages().
+	PageErrors(options ...PageSubscriptionOptions) *PageErrorSubscription
+	// Returns a subscription which delivers the requests of the page on a channel, see ConsoleMessages().
+	Requests(options ...PageSubscriptionOptions) *RequestSubscription
+	// Returns a subscription which delivers the responses of the page on a channel, see ConsoleMessages().
+	Responses(options ...PageSubscriptionOptions) *ResponseSubscription
+	// Waits for a frame which matches `urlOrPredicate` to attach and to reach the `waitUntil` load state, and returns it.
+	// Frames which are already attached count as well. `urlOrPredicate` is either a URL glob pattern, a `*regexp.Regexp` or a
+	// `func(string) bool` which receive the URL of the frame, or a `func(Frame) bool` which receives the frame itself.
+	WaitForFrame(urlOrPredicate interface{}, options ...PageWaitForFrameOptions) (Frame, error)
 	// Returns element attribute value.
 	GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (string, error)
+	// Allows locating elements by their alt text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByAltText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by the text of the associated `<label>` or `aria-labelledby` element, or by the
+	// `aria-label` attribute. `text` is either a string or a *regexp.Regexp.
+	GetByLabel(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating input elements by their placeholder text, `text` is either a string or a *regexp.Regexp.
+	GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their [ARIA role](https://www.w3.org/TR/wai-aria-1.2/#roles), explicit or implicit,
+	// [ARIA attributes](https://www.w3.org/TR/wai-aria-1.2/#aria-attributes) and
+	// [accessible name](https://w3c.github.io/accname/#dfn-accessible-name). Elements which are hidden from the
+	// accessibility tree only match with the `IncludeHidden` option.
+	GetByRole(role string, options ...GetByRoleOptions) Locator
+	// Allows locating elements by their test id, `testId` is either a string or a *regexp.Regexp. Strings match the whole
+	// value of the attribute, which is `data-testid` unless changed with SetTestIdAttribute().
+	GetByTestId(testId interface{}) Locator
+	// Allows locating elements that contain the given text, `text` is either a string or a *regexp.Regexp. Strings match
+	// case-insensitive substrings unless the `Exact` option is set, whitespace gets normalized.
+	GetByText(text interface{}, options ...GetByTextOptions) Locator
+	// Allows locating elements by their title attribute, `text` is either a string or a *regexp.Regexp.
+	GetByTitle(text interface{}, options ...GetByTextOptions) Locator
 	// Returns the main resource response. In case of multiple redirects, the navigation will resolve with the response of the
 	// last redirect. If can not go back, returns `null`.
 	// Navigate to the previous page in history.
@@ -1118,6 +1933,9 @@ type Page interface {
 	InnerHTML(selector string, options ...PageInnerHTMLOptions) (string, error)
 	// Returns `element.innerText`.
 	InnerText(selector string, options ...PageInnerTextOptions) (string, error)
+	// Returns the normalized plain text of the page including the texts of all its frames. Shortcut for main frame's
+	// Frame.innerTextAll().
+	InnerTextAll() (string, error)
 	// Indicates that the page has been closed.
 	IsClosed() bool
 	// Returns whether the element is checked. Throws if the element is not a checkbox or radio input.
@@ -1134,8 +1952,23 @@ type Page interface {
 	// Returns whether the element is [visible](./actionability.md#visible). `selector` that does not match any elements is
 	// considered not visible.
 	IsVisible(selector string, options ...FrameIsVisibleOptions) (bool, error)
+	// The method returns an element locator that can be used to perform actions on the page. Locator is resolved to the
+	// element immediately before performing an action, so a series of actions on the same locator can in fact be performed
+	// on different DOM elements. Shortcut for main frame's Frame.locator().
+	// Returns the localStorage of the main frame or, when `origin` is given, of the first frame with that origin, e.g.
+	// `https://example.com`. The frame gets looked up on each call.
+	LocalStorage(origin ...string) WebStorage
+	Locator(selector string) Locator
 	// The page's main frame. Page is guaranteed to have a main frame which persists during navigations.
 	MainFrame() Frame
+	// Returns the metadata of the context of the page merged with the metadata of the page itself.
+	Metadata() map[string]string
+	// Returns the counters of the requests and responses of the page since it got created, e.g. to enforce data budgets
+	// or to detect runaway asset loading. The returned value is a copy.
+	NetworkStats() NetworkStats
+	// Creates a new CDP session attached to the page, a shortcut for BrowserContext.NewCDPSession(). Returns an
+	// error matching ErrNotSupported in browsers other than Chromium.
+	NewCDPSession() (CDPSession, error)
 	// Returns the opener for popup pages and `null` for others. If the opener has been closed already the returns `null`.
 	Opener() (Page, error)
 	// Returns the PDF buffer.
@@ -1194,6 +2027,9 @@ type Page interface {
 	// Returns the main resource response. In case of multiple redirects, the navigation will resolve with the response of the
 	// last redirect.
 	Reload(options ...PageReloadOptions) (Response, error)
+	// API testing helper associated with this page. It is the one of the page's browser context, see
+	// BrowserContext.Request().
+	Request() APIRequestContext
 	// Routing provides the capability to modify network requests that are made by a page.
 	// Once routing is enabled, every request matching the url pattern will stall unless it's continued, fulfilled or aborted.
 	// > NOTE: The handler will only be called for the first url if the response is a redirect.
@@ -1205,7 +2041,17 @@ type Page interface {
 	// matches both handlers.
 	// To remove a route with its handler you can use Page.unroute().
 	// > NOTE: Enabling routing disables http cache.
-	Route(url interface{}, handler routeHandler) error
+	// With the `Times` option the route gets removed after it handled the given number of requests.
+	Route(url interface{}, handler func(Route, Request), options ...PageRouteOptions) error
+	// Routes the WebSockets of the page whose URL matches `url` to `handler`, a routed WebSocket does not connect to the
+	// server unless the handler calls WebSocketRoute.ConnectToServer(). `url` is a glob pattern, regex pattern or
+	// predicate receiving the URL. The routing applies to documents which get loaded after the call.
+	RouteWebSocket(url interface{}, handler func(WebSocketRoute)) error
+	// If specified the network requests that are made in the page will be served from the HAR file, which can be
+	// recorded with the `RecordHarPath` option of Browser.NewContext() or with the `Update` option. Requests which are not
+	// in the HAR get aborted, or sent to the network with HarNotFoundFallback. HAR files ending with `.zip` are archives
+	// with the HAR and its attachments.
+	RouteFromHAR(har string, options ...PageRouteFromHAROptions) error
 	// Returns the buffer with the captured screenshot.
 	Screenshot(options ...PageScreenshotOptions) ([]byte, error)
 	// This method waits for an element matching `selector`, waits for [actionability](./actionability.md) checks, waits until
@@ -1217,7 +2063,15 @@ type Page interface {
 	// Triggers a `change` and `input` event once all the provided options have been selected.
 	// Shortcut for main frame's Frame.selectOption().
 	SelectOption(selector string, values SelectOptionValues, options ...FrameSelectOptionOptions) ([]string, error)
+	// Returns the sessionStorage of the main frame or, when `origin` is given, of the first frame with that origin.
+	SessionStorage(origin ...string) WebStorage
 	SetContent(content string, options ...PageSetContentOptions) error
+	// Streams the HTML markup read from `r` into the document in chunks, which avoids transferring multi-megabyte
+	// documents in a single protocol message. The `networkidle` state falls back to `load`.
+	SetContentFromReader(r io.Reader, options ...PageSetContentOptions) error
+	// Sets the metadata `key` of the page, it takes precedence over the metadata of the context. The `{key}` placeholders of
+	// the video path get replaced with the metadata values when the video gets saved.
+	SetMetadata(key, value string)
 	// This setting will change the default maximum navigation time for the following methods and related shortcuts:
 	// - Page.goBack()
 	// - Page.goForward()
@@ -1233,8 +2087,15 @@ type Page interface {
 	// > NOTE: Page.setDefaultNavigationTimeout`] takes priority over [`method: Page.setDefaultTimeout().
 	SetDefaultTimeout(timeout float64)
 	// The extra HTTP headers will be sent with every request the page initiates.
+	// Pass `Merge` to add the headers to the current ones instead of replacing them, and `URL` to only send them with
+	// requests to matching URLs, so e.g. authorization headers do not leak to third-party origins.
 	// > NOTE: Page.setExtraHTTPHeaders() does not guarantee the order of headers in the outgoing requests.
-	SetExtraHTTPHeaders(headers map[string]string) error
+	SetExtraHTTPHeaders(headers map[string]string, options ...PageSetExtraHTTPHeadersOptions) error
+	// Checks or unchecks an element matching `selector`, depending on `checked`, like Page.check() and
+	// Page.uncheck(). Elements which are in the desired state already are left untouched. Throws when the element is no
+	// checkbox or radio input, or when it does not end up in the desired state.
+	// Shortcut for main frame's Frame.setChecked().
+	SetChecked(selector string, checked bool, options ...FrameSetCheckedOptions) error
 	// This method expects `selector` to point to an
 	// [input element](https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input).
 	// Sets the value of the file input to these file paths or files. If some of the `filePaths` are relative paths, then they
@@ -1244,7 +2105,12 @@ type Page interface {
 	// Browser.newContext() allows to set viewport size (and more) for all pages in the context at once.
 	// `page.setViewportSize` will resize the page. A lot of websites don't expect phones to change size, so you should set the
 	// viewport size before navigating to the page.
-	SetViewportSize(width, height int) error
+	// In Chromium, the device scale factor and the screen orientation can be changed as well, which emits the `resize` and
+	// `orientationchange` events in the page.
+	SetViewportSize(width, height int, options ...PageSetViewportSizeOptions) error
+	// Waits until the page is ready for a visual comparison: all fonts are loaded, all images are loaded and decoded and all
+	// finite animations and transitions finished. The same waits run before a screenshot with the `Stabilize` option.
+	Stabilize(options ...StabilizeOptions) error
 	// This method taps an element matching `selector` by performing the following steps:
 	// 1. Find an element matching `selector`. If there is none, wait until a matching element is attached to the DOM.
 	// 1. Wait for [actionability](./actionability.md) checks on the matched element, unless `force` option is set. If the
@@ -1283,16 +2149,23 @@ type Page interface {
 	// Shortcut for main frame's Frame.uncheck().
 	Uncheck(selector string, options ...FrameUncheckOptions) error
 	// Removes a route created with Page.route(). When `handler` is not specified, removes all routes for the `url`.
-	Unroute(url interface{}, handler ...routeHandler) error
+	Unroute(url interface{}, handler ...func(Route, Request)) error
+	// Removes all routes created with Page.route() and Page.RouteFromHAR(). The `Behavior` option decides whether to
+	// wait for the handlers which are still running.
+	UnrouteAll(options ...PageUnrouteAllOptions) error
 	// Video object associated with this page.
 	Video() Video
 	ViewportSize() ViewportSize
+	// Waits until the web fonts of the document are loaded and the images in the viewport are loaded and decoded, e.g.
+	// before a screenshot or layout assertions. Unlike Page.stabilize() it does not wait for animations.
+	WaitForAssetsLoaded(options ...PageWaitForAssetsLoadedOptions) error
 	// Waits for event to fire and passes its value into the predicate function. Returns when the predicate returns truthy
 	// value. Will throw an error if the page is closed before the event is fired. Returns the event data value.
 	WaitForEvent(event string, predicate ...interface{}) interface{}
 	// Returns when the `expression` returns a truthy value. It resolves to a JSHandle of the truthy value.
 	// The Page.waitForFunction() can be used to observe viewport size change:
 	// To pass an argument to the predicate of Page.waitForFunction() function:
+	// A predicate which blocks the page past the timeout gets terminated in Chromium.
 	// Shortcut for main frame's Frame.waitForFunction().
 	WaitForFunction(expression string, arg interface{}, options ...FrameWaitForFunctionOptions) (JSHandle, error)
 	// Returns when the required load state has been reached.
@@ -1343,11 +2216,37 @@ type Page interface {
 	InputValue(selector string, options ...FrameInputValueOptions) (string, error)
 	// Waits for the main frame to navigate to the given URL.
 	// Shortcut for main frame's Frame.waitForURL().
-	WaitForURL(url string, options ...FrameWaitForURLOptions) error
+	WaitForURL(url interface{}, options ...FrameWaitForURLOptions) error
 }
 
+// BackgroundPage represents the [background page](https://developer.chrome.com/extensions/background_pages) of a
+// Chromium extension. Background pages are emitted via the `backgroundpage` event of the `BrowserContext`.
 type BackgroundPage interface {
-	EventEmitter
+	Page
+}
+
+// Extension represents a Chromium extension which is loaded into a persistent browser context. It gives access to the
+// execution context of the extension, which is either a background page (Manifest V2) or a service worker (Manifest V3).
+type Extension interface {
+	// Returns the extension ID.
+	ID() string
+	// Returns the absolute `chrome-extension://` URL of a resource of the extension.
+	URL(path string) string
+	// Returns the background page of the extension or `nil` if it uses a service worker.
+	BackgroundPage() BackgroundPage
+	// Returns the service worker of the extension or `nil` if it uses a background page.
+	ServiceWorker() Worker
+	// Evaluates `expression` in the background page or service worker of the extension.
+	Evaluate(expression string, options ...interface{}) (interface{}, error)
+	// Returns the manifest of the extension as returned by `chrome.runtime.getManifest()`.
+	Manifest() (map[string]interface{}, error)
+	// Opens the popup page of the extension which is configured in its manifest in a new page.
+	OpenPopup() (Page, error)
+	// Opens the options page of the extension which is configured in its manifest in a new page.
+	OpenOptions() (Page, error)
+	// Sends `message` to the extension via `chrome.runtime.sendMessage` and returns the response. The message gets sent
+	// from an already opened extension page or from its popup or options page otherwise.
+	SendMessage(message interface{}) (interface{}, error)
 }
 
 // Whenever the page sends a request for a network resource the following sequence of events are emitted by `Page`:
@@ -1390,6 +2289,10 @@ type Request interface {
 	// New request issued by the browser if the server responded with redirect.
 	// This method is the opposite of Request.redirectedFrom():
 	RedirectedTo() Request
+	// Issues the request again, optionally modified, through the network stack of the browser context and returns its
+	// response. The replay shares the cookies of the context but does not go through the routes of the pages, which
+	// makes it useful for probing flaky endpoints observed during a run.
+	Replay(options ...RequestReplayOptions) (APIResponse, error)
 	// Contains the request's resource type as it was perceived by the rendering engine. ResourceType will be one of the
 	// following: `document`, `stylesheet`, `image`, `media`, `font`, `script`, `texttrack`, `xhr`, `fetch`, `eventsource`,
 	// `websocket`, `manifest`, `other`.
@@ -1410,6 +2313,11 @@ type Response interface {
 	Body() ([]byte, error)
 	// Waits for this response to finish, returns failure error if request failed.
 	Finished() error
+	// Returns whether the response was served from the HTTP cache of the browser, either because it got revalidated with a
+	// `304` status or because its body was not transferred according to the resource timing of its frame. Returns `false`
+	// when the browser does not expose the transfer size, e.g. for cross-origin responses without a
+	// `Timing-Allow-Origin` header.
+	FromCache() (bool, error)
 	// Returns the `Frame` that initiated this response.
 	Frame() Frame
 	// Returns the object with HTTP headers associated with the response. All header names are lower-case.
@@ -1438,14 +2346,47 @@ type Route interface {
 	Abort(errorCode ...string) error
 	// Continues route's request with optional overrides.
 	Continue(options ...RouteContinueOptions) error
+	// Fetches the response of the route's request without following redirects and fulfills the route with it. If the
+	// response is a redirect, `handler` gets called with the hop and can change its `Location` before the redirect is
+	// returned to the browser. The browser requests the redirect target itself, which passes through the routes again, so
+	// each hop of a chain can be observed by routing all of its URLs. The response is fetched with the cookies, the proxy
+	// and the HTTPS settings of the browser context.
+	ContinueWithRedirects(handler func(hop *RedirectHop)) error
+	// Performs the route's request, with optional overrides, through the request context of the browser context and returns
+	// the response without fulfilling the route. The response can be patched and passed to Fulfill() via the `Response`
+	// option, redirects get followed.
+	Fetch(options ...RouteFetchOptions) (APIResponse, error)
 	// Fulfills route's request with given response.
 	// An example of fulfilling all requests with 404 responses:
 	// An example of serving static file:
+	// The response can also be based on an APIResponse, e.g. one returned by Route.Fetch(), via the `Response` option, and
+	// Go values can be sent as JSON via the `JSON` option.
 	Fulfill(options RouteFulfillOptions) error
+	// Fetches the original response of the route's request and fulfills the route with it after piping its body through
+	// `transforms` in order. The response is fetched with the cookies, the proxy and the HTTPS settings of the browser
+	// context. Status and headers of the original response are kept, except for `content-length` and `content-encoding`.
+	// The driver can only fulfill a route with a complete body, so the whole transformed body is held in memory before it
+	// is sent to the browser, only the original body is streamed through the transforms.
+	FulfillWithTransform(transforms ...ResponseTransform) error
+	// Sends the route's request through `transport` instead of the browser's network stack and fulfills the route with the
+	// response, e.g. to send the requests of a single page through an *http.Transport with its own `Proxy`. Redirects are
+	// returned to the browser, which requests their targets itself. Use ForwardTo() to create a route handler.
+	Forward(transport http.RoundTripper) error
 	// A request to be routed.
 	Request() Request
 }
 
+// Selectors can be used to install custom selector engines.
+type Selectors interface {
+	// Registers a selector engine with the given `name`, which can then be used in selectors like `name=body`. `script`
+	// has to evaluate to an object with `query(root, selector)` and `queryAll(root, selector)` functions. Engines only
+	// apply to pages which get created afterwards.
+	Register(name string, script string, options ...SelectorsRegisterOptions) error
+	// Defines the attribute which GetByTestId() locators match, defaults to `data-testid`. Same as the package-level
+	// SetTestIdAttribute().
+	SetTestIdAttribute(name string)
+}
+
 // The Touchscreen class operates in main-frame CSS pixels relative to the top-left corner of the viewport. Methods on the
 // touchscreen can only be used in browser contexts that have been initialized with `hasTouch` set to true.
 type Touchscreen interface {
@@ -1454,6 +2395,8 @@ type Touchscreen interface {
 }
 
 // The `WebSocket` class represents websocket connections in the page.
+// The `framesent` and `framereceived` events pass the payload as []byte and a *WebSocketFrame, which tells text and
+// binary frames apart. The `socketerror` event passes the error message as string.
 type WebSocket interface {
 	EventEmitter
 	// Indicates that the web socket has been closed.
@@ -1465,6 +2408,43 @@ type WebSocket interface {
 	WaitForEvent(event string, predicate ...interface{}) interface{}
 }
 
+// WebSocketRoute is a WebSocket routed with Page.RouteWebSocket() or BrowserContext.RouteWebSocket(). The page side
+// gets passed to the handler, it opens in the page once the handler returned. Calling ConnectToServer() returns the
+// server side, messages and closes get forwarded between both sides unless a handler is set with OnMessage() or
+// OnClose() on the receiving side.
+type WebSocketRoute interface {
+	// Closes the WebSocket of the page, or the connection to the server when called on the server side.
+	Close(options ...WebSocketRouteCloseOptions) error
+	// Connects to the actual server and returns the server side of the route. The WebSocket of the page opens once the
+	// server connection is open.
+	ConnectToServer() (WebSocketRoute, error)
+	// Sets the handler for closes: closes of the WebSocket of the page on the page side, closes of the server on the
+	// server side.
+	OnClose(handler func(code int, reason string))
+	// Sets the handler for messages: messages sent by the page on the page side, messages sent by the server on the
+	// server side. Messages are a string or []byte.
+	OnMessage(handler func(message interface{}))
+	// Sends a message, a string or []byte, to the page, or to the server when called on the server side.
+	Send(message interface{}) error
+	// URL of the WebSocket.
+	URL() string
+}
+
+// WebStorage provides access to the localStorage or sessionStorage of a frame, see Page.LocalStorage() and
+// Page.SessionStorage().
+type WebStorage interface {
+	// Removes all items.
+	Clear() error
+	// Returns the value of the item with `key` and whether it exists.
+	GetItem(key string) (string, bool, error)
+	// Returns all items.
+	Items() (map[string]string, error)
+	// Removes the item with `key`.
+	RemoveItem(key string) error
+	// Sets the item with `key` to `value`.
+	SetItem(key, value string) error
+}
+
 // When browser context is created with the `recordVideo` option, each page has a video object associated with it.
 type Video interface {
 	// Returns the file system path this video will be recorded to. The video is guaranteed to be written to the filesystem
@@ -1473,7 +2453,8 @@ type Video interface {
 	// Deletes the video file. Will wait for the video to finish if necessary.
 	Delete() error
 	// Saves the video to a user-specified path. It is safe to call this method while the video is still in progress, or after
-	// the page has closed. This method waits until the page is closed and the video is fully saved.
+	// the page has closed. This method waits until the page is closed and the video is fully saved. The `{key}`
+	// placeholders of `path` get replaced with the values of Page.Metadata().
 	SaveAs(path string) error
 }
 
@@ -0,0 +1,191 @@
+package playwright
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// GeolocationWaypoint is a position on a GeolocationJourney.
+type GeolocationWaypoint struct {
+	Latitude  float64
+	Longitude float64
+	Accuracy  *int
+	// Time it takes to travel from the previous waypoint to this one. It is
+	// ignored for the first waypoint.
+	Duration time.Duration
+}
+
+// GeolocationJourney moves the geolocation of a browser context along a list
+// of waypoints. Positions between two waypoints are linearly interpolated. The
+// journey can either be played in real time via Play() or be driven by a
+// mocked clock via Advance().
+type GeolocationJourney struct {
+	sync.Mutex
+	context   BrowserContext
+	waypoints []GeolocationWaypoint
+	elapsed   time.Duration
+	stop      chan bool
+	done      chan bool
+	err       error
+}
+
+// NewGeolocationJourney creates a journey through the given waypoints for the
+// browser context. The context needs the `geolocation` permission so that the
+// pages can read the position.
+func NewGeolocationJourney(context BrowserContext, waypoints ...GeolocationWaypoint) *GeolocationJourney {
+	return &GeolocationJourney{
+		context:   context,
+		waypoints: waypoints,
+	}
+}
+
+// Duration returns the total time it takes to travel along all waypoints.
+func (j *GeolocationJourney) Duration() time.Duration {
+	var duration time.Duration
+	for i := 1; i < len(j.waypoints); i++ {
+		duration += j.waypoints[i].Duration
+	}
+	return duration
+}
+
+// Elapsed returns the time which has passed since the start of the journey.
+func (j *GeolocationJourney) Elapsed() time.Duration {
+	j.Lock()
+	defer j.Unlock()
+	return j.elapsed
+}
+
+// PositionAt returns the interpolated position after the given time has
+// elapsed since the start of the journey. Waypoints without an accuracy keep
+// the accuracy of the previous one.
+func (j *GeolocationJourney) PositionAt(elapsed time.Duration) *SetGeolocationOptions {
+	if len(j.waypoints) == 0 {
+		return nil
+	}
+	from := j.waypoints[0]
+	for _, to := range j.waypoints[1:] {
+		if elapsed < to.Duration {
+			progress := float64(elapsed) / float64(to.Duration)
+			return &SetGeolocationOptions{
+				Latitude:  from.Latitude + (to.Latitude-from.Latitude)*progress,
+				Longitude: from.Longitude + (to.Longitude-from.Longitude)*progress,
+				Accuracy:  from.Accuracy,
+			}
+		}
+		elapsed -= to.Duration
+		if to.Accuracy == nil {
+			to.Accuracy = from.Accuracy
+		}
+		from = to
+	}
+	return &SetGeolocationOptions{
+		Latitude:  from.Latitude,
+		Longitude: from.Longitude,
+		Accuracy:  from.Accuracy,
+	}
+}
+
+// Start moves the geolocation of the browser context to the first waypoint.
+func (j *GeolocationJourney) Start() error {
+	j.Lock()
+	j.elapsed = 0
+	j.Unlock()
+	return j.update()
+}
+
+// Advance moves the journey forward by the given duration and updates the
+// geolocation of the browser context. Use it to drive the journey from a
+// mocked clock.
+func (j *GeolocationJourney) Advance(duration time.Duration) error {
+	j.Lock()
+	j.elapsed += duration
+	j.Unlock()
+	return j.update()
+}
+
+// Play travels along the waypoints in real time and updates the geolocation of
+// the browser context every interval until the last waypoint got reached or
+// Stop() got called.
+func (j *GeolocationJourney) Play(interval time.Duration) error {
+	j.Lock()
+	if j.stop != nil {
+		j.Unlock()
+		return errors.New("journey is already playing")
+	}
+	j.stop = make(chan bool)
+	j.done = make(chan bool)
+	j.err = nil
+	j.Unlock()
+	if err := j.Start(); err != nil {
+		// release Stop() and Wait() callers and allow to play again
+		j.finish()
+		return err
+	}
+	go func() {
+		defer j.finish()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		start := time.Now()
+		for {
+			select {
+			case <-j.stop:
+				return
+			case <-ticker.C:
+				j.Lock()
+				j.elapsed = time.Since(start)
+				j.Unlock()
+				if err := j.update(); err != nil {
+					j.Lock()
+					j.err = err
+					j.Unlock()
+					return
+				}
+				if j.Elapsed() >= j.Duration() {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops a journey which is being played.
+func (j *GeolocationJourney) Stop() {
+	j.Lock()
+	stop := j.stop
+	j.Unlock()
+	if stop != nil {
+		close(stop)
+		j.Wait()
+	}
+}
+
+// Wait blocks until a journey which is being played has finished and returns
+// the error which occurred while updating the geolocation, if any.
+func (j *GeolocationJourney) Wait() error {
+	j.Lock()
+	done := j.done
+	j.Unlock()
+	if done != nil {
+		<-done
+	}
+	j.Lock()
+	defer j.Unlock()
+	return j.err
+}
+
+func (j *GeolocationJourney) finish() {
+	j.Lock()
+	defer j.Unlock()
+	close(j.done)
+	j.stop = nil
+}
+
+func (j *GeolocationJourney) update() error {
+	position := j.PositionAt(j.Elapsed())
+	if position == nil {
+		return errors.New("journey does not have any waypoints")
+	}
+	return j.context.SetGeolocation(position)
+}
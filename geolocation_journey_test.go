@@ -0,0 +1,31 @@
+package playwright
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeolocationJourneyPositionAt(t *testing.T) {
+	journey := NewGeolocationJourney(nil,
+		GeolocationWaypoint{Latitude: 10, Longitude: 20, Accuracy: Int(5)},
+		GeolocationWaypoint{Latitude: 20, Longitude: 40, Duration: 10 * time.Second},
+		GeolocationWaypoint{Latitude: 20, Longitude: 30, Duration: 5 * time.Second, Accuracy: Int(1)},
+	)
+	require.Equal(t, 15*time.Second, journey.Duration())
+	require.Equal(t, &SetGeolocationOptions{Latitude: 10, Longitude: 20, Accuracy: Int(5)}, journey.PositionAt(0))
+	require.Equal(t, &SetGeolocationOptions{Latitude: 15, Longitude: 30, Accuracy: Int(5)}, journey.PositionAt(5*time.Second))
+	require.Equal(t, &SetGeolocationOptions{Latitude: 20, Longitude: 40, Accuracy: Int(5)}, journey.PositionAt(10*time.Second))
+	require.Equal(t, &SetGeolocationOptions{Latitude: 20, Longitude: 35, Accuracy: Int(5)}, journey.PositionAt(12500*time.Millisecond))
+	require.Equal(t, &SetGeolocationOptions{Latitude: 20, Longitude: 30, Accuracy: Int(1)}, journey.PositionAt(time.Minute))
+	require.Nil(t, NewGeolocationJourney(nil).PositionAt(0))
+}
+
+func TestGeolocationJourneyPlayStartError(t *testing.T) {
+	journey := NewGeolocationJourney(nil)
+	require.EqualError(t, journey.Play(time.Millisecond), "journey does not have any waypoints")
+	require.NoError(t, journey.Wait())
+	journey.Stop()
+	require.EqualError(t, journey.Play(time.Millisecond), "journey does not have any waypoints")
+}
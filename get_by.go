@@ -0,0 +1,515 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// GetByRoleOptions are the options of the GetByRole() locator factories.
+type GetByRoleOptions struct {
+	// Accessible name of the element, either a string or a *regexp.Regexp. Strings match case-insensitive substrings,
+	// unless `Exact` is set.
+	Name interface{}
+	// Whether `Name` has to match case-sensitive and whole-string. Ignored when `Name` is a regular expression.
+	Exact *bool
+	// Only match elements which are checked or not, set by `checked` or `aria-checked`.
+	Checked *bool
+	// Only match elements which are disabled or not, set by `disabled` or `aria-disabled`.
+	Disabled *bool
+	// Only match elements which are expanded or not, set by `aria-expanded`.
+	Expanded *bool
+	// Only match headings of this level, set by `<h1>`-`<h6>` or `aria-level`.
+	Level *int
+	// Only match elements which are pressed or not, set by `aria-pressed`.
+	Pressed *bool
+	// Only match elements which are selected or not, set by `selected` or `aria-selected`.
+	Selected *bool
+	// Whether to also match elements which are hidden from the accessibility tree. Defaults to `false`.
+	IncludeHidden *bool
+}
+
+// GetByTextOptions are the options of the GetByText(), GetByLabel(), GetByPlaceholder(), GetByAltText() and
+// GetByTitle() locator factories.
+type GetByTextOptions struct {
+	// Whether the text has to match case-sensitive and whole-string. Strings match case-insensitive substrings by default,
+	// ignored when a regular expression is given. Whitespace gets normalized in both cases.
+	Exact *bool
+}
+
+// The selector engines of the GetBy*() locators, they get registered when
+// Playwright starts.
+const (
+	getByRoleEngine      = "getby-role"
+	getByLabelEngine     = "getby-label"
+	getByAttributeEngine = "getby-attribute"
+)
+
+const getByEngineHelpers = `
+  const normalize = text => (text || '').replace(/\s+/g, ' ').trim();
+  const matchesText = (value, options) => {
+    value = normalize(value);
+    if (options.regex)
+      return new RegExp(options.regex[0], options.regex[1]).test(value);
+    if (options.exact)
+      return value === normalize(options.text);
+    return value.toLowerCase().includes(normalize(options.text).toLowerCase());
+  };
+  const allElements = root => {
+    const result = [];
+    const visit = node => {
+      for (const element of node.querySelectorAll('*')) {
+        result.push(element);
+        if (element.shadowRoot)
+          visit(element.shadowRoot);
+      }
+    };
+    visit(root);
+    return result;
+  };
+  const parentOf = element => element.parentElement || (element.getRootNode() && element.getRootNode().host);
+  const isHidden = element => {
+    for (let e = element; e; e = parentOf(e)) {
+      if (e.getAttribute('aria-hidden') === 'true')
+        return true;
+      const style = e.ownerDocument.defaultView.getComputedStyle(e);
+      if (style.display === 'none')
+        return true;
+      if (e === element && (style.visibility === 'hidden' || style.visibility === 'collapse'))
+        return true;
+    }
+    return false;
+  };
+  const textOf = element => {
+    let text = '';
+    for (const child of element.childNodes) {
+      if (child.nodeType === 3) {
+        text += child.textContent;
+      } else if (child.nodeType === 1 && !isHidden(child)) {
+        const tag = child.tagName.toLowerCase();
+        if (tag === 'img')
+          text += ' ' + (child.getAttribute('aria-label') || child.getAttribute('alt') || '') + ' ';
+        else
+          text += ' ' + (child.getAttribute('aria-label') || textOf(child)) + ' ';
+      }
+    }
+    return text;
+  };
+  const labelledByText = element => {
+    const ids = (element.getAttribute('aria-labelledby') || '').split(/\s+/).filter(Boolean);
+    return ids.map(id => element.getRootNode().getElementById ? element.getRootNode().getElementById(id) : element.ownerDocument.getElementById(id))
+        .filter(Boolean).map(label => textOf(label)).join(' ');
+  };
+  const labelsOf = element => {
+    const labels = [];
+    if (element.labels)
+      labels.push(...Array.from(element.labels).map(label => textOf(label)));
+    const labelledBy = labelledByText(element);
+    if (normalize(labelledBy))
+      labels.push(labelledBy);
+    if (element.hasAttribute('aria-label'))
+      labels.push(element.getAttribute('aria-label'));
+    return labels;
+  };
+`
+
+const getByRoleEngineScript = `(() => {` + getByEngineHelpers + `
+  const implicitRole = element => {
+    const tag = element.tagName.toLowerCase();
+    switch (tag) {
+      case 'a':
+      case 'area':
+        return element.hasAttribute('href') ? 'link' : null;
+      case 'article': return 'article';
+      case 'aside': return 'complementary';
+      case 'button': return 'button';
+      case 'datalist': return 'listbox';
+      case 'dd': return 'definition';
+      case 'details': return 'group';
+      case 'dialog': return 'dialog';
+      case 'dt': return 'term';
+      case 'fieldset': return 'group';
+      case 'footer': return element.closest('article, aside, main, nav, section') ? null : 'contentinfo';
+      case 'form': return 'form';
+      case 'h1': case 'h2': case 'h3': case 'h4': case 'h5': case 'h6': return 'heading';
+      case 'header': return element.closest('article, aside, main, nav, section') ? null : 'banner';
+      case 'hr': return 'separator';
+      case 'img': return element.getAttribute('alt') === '' ? 'presentation' : 'img';
+      case 'input': {
+        const type = (element.getAttribute('type') || 'text').toLowerCase();
+        switch (type) {
+          case 'button': case 'image': case 'reset': case 'submit': return 'button';
+          case 'checkbox': return 'checkbox';
+          case 'radio': return 'radio';
+          case 'range': return 'slider';
+          case 'number': return 'spinbutton';
+          case 'hidden': return null;
+          case 'search': return element.hasAttribute('list') ? 'combobox' : 'searchbox';
+          default: return element.hasAttribute('list') ? 'combobox' : 'textbox';
+        }
+      }
+      case 'li': return 'listitem';
+      case 'main': return 'main';
+      case 'menu': case 'ol': case 'ul': return 'list';
+      case 'meter': return 'meter';
+      case 'nav': return 'navigation';
+      case 'optgroup': return 'group';
+      case 'option': return 'option';
+      case 'output': return 'status';
+      case 'progress': return 'progressbar';
+      case 'section': return element.hasAttribute('aria-label') || element.hasAttribute('aria-labelledby') ? 'region' : null;
+      case 'select': return element.multiple || element.size > 1 ? 'listbox' : 'combobox';
+      case 'table': return 'table';
+      case 'tbody': case 'tfoot': case 'thead': return 'rowgroup';
+      case 'td': return 'cell';
+      case 'textarea': return 'textbox';
+      case 'th': return 'columnheader';
+      case 'tr': return 'row';
+    }
+    return null;
+  };
+  const roleOf = element => (element.getAttribute('role') || '').trim().split(/\s+/)[0] || implicitRole(element);
+  const nameFromContent = new Set(['button', 'cell', 'checkbox', 'columnheader', 'gridcell', 'heading', 'link', 'menuitem',
+    'menuitemcheckbox', 'menuitemradio', 'option', 'radio', 'row', 'rowheader', 'switch', 'tab', 'tooltip', 'treeitem']);
+  const accessibleName = (element, role) => {
+    const labelledBy = normalize(labelledByText(element));
+    if (labelledBy)
+      return labelledBy;
+    const label = normalize(element.getAttribute('aria-label'));
+    if (label)
+      return label;
+    const tag = element.tagName.toLowerCase();
+    if (tag === 'input') {
+      const type = element.type;
+      if (type === 'button' || type === 'submit' || type === 'reset')
+        return normalize(element.value || (type === 'submit' ? 'Submit' : type === 'reset' ? 'Reset' : ''));
+      if (type === 'image')
+        return normalize(element.getAttribute('alt') || element.value);
+    }
+    if (element.labels && element.labels.length)
+      return normalize(Array.from(element.labels).map(label => textOf(label)).join(' '));
+    if ((tag === 'img' || tag === 'area') && element.hasAttribute('alt'))
+      return normalize(element.getAttribute('alt'));
+    if (tag === 'fieldset' || tag === 'table') {
+      const caption = element.querySelector(tag === 'fieldset' ? ':scope > legend' : ':scope > caption');
+      if (caption)
+        return normalize(textOf(caption));
+    }
+    if (nameFromContent.has(role)) {
+      const text = normalize(textOf(element));
+      if (text)
+        return text;
+    }
+    return normalize(element.getAttribute('title') || element.getAttribute('placeholder'));
+  };
+  const ariaState = (element, name) => {
+    const value = element.getAttribute('aria-' + name);
+    return value === null ? null : value === 'true';
+  };
+  const checkedOf = element => {
+    if (element.tagName === 'INPUT' && (element.type === 'checkbox' || element.type === 'radio'))
+      return element.checked;
+    return !!ariaState(element, 'checked');
+  };
+  const disabledOf = element => {
+    if (['BUTTON', 'INPUT', 'SELECT', 'TEXTAREA', 'OPTION', 'OPTGROUP', 'FIELDSET'].includes(element.tagName) && element.matches(':disabled'))
+      return true;
+    return !!element.closest('[aria-disabled="true"]');
+  };
+  const levelOf = element => {
+    const level = parseInt(element.getAttribute('aria-level'), 10);
+    if (!isNaN(level))
+      return level;
+    const match = /^H([1-6])$/.exec(element.tagName);
+    return match ? parseInt(match[1], 10) : null;
+  };
+  const selectedOf = element => {
+    if (element.tagName === 'OPTION')
+      return element.selected;
+    return !!ariaState(element, 'selected');
+  };
+  const matches = (element, options) => {
+    const role = roleOf(element);
+    if (role !== options.role)
+      return false;
+    if (!options.includeHidden && isHidden(element))
+      return false;
+    if (options.checked !== undefined && checkedOf(element) !== options.checked)
+      return false;
+    if (options.disabled !== undefined && disabledOf(element) !== options.disabled)
+      return false;
+    if (options.expanded !== undefined && !!ariaState(element, 'expanded') !== options.expanded)
+      return false;
+    if (options.level !== undefined && levelOf(element) !== options.level)
+      return false;
+    if (options.pressed !== undefined && !!ariaState(element, 'pressed') !== options.pressed)
+      return false;
+    if (options.selected !== undefined && selectedOf(element) !== options.selected)
+      return false;
+    if (options.name !== undefined && !matchesText(accessibleName(element, role), options.name))
+      return false;
+    return true;
+  };
+  const queryAll = (root, body) => {
+    const options = JSON.parse(body);
+    return allElements(root).filter(element => matches(element, options));
+  };
+  return {
+    query: (root, body) => queryAll(root, body)[0] || null,
+    queryAll,
+  };
+})()`
+
+const getByLabelEngineScript = `(() => {` + getByEngineHelpers + `
+  const queryAll = (root, body) => {
+    const options = JSON.parse(body);
+    return allElements(root).filter(element => labelsOf(element).some(label => matchesText(label, options)));
+  };
+  return {
+    query: (root, body) => queryAll(root, body)[0] || null,
+    queryAll,
+  };
+})()`
+
+const getByAttributeEngineScript = `(() => {` + getByEngineHelpers + `
+  const queryAll = (root, body) => {
+    const options = JSON.parse(body);
+    return allElements(root).filter(element => element.hasAttribute(options.attribute) && matchesText(element.getAttribute(options.attribute), options));
+  };
+  return {
+    query: (root, body) => queryAll(root, body)[0] || null,
+    queryAll,
+  };
+})()`
+
+var (
+	testIdAttributeLock sync.RWMutex
+	testIdAttribute     = "data-testid"
+)
+
+// SetTestIdAttribute changes the attribute which GetByTestId() locators
+// match, defaults to `data-testid`. Locators which were already created keep
+// using the previous attribute.
+func SetTestIdAttribute(name string) {
+	testIdAttributeLock.Lock()
+	defer testIdAttributeLock.Unlock()
+	testIdAttribute = name
+}
+
+func getTestIdAttribute() string {
+	testIdAttributeLock.RLock()
+	defer testIdAttributeLock.RUnlock()
+	return testIdAttribute
+}
+
+// registerGetBySelectorEngines installs the selector engines of the GetBy*()
+// locators. Servers which are shared with other clients might have them
+// already.
+func registerGetBySelectorEngines(selectors Selectors) error {
+	engines := map[string]string{
+		getByRoleEngine:      getByRoleEngineScript,
+		getByLabelEngine:     getByLabelEngineScript,
+		getByAttributeEngine: getByAttributeEngineScript,
+	}
+	for name, script := range engines {
+		if err := selectors.Register(name, script); err != nil && !strings.Contains(err.Error(), "already registered") {
+			return fmt.Errorf("could not register selector engine %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// textMatcherOptions returns the options of the GetBy*() selector engines for
+// text, which is either a string or a *regexp.Regexp.
+func textMatcherOptions(text interface{}, exact *bool) map[string]interface{} {
+	options := make(map[string]interface{})
+	switch v := text.(type) {
+	case *regexp.Regexp:
+		source, flags := jsRegexp(v)
+		options["regex"] = []string{source, flags}
+	default:
+		options["text"] = fmt.Sprint(v)
+		options["exact"] = exact != nil && *exact
+	}
+	return options
+}
+
+// jsRegexp converts a Go regular expression into the source and the flags of
+// a JavaScript one, leading flag groups like `(?i)` become flags.
+func jsRegexp(re *regexp.Regexp) (string, string) {
+	source := re.String()
+	flags := ""
+	if match := regexp.MustCompile(`^\(\?([imsU]+)\)`).FindStringSubmatch(source); match != nil {
+		source = source[len(match[0]):]
+		for _, flag := range match[1] {
+			if flag == 'i' || flag == 'm' || flag == 's' {
+				flags += string(flag)
+			}
+		}
+	}
+	return source, flags
+}
+
+func engineSelector(engine string, body interface{}) string {
+	encoded, _ := json.Marshal(body)
+	return engine + "=" + string(encoded)
+}
+
+// cssString quotes text as a CSS string.
+func cssString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `).Replace(text) + `"`
+}
+
+func getByRoleSelector(role string, options ...GetByRoleOptions) string {
+	body := map[string]interface{}{
+		"role": role,
+	}
+	if len(options) == 1 {
+		option := options[0]
+		if option.Name != nil {
+			body["name"] = textMatcherOptions(option.Name, option.Exact)
+		}
+		if option.Checked != nil {
+			body["checked"] = *option.Checked
+		}
+		if option.Disabled != nil {
+			body["disabled"] = *option.Disabled
+		}
+		if option.Expanded != nil {
+			body["expanded"] = *option.Expanded
+		}
+		if option.Level != nil {
+			body["level"] = *option.Level
+		}
+		if option.Pressed != nil {
+			body["pressed"] = *option.Pressed
+		}
+		if option.Selected != nil {
+			body["selected"] = *option.Selected
+		}
+		if option.IncludeHidden != nil {
+			body["includeHidden"] = *option.IncludeHidden
+		}
+	}
+	return engineSelector(getByRoleEngine, body)
+}
+
+func getByTextSelector(text interface{}, options ...GetByTextOptions) string {
+	exact := len(options) == 1 && options[0].Exact != nil && *options[0].Exact
+	if re, ok := text.(*regexp.Regexp); ok {
+		source, flags := jsRegexp(re)
+		return fmt.Sprintf("css=:text-matches(%s, %s)", cssString(source), cssString(flags))
+	}
+	if exact {
+		return fmt.Sprintf("css=:text-is(%s)", cssString(fmt.Sprint(text)))
+	}
+	return fmt.Sprintf("css=:text(%s)", cssString(fmt.Sprint(text)))
+}
+
+func getByLabelSelector(text interface{}, options ...GetByTextOptions) string {
+	var exact *bool
+	if len(options) == 1 {
+		exact = options[0].Exact
+	}
+	return engineSelector(getByLabelEngine, textMatcherOptions(text, exact))
+}
+
+func getByAttributeSelector(attribute string, text interface{}, options ...GetByTextOptions) string {
+	var exact *bool
+	if len(options) == 1 {
+		exact = options[0].Exact
+	}
+	body := textMatcherOptions(text, exact)
+	body["attribute"] = attribute
+	return engineSelector(getByAttributeEngine, body)
+}
+
+func getByTestIdSelector(testId interface{}) string {
+	return getByAttributeSelector(getTestIdAttribute(), testId, GetByTextOptions{Exact: Bool(true)})
+}
+
+func (f *frameImpl) GetByRole(role string, options ...GetByRoleOptions) Locator {
+	return f.Locator(getByRoleSelector(role, options...))
+}
+
+func (f *frameImpl) GetByText(text interface{}, options ...GetByTextOptions) Locator {
+	return f.Locator(getByTextSelector(text, options...))
+}
+
+func (f *frameImpl) GetByLabel(text interface{}, options ...GetByTextOptions) Locator {
+	return f.Locator(getByLabelSelector(text, options...))
+}
+
+func (f *frameImpl) GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator {
+	return f.Locator(getByAttributeSelector("placeholder", text, options...))
+}
+
+func (f *frameImpl) GetByAltText(text interface{}, options ...GetByTextOptions) Locator {
+	return f.Locator(getByAttributeSelector("alt", text, options...))
+}
+
+func (f *frameImpl) GetByTitle(text interface{}, options ...GetByTextOptions) Locator {
+	return f.Locator(getByAttributeSelector("title", text, options...))
+}
+
+func (f *frameImpl) GetByTestId(testId interface{}) Locator {
+	return f.Locator(getByTestIdSelector(testId))
+}
+
+func (p *pageImpl) GetByRole(role string, options ...GetByRoleOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByRole(role, options...)
+}
+
+func (p *pageImpl) GetByText(text interface{}, options ...GetByTextOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByText(text, options...)
+}
+
+func (p *pageImpl) GetByLabel(text interface{}, options ...GetByTextOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByLabel(text, options...)
+}
+
+func (p *pageImpl) GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByPlaceholder(text, options...)
+}
+
+func (p *pageImpl) GetByAltText(text interface{}, options ...GetByTextOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByAltText(text, options...)
+}
+
+func (p *pageImpl) GetByTitle(text interface{}, options ...GetByTextOptions) Locator {
+	return p.mainFrame.(*frameImpl).GetByTitle(text, options...)
+}
+
+func (p *pageImpl) GetByTestId(testId interface{}) Locator {
+	return p.mainFrame.(*frameImpl).GetByTestId(testId)
+}
+
+func (l *locatorImpl) GetByRole(role string, options ...GetByRoleOptions) Locator {
+	return l.Locator(getByRoleSelector(role, options...))
+}
+
+func (l *locatorImpl) GetByText(text interface{}, options ...GetByTextOptions) Locator {
+	return l.Locator(getByTextSelector(text, options...))
+}
+
+func (l *locatorImpl) GetByLabel(text interface{}, options ...GetByTextOptions) Locator {
+	return l.Locator(getByLabelSelector(text, options...))
+}
+
+func (l *locatorImpl) GetByPlaceholder(text interface{}, options ...GetByTextOptions) Locator {
+	return l.Locator(getByAttributeSelector("placeholder", text, options...))
+}
+
+func (l *locatorImpl) GetByAltText(text interface{}, options ...GetByTextOptions) Locator {
+	return l.Locator(getByAttributeSelector("alt", text, options...))
+}
+
+func (l *locatorImpl) GetByTitle(text interface{}, options ...GetByTextOptions) Locator {
+	return l.Locator(getByAttributeSelector("title", text, options...))
+}
+
+func (l *locatorImpl) GetByTestId(testId interface{}) Locator {
+	return l.Locator(getByTestIdSelector(testId))
+}
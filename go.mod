@@ -1,6 +1,6 @@
 module github.com/neilspage/playwright-go
 
-go 1.14
+go 1.16
 
 require (
 	github.com/danwakefield/fnmatch v0.0.0-20160403171240-cbb64ac3d964
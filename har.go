@@ -0,0 +1,216 @@
+package playwright
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// harRecorder finishes the HAR file the driver writes when the context gets
+// closed. The driver only knows whether to omit the content, the URL filter,
+// the minimal mode and attached content are applied afterwards.
+type harRecorder struct {
+	// path the driver writes the HAR to
+	path string
+	// path requested by the user, a zip archive gets created for .zip paths
+	target    string
+	content   HarContentPolicy
+	mode      HarMode
+	urlFilter *urlMatcher
+}
+
+// newHarRecorder returns the recorder and the recordHar option of the driver.
+func newHarRecorder(path string, omitContent *bool, content *HarContentPolicy, mode *HarMode, urlFilter interface{}) (*harRecorder, map[string]interface{}, error) {
+	recorder := &harRecorder{
+		path:    path,
+		target:  path,
+		content: *HarContentPolicyEmbed,
+		mode:    *HarModeFull,
+	}
+	if strings.HasSuffix(strings.ToLower(path), ".zip") {
+		dir, err := ioutil.TempDir("", "playwright-har-")
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create HAR directory: %w", err)
+		}
+		recorder.path = filepath.Join(dir, "har.har")
+		recorder.content = *HarContentPolicyAttach
+	}
+	if omitContent != nil && *omitContent {
+		recorder.content = *HarContentPolicyOmit
+	}
+	if content != nil {
+		recorder.content = *content
+	}
+	if mode != nil {
+		recorder.mode = *mode
+	}
+	if urlFilter != nil {
+		recorder.urlFilter = newURLMatcher(urlFilter)
+	}
+	option := map[string]interface{}{
+		"path":        recorder.path,
+		"omitContent": recorder.content == *HarContentPolicyOmit,
+	}
+	return recorder, option, nil
+}
+
+// finish applies the options which the driver does not support to the HAR.
+func (h *harRecorder) finish() error {
+	if h.path == h.target && h.urlFilter == nil && h.mode == *HarModeFull && h.content != *HarContentPolicyAttach && !redactions.enabled() {
+		return nil
+	}
+	data, err := ioutil.ReadFile(h.path)
+	if err != nil {
+		return fmt.Errorf("could not read HAR: %w", err)
+	}
+	var har map[string]interface{}
+	if err := json.Unmarshal(data, &har); err != nil {
+		return fmt.Errorf("could not parse HAR: %w", err)
+	}
+	attachments, err := h.rewrite(har)
+	if err != nil {
+		return err
+	}
+	data, err = json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not serialize HAR: %w", err)
+	}
+	if h.path != h.target {
+		defer os.RemoveAll(filepath.Dir(h.path))
+		return writeHarArchive(h.target, data, attachments)
+	}
+	dir := filepath.Dir(h.target)
+	for name, content := range attachments {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return fmt.Errorf("could not write HAR attachment: %w", err)
+		}
+	}
+	return ioutil.WriteFile(h.target, data, 0644)
+}
+
+// rewrite drops the entries which do not match the URL filter, masks the
+// secrets registered with RedactValue(), strips the information which is not
+// needed for replaying in the minimal mode and moves the content of the
+// responses into attachments.
+func (h *harRecorder) rewrite(har map[string]interface{}) (map[string][]byte, error) {
+	attachments := map[string][]byte{}
+	log, ok := har["log"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("could not parse HAR: missing log")
+	}
+	rawEntries, _ := log["entries"].([]interface{})
+	entries := []interface{}{}
+	for _, rawEntry := range rawEntries {
+		entry, ok := rawEntry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		request, _ := entry["request"].(map[string]interface{})
+		if h.urlFilter != nil {
+			url, _ := request["url"].(string)
+			if !h.urlFilter.Matches(url) {
+				continue
+			}
+		}
+		redactions.redactValue(entry)
+		response, _ := entry["response"].(map[string]interface{})
+		if h.mode == *HarModeMinimal {
+			minimizeHarEntry(entry, request, response)
+		}
+		if h.content == *HarContentPolicyAttach && response != nil {
+			if err := attachHarContent(response, attachments); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+	log["entries"] = entries
+	if h.mode == *HarModeMinimal {
+		log["pages"] = []interface{}{}
+	}
+	return attachments, nil
+}
+
+func minimizeHarEntry(entry, request, response map[string]interface{}) {
+	for _, key := range []string{"pageref", "serverIPAddress", "connection", "_securityDetails"} {
+		delete(entry, key)
+	}
+	entry["time"] = -1
+	entry["timings"] = map[string]interface{}{"send": -1, "wait": -1, "receive": -1}
+	for _, message := range []map[string]interface{}{request, response} {
+		if message == nil {
+			continue
+		}
+		message["cookies"] = []interface{}{}
+		message["headersSize"] = -1
+		message["bodySize"] = -1
+	}
+}
+
+func attachHarContent(response map[string]interface{}, attachments map[string][]byte) error {
+	content, ok := response["content"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	text, ok := content["text"].(string)
+	if !ok {
+		return nil
+	}
+	body := []byte(text)
+	if content["encoding"] == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return fmt.Errorf("could not decode HAR content: %w", err)
+		}
+		body = decoded
+	}
+	sum := sha1.Sum(body)
+	name := hex.EncodeToString(sum[:])
+	if mimeType, ok := content["mimeType"].(string); ok {
+		if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+			if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+				name += extensions[0]
+			}
+		}
+	}
+	attachments[name] = body
+	content["_file"] = name
+	delete(content, "text")
+	delete(content, "encoding")
+	return nil
+}
+
+func writeHarArchive(path string, har []byte, attachments map[string][]byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create HAR archive: %w", err)
+	}
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	if err := writeZipEntry(writer, "har.har", har); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(attachments))
+	for name := range attachments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeZipEntry(writer, name, attachments[name]); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
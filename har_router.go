@@ -0,0 +1,479 @@
+package playwright
+
+import (
+	"archive/zip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// harArchive is the subset of an HTTP Archive which is needed for serving
+// requests from it, see http://www.softwareishard.com/blog/har-12-spec/.
+type harArchive struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string        `json:"version"`
+	Creator harCreator    `json:"creator"`
+	Pages   []interface{} `json:"pages"`
+	Entries []harEntry    `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string                 `json:"startedDateTime"`
+	Time            float64                `json:"time"`
+	Request         harRequest             `json:"request"`
+	Response        harResponse            `json:"response"`
+	Cache           map[string]interface{} `json:"cache"`
+	Timings         map[string]float64     `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []interface{}  `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []interface{}  `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	File     string `json:"_file,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// harRouter serves the requests of a page or browser context from a HAR, or
+// records them into it in the update mode.
+type harRouter struct {
+	sync.Mutex
+	path     string
+	notFound HarNotFound
+	entries  []harEntry
+	// attachments of a zip archive, for HAR files the attachments are read
+	// from the directory of the file
+	attachments map[string][]byte
+	// update mode
+	update    bool
+	urlFilter *urlMatcher
+	content   HarContentPolicy
+	pending   sync.WaitGroup
+}
+
+func newHarRouter(path string, options ...BrowserContextRouteFromHAROptions) (*harRouter, error) {
+	router := &harRouter{
+		path:     path,
+		notFound: *HarNotFoundAbort,
+		content:  *HarContentPolicyEmbed,
+	}
+	if isHarArchive(path) {
+		router.content = *HarContentPolicyAttach
+	}
+	if len(options) == 1 {
+		option := options[0]
+		if option.NotFound != nil {
+			router.notFound = *option.NotFound
+		}
+		router.update = option.Update != nil && *option.Update
+		if option.URL != nil {
+			router.urlFilter = newURLMatcher(option.URL)
+		}
+		if option.UpdateContent != nil {
+			router.content = *option.UpdateContent
+		}
+	}
+	if router.update {
+		return router, nil
+	}
+	if err := router.load(); err != nil {
+		return nil, err
+	}
+	return router, nil
+}
+
+func isHarArchive(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".zip")
+}
+
+func (h *harRouter) load() error {
+	var data []byte
+	if isHarArchive(h.path) {
+		reader, err := zip.OpenReader(h.path)
+		if err != nil {
+			return fmt.Errorf("could not open HAR: %w", err)
+		}
+		defer reader.Close()
+		h.attachments = map[string][]byte{}
+		for _, entry := range reader.File {
+			content, err := readZipEntry(entry)
+			if err != nil {
+				return fmt.Errorf("could not read HAR: %w", err)
+			}
+			if strings.HasSuffix(entry.Name, ".har") {
+				data = content
+			} else {
+				h.attachments[entry.Name] = content
+			}
+		}
+		if data == nil {
+			return fmt.Errorf("could not open HAR: %s contains no .har file", h.path)
+		}
+	} else {
+		var err error
+		if data, err = ioutil.ReadFile(h.path); err != nil {
+			return fmt.Errorf("could not open HAR: %w", err)
+		}
+	}
+	var archive harArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return fmt.Errorf("could not parse HAR: %w", err)
+	}
+	h.entries = archive.Log.Entries
+	return nil
+}
+
+// find returns the entry for a request. The URL without the fragment and the
+// method need to match, for requests with a body an entry with the same body
+// is preferred.
+func (h *harRouter) find(method, requestURL string, postData []byte) *harEntry {
+	requestURL = stripURLFragment(requestURL)
+	var candidate *harEntry
+	for i := range h.entries {
+		entry := &h.entries[i]
+		if !strings.EqualFold(entry.Request.Method, method) || stripURLFragment(entry.Request.URL) != requestURL {
+			continue
+		}
+		if len(postData) == 0 || (entry.Request.PostData != nil && entry.Request.PostData.Text == string(postData)) {
+			return entry
+		}
+		if candidate == nil {
+			candidate = entry
+		}
+	}
+	return candidate
+}
+
+func stripURLFragment(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+func (h *harRouter) body(entry *harEntry) ([]byte, error) {
+	content := entry.Response.Content
+	if content.File != "" {
+		if h.attachments != nil {
+			body, ok := h.attachments[content.File]
+			if !ok {
+				return nil, fmt.Errorf("HAR attachment %s is missing", content.File)
+			}
+			return body, nil
+		}
+		return ioutil.ReadFile(filepath.Join(filepath.Dir(h.path), content.File))
+	}
+	if content.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(content.Text)
+	}
+	return []byte(content.Text), nil
+}
+
+func (h *harRouter) handle(route Route, request Request) {
+	if err := h.serve(route, request); err != nil {
+		log.Printf("could not serve request from HAR: %v", err)
+	}
+}
+
+func (h *harRouter) serve(route Route, request Request) error {
+	postData, _ := request.PostDataBuffer()
+	entry := h.find(request.Method(), request.URL(), postData)
+	if entry == nil {
+		if h.notFound == *HarNotFoundFallback {
+			return route.Continue()
+		}
+		return route.Abort()
+	}
+	body, err := h.body(entry)
+	if err != nil {
+		if abortErr := route.Abort(); abortErr != nil {
+			return abortErr
+		}
+		return err
+	}
+	return route.Fulfill(RouteFulfillOptions{
+		Status:  Int(entry.Response.Status),
+		Headers: harHeaders(entry.Response.Headers),
+		Body:    body,
+	})
+}
+
+// harHeaders converts recorded headers, repeated ones get joined like the
+// browsers do, Set-Cookie headers with new lines.
+func harHeaders(headers []harNameValue) map[string]string {
+	out := map[string]string{}
+	for _, header := range headers {
+		name := strings.ToLower(header.Name)
+		// the body gets sent as it is recorded, already decoded
+		if name == "content-encoding" || name == "content-length" || strings.HasPrefix(name, ":") {
+			continue
+		}
+		if value, ok := out[name]; ok {
+			separator := ", "
+			if name == "set-cookie" {
+				separator = "\n"
+			}
+			out[name] = value + separator + header.Value
+			continue
+		}
+		out[name] = header.Value
+	}
+	return out
+}
+
+// record adds the finished request to the HAR in the update mode. It gets
+// called from an event handler, the body gets fetched in the background.
+func (h *harRouter) record(request Request) {
+	if h.urlFilter != nil && !h.urlFilter.Matches(request.URL()) {
+		return
+	}
+	h.pending.Add(1)
+	go func() {
+		defer h.pending.Done()
+		entry, err := newHarEntry(request, h.content)
+		if err != nil {
+			log.Printf("could not record request into HAR: %v", err)
+			return
+		}
+		h.Lock()
+		defer h.Unlock()
+		h.entries = append(h.entries, *entry)
+	}()
+}
+
+func newHarEntry(request Request, content HarContentPolicy) (*harEntry, error) {
+	response, err := request.Response()
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("request %s has no response", request.URL())
+	}
+	entry := &harEntry{
+		StartedDateTime: time.Now().UTC().Format(time.RFC3339Nano),
+		Time:            -1,
+		Cache:           map[string]interface{}{},
+		Timings:         map[string]float64{"send": -1, "wait": -1, "receive": -1},
+		Request: harRequest{
+			Method:      request.Method(),
+			URL:         request.URL(),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []interface{}{},
+			Headers:     toHarHeaders(request.Headers()),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+		},
+		Response: harResponse{
+			Status:      response.Status(),
+			StatusText:  response.StatusText(),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []interface{}{},
+			Headers:     toHarHeaders(response.Headers()),
+			RedirectURL: response.Headers()["location"],
+			HeadersSize: -1,
+		},
+	}
+	if parsed, err := url.Parse(request.URL()); err == nil {
+		for name, values := range parsed.Query() {
+			for _, value := range values {
+				entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+			}
+		}
+	}
+	if postData, _ := request.PostDataBuffer(); len(postData) > 0 {
+		entry.Request.BodySize = len(postData)
+		entry.Request.PostData = &harPostData{
+			MimeType: request.Headers()["content-type"],
+			Text:     string(postData),
+		}
+	}
+	entry.Response.Content.MimeType = response.Headers()["content-type"]
+	// redirects have no body
+	if entry.Response.Status < 300 || entry.Response.Status >= 400 {
+		body, err := response.Body()
+		if err != nil {
+			return nil, err
+		}
+		entry.Response.BodySize = len(body)
+		entry.Response.Content.Size = len(body)
+		if content != *HarContentPolicyOmit {
+			entry.Response.Content.Text = base64.StdEncoding.EncodeToString(body)
+			entry.Response.Content.Encoding = "base64"
+		}
+	}
+	return entry, nil
+}
+
+func toHarHeaders(headers map[string]string) []harNameValue {
+	out := make([]harNameValue, 0, len(headers))
+	for name, value := range headers {
+		out = append(out, harNameValue{Name: name, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// save writes the recorded entries to the HAR in the update mode, once the
+// bodies of the finished requests got fetched.
+func (h *harRouter) save() error {
+	if !h.update {
+		return nil
+	}
+	h.pending.Wait()
+	h.Lock()
+	defer h.Unlock()
+	archive := harArchive{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "Playwright", Version: playwrightCliVersion},
+			Pages:   []interface{}{},
+			Entries: h.entries,
+		},
+	}
+	if archive.Log.Entries == nil {
+		archive.Log.Entries = []harEntry{}
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	recorder := &harRecorder{
+		path:    h.path,
+		target:  h.path,
+		content: h.content,
+		mode:    *HarModeFull,
+	}
+	if isHarArchive(h.path) {
+		dir, err := ioutil.TempDir("", "playwright-har-")
+		if err != nil {
+			return err
+		}
+		recorder.path = filepath.Join(dir, "har.har")
+	} else if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(recorder.path, data, 0644); err != nil {
+		return err
+	}
+	return recorder.finish()
+}
+
+func (h *harRouter) install(target EventEmitter, route func(url interface{}, handler routeHandler) error, url interface{}) error {
+	if h.update {
+		target.On("requestfinished", h.record)
+		return nil
+	}
+	if url == nil {
+		url = "**/*"
+	}
+	return route(url, h.handle)
+}
+
+func (b *browserContextImpl) RouteFromHAR(har string, options ...BrowserContextRouteFromHAROptions) error {
+	router, err := newHarRouter(har, options...)
+	if err != nil {
+		return err
+	}
+	var url interface{}
+	if len(options) == 1 {
+		url = options[0].URL
+	}
+	route := func(url interface{}, handler routeHandler) error {
+		return b.Route(url, handler)
+	}
+	if err := router.install(b, route, url); err != nil {
+		return err
+	}
+	b.Lock()
+	b.harRouters = append(b.harRouters, router)
+	b.Unlock()
+	return nil
+}
+
+func (p *pageImpl) RouteFromHAR(har string, options ...PageRouteFromHAROptions) error {
+	var option []BrowserContextRouteFromHAROptions
+	var url interface{}
+	if len(options) == 1 {
+		option = append(option, BrowserContextRouteFromHAROptions(options[0]))
+		url = options[0].URL
+	}
+	router, err := newHarRouter(har, option...)
+	if err != nil {
+		return err
+	}
+	route := func(url interface{}, handler routeHandler) error {
+		return p.Route(url, handler)
+	}
+	if err := router.install(p, route, url); err != nil {
+		return err
+	}
+	p.Lock()
+	p.harRouters = append(p.harRouters, router)
+	p.Unlock()
+	return nil
+}
+
+// saveHars writes the HARs of the routers in the update mode.
+func saveHars(routers []*harRouter) error {
+	for _, router := range routers {
+		if err := router.save(); err != nil {
+			return fmt.Errorf("could not save HAR: %w", err)
+		}
+	}
+	return nil
+}
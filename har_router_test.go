@@ -0,0 +1,80 @@
+package playwright
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testHarEntry(method, url, postData string, status int, body string) harEntry {
+	entry := harEntry{
+		Request: harRequest{Method: method, URL: url},
+		Response: harResponse{
+			Status:  status,
+			Headers: []harNameValue{{Name: "Content-Type", Value: "text/plain"}},
+			Content: harContent{MimeType: "text/plain", Text: body},
+		},
+	}
+	if postData != "" {
+		entry.Request.PostData = &harPostData{Text: postData}
+	}
+	return entry
+}
+
+func TestHarRouterFind(t *testing.T) {
+	router := &harRouter{entries: []harEntry{
+		testHarEntry("GET", "https://example.com/api", "", 200, "get"),
+		testHarEntry("POST", "https://example.com/api", `{"id":1}`, 200, "first"),
+		testHarEntry("POST", "https://example.com/api", `{"id":2}`, 200, "second"),
+	}}
+	require.Equal(t, "get", router.find("get", "https://example.com/api#top", nil).Response.Content.Text)
+	require.Equal(t, "second", router.find("POST", "https://example.com/api", []byte(`{"id":2}`)).Response.Content.Text)
+	require.Equal(t, "first", router.find("POST", "https://example.com/api", []byte(`{"id":3}`)).Response.Content.Text)
+	require.Nil(t, router.find("GET", "https://example.com/other", nil))
+	require.Nil(t, router.find("DELETE", "https://example.com/api", nil))
+}
+
+func TestHarHeaders(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"content-type": "text/html",
+		"set-cookie":   "a=1\nb=2",
+		"vary":         "Accept, Origin",
+	}, harHeaders([]harNameValue{
+		{Name: "Content-Type", Value: "text/html"},
+		{Name: "Content-Encoding", Value: "gzip"},
+		{Name: "Content-Length", Value: "42"},
+		{Name: "Set-Cookie", Value: "a=1"},
+		{Name: "Set-Cookie", Value: "b=2"},
+		{Name: "Vary", Value: "Accept"},
+		{Name: "Vary", Value: "Origin"},
+	}))
+}
+
+func TestHarRouterSaveAndLoad(t *testing.T) {
+	for _, name := range []string{"network.har", "network.zip"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hars", name)
+			recorder, err := newHarRouter(path, BrowserContextRouteFromHAROptions{Update: Bool(true)})
+			require.NoError(t, err)
+			entry := testHarEntry("GET", "https://example.com/logo.png", "", 200, "")
+			entry.Response.Content = harContent{MimeType: "image/png", Text: "iVBO", Encoding: "base64"}
+			recorder.entries = append(recorder.entries, entry)
+			require.NoError(t, recorder.save())
+
+			router, err := newHarRouter(path)
+			require.NoError(t, err)
+			found := router.find("GET", "https://example.com/logo.png", nil)
+			require.NotNil(t, found)
+			body, err := router.body(found)
+			require.NoError(t, err)
+			require.Equal(t, []byte{0x89, 0x50, 0x4e}, body)
+		})
+	}
+}
+
+func TestHarRouterMissingFile(t *testing.T) {
+	_, err := newHarRouter(filepath.Join(t.TempDir(), "missing.har"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not open HAR")
+}
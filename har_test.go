@@ -0,0 +1,111 @@
+package playwright
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testHar = `{"log": {
+  "version": "1.2",
+  "pages": [{"id": "page@1", "title": "Example"}],
+  "entries": [
+    {
+      "pageref": "page@1",
+      "time": 12.5,
+      "request": {"method": "GET", "url": "https://example.com/api/users", "cookies": [{"name": "a", "value": "b"}], "headersSize": 120, "bodySize": 0},
+      "response": {"status": 200, "cookies": [], "headersSize": 80, "bodySize": 17, "content": {"size": 17, "mimeType": "application/json", "text": "{\"users\": [\"a\"]}"}},
+      "timings": {"send": 1, "wait": 10, "receive": 1.5}
+    },
+    {
+      "pageref": "page@1",
+      "request": {"method": "GET", "url": "https://example.com/logo.png"},
+      "response": {"status": 200, "content": {"size": 3, "mimeType": "image/png", "text": "iVBO", "encoding": "base64"}}
+    }
+  ]
+}}`
+
+func writeTestHar(t *testing.T, recorder *harRecorder) {
+	require.NoError(t, ioutil.WriteFile(recorder.path, []byte(testHar), 0644))
+}
+
+func readTestHar(t *testing.T, data []byte) map[string]interface{} {
+	var har map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &har))
+	return har["log"].(map[string]interface{})
+}
+
+func TestHarRecorderDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.har")
+	recorder, option, err := newHarRecorder(path, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"path": path, "omitContent": false}, option)
+	writeTestHar(t, recorder)
+	require.NoError(t, recorder.finish())
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, testHar, string(data))
+
+	_, option, err = newHarRecorder(path, Bool(true), nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, true, option["omitContent"])
+}
+
+func TestHarRecorderURLFilterAndMinimalMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.har")
+	recorder, _, err := newHarRecorder(path, nil, nil, HarModeMinimal, regexp.MustCompile(`/api/`))
+	require.NoError(t, err)
+	writeTestHar(t, recorder)
+	require.NoError(t, recorder.finish())
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	log := readTestHar(t, data)
+	require.Empty(t, log["pages"])
+	entries := log["entries"].([]interface{})
+	require.Len(t, entries, 1)
+	entry := entries[0].(map[string]interface{})
+	require.NotContains(t, entry, "pageref")
+	request := entry["request"].(map[string]interface{})
+	require.Equal(t, "https://example.com/api/users", request["url"])
+	require.Empty(t, request["cookies"])
+	require.Equal(t, float64(-1), request["headersSize"])
+	content := entry["response"].(map[string]interface{})["content"].(map[string]interface{})
+	require.Equal(t, `{"users": ["a"]}`, content["text"])
+}
+
+func TestHarRecorderAttachContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "network.zip")
+	recorder, option, err := newHarRecorder(path, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, path, option["path"])
+	require.Equal(t, false, option["omitContent"])
+	writeTestHar(t, recorder)
+	require.NoError(t, recorder.finish())
+	require.NoDirExists(t, filepath.Dir(recorder.path))
+
+	reader, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	files := map[string][]byte{}
+	for _, entry := range reader.File {
+		content, err := readZipEntry(entry)
+		require.NoError(t, err)
+		files[entry.Name] = content
+	}
+	require.Len(t, files, 3)
+	entries := readTestHar(t, files["har.har"])["entries"].([]interface{})
+	for _, rawEntry := range entries {
+		content := rawEntry.(map[string]interface{})["response"].(map[string]interface{})["content"].(map[string]interface{})
+		require.NotContains(t, content, "text")
+		require.NotContains(t, content, "encoding")
+		require.Contains(t, files, content["_file"])
+	}
+	image := entries[1].(map[string]interface{})["response"].(map[string]interface{})["content"].(map[string]interface{})
+	require.Equal(t, []byte{0x89, 0x50, 0x4e}, files[image["_file"].(string)])
+}
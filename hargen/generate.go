@@ -0,0 +1,409 @@
+package hargen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Options configure Generate.
+type Options struct {
+	// Package is the package name of the generated file, defaults to "fixtures".
+	Package string
+	// Source is mentioned in the doc comments of the generated code, e.g. the
+	// file name of the HAR.
+	Source string
+	// Include selects the entries which get a stub, by default all entries
+	// with a HTTP(S) URL and a response are included. Only the first entry of
+	// an endpoint, i.e. a method and a URL path, is used.
+	Include func(entry *Entry) bool
+}
+
+// skippedHeaders do not describe the recorded body anymore once it got
+// decoded, so they are not replayed.
+var skippedHeaders = map[string]bool{
+	"connection":        true,
+	"content-encoding":  true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"transfer-encoding": true,
+}
+
+type endpoint struct {
+	name   string
+	method string
+	path   string
+	entry  *Entry
+}
+
+// Generate returns the formatted Go source of the stubs for the entries of
+// archive.
+func Generate(archive *HAR, options Options) ([]byte, error) {
+	if options.Package == "" {
+		options.Package = "fixtures"
+	}
+	if options.Source == "" {
+		options.Source = "the HAR"
+	}
+	endpoints, err := collectEndpoints(archive, options.Include)
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by hargen from %s. DO NOT EDIT.\n\n", options.Source)
+	fmt.Fprintf(out, "// Package %s stubs the endpoints recorded in %s.\n", options.Package, options.Source)
+	fmt.Fprintf(out, "package %s\n\n", options.Package)
+	out.WriteString(preamble)
+	for _, e := range endpoints {
+		writeEndpoint(out, e, options.Source)
+	}
+	writeInstall(out, endpoints)
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated code: %w", err)
+	}
+	return source, nil
+}
+
+func collectEndpoints(archive *HAR, include func(entry *Entry) bool) ([]*endpoint, error) {
+	var endpoints []*endpoint
+	seen := make(map[string]bool)
+	names := make(map[string]int)
+	for i := range archive.Log.Entries {
+		entry := &archive.Log.Entries[i]
+		parsed, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL of entry %d: %w", i, err)
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "https" || entry.Response.Status == 0 {
+			continue
+		}
+		if include != nil && !include(entry) {
+			continue
+		}
+		method := strings.ToUpper(entry.Request.Method)
+		path := parsed.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		if seen[method+" "+path] {
+			continue
+		}
+		seen[method+" "+path] = true
+		name := endpointName(method, path)
+		names[name]++
+		if names[name] > 1 {
+			name += strconv.Itoa(names[name])
+		}
+		endpoints = append(endpoints, &endpoint{
+			name:   name,
+			method: method,
+			path:   path,
+			entry:  entry,
+		})
+	}
+	return endpoints, nil
+}
+
+const preamble = `import (
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"regexp"
+
+	"github.com/neilspage/playwright-go"
+)
+
+// Fixture is a recorded response.
+type Fixture struct {
+	Status  int
+	Headers map[string]string
+	Body    string
+	// Encoding is "base64" for binary bodies.
+	Encoding string
+}
+
+// Decode unmarshals the JSON body of the fixture into v.
+func (f Fixture) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(f.Body), v)
+}
+
+// Router is implemented by playwright.Page and playwright.BrowserContext.
+type Router interface {
+	Route(url interface{}, handler func(playwright.Route, playwright.Request)) error
+}
+
+func fulfill(route playwright.Route, fixture Fixture) {
+	var body interface{} = fixture.Body
+	if fixture.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(fixture.Body)
+		if err != nil {
+			log.Printf("could not decode fixture body: %v", err)
+			return
+		}
+		body = decoded
+	}
+	if err := route.Fulfill(playwright.RouteFulfillOptions{
+		Status:  playwright.Int(fixture.Status),
+		Headers: fixture.Headers,
+		Body:    body,
+	}); err != nil {
+		log.Printf("could not fulfill route: %v", err)
+	}
+}
+
+`
+
+func writeEndpoint(out *bytes.Buffer, e *endpoint, source string) {
+	summary := e.method + " " + e.path
+	if postData := e.entry.Request.PostData; postData != nil && postData.Text != "" {
+		if body, ok := decodeJSON(postData.MimeType, postData.Text); ok {
+			fmt.Fprintf(out, "// %sRequest is the request body of %s.\n", e.name, summary)
+			fmt.Fprintf(out, "type %sRequest %s\n\n", e.name, goType(body))
+		}
+		fmt.Fprintf(out, "// %sRequestBody is the request body of %s recorded in %s.\n", e.name, summary, source)
+		fmt.Fprintf(out, "const %sRequestBody = %s\n\n", e.name, goString(postData.Text))
+	}
+	content := e.entry.Response.Content
+	if content.Encoding != "base64" {
+		if body, ok := decodeJSON(content.MimeType, content.Text); ok {
+			fmt.Fprintf(out, "// %sResponse is the response body of %s.\n", e.name, summary)
+			fmt.Fprintf(out, "type %sResponse %s\n\n", e.name, goType(body))
+		}
+	}
+	fmt.Fprintf(out, "// %sFixture is the response of %s recorded in %s.\n", e.name, summary, source)
+	fmt.Fprintf(out, "var %sFixture = Fixture{\n", e.name)
+	fmt.Fprintf(out, "Status: %d,\n", e.entry.Response.Status)
+	fmt.Fprintf(out, "Headers: map[string]string{\n")
+	headers := responseHeaders(e.entry.Response.Headers)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "%s: %s,\n", strconv.Quote(name), strconv.Quote(headers[name]))
+	}
+	fmt.Fprintf(out, "},\n")
+	fmt.Fprintf(out, "Body: %s,\n", goString(content.Text))
+	if content.Encoding != "" {
+		fmt.Fprintf(out, "Encoding: %s,\n", strconv.Quote(content.Encoding))
+	}
+	fmt.Fprintf(out, "}\n\n")
+	fmt.Fprintf(out, "// %s answers %s with %sFixture.\n", e.name, summary, e.name)
+	fmt.Fprintf(out, "func %s(route playwright.Route, request playwright.Request) {\n", e.name)
+	fmt.Fprintf(out, "fulfill(route, %sFixture)\n", e.name)
+	fmt.Fprintf(out, "}\n\n")
+}
+
+// writeInstall writes the Install function, it registers one route per path
+// which dispatches on the method because continued requests do not reach
+// other handlers.
+func writeInstall(out *bytes.Buffer, endpoints []*endpoint) {
+	var paths []string
+	byPath := make(map[string][]*endpoint)
+	for _, e := range endpoints {
+		if _, ok := byPath[e.path]; !ok {
+			paths = append(paths, e.path)
+		}
+		byPath[e.path] = append(byPath[e.path], e)
+	}
+	fmt.Fprintf(out, "// Install routes the recorded endpoints of target to their stubs, requests with\n")
+	fmt.Fprintf(out, "// a method which was not recorded get continued.\n")
+	fmt.Fprintf(out, "func Install(target Router) error {\n")
+	fmt.Fprintf(out, "routes := []struct {\n")
+	fmt.Fprintf(out, "url *regexp.Regexp\n")
+	fmt.Fprintf(out, "handlers map[string]func(playwright.Route, playwright.Request)\n")
+	fmt.Fprintf(out, "}{\n")
+	for _, path := range paths {
+		pattern := "^[a-z]+://[^/]+" + regexp.QuoteMeta(path) + `(?:\?.*)?$`
+		fmt.Fprintf(out, "{\n")
+		fmt.Fprintf(out, "url: regexp.MustCompile(%s),\n", goString(pattern))
+		fmt.Fprintf(out, "handlers: map[string]func(playwright.Route, playwright.Request){\n")
+		for _, e := range byPath[path] {
+			fmt.Fprintf(out, "%s: %s,\n", strconv.Quote(e.method), e.name)
+		}
+		fmt.Fprintf(out, "},\n")
+		fmt.Fprintf(out, "},\n")
+	}
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "for _, r := range routes {\n")
+	fmt.Fprintf(out, "handlers := r.handlers\n")
+	fmt.Fprintf(out, "if err := target.Route(r.url, func(route playwright.Route, request playwright.Request) {\n")
+	fmt.Fprintf(out, "if handler, ok := handlers[request.Method()]; ok {\n")
+	fmt.Fprintf(out, "handler(route, request)\n")
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "if err := route.Continue(); err != nil {\n")
+	fmt.Fprintf(out, "log.Printf(\"could not continue route: %%v\", err)\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}); err != nil {\n")
+	fmt.Fprintf(out, "return err\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "return nil\n")
+	fmt.Fprintf(out, "}\n")
+}
+
+// responseHeaders lower-cases the header names and joins repeated headers.
+func responseHeaders(recorded []NameValue) map[string]string {
+	headers := make(map[string]string, len(recorded))
+	for _, header := range recorded {
+		name := strings.ToLower(header.Name)
+		if skippedHeaders[name] || strings.HasPrefix(name, ":") {
+			continue
+		}
+		if existing, ok := headers[name]; ok {
+			separator := ", "
+			if name == "set-cookie" {
+				separator = "\n"
+			}
+			headers[name] = existing + separator + header.Value
+			continue
+		}
+		headers[name] = header.Value
+	}
+	return headers
+}
+
+func decodeJSON(mimeType, text string) (interface{}, bool) {
+	if !strings.Contains(strings.ToLower(mimeType), "json") {
+		return nil, false
+	}
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// goType returns the Go type expression for a decoded JSON value. Objects
+// become structs with their keys sorted, arrays take the type of their first
+// element.
+func goType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "interface{}"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "int64"
+		}
+		return "float64"
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]interface{}"
+		}
+		return "[]" + goType(v[0])
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make(map[string]int)
+		out := &strings.Builder{}
+		out.WriteString("struct {\n")
+		for _, key := range keys {
+			name := fieldName(key)
+			fields[name]++
+			if fields[name] > 1 {
+				name += strconv.Itoa(fields[name])
+			}
+			fmt.Fprintf(out, "%s %s `json:%s`\n", name, goType(v[key]), strconv.Quote(key))
+		}
+		out.WriteString("}")
+		return out.String()
+	}
+	return "interface{}"
+}
+
+// initialisms are spelled in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"API": true, "CSS": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "SQL": true, "UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// identifier joins the words of s in camel case, e.g. "user_id" becomes
+// "UserID".
+func identifier(s string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	previous := rune(0)
+	for _, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+		previous = r
+	}
+	flush()
+	out := &strings.Builder{}
+	for _, w := range words {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			out.WriteString(upper)
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(w)
+		out.WriteRune(unicode.ToUpper(first))
+		out.WriteString(w[size:])
+	}
+	return out.String()
+}
+
+func fieldName(key string) string {
+	name := identifier(key)
+	if name == "" {
+		return "Field"
+	}
+	if first, _ := utf8.DecodeRuneInString(name); unicode.IsDigit(first) {
+		return "Field" + name
+	}
+	return name
+}
+
+// endpointName returns the name of the handler of an endpoint, e.g.
+// "GetAPIUsers" for GET /api/users.
+func endpointName(method, path string) string {
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	name := identifier(path)
+	if name == "" {
+		name = "Root"
+	}
+	return identifier(strings.ToLower(method)) + name
+}
+
+// goString returns a Go literal for s, a raw string literal when possible
+// to keep recorded JSON readable.
+func goString(s string) string {
+	if !strings.ContainsAny(s, "`\r\x00\uFEFF") && utf8.ValidString(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,95 @@
+// Package hargen turns a HAR recording into Go route handler stubs, so an
+// offline test suite can be bootstrapped from a single recorded session.
+//
+//	archive, err := hargen.Load("session.har")
+//	source, err := hargen.Generate(archive, hargen.Options{Package: "fixtures", Source: "session.har"})
+//
+// The generated file contains one handler per recorded endpoint, a fixture
+// with the recorded response and, for JSON bodies, struct types of the
+// request and response bodies. Its Install function routes a page or a
+// browser context to the handlers.
+package hargen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// HAR is the root of an HTTP Archive, see http://www.softwareishard.com/blog/har-12-spec/.
+// Only the fields needed for generating stubs are decoded.
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log holds the recorded entries.
+type Log struct {
+	Version string  `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a single recorded request and its response.
+type Entry struct {
+	StartedDateTime string   `json:"startedDateTime"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+	// ResourceType is recorded by Chromium based browsers, e.g. "xhr" or "fetch".
+	ResourceType string `json:"_resourceType"`
+}
+
+// Request is the recorded request of an entry.
+type Request struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Headers  []NameValue `json:"headers"`
+	PostData *PostData   `json:"postData"`
+}
+
+// PostData is the recorded request body.
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Response is the recorded response of an entry.
+type Response struct {
+	Status     int         `json:"status"`
+	StatusText string      `json:"statusText"`
+	Headers    []NameValue `json:"headers"`
+	Content    Content     `json:"content"`
+}
+
+// Content is the recorded response body. Binary bodies are base64 encoded
+// and have Encoding set to "base64".
+type Content struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
+
+// NameValue is a header of a request or a response.
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Parse decodes a HAR from r.
+func Parse(r io.Reader) (*HAR, error) {
+	var archive HAR
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("could not parse HAR: %w", err)
+	}
+	return &archive, nil
+}
+
+// Load reads the HAR file at path.
+func Load(path string) (*HAR, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open HAR: %w", err)
+	}
+	defer file.Close()
+	return Parse(file)
+}
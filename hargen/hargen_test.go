@@ -0,0 +1,93 @@
+package hargen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testHAR = `{
+  "log": {
+    "version": "1.2",
+    "entries": [
+      {
+        "request": {"method": "GET", "url": "https://example.com/api/users?page=1", "headers": []},
+        "response": {
+          "status": 200,
+          "headers": [
+            {"name": "Content-Type", "value": "application/json"},
+            {"name": "Content-Length", "value": "42"},
+            {"name": "Set-Cookie", "value": "a=1"},
+            {"name": "Set-Cookie", "value": "b=2"}
+          ],
+          "content": {"mimeType": "application/json", "text": "[{\"id\": 1, \"user_name\": \"foo\", \"score\": 1.5, \"tags\": [], \"profile\": {\"avatarURL\": null}}]"}
+        }
+      },
+      {
+        "request": {"method": "GET", "url": "https://example.com/api/users?page=2", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"mimeType": "application/json", "text": "[]"}}
+      },
+      {
+        "request": {
+          "method": "POST", "url": "https://example.com/api/users", "headers": [],
+          "postData": {"mimeType": "application/json", "text": "{\"user_name\": \"bar\"}"}
+        },
+        "response": {"status": 201, "headers": [], "content": {"mimeType": "application/json", "text": "{\"id\": 2}"}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://example.com/logo.png", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"mimeType": "image/png", "text": "iVBORw0KGgo=", "encoding": "base64"}}
+      },
+      {
+        "request": {"method": "GET", "url": "data:text/plain,hello", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"mimeType": "text/plain", "text": "hello"}}
+      }
+    ]
+  }
+}`
+
+func TestGenerate(t *testing.T) {
+	archive, err := Parse(strings.NewReader(testHAR))
+	require.NoError(t, err)
+	source, err := Generate(archive, Options{Package: "stubs", Source: "session.har"})
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "stubs.go", source, parser.AllErrors)
+	require.NoError(t, err)
+	code := string(source)
+	require.Contains(t, code, "// Code generated by hargen from session.har. DO NOT EDIT.")
+	require.Contains(t, code, "package stubs")
+	require.Contains(t, code, "func GetAPIUsers(route playwright.Route, request playwright.Request) {")
+	require.Contains(t, code, "func PostAPIUsers(route playwright.Route, request playwright.Request) {")
+	require.Contains(t, code, "func GetLogoPng(route playwright.Route, request playwright.Request) {")
+	require.Contains(t, code, "UserName string `json:\"user_name\"`")
+	require.Contains(t, code, "ID      int64 `json:\"id\"`")
+	require.Contains(t, code, "Score    float64")
+	require.Contains(t, code, "Tags     []interface{}")
+	require.Contains(t, code, "AvatarURL interface{} `json:\"avatarURL\"`")
+	require.Contains(t, code, "type PostAPIUsersRequest struct {")
+	require.Contains(t, code, "const PostAPIUsersRequestBody = `{\"user_name\": \"bar\"}`")
+	require.Contains(t, code, "\"set-cookie\":   \"a=1\\nb=2\"")
+	require.NotContains(t, code, "content-length")
+	require.NotContains(t, code, "GetAPIUsers2")
+	require.NotContains(t, code, "hello")
+	require.Contains(t, code, `"GET":  GetAPIUsers,`)
+	require.Contains(t, code, `"POST": PostAPIUsers,`)
+
+	source, err = Generate(archive, Options{Include: func(entry *Entry) bool {
+		return entry.Request.Method == "POST"
+	}})
+	require.NoError(t, err)
+	require.Contains(t, string(source), "package fixtures")
+	require.NotContains(t, string(source), "GetAPIUsers")
+}
+
+func TestIdentifier(t *testing.T) {
+	require.Equal(t, "UserID", identifier("user_id"))
+	require.Equal(t, "AvatarURL", identifier("avatarUrl"))
+	require.Equal(t, "APIV1Users42", identifier("/api/v1/users/42"))
+	require.Equal(t, "Field2fa", fieldName("2fa"))
+	require.Equal(t, "GetRoot", endpointName("GET", "/"))
+}
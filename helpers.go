@@ -1,10 +1,17 @@
 package playwright
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net"
 	"reflect"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/danwakefield/fnmatch"
 )
@@ -64,6 +71,9 @@ func transformStructIntoMapIfNeeded(inStruct interface{}) map[string]interface{}
 				// out of the field.
 				tagv := fi.Tag.Get("json")
 				key := strings.Split(tagv, ",")[0]
+				if key == "-" {
+					continue
+				}
 				if key == "" {
 					key = fi.Name
 				}
@@ -139,6 +149,17 @@ func remapValue(inMapValue reflect.Value, outStructValue reflect.Value) {
 		for i := 0; i < inMapValue.Len(); i++ {
 			remapValue(inMapValue.Index(i).Elem(), outStructValue.Index(i))
 		}
+	case reflect.Interface:
+		if inMapValue.IsValid() {
+			outStructValue.Set(inMapValue)
+		}
+	case reflect.Map:
+		outStructValue.Set(reflect.MakeMapWithSize(outStructValue.Type(), inMapValue.Len()))
+		for _, key := range inMapValue.MapKeys() {
+			value := reflect.New(outStructValue.Type().Elem()).Elem()
+			remapValue(inMapValue.MapIndex(key).Elem(), value)
+			outStructValue.SetMapIndex(key, value)
+		}
 	case reflect.Struct:
 		structTyp := outStructValue.Type()
 		for i := 0; i < outStructValue.NumField(); i++ {
@@ -173,6 +194,36 @@ func isFunctionBody(expression string) bool {
 		strings.Contains(expression, "=> ")
 }
 
+// addInitFunction adds an init script which calls fn with arg and calls fn in
+// the current documents of the pages as well, since init scripts only run in
+// documents which get created afterwards. fn has to be idempotent.
+func addInitFunction(addInitScript func(script string) error, pages []Page, fn string, arg interface{}) error {
+	encodedArg, err := json.Marshal(arg)
+	if err != nil {
+		return fmt.Errorf("could not serialize init script argument: %w", err)
+	}
+	if err := addInitScript(fmt.Sprintf("(%s)(%s)", fn, encodedArg)); err != nil {
+		return err
+	}
+	return evaluateInFrames(pages, fn, arg)
+}
+
+// evaluateInFrames calls fn with arg in all frames of the pages. Frames which
+// get detached meanwhile are skipped.
+func evaluateInFrames(pages []Page, fn string, arg interface{}) error {
+	for _, page := range pages {
+		for _, frame := range page.Frames() {
+			if frame.IsDetached() {
+				continue
+			}
+			if _, err := frame.Evaluate(fn, arg); err != nil && !frame.IsDetached() {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type urlMatcher struct {
 	urlOrPredicate interface{}
 }
@@ -199,14 +250,131 @@ func (u *urlMatcher) Matches(url string) bool {
 }
 
 type routeHandlerEntry struct {
+	sync.Mutex
 	matcher *urlMatcher
 	handler routeHandler
+	// times is the number of requests the handler handles, 0 means unlimited
+	times   int
+	handled int
+	// removed is set by UnrouteAll(), in-flight handlers are tracked so that
+	// it can wait for them or ignore their errors
+	removed  bool
+	inFlight map[*routeImpl]bool
+	wg       sync.WaitGroup
+}
+
+func newRouteHandlerEntry(matcher *urlMatcher, handler routeHandler, times ...*int) *routeHandlerEntry {
+	entry := &routeHandlerEntry{
+		matcher:  matcher,
+		handler:  handler,
+		inFlight: make(map[*routeImpl]bool),
+	}
+	if len(times) == 1 && times[0] != nil {
+		entry.times = *times[0]
+	}
+	return entry
+}
+
+// claim reserves the handler for the route, it fails when the handler got
+// removed or handled its number of requests. expired reports whether the
+// route is the last one the handler handles.
+func (r *routeHandlerEntry) claim(route *routeImpl) (ok bool, expired bool) {
+	r.Lock()
+	defer r.Unlock()
+	if r.removed || (r.times > 0 && r.handled >= r.times) {
+		return false, false
+	}
+	r.handled++
+	r.inFlight[route] = true
+	r.wg.Add(1)
+	return true, r.times > 0 && r.handled == r.times
+}
+
+// clone returns a new entry with the same handler, which handles the same
+// remaining number of requests.
+func (r *routeHandlerEntry) clone() *routeHandlerEntry {
+	r.Lock()
+	defer r.Unlock()
+	entry := newRouteHandlerEntry(r.matcher, r.handler)
+	entry.times = r.times
+	entry.handled = r.handled
+	return entry
+}
+
+// remainingTimes returns the number of requests the handler still handles,
+// nil means unlimited. ok is false when the handler is used up or removed.
+func (r *routeHandlerEntry) remainingTimes() (times *int, ok bool) {
+	r.Lock()
+	defer r.Unlock()
+	if r.removed || (r.times > 0 && r.handled >= r.times) {
+		return nil, false
+	}
+	if r.times == 0 {
+		return nil, true
+	}
+	return Int(r.times - r.handled), true
+}
+
+func (r *routeHandlerEntry) release(route *routeImpl) {
+	r.Lock()
+	delete(r.inFlight, route)
+	r.Unlock()
+	r.wg.Done()
+}
+
+// remove stops the handler from handling further requests and applies the
+// behavior to the requests it is handling.
+func (r *routeHandlerEntry) remove(behavior *UnrouteBehavior) {
+	r.Lock()
+	r.removed = true
+	if behavior != nil && *behavior == *UnrouteBehaviorIgnoreErrors {
+		for route := range r.inFlight {
+			route.ignoreErrors()
+		}
+	}
+	r.Unlock()
+	if behavior != nil && *behavior == *UnrouteBehaviorWait {
+		r.wg.Wait()
+	}
+}
+
+// dispatchRoute runs the first handler of routes which matches the request
+// and did not expire. expired is the handler which handled its last request
+// and needs to be removed.
+func dispatchRoute(routes []*routeHandlerEntry, route *routeImpl, request *requestImpl) (handled bool, expired *routeHandlerEntry) {
+	for _, entry := range routes {
+		if !entry.matcher.Matches(request.URL()) {
+			continue
+		}
+		ok, last := entry.claim(route)
+		if !ok {
+			continue
+		}
+		entry.handler(route, request)
+		entry.release(route)
+		if last {
+			expired = entry
+		}
+		return true, expired
+	}
+	return false, nil
+}
+
+// removeRouteHandlerEntry returns the routes without entry.
+func removeRouteHandlerEntry(routes []*routeHandlerEntry, entry *routeHandlerEntry) []*routeHandlerEntry {
+	filtered := make([]*routeHandlerEntry, 0, len(routes))
+	for _, route := range routes {
+		if route != entry {
+			filtered = append(filtered, route)
+		}
+	}
+	return filtered
 }
 
-func newRouteHandlerEntry(matcher *urlMatcher, handler routeHandler) *routeHandlerEntry {
-	return &routeHandlerEntry{
-		matcher: matcher,
-		handler: handler,
+// unrouteAll removes all routes with the behavior, see UnrouteAll().
+func unrouteAll(routes []*routeHandlerEntry, behavior *UnrouteBehavior) {
+	for _, entry := range routes {
+		entry.remove(behavior)
 	}
 }
 
@@ -304,7 +472,7 @@ func waitForEvent(emitter EventEmitter, event string, predicate ...interface{})
 	removeHandler := make(chan bool, 1)
 	handler := func(ev ...interface{}) {
 		if len(predicate) == 0 {
-			if len(ev) == 1 {
+			if len(ev) > 0 {
 				evChan <- ev[0]
 			} else {
 				evChan <- nil
@@ -376,7 +544,9 @@ func convertSelectOptionSet(values SelectOptionValues) map[string]interface{} {
 	return out
 }
 
-func unroute(channel *channel, inRoutes []*routeHandlerEntry, url interface{}, handlers ...routeHandler) ([]*routeHandlerEntry, error) {
+// filterRoutes returns the routes without the ones registered for url and,
+// when given, handler.
+func filterRoutes(inRoutes []*routeHandlerEntry, url interface{}, handlers ...routeHandler) []*routeHandlerEntry {
 	var handler routeHandler
 	if len(handlers) == 1 {
 		handler = handlers[0]
@@ -392,16 +562,7 @@ func unroute(channel *channel, inRoutes []*routeHandlerEntry, url interface{}, h
 			routes = append(routes, route)
 		}
 	}
-
-	if len(routes) == 0 {
-		_, err := channel.Send("setNetworkInterceptionEnabled", map[string]interface{}{
-			"enabled": false,
-		})
-		if err != nil {
-			return nil, err
-		}
-	}
-	return routes, nil
+	return routes
 }
 
 func serializeMapToNameAndValue(headers map[string]string) []map[string]string {
@@ -414,3 +575,77 @@ func serializeMapToNameAndValue(headers map[string]string) []map[string]string {
 	}
 	return serialized
 }
+
+// maxRetryBackoff caps the exponential backoff between two retries.
+const maxRetryBackoff = 30 * time.Second
+
+// transientErrorMessages are the messages of launch and connect errors which
+// can go away when retrying, e.g. while the server or the driver is not ready
+// yet.
+var transientErrorMessages = []string{
+	"connection refused",
+	"connection reset",
+	"bad handshake",
+	"i/o timeout",
+	"Timeout",
+	"Target closed",
+	"Browser closed",
+	"browser has disconnected",
+}
+
+// isTransientError reports whether retrying the launch or connect which
+// failed with err can succeed.
+func isTransientError(err error) bool {
+	var optionsErr *OptionsError
+	if errors.As(err, &optionsErr) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	for _, message := range transientErrorMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryWithBackoff calls fn until it succeeds, fails with an error which is
+// not transient or it got retried the given amount of times. The backoff
+// between the retries gets doubled after every attempt.
+func retryWithBackoff(retries int, backoff time.Duration, fn func() error) error {
+	err := fn()
+	for attempt := 0; err != nil && isTransientError(err) && attempt < retries; attempt++ {
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+		err = fn()
+	}
+	return err
+}
+
+// retryOptions returns the amount of retries and the initial backoff of the
+// Retries and RetryBackoff options.
+func retryOptions(retries *int, retryBackoff *float64) (int, time.Duration) {
+	count := 0
+	if retries != nil {
+		count = *retries
+	}
+	backoff := time.Second
+	if retryBackoff != nil {
+		backoff = time.Duration(*retryBackoff * float64(time.Millisecond))
+	}
+	return count, backoff
+}
+
+// readAsset reads the file at path from fsys, e.g. an embed.FS, or from the
+// working directory if fsys is nil.
+func readAsset(fsys fs.FS, path string) ([]byte, error) {
+	if fsys != nil {
+		return fs.ReadFile(fsys, path)
+	}
+	return ioutil.ReadFile(path)
+}
@@ -1,7 +1,14 @@
 package playwright
 
 import (
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -95,6 +102,28 @@ func TestRemapMapToStruct(t *testing.T) {
 	require.Equal(t, ourStruct.V1, "foobar")
 }
 
+func TestRemapMapToStructWithMapsAndInterfaces(t *testing.T) {
+	ourStruct := struct {
+		Headers map[string]string      `json:"headers"`
+		Params  map[string]interface{} `json:"params"`
+		Value   interface{}            `json:"value"`
+	}{}
+	inMap := map[string]interface{}{
+		"headers": map[string]interface{}{
+			"foo": "bar",
+		},
+		"params": map[string]interface{}{
+			"count": 1.0,
+			"list":  []interface{}{"a"},
+		},
+		"value": true,
+	}
+	remapMapToStruct(inMap, &ourStruct)
+	require.Equal(t, map[string]string{"foo": "bar"}, ourStruct.Headers)
+	require.Equal(t, map[string]interface{}{"count": 1.0, "list": []interface{}{"a"}}, ourStruct.Params)
+	require.Equal(t, true, ourStruct.Value)
+}
+
 func TestConvertSelectOptionSet(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -137,3 +166,209 @@ func TestConvertSelectOptionSet(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryWithBackoff(t *testing.T) {
+	attempts := 0
+	start := time.Now()
+	err := retryWithBackoff(3, 10*time.Millisecond, func() error {
+		attempts++
+		return errors.New("dial tcp: connection refused")
+	})
+	require.EqualError(t, err, "dial tcp: connection refused")
+	require.Equal(t, 4, attempts)
+	require.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	attempts = 0
+	require.NoError(t, retryWithBackoff(3, time.Millisecond, func() error {
+		if attempts++; attempts < 2 {
+			return errors.New("websocket: bad handshake")
+		}
+		return nil
+	}))
+	require.Equal(t, 2, attempts)
+
+	attempts = 0
+	err = retryWithBackoff(3, time.Millisecond, func() error {
+		attempts++
+		return errors.New("Executable doesn't exist at /ms-playwright/chromium/chrome")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+
+	attempts = 0
+	err = retryWithBackoff(3, time.Millisecond, func() error {
+		attempts++
+		return &OptionsError{Options: "BrowserTypeLaunchOptions", Field: "Timeout", Reason: "Timeout must not be negative"}
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryOptions(t *testing.T) {
+	retries, backoff := retryOptions(nil, nil)
+	require.Equal(t, 0, retries)
+	require.Equal(t, time.Second, backoff)
+	retries, backoff = retryOptions(Int(2), Float(250))
+	require.Equal(t, 2, retries)
+	require.Equal(t, 250*time.Millisecond, backoff)
+}
+
+func TestScopeInitScript(t *testing.T) {
+	require.Equal(t, "window.a = 1", scopeInitScript("window.a = 1", nil, false))
+	require.Equal(t,
+		"if ([\"https://example.com\",\"http://localhost:8080\"].includes(location.origin)) {\nwindow.a = 1\n}",
+		scopeInitScript("window.a = 1", []string{"https://example.com/", "http://localhost:8080"}, false))
+	require.Equal(t,
+		"if ([\"https://example.com\"].includes(location.origin) && window === window.top) {\nwindow.a = 1\n}",
+		scopeInitScript("window.a = 1", []string{"https://example.com"}, true))
+	require.Equal(t, "if (window === window.top) {\nwindow.a = 1\n}", scopeInitScript("window.a = 1", nil, true))
+}
+
+func TestGetBySelectors(t *testing.T) {
+	require.Equal(t, `css=:text("Say \"hi\"")`, getByTextSelector(`Say "hi"`))
+	require.Equal(t, `css=:text-is("Hello")`, getByTextSelector("Hello", GetByTextOptions{Exact: Bool(true)}))
+	require.Equal(t, `css=:text-matches("hel+o", "i")`, getByTextSelector(regexp.MustCompile(`(?i)hel+o`)))
+	require.Equal(t, `getby-role={"role":"button"}`, getByRoleSelector("button"))
+	require.Equal(t,
+		`getby-role={"checked":true,"level":2,"name":{"exact":true,"text":"Submit"},"role":"heading"}`,
+		getByRoleSelector("heading", GetByRoleOptions{Name: "Submit", Exact: Bool(true), Checked: Bool(true), Level: Int(2)}),
+	)
+	require.Equal(t, `getby-role={"name":{"regex":["sub","i"]},"role":"button"}`,
+		getByRoleSelector("button", GetByRoleOptions{Name: regexp.MustCompile(`(?i)sub`)}))
+	require.Equal(t, `getby-label={"exact":false,"text":"Email"}`, getByLabelSelector("Email"))
+	require.Equal(t, `getby-attribute={"attribute":"placeholder","exact":true,"text":"Search"}`,
+		getByAttributeSelector("placeholder", "Search", GetByTextOptions{Exact: Bool(true)}))
+}
+
+func TestGetByTestIdSelector(t *testing.T) {
+	require.Equal(t, `getby-attribute={"attribute":"data-testid","exact":true,"text":"submit"}`, getByTestIdSelector("submit"))
+	SetTestIdAttribute("data-qa")
+	defer SetTestIdAttribute("data-testid")
+	require.Equal(t, `getby-attribute={"attribute":"data-qa","exact":true,"text":"submit"}`, getByTestIdSelector("submit"))
+}
+
+func TestScopedHeaders(t *testing.T) {
+	require.Equal(t, map[string]string{"accept": "*/*", "Authorization": "token"},
+		mergeHeaders(map[string]string{"accept": "*/*", "authorization": "old"}, map[string]string{"Authorization": "token"}))
+	var entries []*scopedHeaders
+	entries = setScopedHeaders(entries, "https://api.example.com/**", map[string]string{"Authorization": "token"}, false)
+	entries = setScopedHeaders(entries, "https://api.example.com/**", map[string]string{"X-Trace": "1"}, true)
+	entries = setScopedHeaders(entries, regexp.MustCompile(`/admin/`), map[string]string{"Authorization": "admin"}, false)
+	require.Len(t, entries, 2)
+	require.Equal(t, map[string]string{"Authorization": "token", "X-Trace": "1"}, scopedHeadersForURL(entries, "https://api.example.com/users"))
+	require.Equal(t, map[string]string{"Authorization": "admin", "X-Trace": "1"}, scopedHeadersForURL(entries, "https://api.example.com/admin/users"))
+	require.Empty(t, scopedHeadersForURL(entries, "https://cdn.example.com/app.js"))
+	entries = setScopedHeaders(entries, "https://api.example.com/**", nil, false)
+	require.Len(t, entries, 1)
+}
+
+func TestReadAsset(t *testing.T) {
+	fsys := fstest.MapFS{
+		"scripts/helper.js": &fstest.MapFile{Data: []byte("window.helper = 1")},
+	}
+	content, err := readAsset(fsys, "scripts/helper.js")
+	require.NoError(t, err)
+	require.Equal(t, "window.helper = 1", string(content))
+	_, err = readAsset(fsys, "scripts/missing.js")
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+
+	path := filepath.Join(t.TempDir(), "helper.js")
+	require.NoError(t, ioutil.WriteFile(path, []byte("window.helper = 2"), 0644))
+	content, err = readAsset(nil, path)
+	require.NoError(t, err)
+	require.Equal(t, "window.helper = 2", string(content))
+}
+
+func TestDispatchRouteTimes(t *testing.T) {
+	request := &requestImpl{}
+	request.initializer = map[string]interface{}{"url": "https://example.com/api"}
+	calls := 0
+	entry := newRouteHandlerEntry(newURLMatcher("**/api"), func(route Route, request Request) {
+		calls++
+	}, Int(2))
+	fallback := newRouteHandlerEntry(newURLMatcher("**/*"), func(route Route, request Request) {})
+	routes := []*routeHandlerEntry{entry, fallback}
+
+	handled, expired := dispatchRoute(routes, &routeImpl{}, request)
+	require.True(t, handled)
+	require.Nil(t, expired)
+	handled, expired = dispatchRoute(routes, &routeImpl{}, request)
+	require.True(t, handled)
+	require.Equal(t, entry, expired)
+	handled, expired = dispatchRoute(routes, &routeImpl{}, request)
+	require.True(t, handled)
+	require.Nil(t, expired)
+	require.Equal(t, 2, calls)
+	require.Equal(t, []*routeHandlerEntry{fallback}, removeRouteHandlerEntry(routes, entry))
+
+	unrouteAll(routes, nil)
+	handled, _ = dispatchRoute(routes, &routeImpl{}, request)
+	require.False(t, handled)
+}
+
+func TestUnrouteAllBehavior(t *testing.T) {
+	request := &requestImpl{}
+	request.initializer = map[string]interface{}{"url": "https://example.com/"}
+	started := make(chan *routeImpl)
+	finish := make(chan bool)
+	entry := newRouteHandlerEntry(newURLMatcher("**/*"), func(route Route, request Request) {
+		started <- route.(*routeImpl)
+		<-finish
+	})
+	go dispatchRoute([]*routeHandlerEntry{entry}, &routeImpl{}, request)
+	route := <-started
+
+	unrouteAll([]*routeHandlerEntry{entry}, UnrouteBehaviorIgnoreErrors)
+	require.NoError(t, route.checkError(errors.New("target closed")))
+
+	waited := make(chan bool)
+	go func() {
+		unrouteAll([]*routeHandlerEntry{entry}, UnrouteBehaviorWait)
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("UnrouteAll returned before the handler finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(finish)
+	<-waited
+}
+
+func TestSerializeArgumentNumbers(t *testing.T) {
+	for _, value := range []interface{}{int64(1577836800000), float64(1.5), int32(3), uint(4)} {
+		serialized := serializeArgument(value).(map[string]interface{})
+		require.Equal(t, map[string]interface{}{"n": value}, serialized["value"])
+	}
+}
+
+func TestRouteHandlerEntryRemainingTimes(t *testing.T) {
+	entry := newRouteHandlerEntry(newURLMatcher("**/*"), nil, Int(2))
+	times, ok := entry.remainingTimes()
+	require.True(t, ok)
+	require.Equal(t, 2, *times)
+	claimed, _ := entry.claim(&routeImpl{})
+	require.True(t, claimed)
+	times, ok = entry.remainingTimes()
+	require.True(t, ok)
+	require.Equal(t, 1, *times)
+	claimed, _ = entry.claim(&routeImpl{})
+	require.True(t, claimed)
+	_, ok = entry.remainingTimes()
+	require.False(t, ok)
+	times, ok = newRouteHandlerEntry(newURLMatcher("**/*"), nil).remainingTimes()
+	require.True(t, ok)
+	require.Nil(t, times)
+}
+
+func TestRouteHandlerEntryClone(t *testing.T) {
+	entry := newRouteHandlerEntry(newURLMatcher("**/*"), nil, Int(3))
+	claimed, _ := entry.claim(&routeImpl{})
+	require.True(t, claimed)
+	clone := entry.clone()
+	require.Equal(t, 3, clone.times)
+	require.Equal(t, 1, clone.handled)
+	times, ok := clone.remainingTimes()
+	require.True(t, ok)
+	require.Equal(t, 2, *times)
+}
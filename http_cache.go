@@ -0,0 +1,52 @@
+package playwright
+
+import "strings"
+
+func (b *browserContextImpl) SetHTTPCacheDisabled(disabled bool) error {
+	// Enabling the network interception makes the browsers bypass their HTTP
+	// cache, requests which no route handles just get continued.
+	return b.updateInterception(func() {
+		b.httpCacheDisabled = disabled
+	})
+}
+
+func (p *pageImpl) ClearBrowserCache() error {
+	session, err := p.chromiumSession("clearing the browser cache is")
+	if err != nil {
+		return err
+	}
+	return session.Network().ClearBrowserCache()
+}
+
+func (p *pageImpl) ClearStorageForOrigin(origin string, storageTypes ...string) error {
+	session, err := p.chromiumSession("clearing the storage is")
+	if err != nil {
+		return err
+	}
+	types := "all"
+	if len(storageTypes) > 0 {
+		types = strings.Join(storageTypes, ",")
+	}
+	return session.Storage().ClearDataForOrigin(origin, types)
+}
+
+// fromCacheScript looks the response up in the resource timing entries of
+// the frame, nothing got transferred when the body came from the cache.
+const fromCacheScript = `url => {
+  const entries = performance.getEntriesByName(url);
+  const entry = entries[entries.length - 1];
+  if (!entry || entry.transferSize === undefined)
+    return false;
+  return entry.transferSize === 0 && entry.decodedBodySize > 0;
+}`
+
+func (r *responseImpl) FromCache() (bool, error) {
+	if r.Status() == 304 {
+		return true, nil
+	}
+	result, err := r.Frame().Evaluate(fromCacheScript, r.URL())
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
@@ -0,0 +1,244 @@
+package playwright
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const inputRecordingBinding = "__playwrightInputRecording"
+
+// inputRecordingScript reports the trusted mouse and keyboard events of the
+// top level document to the binding whose name is passed in. The calls get
+// chained so that they arrive in order, the flush function resolves once all
+// of them got handled.
+const inputRecordingScript = `binding => {
+  if (window !== window.top || window[binding + 'Flush'])
+    return;
+  let last = Promise.resolve();
+  const report = event => {
+    last = last.then(() => window[binding](event)).catch(() => {});
+  };
+  window[binding + 'Flush'] = () => last;
+  const onMouse = event => {
+    if (!event.isTrusted)
+      return;
+    report({ type: event.type, time: Date.now(), x: event.clientX, y: event.clientY, button: event.button, clickCount: event.detail });
+  };
+  const onKey = event => {
+    if (!event.isTrusted)
+      return;
+    report({ type: event.type, time: Date.now(), key: event.key });
+  };
+  for (const type of ['mousemove', 'mousedown', 'mouseup'])
+    window.addEventListener(type, onMouse, true);
+  for (const type of ['keydown', 'keyup'])
+    window.addEventListener(type, onKey, true);
+}`
+
+var inputRecordingCounter int32
+
+// InputEvent is a low-level input event of an InputRecording.
+type InputEvent struct {
+	// One of "mousemove", "mousedown", "mouseup", "keydown" or "keyup".
+	Type string `json:"type"`
+	// Time since the start of the recording.
+	Time time.Duration `json:"time"`
+	// Position of the mouse events in CSS pixels relative to the viewport.
+	X float64 `json:"x,omitempty"`
+	Y float64 `json:"y,omitempty"`
+	// Button of the mousedown and mouseup events.
+	Button *MouseButton `json:"button,omitempty"`
+	// Click count of the mousedown and mouseup events, 2 for a double click.
+	ClickCount int `json:"clickCount,omitempty"`
+	// Key of the keydown and keyup events, e.g. "a" or "Enter".
+	Key string `json:"key,omitempty"`
+}
+
+// InputRecording records the mouse and keyboard events which get performed on
+// a page, e.g. by hand in a headed browser, so that they can be saved and
+// replayed later on to reproduce gesture-heavy bugs. Only the events of the
+// top level document get recorded, wheel events and events inside of iframes
+// are not supported.
+type InputRecording struct {
+	sync.Mutex
+	page      Page
+	binding   string
+	start     time.Time
+	recording bool
+	events    []InputEvent
+}
+
+// RecordInput starts recording the input events of the page, including the
+// ones of documents which get navigated to later on. Call Stop() on the
+// returned recording to end it.
+func RecordInput(page Page) (*InputRecording, error) {
+	r := &InputRecording{
+		page:      page,
+		binding:   fmt.Sprintf("%s%d", inputRecordingBinding, atomic.AddInt32(&inputRecordingCounter, 1)),
+		start:     time.Now(),
+		recording: true,
+	}
+	if err := page.ExposeBinding(r.binding, r.onEvent); err != nil {
+		return nil, fmt.Errorf("could not record input: %w", err)
+	}
+	if err := addInitFunction(func(script string) error {
+		return page.AddInitScript(PageAddInitScriptOptions{
+			Script: String(script),
+		})
+	}, []Page{page}, inputRecordingScript, r.binding); err != nil {
+		return nil, fmt.Errorf("could not record input: %w", err)
+	}
+	return r, nil
+}
+
+// LoadInputRecording loads a recording which got saved with Save().
+func LoadInputRecording(path string) (*InputRecording, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load input recording: %w", err)
+	}
+	var events []InputEvent
+	if err := json.Unmarshal(content, &events); err != nil {
+		return nil, fmt.Errorf("could not load input recording: %w", err)
+	}
+	return &InputRecording{events: events}, nil
+}
+
+func (r *InputRecording) onEvent(source *BindingSource, args ...interface{}) interface{} {
+	data, ok := args[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	if !r.recording {
+		return nil
+	}
+	r.events = append(r.events, parseInputEvent(data, r.start))
+	return nil
+}
+
+// parseInputEvent converts an event reported by the inputRecordingScript, its
+// time is the wall clock time of the page in milliseconds.
+func parseInputEvent(data map[string]interface{}, start time.Time) InputEvent {
+	number := func(key string) float64 {
+		switch v := data[key].(type) {
+		case int:
+			return float64(v)
+		case float64:
+			return v
+		}
+		return 0
+	}
+	event := InputEvent{}
+	event.Type, _ = data["type"].(string)
+	event.Time = time.Duration(number("time")*float64(time.Millisecond)) - time.Duration(start.UnixNano())
+	if event.Time < 0 {
+		event.Time = 0
+	}
+	switch event.Type {
+	case "mousemove", "mousedown", "mouseup":
+		event.X = number("x")
+		event.Y = number("y")
+		if event.Type != "mousemove" {
+			event.Button = []*MouseButton{MouseButtonLeft, MouseButtonMiddle, MouseButtonRight}[int(number("button"))%3]
+			event.ClickCount = int(number("clickCount"))
+		}
+	case "keydown", "keyup":
+		event.Key, _ = data["key"].(string)
+	}
+	return event
+}
+
+// Stop ends the recording once all events which were performed so far got
+// recorded.
+func (r *InputRecording) Stop() error {
+	r.Lock()
+	page := r.page
+	r.Unlock()
+	if page != nil && !page.IsClosed() {
+		if _, err := page.Evaluate(`binding => window[binding + 'Flush'] && window[binding + 'Flush']()`, r.binding); err != nil {
+			return fmt.Errorf("could not stop input recording: %w", err)
+		}
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.recording = false
+	return nil
+}
+
+// Events returns a copy of the recorded events.
+func (r *InputRecording) Events() []InputEvent {
+	r.Lock()
+	defer r.Unlock()
+	return append([]InputEvent{}, r.events...)
+}
+
+// Save writes the recorded events as JSON to the given path.
+func (r *InputRecording) Save(path string) error {
+	content, err := json.MarshalIndent(r.Events(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not save input recording: %w", err)
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("could not save input recording: %w", err)
+	}
+	return nil
+}
+
+// Replay performs the recorded events on the page with the original timing,
+// which can be scaled with the Speed option.
+func (r *InputRecording) Replay(page Page, options ...InputRecordingReplayOptions) error {
+	speed := 1.0
+	if len(options) == 1 && options[0].Speed != nil {
+		speed = *options[0].Speed
+	}
+	events := r.Events()
+	start := time.Now()
+	moved := false
+	var x, y float64
+	for _, event := range events {
+		if speed > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(float64(event.Time) / speed))))
+		}
+		var err error
+		switch event.Type {
+		case "mousemove":
+			err = page.Mouse().Move(event.X, event.Y)
+		case "mousedown", "mouseup":
+			if !moved || x != event.X || y != event.Y {
+				if err = page.Mouse().Move(event.X, event.Y); err != nil {
+					break
+				}
+			}
+			clickCount := Int(event.ClickCount)
+			if event.ClickCount < 1 {
+				clickCount = nil
+			}
+			if event.Type == "mousedown" {
+				err = page.Mouse().Down(MouseDownOptions{Button: event.Button, ClickCount: clickCount})
+			} else {
+				err = page.Mouse().Up(MouseUpOptions{Button: event.Button, ClickCount: clickCount})
+			}
+		case "keydown":
+			err = page.Keyboard().Down(event.Key)
+		case "keyup":
+			err = page.Keyboard().Up(event.Key)
+		default:
+			err = errors.New("unknown input event type: " + event.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("could not replay input recording: %w", err)
+		}
+		if event.Type != "keydown" && event.Type != "keyup" {
+			moved = true
+			x, y = event.X, event.Y
+		}
+	}
+	return nil
+}
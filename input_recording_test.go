@@ -0,0 +1,43 @@
+package playwright
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInputEvent(t *testing.T) {
+	start := time.Unix(1000, 0)
+	require.Equal(t, InputEvent{
+		Type:       "mousedown",
+		Time:       250 * time.Millisecond,
+		X:          10,
+		Y:          20.5,
+		Button:     MouseButtonRight,
+		ClickCount: 2,
+	}, parseInputEvent(map[string]interface{}{
+		"type": "mousedown", "time": 1000250, "x": 10, "y": 20.5, "button": 2, "clickCount": 2,
+	}, start))
+	require.Equal(t, InputEvent{Type: "mousemove", Time: 1500 * time.Millisecond, X: 1, Y: 2}, parseInputEvent(map[string]interface{}{
+		"type": "mousemove", "time": 1001500, "x": 1, "y": 2, "button": 0, "clickCount": 0,
+	}, start))
+	require.Equal(t, InputEvent{Type: "keyup", Key: "Enter"}, parseInputEvent(map[string]interface{}{
+		"type": "keyup", "time": 999000, "key": "Enter",
+	}, start))
+}
+
+func TestInputRecordingSaveLoad(t *testing.T) {
+	recording := &InputRecording{events: []InputEvent{
+		{Type: "mousedown", Time: time.Second, X: 5, Y: 6, Button: MouseButtonLeft, ClickCount: 1},
+		{Type: "keydown", Time: 2 * time.Second, Key: "a"},
+	}}
+	path := filepath.Join(t.TempDir(), "input.json")
+	require.NoError(t, recording.Save(path))
+	loaded, err := LoadInputRecording(path)
+	require.NoError(t, err)
+	require.Equal(t, recording.Events(), loaded.Events())
+	_, err = LoadInputRecording(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -58,11 +58,10 @@ func (j *jsHandleImpl) EvaluateHandle(expression string, options ...interface{})
 	if err != nil {
 		return nil, err
 	}
-	channelOwner := fromChannel(result)
-	if channelOwner == nil {
+	if result == nil {
 		return nil, nil
 	}
-	return channelOwner.(*jsHandleImpl), nil
+	return fromChannel(result).(JSHandle), nil
 }
 
 func (j *jsHandleImpl) GetProperty(name string) (JSHandle, error) {
@@ -72,7 +71,10 @@ func (j *jsHandleImpl) GetProperty(name string) (JSHandle, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromChannel(channel).(*jsHandleImpl), nil
+	if channel == nil {
+		return nil, nil
+	}
+	return fromChannel(channel).(JSHandle), nil
 }
 
 func (j *jsHandleImpl) GetProperties() (map[string]JSHandle, error) {
@@ -83,7 +85,7 @@ func (j *jsHandleImpl) GetProperties() (map[string]JSHandle, error) {
 	propertiesMap := make(map[string]JSHandle)
 	for _, property := range properties.([]interface{}) {
 		item := property.(map[string]interface{})
-		propertiesMap[item["name"].(string)] = fromChannel(item["value"]).(*jsHandleImpl)
+		propertiesMap[item["name"].(string)] = fromChannel(item["value"]).(JSHandle)
 	}
 	return propertiesMap, nil
 }
@@ -235,7 +237,7 @@ func serializeValue(value interface{}, handles *[]*channel, depth int) interface
 		return map[string]interface{}{
 			"d": v.Format(time.RFC3339) + "Z",
 		}
-	case int:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		return map[string]interface{}{
 			"n": v,
 		}
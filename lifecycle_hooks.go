@@ -0,0 +1,73 @@
+package playwright
+
+import (
+	"fmt"
+	"sync"
+)
+
+// lifecycleHooks are the hooks registered on a Playwright instance. Instances
+// created by BrowserType.Connect() share them with the instance they got
+// connected from.
+type lifecycleHooks struct {
+	sync.Mutex
+	browserLaunched []func(browser Browser)
+	contextCreated  []func(context BrowserContext)
+}
+
+// OnBrowserLaunched registers a hook which gets called with every browser
+// returned by BrowserType.Launch() and BrowserType.Connect().
+func (p *Playwright) OnBrowserLaunched(hook func(browser Browser)) {
+	p.hooks.Lock()
+	defer p.hooks.Unlock()
+	p.hooks.browserLaunched = append(p.hooks.browserLaunched, hook)
+}
+
+// OnContextCreated registers a hook which gets called with every browser
+// context returned by Browser.NewContext() and
+// BrowserType.LaunchPersistentContext(), once its options got applied. Use it
+// to register BrowserContext.OnBeforeClose() hooks on all contexts.
+func (p *Playwright) OnContextCreated(hook func(context BrowserContext)) {
+	p.hooks.Lock()
+	defer p.hooks.Unlock()
+	p.hooks.contextCreated = append(p.hooks.contextCreated, hook)
+}
+
+func (h *lifecycleHooks) onBrowserLaunched(browser Browser) {
+	h.Lock()
+	hooks := h.browserLaunched
+	h.Unlock()
+	for _, hook := range hooks {
+		hook(browser)
+	}
+}
+
+func (h *lifecycleHooks) onContextCreated(context BrowserContext) {
+	h.Lock()
+	hooks := h.contextCreated
+	h.Unlock()
+	for _, hook := range hooks {
+		hook(context)
+	}
+}
+
+func (b *browserContextImpl) OnBeforeClose(hook func(context BrowserContext) error) {
+	b.Lock()
+	defer b.Unlock()
+	b.beforeCloseHooks = append(b.beforeCloseHooks, hook)
+}
+
+// runBeforeCloseHooks calls all before close hooks once, even if one of them
+// fails, and returns the first error.
+func (b *browserContextImpl) runBeforeCloseHooks() error {
+	b.Lock()
+	hooks := b.beforeCloseHooks
+	b.beforeCloseHooks = nil
+	b.Unlock()
+	var firstErr error
+	for _, hook := range hooks {
+		if err := hook(b); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("before close hook failed: %w", err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,43 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBeforeCloseHooks(t *testing.T) {
+	context := &browserContextImpl{}
+	calls := 0
+	context.OnBeforeClose(func(BrowserContext) error {
+		calls++
+		return errors.New("first")
+	})
+	context.OnBeforeClose(func(BrowserContext) error {
+		calls++
+		return errors.New("second")
+	})
+	require.EqualError(t, context.runBeforeCloseHooks(), "before close hook failed: first")
+	require.Equal(t, 2, calls)
+	require.NoError(t, context.runBeforeCloseHooks())
+	require.Equal(t, 2, calls)
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	pw := &Playwright{hooks: &lifecycleHooks{}}
+	launched := []Browser{}
+	created := []BrowserContext{}
+	pw.OnBrowserLaunched(func(browser Browser) {
+		launched = append(launched, browser)
+	})
+	pw.OnContextCreated(func(context BrowserContext) {
+		created = append(created, context)
+	})
+	browser := &browserImpl{}
+	context := &browserContextImpl{}
+	pw.hooks.onBrowserLaunched(browser)
+	pw.hooks.onContextCreated(context)
+	require.Equal(t, []Browser{browser}, launched)
+	require.Equal(t, []BrowserContext{context}, created)
+}
@@ -0,0 +1,174 @@
+package playwright
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ContextLimits are guardrails for a browser context, e.g. of a crawler,
+// which protect against runaway pages. When a limit is exceeded the context
+// gets closed and the *LimitExceededError is returned by
+// BrowserContext.LimitError().
+type ContextLimits struct {
+	// Maximum number of pages which are open at the same time, including
+	// popups.
+	MaxPages *int
+	// Maximum number of navigations of the main frames of the pages to new
+	// documents.
+	MaxNavigations *int
+	// Maximum wall-clock time the context may be open for.
+	MaxDuration *time.Duration
+	// Maximum number of bytes received, counted like
+	// NetworkStats.BytesReceived by the Content-Length of the responses.
+	MaxBytesReceived *int64
+}
+
+// LimitExceededError is the error a browser context got closed with because
+// one of its ContextLimits was exceeded.
+type LimitExceededError struct {
+	// The limit which was exceeded.
+	Limit ContextLimit
+	// Description of the limit, e.g. "more than 10 pages".
+	Description string
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("context limit exceeded: %s", e.Description)
+}
+
+// contextLimiter enforces the ContextLimits of a browser context. It gets
+// driven from the event handlers of the context.
+type contextLimiter struct {
+	sync.Mutex
+	context     *browserContextImpl
+	limits      ContextLimits
+	navigations int
+	bytes       int64
+	timer       *time.Timer
+	err         *LimitExceededError
+}
+
+func newContextLimiter(context *browserContextImpl, limits ContextLimits) *contextLimiter {
+	limiter := &contextLimiter{
+		context: context,
+		limits:  limits,
+	}
+	if limits.MaxDuration != nil {
+		limiter.Lock()
+		limiter.timer = time.AfterFunc(*limits.MaxDuration, func() {
+			limiter.exceeded(ContextLimitDuration, fmt.Sprintf("open for more than %s", *limits.MaxDuration))
+		})
+		limiter.Unlock()
+	}
+	return limiter
+}
+
+// applyLimits starts enforcing limits, the pages which are open already, e.g.
+// the initial page of a persistent context, count towards them.
+func (b *browserContextImpl) applyLimits(limits ContextLimits) {
+	limiter := newContextLimiter(b, limits)
+	b.Lock()
+	b.limiter = limiter
+	pages := len(b.pages)
+	b.Unlock()
+	limiter.checkPages(pages)
+}
+
+func (b *browserContextImpl) contextLimiter() *contextLimiter {
+	b.Lock()
+	defer b.Unlock()
+	return b.limiter
+}
+
+func (b *browserContextImpl) LimitError() error {
+	if limiter := b.contextLimiter(); limiter != nil {
+		limiter.Lock()
+		defer limiter.Unlock()
+		if limiter.err != nil {
+			return limiter.err
+		}
+	}
+	return nil
+}
+
+// checkNewPage returns the error for a page which would exceed the limit of
+// pages, before the page gets created.
+func (l *contextLimiter) checkNewPage(pages int) error {
+	if l == nil {
+		return nil
+	}
+	l.checkPages(pages + 1)
+	l.Lock()
+	defer l.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	return nil
+}
+
+func (l *contextLimiter) checkPages(pages int) {
+	if l == nil || l.limits.MaxPages == nil || pages <= *l.limits.MaxPages {
+		return
+	}
+	l.exceeded(ContextLimitPages, fmt.Sprintf("more than %d pages", *l.limits.MaxPages))
+}
+
+func (l *contextLimiter) onNavigation() {
+	if l == nil || l.limits.MaxNavigations == nil {
+		return
+	}
+	l.Lock()
+	l.navigations++
+	exceeded := l.navigations > *l.limits.MaxNavigations
+	l.Unlock()
+	if exceeded {
+		l.exceeded(ContextLimitNavigations, fmt.Sprintf("more than %d navigations", *l.limits.MaxNavigations))
+	}
+}
+
+func (l *contextLimiter) onResponse(response *responseImpl) {
+	if l == nil || l.limits.MaxBytesReceived == nil {
+		return
+	}
+	length, _ := strconv.ParseInt(response.Headers()["content-length"], 10, 64)
+	l.Lock()
+	l.bytes += length
+	exceeded := l.bytes > *l.limits.MaxBytesReceived
+	l.Unlock()
+	if exceeded {
+		l.exceeded(ContextLimitBytesReceived, fmt.Sprintf("more than %d bytes received", *l.limits.MaxBytesReceived))
+	}
+}
+
+// exceeded records the first exceeded limit and closes the context. Closing
+// waits for the driver, so it must not block the goroutine which dispatches
+// the events.
+func (l *contextLimiter) exceeded(limit *ContextLimit, description string) {
+	l.Lock()
+	if l.err != nil {
+		l.Unlock()
+		return
+	}
+	l.err = &LimitExceededError{Limit: *limit, Description: description}
+	l.Unlock()
+	l.stop()
+	go func() {
+		if err := l.context.Close(); err != nil {
+			log.Printf("could not close context after exceeding its limits: %v", err)
+		}
+	}()
+}
+
+func (l *contextLimiter) stop() {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+}
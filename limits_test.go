@@ -0,0 +1,75 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLimiter returns a limiter of a context which counts as closed
+// already, so exceeding a limit does not talk to the driver.
+func newTestLimiter(limits ContextLimits) (*browserContextImpl, *contextLimiter) {
+	context := &browserContextImpl{isClosedOrClosing: true}
+	context.applyLimits(limits)
+	return context, context.limiter
+}
+
+func newTestResponse(contentLength string) *responseImpl {
+	response := &responseImpl{}
+	response.initializer = map[string]interface{}{
+		"headers": []interface{}{
+			map[string]interface{}{"name": "Content-Length", "value": contentLength},
+		},
+	}
+	return response
+}
+
+func TestContextLimiterPages(t *testing.T) {
+	context, limiter := newTestLimiter(ContextLimits{MaxPages: Int(2)})
+	require.NoError(t, limiter.checkNewPage(1))
+	limiter.checkPages(2)
+	require.NoError(t, context.LimitError())
+	err := limiter.checkNewPage(2)
+	require.EqualError(t, err, "context limit exceeded: more than 2 pages")
+	var limitErr *LimitExceededError
+	require.True(t, errors.As(context.LimitError(), &limitErr))
+	require.Equal(t, *ContextLimitPages, limitErr.Limit)
+}
+
+func TestContextLimiterNavigationsAndBytes(t *testing.T) {
+	context, limiter := newTestLimiter(ContextLimits{MaxNavigations: Int(2)})
+	limiter.onNavigation()
+	limiter.onNavigation()
+	require.NoError(t, context.LimitError())
+	limiter.onNavigation()
+	require.EqualError(t, context.LimitError(), "context limit exceeded: more than 2 navigations")
+
+	maxBytes := int64(1000)
+	context, limiter = newTestLimiter(ContextLimits{MaxBytesReceived: &maxBytes})
+	limiter.onResponse(newTestResponse("600"))
+	require.NoError(t, context.LimitError())
+	limiter.onResponse(newTestResponse("600"))
+	require.EqualError(t, context.LimitError(), "context limit exceeded: more than 1000 bytes received")
+	// only the first exceeded limit gets reported
+	limiter.checkPages(100)
+	require.Equal(t, *ContextLimitBytesReceived, context.LimitError().(*LimitExceededError).Limit)
+}
+
+func TestContextLimiterDuration(t *testing.T) {
+	maxDuration := 10 * time.Millisecond
+	context, _ := newTestLimiter(ContextLimits{MaxDuration: &maxDuration})
+	require.Eventually(t, func() bool {
+		return context.LimitError() != nil
+	}, time.Second, 5*time.Millisecond)
+	require.EqualError(t, context.LimitError(), "context limit exceeded: open for more than 10ms")
+}
+
+func TestContextWithoutLimits(t *testing.T) {
+	context := &browserContextImpl{}
+	require.NoError(t, context.contextLimiter().checkNewPage(100))
+	context.contextLimiter().onNavigation()
+	context.contextLimiter().stop()
+	require.NoError(t, context.LimitError())
+}
@@ -0,0 +1,422 @@
+package playwright
+
+import "fmt"
+
+type locatorImpl struct {
+	frame    *frameImpl
+	selector string
+}
+
+func newLocator(frame *frameImpl, selector string) *locatorImpl {
+	return &locatorImpl{
+		frame:    frame,
+		selector: selector,
+	}
+}
+
+func (l *locatorImpl) String() string {
+	return fmt.Sprintf("Locator@%s", l.selector)
+}
+
+func (l *locatorImpl) ElementHandle(options ...LocatorElementHandleOptions) (ElementHandle, error) {
+	option := PageWaitForSelectorOptions{
+		State:  WaitForSelectorStateAttached,
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.WaitForSelector(l.selector, option)
+}
+
+// withElement resolves the locator and runs fn with the element, which gets
+// disposed afterwards.
+func (l *locatorImpl) withElement(fn func(element ElementHandle) error, timeout *float64) error {
+	element, err := l.ElementHandle(LocatorElementHandleOptions{
+		Timeout: timeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer element.Dispose()
+	return fn(element)
+}
+
+func (l *locatorImpl) BoundingBox(options ...LocatorBoundingBoxOptions) (*Rect, error) {
+	option := LocatorBoundingBoxOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var rect *Rect
+	err := l.withElement(func(element ElementHandle) error {
+		var err error
+		rect, err = element.BoundingBox(ElementHandleBoundingBoxOptions{
+			Space: option.Space,
+		})
+		return err
+	}, option.Timeout)
+	return rect, err
+}
+
+func (l *locatorImpl) SelectText(options ...LocatorSelectTextOptions) error {
+	option := LocatorSelectTextOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return l.withElement(func(element ElementHandle) error {
+		return element.SelectText(ElementHandleSelectTextOptions{
+			Force:   option.Force,
+			Timeout: option.Timeout,
+		})
+	}, option.Timeout)
+}
+
+func (l *locatorImpl) PressSequentially(text string, options ...LocatorPressSequentiallyOptions) error {
+	option := PageTypeOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Delay = options[0].Delay
+		option.NoWaitAfter = options[0].NoWaitAfter
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.Type(l.selector, text, option)
+}
+
+func (l *locatorImpl) GetAttribute(name string, options ...LocatorGetAttributeOptions) (string, error) {
+	option := PageGetAttributeOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.GetAttribute(l.selector, name, option)
+}
+
+func (l *locatorImpl) InnerHTML(options ...LocatorInnerHTMLOptions) (string, error) {
+	option := PageInnerHTMLOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.InnerHTML(l.selector, option)
+}
+
+func (l *locatorImpl) InnerText(options ...LocatorInnerTextOptions) (string, error) {
+	option := PageInnerTextOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.InnerText(l.selector, option)
+}
+
+func (l *locatorImpl) InputValue(options ...LocatorInputValueOptions) (string, error) {
+	option := FrameInputValueOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.InputValue(l.selector, option)
+}
+
+func (l *locatorImpl) IsChecked(options ...LocatorIsCheckedOptions) (bool, error) {
+	option := FrameIsCheckedOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsChecked(l.selector, option)
+}
+
+func (l *locatorImpl) IsDisabled(options ...LocatorIsDisabledOptions) (bool, error) {
+	option := FrameIsDisabledOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsDisabled(l.selector, option)
+}
+
+func (l *locatorImpl) IsEditable(options ...LocatorIsEditableOptions) (bool, error) {
+	option := FrameIsEditableOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsEditable(l.selector, option)
+}
+
+func (l *locatorImpl) IsEnabled(options ...LocatorIsEnabledOptions) (bool, error) {
+	option := FrameIsEnabledOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsEnabled(l.selector, option)
+}
+
+func (l *locatorImpl) IsHidden(options ...LocatorIsHiddenOptions) (bool, error) {
+	option := FrameIsHiddenOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsHidden(l.selector, option)
+}
+
+func (l *locatorImpl) IsVisible(options ...LocatorIsVisibleOptions) (bool, error) {
+	option := FrameIsVisibleOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.IsVisible(l.selector, option)
+}
+
+func (l *locatorImpl) TextContent(options ...LocatorTextContentOptions) (string, error) {
+	option := FrameTextContentOptions{
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	return l.frame.TextContent(l.selector, option)
+}
+
+// send sends method to the frame of the locator. The selector gets resolved
+// in strict mode, so the call fails when it matches more than one element.
+func (l *locatorImpl) send(method string, params map[string]interface{}, options interface{}) (interface{}, error) {
+	params["selector"] = l.selector
+	params["strict"] = true
+	return l.frame.channel.Send(method, params, options)
+}
+
+func (l *locatorImpl) Locator(selector string) Locator {
+	return newLocator(l.frame, l.selector+" >> "+selector)
+}
+
+func (l *locatorImpl) First() Locator {
+	return l.Nth(0)
+}
+
+func (l *locatorImpl) Last() Locator {
+	return l.Nth(-1)
+}
+
+func (l *locatorImpl) Nth(index int) Locator {
+	return newLocator(l.frame, fmt.Sprintf("%s >> nth=%d", l.selector, index))
+}
+
+func (l *locatorImpl) Page() Page {
+	return l.frame.Page()
+}
+
+func (l *locatorImpl) Count() (int, error) {
+	count, err := l.frame.EvalOnSelectorAll(l.selector, "ee => ee.length")
+	if err != nil {
+		return 0, err
+	}
+	switch v := count.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	}
+	return 0, fmt.Errorf("unexpected count: %v", count)
+}
+
+func (l *locatorImpl) ElementHandles() ([]ElementHandle, error) {
+	return l.frame.QuerySelectorAll(l.selector)
+}
+
+func (l *locatorImpl) AllInnerTexts() ([]string, error) {
+	texts, err := l.frame.EvalOnSelectorAll(l.selector, "ee => ee.map(e => e.innerText)")
+	if err != nil {
+		return nil, err
+	}
+	return transformToStringList(texts), nil
+}
+
+func (l *locatorImpl) AllTextContents() ([]string, error) {
+	texts, err := l.frame.EvalOnSelectorAll(l.selector, "ee => ee.map(e => e.textContent || '')")
+	if err != nil {
+		return nil, err
+	}
+	return transformToStringList(texts), nil
+}
+
+func (l *locatorImpl) Evaluate(expression string, arg interface{}, options ...LocatorEvaluateOptions) (interface{}, error) {
+	option := LocatorEvaluateOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var result interface{}
+	err := l.withElement(func(element ElementHandle) error {
+		var err error
+		result, err = element.Evaluate(expression, arg)
+		return err
+	}, option.Timeout)
+	return result, err
+}
+
+func (l *locatorImpl) EvaluateAll(expression string, options ...interface{}) (interface{}, error) {
+	return l.frame.EvalOnSelectorAll(l.selector, expression, options...)
+}
+
+func (l *locatorImpl) EvaluateHandle(expression string, arg interface{}, options ...LocatorEvaluateHandleOptions) (JSHandle, error) {
+	option := LocatorEvaluateHandleOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var result JSHandle
+	err := l.withElement(func(element ElementHandle) error {
+		var err error
+		result, err = element.EvaluateHandle(expression, arg)
+		return err
+	}, option.Timeout)
+	return result, err
+}
+
+func (l *locatorImpl) WaitFor(options ...LocatorWaitForOptions) error {
+	option := PageWaitForSelectorOptions{
+		State:  WaitForSelectorStateVisible,
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		if options[0].State != nil {
+			option.State = options[0].State
+		}
+		option.Context = options[0].Context
+		option.Timeout = options[0].Timeout
+	}
+	element, err := l.frame.WaitForSelector(l.selector, option)
+	if err != nil {
+		return err
+	}
+	if element != nil {
+		return element.Dispose()
+	}
+	return nil
+}
+
+func (l *locatorImpl) Check(options ...LocatorCheckOptions) error {
+	_, err := l.send("check", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Uncheck(options ...LocatorUncheckOptions) error {
+	_, err := l.send("uncheck", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) SetChecked(checked bool, options ...LocatorSetCheckedOptions) error {
+	method := "uncheck"
+	if checked {
+		method = "check"
+	}
+	_, err := l.send(method, map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Click(options ...LocatorClickOptions) error {
+	_, err := l.send("click", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Dblclick(options ...LocatorDblclickOptions) error {
+	_, err := l.send("dblclick", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Tap(options ...LocatorTapOptions) error {
+	_, err := l.send("tap", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Hover(options ...LocatorHoverOptions) error {
+	_, err := l.send("hover", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Focus(options ...LocatorFocusOptions) error {
+	_, err := l.send("focus", map[string]interface{}{}, options)
+	return err
+}
+
+func (l *locatorImpl) Fill(value string, options ...LocatorFillOptions) error {
+	_, err := l.send("fill", map[string]interface{}{
+		"value": value,
+	}, options)
+	return err
+}
+
+func (l *locatorImpl) Press(key string, options ...LocatorPressOptions) error {
+	_, err := l.send("press", map[string]interface{}{
+		"key": key,
+	}, options)
+	return err
+}
+
+func (l *locatorImpl) Type(text string, options ...LocatorTypeOptions) error {
+	_, err := l.send("type", map[string]interface{}{
+		"text": text,
+	}, options)
+	return err
+}
+
+func (l *locatorImpl) DispatchEvent(typ string, eventInit interface{}, options ...LocatorDispatchEventOptions) error {
+	_, err := l.send("dispatchEvent", map[string]interface{}{
+		"type":      typ,
+		"eventInit": serializeArgument(eventInit),
+	}, options)
+	return err
+}
+
+func (l *locatorImpl) SelectOption(values SelectOptionValues, options ...LocatorSelectOptionOptions) ([]string, error) {
+	selected, err := l.send("selectOption", convertSelectOptionSet(values), options)
+	if err != nil {
+		return nil, err
+	}
+	return transformToStringList(selected), nil
+}
+
+func (l *locatorImpl) SetInputFiles(files []InputFile, options ...LocatorSetInputFilesOptions) error {
+	_, err := l.send("setInputFiles", map[string]interface{}{
+		"files": normalizeFilePayloads(files),
+	}, options)
+	return err
+}
+
+func (l *locatorImpl) Screenshot(options ...LocatorScreenshotOptions) ([]byte, error) {
+	option := LocatorScreenshotOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var screenshot []byte
+	err := l.withElement(func(element ElementHandle) error {
+		var err error
+		screenshot, err = element.Screenshot(ElementHandleScreenshotOptions(option))
+		return err
+	}, option.Timeout)
+	return screenshot, err
+}
+
+func (l *locatorImpl) ScrollIntoViewIfNeeded(options ...LocatorScrollIntoViewIfNeededOptions) error {
+	option := LocatorScrollIntoViewIfNeededOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return l.withElement(func(element ElementHandle) error {
+		return element.ScrollIntoViewIfNeeded(ElementHandleScrollIntoViewIfNeededOptions(option))
+	}, option.Timeout)
+}
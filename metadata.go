@@ -0,0 +1,76 @@
+package playwright
+
+import (
+	"regexp"
+	"strings"
+)
+
+// artifactPathPlaceholder matches the `{key}` placeholders of artifact paths.
+var artifactPathPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_.-]+)\}`)
+
+// artifactNameReplacer replaces the characters of metadata values which are
+// not allowed in file names on all platforms.
+var artifactNameReplacer = strings.NewReplacer(
+	"/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_",
+)
+
+// expandArtifactPath replaces the `{key}` placeholders of path with the values
+// of metadata, e.g. "traces/{test}.zip". Placeholders without a value are
+// kept.
+func expandArtifactPath(path string, metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return path
+	}
+	return artifactPathPlaceholder.ReplaceAllStringFunc(path, func(placeholder string) string {
+		value, ok := metadata[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		return artifactNameReplacer.Replace(value)
+	})
+}
+
+func copyMetadata(metadata map[string]string) map[string]string {
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		result[key] = value
+	}
+	return result
+}
+
+func (b *browserContextImpl) Metadata() map[string]string {
+	b.Lock()
+	defer b.Unlock()
+	return copyMetadata(b.metadata)
+}
+
+func (b *browserContextImpl) SetMetadata(key, value string) {
+	b.Lock()
+	defer b.Unlock()
+	if b.metadata == nil {
+		b.metadata = make(map[string]string)
+	}
+	b.metadata[key] = value
+}
+
+func (p *pageImpl) Metadata() map[string]string {
+	metadata := map[string]string{}
+	if p.browserContext != nil {
+		metadata = p.browserContext.Metadata()
+	}
+	p.Lock()
+	defer p.Unlock()
+	for key, value := range p.metadata {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+func (p *pageImpl) SetMetadata(key, value string) {
+	p.Lock()
+	defer p.Unlock()
+	if p.metadata == nil {
+		p.metadata = make(map[string]string)
+	}
+	p.metadata[key] = value
+}
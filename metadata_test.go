@@ -0,0 +1,30 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandArtifactPath(t *testing.T) {
+	metadata := map[string]string{
+		"test":   "TestCheckout/guest user",
+		"ticket": "SHOP-123",
+	}
+	require.Equal(t, "traces/TestCheckout_guest user-SHOP-123-{owner}.zip", expandArtifactPath("traces/{test}-{ticket}-{owner}.zip", metadata))
+	require.Equal(t, "traces/{test}.zip", expandArtifactPath("traces/{test}.zip", nil))
+}
+
+func TestPageMetadata(t *testing.T) {
+	context := &browserContextImpl{}
+	page := &pageImpl{browserContext: context}
+	context.SetMetadata("test", "TestCheckout")
+	context.SetMetadata("owner", "shop-team")
+	page.SetMetadata("owner", "payments-team")
+	require.Equal(t, map[string]string{"test": "TestCheckout", "owner": "shop-team"}, context.Metadata())
+	require.Equal(t, map[string]string{"test": "TestCheckout", "owner": "payments-team"}, page.Metadata())
+
+	metadata := context.Metadata()
+	metadata["test"] = "changed"
+	require.Equal(t, "TestCheckout", context.Metadata()["test"])
+}
@@ -0,0 +1,187 @@
+// Package mocks provides route handlers which stub out common third-party
+// scripts like analytics, tag managers and ads, so tests neither depend on
+// them nor send data to them.
+//
+//	err := mocks.Install(mocks.PageRouter(page))
+//	err := mocks.Install(mocks.ContextRouter(context), mocks.Options{ExtraDomains: []string{"example-ads.com"}})
+package mocks
+
+import (
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/neilspage/playwright-go"
+)
+
+// DefaultDomains are the vendors which get stubbed when Options.Domains is not set.
+var DefaultDomains = []string{
+	// Analytics
+	"google-analytics.com",
+	"analytics.google.com",
+	"segment.com",
+	"segment.io",
+	"mixpanel.com",
+	"amplitude.com",
+	"hotjar.com",
+	"fullstory.com",
+	"heap.io",
+	"heapanalytics.com",
+	"clarity.ms",
+	"newrelic.com",
+	"nr-data.net",
+	// Tag managers
+	"googletagmanager.com",
+	"tealiumiq.com",
+	"ensighten.com",
+	// Ads and tracking pixels
+	"doubleclick.net",
+	"googlesyndication.com",
+	"googleadservices.com",
+	"adservice.google.com",
+	"facebook.net",
+	"connect.facebook.net",
+	"ads-twitter.com",
+	"analytics.twitter.com",
+	"ads.linkedin.com",
+	"snap.licdn.com",
+	"bat.bing.com",
+	"criteo.com",
+	"taboola.com",
+	"outbrain.com",
+}
+
+// NoopScript is served instead of the scripts of the stubbed vendors. It
+// defines the globals pages commonly call, so inline snippets keep working.
+const NoopScript = `(() => {
+  const noop = function() {};
+  window.dataLayer = window.dataLayer || [];
+  window.gtag = window.gtag || noop;
+  window.ga = window.ga || noop;
+  window.fbq = window.fbq || noop;
+  window._fbq = window._fbq || window.fbq;
+  window.twq = window.twq || noop;
+  window.uetq = window.uetq || [];
+  window.hj = window.hj || noop;
+  window.mixpanel = window.mixpanel || { init: noop, track: noop, identify: noop, people: { set: noop } };
+  window.amplitude = window.amplitude || { getInstance: () => ({ init: noop, logEvent: noop, setUserId: noop }) };
+  window.analytics = window.analytics || { load: noop, page: noop, track: noop, identify: noop, ready: noop };
+})();`
+
+// Options configure which requests get stubbed and what they get fulfilled with.
+type Options struct {
+	// Domains to stub, defaults to DefaultDomains. Subdomains match as well.
+	Domains []string
+	// Domains to stub in addition to Domains.
+	ExtraDomains []string
+	// Script which gets served for script requests, defaults to NoopScript.
+	Script *string
+}
+
+// Router registers route handlers. Page.Route() and BrowserContext.Route()
+// take different option types, PageRouter() and ContextRouter() adapt them.
+type Router interface {
+	// Route registers the handler for the matching requests. It handles times
+	// requests, all of them if times is not set.
+	Route(url interface{}, handler func(playwright.Route, playwright.Request), times ...int) error
+}
+
+type pageRouter struct {
+	page playwright.Page
+}
+
+// PageRouter returns the Router of the page.
+func PageRouter(page playwright.Page) Router {
+	return &pageRouter{page: page}
+}
+
+func (r *pageRouter) Route(url interface{}, handler func(playwright.Route, playwright.Request), times ...int) error {
+	options := playwright.PageRouteOptions{}
+	if len(times) == 1 {
+		options.Times = playwright.Int(times[0])
+	}
+	return r.page.Route(url, handler, options)
+}
+
+type contextRouter struct {
+	context playwright.BrowserContext
+}
+
+// ContextRouter returns the Router of the browser context, it applies to all
+// of its pages.
+func ContextRouter(context playwright.BrowserContext) Router {
+	return &contextRouter{context: context}
+}
+
+func (r *contextRouter) Route(url interface{}, handler func(playwright.Route, playwright.Request), times ...int) error {
+	options := playwright.BrowserContextRouteOptions{}
+	if len(times) == 1 {
+		options.Times = playwright.Int(times[0])
+	}
+	return r.context.Route(url, handler, options)
+}
+
+// Install registers a route which stubs the requests to the configured
+// domains.
+func Install(router Router, options ...Options) error {
+	return router.Route(Matcher(options...), Handler(options...))
+}
+
+// Matcher returns a URL predicate which matches the configured domains, it
+// can be passed to Route together with a custom handler.
+func Matcher(options ...Options) func(string) bool {
+	domains := domainsFromOptions(options...)
+	return func(rawURL string) bool {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return false
+		}
+		return matchesDomain(u.Hostname(), domains)
+	}
+}
+
+// Handler returns a route handler which fulfills script requests with a
+// no-op script and all other requests with an empty response.
+func Handler(options ...Options) func(playwright.Route, playwright.Request) {
+	script := NoopScript
+	if len(options) == 1 && options[0].Script != nil {
+		script = *options[0].Script
+	}
+	return func(route playwright.Route, request playwright.Request) {
+		fulfillOptions := playwright.RouteFulfillOptions{
+			Status: playwright.Int(204),
+		}
+		if request.ResourceType() == "script" {
+			fulfillOptions = playwright.RouteFulfillOptions{
+				Status:      playwright.Int(200),
+				ContentType: playwright.String("application/javascript"),
+				Body:        script,
+			}
+		}
+		if err := route.Fulfill(fulfillOptions); err != nil {
+			log.Printf("could not stub %s: %v", request.URL(), err)
+		}
+	}
+}
+
+func domainsFromOptions(options ...Options) []string {
+	domains := DefaultDomains
+	if len(options) == 1 {
+		if options[0].Domains != nil {
+			domains = options[0].Domains
+		}
+		domains = append(append([]string{}, domains...), options[0].ExtraDomains...)
+	}
+	return domains
+}
+
+func matchesDomain(host string, domains []string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/neilspage/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRouter struct {
+	url   interface{}
+	times []int
+}
+
+func (r *fakeRouter) Route(url interface{}, handler func(playwright.Route, playwright.Request), times ...int) error {
+	r.url = url
+	r.times = times
+	return nil
+}
+
+func TestMatcher(t *testing.T) {
+	match := Matcher()
+	require.True(t, match("https://www.google-analytics.com/analytics.js"))
+	require.True(t, match("https://connect.facebook.net/en_US/fbevents.js"))
+	require.True(t, match("https://WWW.GOOGLETAGMANAGER.COM/gtm.js?id=GTM-1"))
+	require.False(t, match("https://example.com/app.js"))
+	require.False(t, match("https://notdoubleclick.net/ad.js"))
+	require.False(t, match("https://example.com/?ref=doubleclick.net"))
+
+	match = Matcher(Options{Domains: []string{"ads.example.com"}, ExtraDomains: []string{".tracker.io"}})
+	require.True(t, match("https://ads.example.com/pixel.gif"))
+	require.True(t, match("https://cdn.tracker.io/t.js"))
+	require.False(t, match("https://www.google-analytics.com/analytics.js"))
+	require.False(t, match("https://example.com/"))
+}
+
+func TestInstall(t *testing.T) {
+	router := &fakeRouter{}
+	require.NoError(t, Install(router, Options{Domains: []string{"ads.example.com"}}))
+	match, ok := router.url.(func(string) bool)
+	require.True(t, ok)
+	require.True(t, match("https://ads.example.com/pixel.gif"))
+	require.Empty(t, router.times)
+}
@@ -0,0 +1,119 @@
+package playwright
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// moduleGlobalName is the variable the exports of a bundled module get
+// assigned to.
+const moduleGlobalName = "__playwrightModule"
+
+// isTypeScriptFile reports whether a script needs to be transpiled before it
+// can be injected into a page.
+func isTypeScriptFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ts", ".tsx", ".mts", ".cts", ".jsx":
+		return true
+	}
+	return false
+}
+
+// esbuildPath returns the esbuild executable which bundles the modules, the
+// driver does not transpile or bundle scripts itself.
+func esbuildPath() (string, error) {
+	if path := os.Getenv("PLAYWRIGHT_ESBUILD_PATH"); path != "" {
+		return path, nil
+	}
+	path, err := exec.LookPath("esbuild")
+	if err != nil {
+		return "", errors.New("esbuild executable not found, install it from https://esbuild.github.io or set PLAYWRIGHT_ESBUILD_PATH")
+	}
+	return path, nil
+}
+
+// esbuildArgs returns the arguments which bundle the module at path, or the
+// module read from stdin if path is empty, into a single classic script.
+// TypeScript gets transpiled, the exports get assigned to moduleGlobalName.
+func esbuildArgs(path string) []string {
+	args := []string{
+		"--bundle",
+		"--format=iife",
+		"--global-name=" + moduleGlobalName,
+		"--platform=browser",
+		"--target=es2019",
+		"--log-level=error",
+	}
+	if path == "" {
+		return append(args, "--loader=ts", "--sourcefile=module.ts")
+	}
+	return append(args, path)
+}
+
+// bundleModule bundles the ES module in the file at path or in content, with
+// relative imports resolved against the directory of the file, respectively
+// the current working directory.
+func bundleModule(path, content string) (string, error) {
+	executable, err := esbuildPath()
+	if err != nil {
+		return "", fmt.Errorf("could not bundle module: %w", err)
+	}
+	cmd := exec.Command(executable, esbuildArgs(path)...)
+	if path == "" {
+		cmd.Stdin = strings.NewReader(content)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("could not bundle module: %s", message)
+		}
+		return "", fmt.Errorf("could not bundle module: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+// moduleFunction wraps a bundled module into a function which returns the
+// given export, called with the argument if it is a function.
+func moduleFunction(bundle, export string) string {
+	name, _ := json.Marshal(export)
+	return fmt.Sprintf(`async arg => {
+%s
+const value = %s[%s];
+if (value === undefined)
+  throw new Error('module has no export named ' + %s);
+return typeof value === 'function' ? value(arg) : value;
+}`, bundle, moduleGlobalName, name, name)
+}
+
+func (f *frameImpl) EvaluateModule(options FrameEvaluateModuleOptions) (interface{}, error) {
+	var path, content string
+	switch {
+	case options.Path != nil:
+		path = *options.Path
+	case options.Content != nil:
+		content = *options.Content
+	default:
+		return nil, errors.New("either path or content is required")
+	}
+	bundle, err := bundleModule(path, content)
+	if err != nil {
+		return nil, err
+	}
+	export := "default"
+	if options.Export != nil {
+		export = *options.Export
+	}
+	return f.Evaluate(moduleFunction(bundle, export), options.Arg)
+}
+
+func (p *pageImpl) EvaluateModule(options PageEvaluateModuleOptions) (interface{}, error) {
+	return p.mainFrame.EvaluateModule(FrameEvaluateModuleOptions(options))
+}
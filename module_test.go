@@ -0,0 +1,38 @@
+package playwright
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTypeScriptFile(t *testing.T) {
+	require.True(t, isTypeScriptFile("utils/helpers.ts"))
+	require.True(t, isTypeScriptFile("Widget.TSX"))
+	require.False(t, isTypeScriptFile("helpers.js"))
+	require.False(t, isTypeScriptFile("helpers.mjs"))
+}
+
+func TestEsbuildArgs(t *testing.T) {
+	args := esbuildArgs("main.ts")
+	require.Contains(t, args, "--bundle")
+	require.Contains(t, args, "--global-name="+moduleGlobalName)
+	require.Equal(t, "main.ts", args[len(args)-1])
+	require.Contains(t, esbuildArgs(""), "--loader=ts")
+}
+
+func TestBundleModuleWithoutEsbuild(t *testing.T) {
+	os.Setenv("PLAYWRIGHT_ESBUILD_PATH", filepath.Join(t.TempDir(), "esbuild"))
+	defer os.Unsetenv("PLAYWRIGHT_ESBUILD_PATH")
+	_, err := bundleModule("", "export default 1")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not bundle module")
+}
+
+func TestModuleFunction(t *testing.T) {
+	script := moduleFunction("var "+moduleGlobalName+" = {};", "it's")
+	require.True(t, isFunctionBody(script))
+	require.Contains(t, script, moduleGlobalName+`["it's"]`)
+}
@@ -0,0 +1,54 @@
+package playwright
+
+import (
+	"strings"
+)
+
+// navigationInterruptionMessages are the errors of the browsers for calls
+// whose execution context got destroyed by a navigation.
+var navigationInterruptionMessages = []string{
+	"Execution context was destroyed",
+	"Cannot find context with specified id",
+	"Inspected target navigated or closed",
+}
+
+func isNavigationInterruption(err error) bool {
+	for _, message := range navigationInterruptionMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNavigationRetries makes the actions of the pages of the context, e.g.
+// Locator.Click() or Page.Fill(), retry up to the given number of times when
+// they fail because the page navigated or reloaded while they were performed.
+func (b *browserContextImpl) SetNavigationRetries(retries int) {
+	b.Lock()
+	defer b.Unlock()
+	b.navigationRetries = retries
+}
+
+func (b *browserContextImpl) getNavigationRetries() int {
+	b.Lock()
+	defer b.Unlock()
+	return b.navigationRetries
+}
+
+// retryAfterNavigation sends an action which failed because of a navigation
+// again, as often as the context allows. Each attempt gets the full timeout.
+func (f *frameImpl) retryAfterNavigation(method string, params map[string]interface{}, err error) (interface{}, error) {
+	if !selectorActions[method] || f.page == nil || f.page.browserContext == nil {
+		return nil, err
+	}
+	retries := f.page.browserContext.getNavigationRetries()
+	for retry := 0; retry < retries && isNavigationInterruption(err); retry++ {
+		var result interface{}
+		result, err = f.channel.connection.SendMessageToServer(f.channel.guid, method, params)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,21 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNavigationInterruption(t *testing.T) {
+	require.True(t, isNavigationInterruption(&Error{Message: "Execution context was destroyed, most likely because of a navigation"}))
+	require.True(t, isNavigationInterruption(errors.New("Protocol error (Runtime.callFunctionOn): Cannot find context with specified id")))
+	require.False(t, isNavigationInterruption(&TimeoutError{Message: "Timeout 30000ms exceeded."}))
+}
+
+func TestRetryAfterNavigationWithoutContext(t *testing.T) {
+	err := &Error{Message: "Execution context was destroyed"}
+	result, retryErr := (&frameImpl{}).retryAfterNavigation("click", nil, err)
+	require.Nil(t, result)
+	require.Equal(t, err, retryErr)
+}
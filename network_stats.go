@@ -0,0 +1,84 @@
+package playwright
+
+import (
+	"strconv"
+	"sync"
+)
+
+// NetworkStats are the network counters of a page or browser context, see
+// Page.NetworkStats() and BrowserContext.NetworkStats().
+type NetworkStats struct {
+	// Number of requests which were issued.
+	Requests int `json:"requests"`
+	// Number of requests per resource type, see Request.ResourceType().
+	RequestsByType map[string]int `json:"requestsByType"`
+	// Number of requests which failed, e.g. because they got aborted.
+	FailedRequests int `json:"failedRequests"`
+	// Number of responses which were received.
+	Responses int `json:"responses"`
+	// Number of responses with status 304, the browser used its cached copy for them.
+	CacheHits int `json:"cacheHits"`
+	// Sum of the request bodies which were sent.
+	BytesSent int64 `json:"bytesSent"`
+	// Sum of the Content-Length headers of the responses. Responses without the header, e.g. chunked ones, do not
+	// contribute to it.
+	BytesReceived int64 `json:"bytesReceived"`
+}
+
+type networkStatsCounter struct {
+	sync.Mutex
+	stats NetworkStats
+}
+
+func newNetworkStatsCounter() *networkStatsCounter {
+	return &networkStatsCounter{
+		stats: NetworkStats{
+			RequestsByType: make(map[string]int),
+		},
+	}
+}
+
+func (n *networkStatsCounter) onRequest(request *requestImpl) {
+	body, _ := request.PostDataBuffer()
+	n.Lock()
+	defer n.Unlock()
+	n.stats.Requests++
+	n.stats.RequestsByType[request.ResourceType()]++
+	n.stats.BytesSent += int64(len(body))
+}
+
+func (n *networkStatsCounter) onRequestFailed() {
+	n.Lock()
+	defer n.Unlock()
+	n.stats.FailedRequests++
+}
+
+func (n *networkStatsCounter) onResponse(response *responseImpl) {
+	length, _ := strconv.ParseInt(response.Headers()["content-length"], 10, 64)
+	n.Lock()
+	defer n.Unlock()
+	n.stats.Responses++
+	if response.Status() == 304 {
+		n.stats.CacheHits++
+	}
+	n.stats.BytesReceived += length
+}
+
+func (n *networkStatsCounter) snapshot() NetworkStats {
+	n.Lock()
+	defer n.Unlock()
+	stats := n.stats
+	stats.RequestsByType = make(map[string]int, len(n.stats.RequestsByType))
+	for resourceType, count := range n.stats.RequestsByType {
+		stats.RequestsByType[resourceType] = count
+	}
+	return stats
+}
+
+func (p *pageImpl) NetworkStats() NetworkStats {
+	return p.networkStats.snapshot()
+}
+
+func (b *browserContextImpl) NetworkStats() NetworkStats {
+	return b.networkStats.snapshot()
+}
@@ -41,7 +41,7 @@ func createObjectFactory(parent *channelOwner, objectType string, guid string, i
 	case "Worker":
 		return newWorker(parent, objectType, guid, initializer)
 	case "Selectors":
-		return nil
+		return newSelectors(parent, objectType, guid, initializer)
 	case "Electron":
 		return nil
 	default:
@@ -0,0 +1,75 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// frameOrigin returns the origin of the document of the frame, e.g.
+// `https://example.com:8080`.
+func frameOrigin(frame Frame) string {
+	u, err := url.Parse(frame.URL())
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(origin, "/")
+}
+
+// frameForOrigin returns the main frame of the page or, when an origin is
+// given, the first frame which shows a document of it.
+func (p *pageImpl) frameForOrigin(origin ...string) (Frame, error) {
+	if len(origin) == 0 {
+		return p.mainFrame, nil
+	}
+	want := normalizeOrigin(origin[0])
+	for _, frame := range p.Frames() {
+		if frameOrigin(frame) == want {
+			return frame, nil
+		}
+	}
+	return nil, fmt.Errorf("no frame of the page has the origin %s", want)
+}
+
+func (p *pageImpl) EvaluateInOrigin(origin string, expression string, options ...interface{}) ([]interface{}, error) {
+	origin = normalizeOrigin(origin)
+	results := make([]interface{}, 0)
+	for _, frame := range p.Frames() {
+		if frameOrigin(frame) != origin {
+			continue
+		}
+		result, err := frame.Evaluate(expression, options...)
+		if err != nil {
+			return nil, fmt.Errorf("could not evaluate in frame %s: %w", frame.URL(), err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// scopeInitScript wraps the source of an init script so it only runs in
+// documents of the given origins and, if mainFrameOnly is set, only in main
+// frames.
+func scopeInitScript(source string, origins []string, mainFrameOnly bool) string {
+	if len(origins) == 0 && !mainFrameOnly {
+		return source
+	}
+	conditions := make([]string, 0, 2)
+	if len(origins) > 0 {
+		normalized := make([]string, 0, len(origins))
+		for _, origin := range origins {
+			normalized = append(normalized, normalizeOrigin(origin))
+		}
+		encoded, _ := json.Marshal(normalized)
+		conditions = append(conditions, fmt.Sprintf("%s.includes(location.origin)", encoded))
+	}
+	if mainFrameOnly {
+		conditions = append(conditions, "window === window.top")
+	}
+	return fmt.Sprintf("if (%s) {\n%s\n}", strings.Join(conditions, " && "), source)
+}
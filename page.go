@@ -1,28 +1,52 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log"
 	"reflect"
+	"sync"
+	"time"
 )
 
 type pageImpl struct {
 	channelOwner
-	isClosed        bool
-	video           *videoImpl
-	mouse           *mouseImpl
-	keyboard        *keyboardImpl
-	touchscreen     *touchscreenImpl
-	timeoutSettings *timeoutSettings
-	browserContext  *browserContextImpl
-	frames          []Frame
-	workers         []Worker
-	mainFrame       Frame
-	routes          []*routeHandlerEntry
-	viewportSize    ViewportSize
-	ownedContext    BrowserContext
-	bindings        map[string]BindingCallFunction
+	isClosed          bool
+	video             *videoImpl
+	accessibility     *accessibilityImpl
+	mouse             *mouseImpl
+	keyboard          *keyboardImpl
+	touchscreen       *touchscreenImpl
+	clock             *clockImpl
+	clipboard         *clipboardImpl
+	harRouters        []*harRouter
+	networkStats      *networkStatsCounter
+	timeoutSettings   *timeoutSettings
+	browserContext    *browserContextImpl
+	frames            []Frame
+	workers           []Worker
+	mainFrame         Frame
+	routes            []*routeHandlerEntry
+	viewportSize      ViewportSize
+	ownedContext      BrowserContext
+	bindings          map[string]BindingCallFunction
+	bindingHandles    map[string]bool
+	initScripts       []string
+	inheritToPopups   bool
+	acceptDownloads   *bool
+	cdpSession        *cdpSessionImpl
+	deviceScaleFactor float64
+	orientation       string
+	extraHTTPHeaders  map[string]string
+	scopedHeaders     []*scopedHeaders
+	userAgent         string
+	subscriptions     eventSubscriptions
+	webSocketRoutes   []*webSocketRouteHandler
+	metadata          map[string]string
 }
 
 func (p *pageImpl) Context() BrowserContext {
@@ -30,6 +54,13 @@ func (p *pageImpl) Context() BrowserContext {
 }
 
 func (p *pageImpl) Close(options ...PageCloseOptions) error {
+	p.Lock()
+	routers := p.harRouters
+	p.harRouters = nil
+	p.Unlock()
+	if err := saveHars(routers); err != nil {
+		return err
+	}
 	_, err := p.channel.Send("close", options)
 	if err != nil {
 		return err
@@ -61,6 +92,10 @@ func (p *pageImpl) MainFrame() Frame {
 	return p.mainFrame
 }
 
+func (p *pageImpl) NewCDPSession() (CDPSession, error) {
+	return p.browserContext.NewCDPSession(p)
+}
+
 // PageFrameOptions is the option struct for Page.Frame()
 type PageFrameOptions struct {
 	Name *string
@@ -90,6 +125,89 @@ func (p *pageImpl) Frames() []Frame {
 	return p.frames
 }
 
+func (p *pageImpl) WaitForFrame(urlOrPredicate interface{}, options ...PageWaitForFrameOptions) (Frame, error) {
+	option := PageWaitForFrameOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	timeout := p.timeoutSettings.Timeout()
+	if option.Timeout != nil {
+		timeout = *option.Timeout
+	}
+	state := "load"
+	if option.WaitUntil != nil {
+		state = string(*option.WaitUntil)
+	}
+	matches := framePredicate(urlOrPredicate)
+	loaded := make(chan Frame, 1)
+	resolve := func(frame Frame) {
+		select {
+		case loaded <- frame:
+		default:
+		}
+	}
+	var watchedMu sync.Mutex
+	watched := map[*frameImpl]func(string){}
+	// watch waits for the load state of a frame once it matches, the frame
+	// can match on attach or only after it navigated to the expected URL.
+	watch := func(frame Frame) {
+		if !matches(frame) {
+			return
+		}
+		f := frame.(*frameImpl)
+		watchedMu.Lock()
+		defer watchedMu.Unlock()
+		if _, ok := watched[f]; !ok {
+			handler := func(added string) {
+				if added == state && matches(frame) {
+					resolve(frame)
+				}
+			}
+			watched[f] = handler
+			f.On("loadstate", handler)
+		}
+		if f.loadStates.Has(state) {
+			resolve(frame)
+		}
+	}
+	p.On("frameattached", watch)
+	p.On("framenavigated", watch)
+	defer func() {
+		p.RemoveListener("frameattached", watch)
+		p.RemoveListener("framenavigated", watch)
+		watchedMu.Lock()
+		defer watchedMu.Unlock()
+		for f, handler := range watched {
+			f.RemoveListener("loadstate", handler)
+		}
+	}()
+	for _, frame := range p.Frames() {
+		watch(frame)
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		expired = time.After(time.Duration(timeout) * time.Millisecond)
+	}
+	select {
+	case frame := <-loaded:
+		return frame, nil
+	case <-expired:
+		return nil, fmt.Errorf("Timeout %.2fms exceeded while waiting for frame.", timeout)
+	}
+}
+
+// framePredicate returns a predicate for a func(Frame) bool or for a URL glob
+// pattern, regular expression or func(string) bool.
+func framePredicate(urlOrPredicate interface{}) func(Frame) bool {
+	if predicate, ok := urlOrPredicate.(func(Frame) bool); ok {
+		return predicate
+	}
+	matcher := newURLMatcher(urlOrPredicate)
+	return func(frame Frame) bool {
+		return matcher.Matches(frame.URL())
+	}
+}
+
 func (p *pageImpl) SetDefaultNavigationTimeout(timeout float64) {
 	p.timeoutSettings.SetNavigationTimeout(timeout)
 	p.channel.SendNoReply("setDefaultNavigationTimeoutNoReply", map[string]interface{}{
@@ -136,6 +254,10 @@ func (p *pageImpl) EvalOnSelectorAll(selector string, expression string, options
 	return p.mainFrame.EvalOnSelectorAll(selector, expression, options...)
 }
 
+func (p *pageImpl) ExtractAll(selector string, dest interface{}, fields FieldMap) error {
+	return p.mainFrame.ExtractAll(selector, dest, fields)
+}
+
 func (p *pageImpl) AddScriptTag(options PageAddScriptTagOptions) (ElementHandle, error) {
 	return p.mainFrame.AddScriptTag(options)
 }
@@ -144,28 +266,87 @@ func (p *pageImpl) AddStyleTag(options PageAddStyleTagOptions) (ElementHandle, e
 	return p.mainFrame.AddStyleTag(options)
 }
 
-func (p *pageImpl) SetExtraHTTPHeaders(headers map[string]string) error {
+// sendExtraHTTPHeaders sends the extra HTTP headers of the page together with
+// the User-Agent header if the user agent got overridden without CDP.
+func (p *pageImpl) sendExtraHTTPHeaders() error {
+	p.RLock()
+	headers := make(map[string]string)
+	for name, value := range p.extraHTTPHeaders {
+		headers[name] = value
+	}
+	if p.userAgent != "" {
+		headers["User-Agent"] = p.userAgent
+	}
+	p.RUnlock()
 	_, err := p.channel.Send("setExtraHTTPHeaders", map[string]interface{}{
 		"headers": serializeMapToNameAndValue(headers),
 	})
 	return err
 }
 
+func (p *pageImpl) SetUserAgent(userAgent string, metadata ...UserAgentMetadata) error {
+	if session, err := p.chromiumSession("user agent metadata is"); err == nil {
+		return session.Emulation().SetUserAgentOverride(userAgent, metadata...)
+	}
+	p.Lock()
+	p.userAgent = userAgent
+	p.Unlock()
+	if err := p.sendExtraHTTPHeaders(); err != nil {
+		return fmt.Errorf("could not set user agent: %w", err)
+	}
+	if err := addInitFunction(func(script string) error {
+		return p.AddInitScript(PageAddInitScriptOptions{
+			Script: String(script),
+		})
+	}, []Page{p}, userAgentOverrideScript, userAgent); err != nil {
+		return fmt.Errorf("could not set user agent: %w", err)
+	}
+	return nil
+}
+
 func (p *pageImpl) URL() string {
 	return p.mainFrame.URL()
 }
 
-func (p *pageImpl) Unroute(url interface{}, handlers ...routeHandler) error {
-	p.Lock()
-	defer p.Unlock()
+func (p *pageImpl) Unroute(url interface{}, handlers ...func(Route, Request)) error {
+	return p.updateInterception(func() {
+		p.routes = filterRoutes(p.routes, url, handlers...)
+	})
+}
 
-	routes, err := unroute(p.channel, p.routes, url, handlers...)
-	if err != nil {
-		return err
+func (p *pageImpl) UnrouteAll(options ...PageUnrouteAllOptions) error {
+	var routes []*routeHandlerEntry
+	err := p.updateInterception(func() {
+		routes = p.routes
+		p.routes = make([]*routeHandlerEntry, 0)
+	})
+	var behavior *UnrouteBehavior
+	if len(options) == 1 {
+		behavior = options[0].Behavior
 	}
-	p.routes = routes
+	unrouteAll(routes, behavior)
+	return err
+}
 
-	return nil
+// updateInterception runs update with the lock held and toggles the network
+// interception when update changed whether it is needed.
+func (p *pageImpl) updateInterception(update func()) error {
+	p.Lock()
+	wasIntercepting := p.needsInterception()
+	update()
+	intercepting := p.needsInterception()
+	p.Unlock()
+	if wasIntercepting == intercepting {
+		return nil
+	}
+	_, err := p.channel.Send("setNetworkInterceptionEnabled", map[string]interface{}{
+		"enabled": intercepting,
+	})
+	return err
+}
+
+func (p *pageImpl) needsInterception() bool {
+	return len(p.routes) > 0 || len(p.scopedHeaders) > 0
 }
 
 func (p *pageImpl) Content() (string, error) {
@@ -176,8 +357,23 @@ func (p *pageImpl) SetContent(content string, options ...PageSetContentOptions)
 	return p.mainFrame.SetContent(content, options...)
 }
 
+func (p *pageImpl) SetContentFromReader(r io.Reader, options ...PageSetContentOptions) error {
+	return p.mainFrame.SetContentFromReader(r, options...)
+}
+
+func (p *pageImpl) ContentTo(w io.Writer) error {
+	return p.mainFrame.ContentTo(w)
+}
+
 func (p *pageImpl) Goto(url string, options ...PageGotoOptions) (Response, error) {
-	return p.mainFrame.Goto(url, options...)
+	response, err := p.mainFrame.Goto(url, options...)
+	if err != nil && p.browserContext != nil {
+		// the navigation failed because the context got closed for exceeding its limits
+		if limitErr := p.browserContext.LimitError(); limitErr != nil {
+			return nil, limitErr
+		}
+	}
+	return response, err
 }
 
 func (p *pageImpl) Reload(options ...PageReloadOptions) (Response, error) {
@@ -224,13 +420,38 @@ func (p *pageImpl) EmulateMedia(options ...PageEmulateMediaOptions) error {
 	return err
 }
 
+// MediaFeature is a CSS media feature for Page.EmulateMediaFeatures()
+type MediaFeature struct {
+	// Name of the media feature, e.g. `prefers-reduced-data`.
+	Name string `json:"name"`
+	// Value of the media feature, e.g. `reduce`. An empty value removes the emulation of the feature.
+	Value string `json:"value"`
+}
+
+func (p *pageImpl) EmulateMediaFeatures(features []MediaFeature) error {
+	session, err := p.chromiumSession("emulating media features is")
+	if err != nil {
+		return err
+	}
+	return sendCDPCommand(session, "Emulation.setEmulatedMedia", map[string]interface{}{
+		"features": features,
+	}, nil)
+}
+
 // ViewportSize represents the viewport size
 type ViewportSize struct {
 	Width  int `json:"width"`
 	Height int `json:"height"`
 }
 
-func (p *pageImpl) SetViewportSize(width, height int) error {
+func (p *pageImpl) SetViewportSize(width, height int, options ...PageSetViewportSizeOptions) error {
+	option := PageSetViewportSizeOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Orientation != nil && *option.Orientation != "portrait" && *option.Orientation != "landscape" {
+		return fmt.Errorf("orientation: expected one of (portrait|landscape), got %s", *option.Orientation)
+	}
 	_, err := p.channel.Send("setViewportSize", map[string]interface{}{
 		"viewportSize": map[string]interface{}{
 			"width":  width,
@@ -242,16 +463,165 @@ func (p *pageImpl) SetViewportSize(width, height int) error {
 	}
 	p.viewportSize.Width = width
 	p.viewportSize.Height = height
+	// The device metrics override has its own viewport size, so an active one
+	// has to be updated with the new size as well.
+	p.Lock()
+	overridden := p.deviceScaleFactor != 0 || p.orientation != ""
+	p.Unlock()
+	if option.DeviceScaleFactor == nil && option.Orientation == nil && !overridden {
+		return nil
+	}
+	return p.emulateDeviceMetrics(width, height, option)
+}
+
+// applyOptions emulates the per-page settings which got passed to
+// BrowserContext.NewPage().
+func (p *pageImpl) applyOptions(options BrowserNewPageOptions) error {
+	if options.Viewport != nil && options.Viewport.Width != nil && options.Viewport.Height != nil {
+		if err := p.SetViewportSize(*options.Viewport.Width, *options.Viewport.Height); err != nil {
+			return fmt.Errorf("could not set viewport: %w", err)
+		}
+	}
+	if options.ExtraHttpHeaders != nil {
+		if err := p.SetExtraHTTPHeaders(options.ExtraHttpHeaders); err != nil {
+			return fmt.Errorf("could not set extra HTTP headers: %w", err)
+		}
+	}
+	if options.UserAgent != nil {
+		if err := p.SetUserAgent(*options.UserAgent); err != nil {
+			return err
+		}
+	}
+	if options.ColorScheme != nil {
+		if err := p.EmulateMedia(PageEmulateMediaOptions{
+			ColorScheme: options.ColorScheme,
+		}); err != nil {
+			return fmt.Errorf("could not emulate color scheme: %w", err)
+		}
+	}
 	return nil
 }
 
+// userAgentOverrideScript overrides navigator.userAgent in browsers where the
+// user agent can not be changed for a single page via CDP.
+const userAgentOverrideScript = `userAgent => {
+  Object.defineProperty(Navigator.prototype, 'userAgent', { get: () => userAgent, configurable: true });
+  Object.defineProperty(Navigator.prototype, 'appVersion', { get: () => userAgent.replace(/^Mozilla\//, ''), configurable: true });
+}`
+
+// chromiumSession returns the CDP session which the page uses for the
+// Chromium-only features. The session is kept alive because Chromium resets
+// its overrides once it gets detached.
+func (p *pageImpl) chromiumSession(feature string) (*cdpSessionImpl, error) {
+	p.Lock()
+	defer p.Unlock()
+	if p.cdpSession == nil {
+		session, err := p.browserContext.NewCDPSession(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s only supported in Chromium: %w", feature, err)
+		}
+		p.cdpSession = session.(*cdpSessionImpl)
+	}
+	return p.cdpSession, nil
+}
+
+func (p *pageImpl) emulateDeviceMetrics(width, height int, option PageSetViewportSizeOptions) error {
+	session, err := p.chromiumSession("device scale factor and orientation are")
+	if err != nil {
+		return err
+	}
+	p.Lock()
+	defer p.Unlock()
+	metrics := CDPEmulationDeviceMetrics{
+		Width:  width,
+		Height: height,
+		Mobile: p.browserContext.options != nil && p.browserContext.options.IsMobile != nil && *p.browserContext.options.IsMobile,
+	}
+	if option.DeviceScaleFactor != nil {
+		p.deviceScaleFactor = *option.DeviceScaleFactor
+	}
+	if p.deviceScaleFactor == 0 {
+		dpr, err := p.mainFrame.Evaluate("() => window.devicePixelRatio")
+		if err != nil {
+			return fmt.Errorf("could not get device scale factor: %w", err)
+		}
+		switch v := dpr.(type) {
+		case int:
+			p.deviceScaleFactor = float64(v)
+		case float64:
+			p.deviceScaleFactor = v
+		}
+	}
+	metrics.DeviceScaleFactor = p.deviceScaleFactor
+	if option.Orientation != nil {
+		p.orientation = *option.Orientation
+	}
+	if p.orientation == "landscape" {
+		metrics.ScreenOrientation = &CDPEmulationScreenOrientation{Type: "landscapePrimary", Angle: 90}
+	} else if p.orientation == "portrait" {
+		metrics.ScreenOrientation = &CDPEmulationScreenOrientation{Type: "portraitPrimary", Angle: 0}
+	}
+	return session.Emulation().SetDeviceMetricsOverride(metrics)
+}
+
+// WindowBounds are the bounds and the state of the browser window of a page
+type WindowBounds struct {
+	// The offset from the left edge of the screen to the window in pixels.
+	Left *int `json:"left"`
+	// The offset from the top edge of the screen to the window in pixels.
+	Top *int `json:"top"`
+	// The window width in pixels.
+	Width *int `json:"width"`
+	// The window height in pixels.
+	Height *int `json:"height"`
+	// The window state, one of `normal`, `minimized`, `maximized` or `fullscreen`.
+	WindowState *string `json:"windowState"`
+}
+
+func (p *pageImpl) WindowBounds() (*WindowBounds, error) {
+	session, err := p.chromiumSession("window bounds are")
+	if err != nil {
+		return nil, err
+	}
+	window, err := session.Browser().GetWindowForTarget()
+	if err != nil {
+		return nil, err
+	}
+	return &window.Bounds, nil
+}
+
+func (p *pageImpl) SetWindowBounds(bounds WindowBounds) error {
+	hasGeometry := bounds.Left != nil || bounds.Top != nil || bounds.Width != nil || bounds.Height != nil
+	if hasGeometry && bounds.WindowState != nil && *bounds.WindowState != "normal" {
+		return fmt.Errorf("window state '%s' can not be combined with left, top, width or height", *bounds.WindowState)
+	}
+	session, err := p.chromiumSession("window bounds are")
+	if err != nil {
+		return err
+	}
+	window, err := session.Browser().GetWindowForTarget()
+	if err != nil {
+		return err
+	}
+	// The geometry of a window can only be changed in the normal state.
+	if hasGeometry && window.Bounds.WindowState != nil && *window.Bounds.WindowState != "normal" {
+		if err := session.Browser().SetWindowBounds(window.WindowID, WindowBounds{WindowState: String("normal")}); err != nil {
+			return err
+		}
+	}
+	return session.Browser().SetWindowBounds(window.WindowID, bounds)
+}
+
 func (p *pageImpl) ViewportSize() ViewportSize {
 	return p.viewportSize
 }
 
 func (p *pageImpl) BringToFront() error {
-	_, err := p.channel.Send("bringToFront")
-	return err
+	if _, err := p.channel.Send("bringToFront"); err != nil {
+		return err
+	}
+	p.browserContext.setActivePage(p)
+	return nil
 }
 
 func (p *pageImpl) Type(selector, text string, options ...PageTypeOptions) error {
@@ -275,11 +645,22 @@ func (p *pageImpl) Workers() []Worker {
 }
 
 func (p *pageImpl) Screenshot(options ...PageScreenshotOptions) ([]byte, error) {
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
 	var path *string
 	if len(options) > 0 {
 		path = options[0].Path
+		if options[0].Stabilize != nil {
+			timeout := screenshotTimeout(options[0].Timeout, p.timeoutSettings.Timeout())
+			if err := stabilizeFrame(p.mainFrame, *options[0].Stabilize, timeout); err != nil {
+				return nil, err
+			}
+		}
 	}
-	data, err := p.channel.Send("screenshot", options)
+	params := transformOptions(options)
+	delete(params, "stabilize")
+	data, err := p.channel.Send("screenshot", params)
 	if err != nil {
 		return nil, fmt.Errorf("could not send message :%w", err)
 	}
@@ -296,6 +677,11 @@ func (p *pageImpl) Screenshot(options ...PageScreenshotOptions) ([]byte, error)
 }
 
 func (p *pageImpl) PDF(options ...PagePdfOptions) ([]byte, error) {
+	if p.browserContext != nil {
+		if name := p.browserContext.browserName(); !capabilitiesOf(name).pdf {
+			return nil, &NotSupportedError{Feature: "PDF generation", Browser: name}
+		}
+	}
 	var path *string
 	if len(options) > 0 {
 		path = options[0].Path
@@ -371,15 +757,7 @@ func (p *pageImpl) ExpectEvent(event string, cb func() error, predicates ...inte
 }
 
 func (p *pageImpl) ExpectNavigation(cb func() error, options ...PageWaitForNavigationOptions) (Response, error) {
-	navigationOptions := make([]interface{}, 0)
-	for _, option := range options {
-		navigationOptions = append(navigationOptions, option)
-	}
-	response, err := newExpectWrapper(p.WaitForNavigation, navigationOptions, cb)
-	if response == nil {
-		return nil, err
-	}
-	return response.(*responseImpl), err
+	return p.mainFrame.ExpectNavigation(cb, options...)
 }
 
 func (p *pageImpl) ExpectConsoleMessage(cb func() error) (ConsoleMessage, error) {
@@ -387,19 +765,62 @@ func (p *pageImpl) ExpectConsoleMessage(cb func() error) (ConsoleMessage, error)
 	return consoleMessage.(*consoleMessageImpl), err
 }
 
+func (p *pageImpl) ExpectDialog(cb func() error, options ...PageExpectDialogOptions) (Dialog, error) {
+	option := PageExpectDialogOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var predicate func(ev interface{}) bool
+	if option.Predicate != nil {
+		predicate = func(ev interface{}) bool {
+			return option.Predicate(ev.(*dialogImpl))
+		}
+	}
+	dialog, err := p.expectEvent(option.Context, "dialog", cb, predicate, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return dialog.(*dialogImpl), nil
+}
+
 func (p *pageImpl) ExpectedDialog(cb func() error) (Dialog, error) {
-	dialog, err := newExpectWrapper(p.WaitForEvent, []interface{}{"dialog"}, cb)
-	return dialog.(*dialogImpl), err
+	return p.ExpectDialog(cb)
 }
 
-func (p *pageImpl) ExpectDownload(cb func() error) (Download, error) {
-	download, err := newExpectWrapper(p.WaitForEvent, []interface{}{"download"}, cb)
-	return download.(*downloadImpl), err
+func (p *pageImpl) ExpectDownload(cb func() error, options ...PageExpectDownloadOptions) (Download, error) {
+	option := PageExpectDownloadOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var predicate func(ev interface{}) bool
+	if option.Predicate != nil {
+		predicate = func(ev interface{}) bool {
+			return option.Predicate(ev.(*downloadImpl))
+		}
+	}
+	download, err := p.expectEvent(option.Context, "download", cb, predicate, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return download.(*downloadImpl), nil
 }
 
-func (p *pageImpl) ExpectFileChooser(cb func() error) (FileChooser, error) {
-	response, err := newExpectWrapper(p.WaitForEvent, []interface{}{"filechooser"}, cb)
-	return response.(*fileChooserImpl), err
+func (p *pageImpl) ExpectFileChooser(cb func() error, options ...PageExpectFileChooserOptions) (FileChooser, error) {
+	option := PageExpectFileChooserOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var predicate func(ev interface{}) bool
+	if option.Predicate != nil {
+		predicate = func(ev interface{}) bool {
+			return option.Predicate(ev.(*fileChooserImpl))
+		}
+	}
+	fileChooser, err := p.expectEvent(option.Context, "filechooser", cb, predicate, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return fileChooser.(*fileChooserImpl), nil
 }
 
 func (p *pageImpl) ExpectLoadState(state string, cb func() error) error {
@@ -407,9 +828,26 @@ func (p *pageImpl) ExpectLoadState(state string, cb func() error) error {
 	return err
 }
 
-func (p *pageImpl) ExpectPopup(cb func() error) (Page, error) {
-	popup, err := newExpectWrapper(p.WaitForEvent, []interface{}{"popup"}, cb)
-	return popup.(*pageImpl), err
+func (p *pageImpl) ExpectPopup(cb func() error, options ...PageExpectPopupOptions) (Page, error) {
+	option := PageExpectPopupOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var matcher *urlMatcher
+	if option.URL != nil {
+		matcher = newURLMatcher(option.URL)
+	}
+	popup, err := p.expectEvent(option.Context, "popup", cb, func(ev interface{}) bool {
+		popup := ev.(*pageImpl)
+		if matcher != nil && !matcher.Matches(popup.URL()) {
+			return false
+		}
+		return option.Predicate == nil || option.Predicate(popup)
+	}, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return popup.(*pageImpl), nil
 }
 
 func (p *pageImpl) ExpectResponse(url interface{}, cb func() error, options ...interface{}) (Response, error) {
@@ -428,24 +866,102 @@ func (p *pageImpl) ExpectRequest(url interface{}, cb func() error, options ...in
 	return popup.(*requestImpl), err
 }
 
-func (p *pageImpl) ExpectWorker(cb func() error) (Worker, error) {
-	response, err := newExpectWrapper(p.WaitForEvent, []interface{}{"worker"}, cb)
-	return response.(*workerImpl), err
+func (p *pageImpl) ExpectRequestFinished(cb func() error, options ...PageExpectRequestFinishedOptions) (Request, error) {
+	option := PageExpectRequestFinishedOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var predicate func(ev interface{}) bool
+	if option.Predicate != nil {
+		predicate = func(ev interface{}) bool {
+			return option.Predicate(ev.(*requestImpl))
+		}
+	}
+	request, err := p.expectEvent(option.Context, "requestfinished", cb, predicate, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return request.(*requestImpl), nil
 }
 
-func (p *pageImpl) Route(url interface{}, handler routeHandler) error {
-	p.Lock()
-	defer p.Unlock()
-	p.routes = append(p.routes, newRouteHandlerEntry(newURLMatcher(url), handler))
-	if len(p.routes) == 1 {
-		_, err := p.channel.Send("setNetworkInterceptionEnabled", map[string]interface{}{
-			"enabled": true,
-		})
-		if err != nil {
-			return err
+func (p *pageImpl) ExpectRequestAndResponse(url interface{}, cb func() error, options ...PageExpectRequestAndResponseOptions) (Request, Response, error) {
+	option := PageExpectRequestAndResponseOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	matcher := newURLMatcher(url)
+	response, err := p.expectEvent(option.Context, "response", cb, func(ev interface{}) bool {
+		return matcher.Matches(ev.(*responseImpl).URL())
+	}, option.Timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.(*responseImpl).Request(), response.(*responseImpl), nil
+}
+
+func (p *pageImpl) ExpectWorker(cb func() error, options ...PageExpectWorkerOptions) (Worker, error) {
+	option := PageExpectWorkerOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	var predicate func(ev interface{}) bool
+	if option.Predicate != nil {
+		predicate = func(ev interface{}) bool {
+			return option.Predicate(ev.(*workerImpl))
 		}
 	}
-	return nil
+	worker, err := p.expectEvent(option.Context, "worker", cb, predicate, option.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return worker.(*workerImpl), nil
+}
+
+// expectEvent runs cb and waits for the first event which satisfies the
+// predicate, the listener gets registered before cb runs so that events which
+// cb triggers synchronously do not get lost.
+func (p *pageImpl) expectEvent(ctx context.Context, event string, cb func() error, predicate func(ev interface{}) bool, timeout *float64) (interface{}, error) {
+	if timeout == nil {
+		timeout = Float(p.timeoutSettings.Timeout())
+	}
+	events := make(chan interface{}, 1)
+	handler := func(ev interface{}) {
+		if predicate != nil && !predicate(ev) {
+			return
+		}
+		select {
+		case events <- ev:
+		default:
+		}
+	}
+	p.On(event, handler)
+	defer p.RemoveListener(event, handler)
+	if err := cb(); err != nil {
+		return nil, err
+	}
+	// A timeout of 0 disables the timeout.
+	var expired <-chan time.Time
+	if *timeout > 0 {
+		expired = time.After(time.Duration(*timeout) * time.Millisecond)
+	}
+	select {
+	case ev := <-events:
+		return ev, nil
+	case <-contextDone(ctx):
+		return nil, fmt.Errorf("could not wait for %s: %w", event, ctx.Err())
+	case <-expired:
+		return nil, fmt.Errorf("Timeout %.2fms exceeded while waiting for %s.", *timeout, event)
+	}
+}
+
+func (p *pageImpl) Route(url interface{}, handler func(Route, Request), options ...PageRouteOptions) error {
+	var times *int
+	if len(options) == 1 {
+		times = options[0].Times
+	}
+	return p.updateInterception(func() {
+		p.routes = append(p.routes, newRouteHandlerEntry(newURLMatcher(url), handler, times))
+	})
 }
 
 func (p *pageImpl) GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (string, error) {
@@ -466,16 +982,26 @@ func (p *pageImpl) AddInitScript(options PageAddInitScriptOptions) error {
 		source = *options.Script
 	}
 	if options.Path != nil {
-		content, err := ioutil.ReadFile(*options.Path)
+		content, err := readAsset(options.FS, *options.Path)
 		if err != nil {
 			return err
 		}
 		source = string(content)
 	}
-	_, err := p.channel.Send("addInitScript", map[string]interface{}{
+	source = scopeInitScript(source, options.Origins, options.MainFrameOnly != nil && *options.MainFrameOnly)
+	if _, err := p.channel.Send("addInitScript", map[string]interface{}{
 		"source": source,
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+	p.Lock()
+	p.initScripts = append(p.initScripts, source)
+	p.Unlock()
+	return nil
+}
+
+func (p *pageImpl) Accessibility() Accessibility {
+	return p.accessibility
 }
 
 func (p *pageImpl) Keyboard() Keyboard {
@@ -489,6 +1015,14 @@ func (p *pageImpl) Touchscreen() Touchscreen {
 	return p.touchscreen
 }
 
+func (p *pageImpl) Clock() Clock {
+	return p.clock
+}
+
+func (p *pageImpl) Clipboard() Clipboard {
+	return p.clipboard
+}
+
 func (p *pageImpl) setBrowserContext(browserContext *browserContextImpl) {
 	p.browserContext = browserContext
 	p.timeoutSettings = newTimeoutSettings(browserContext.timeoutSettings)
@@ -496,22 +1030,27 @@ func (p *pageImpl) setBrowserContext(browserContext *browserContextImpl) {
 
 func newPage(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *pageImpl {
 	bt := &pageImpl{
-		mainFrame: fromChannel(initializer["mainFrame"]).(*frameImpl),
-		workers:   make([]Worker, 0),
-		routes:    make([]*routeHandlerEntry, 0),
-		bindings:  make(map[string]BindingCallFunction),
+		mainFrame:      fromChannel(initializer["mainFrame"]).(*frameImpl),
+		workers:        make([]Worker, 0),
+		routes:         make([]*routeHandlerEntry, 0),
+		bindings:       make(map[string]BindingCallFunction),
+		bindingHandles: make(map[string]bool),
 		viewportSize: ViewportSize{
 			Height: int(initializer["viewportSize"].(map[string]interface{})["height"].(float64)),
 			Width:  int(initializer["viewportSize"].(map[string]interface{})["width"].(float64)),
 		},
 		timeoutSettings: newTimeoutSettings(nil),
+		networkStats:    newNetworkStatsCounter(),
 	}
 	bt.frames = []Frame{bt.mainFrame}
 	bt.mainFrame.(*frameImpl).page = bt
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
 	bt.mouse = newMouse(bt.channel)
+	bt.accessibility = newAccessibility(bt.channel)
 	bt.keyboard = newKeyboard(bt.channel)
 	bt.touchscreen = newTouchscreen(bt.channel)
+	bt.clock = newClock(bt)
+	bt.clipboard = newClipboard(bt)
 	bt.channel.On("bindingCall", func(params map[string]interface{}) {
 		bt.onBinding(fromChannel(params["binding"]).(*bindingCallImpl))
 	})
@@ -563,7 +1102,7 @@ func newPage(parent *channelOwner, objectType string, guid string, initializer m
 		url := ev["url"].(string)
 		suggestedFilename := ev["suggestedFilename"].(string)
 		artifact := fromChannel(ev["artifact"]).(*artifactImpl)
-		bt.Emit("download", newDownload(bt, url, suggestedFilename, artifact))
+		bt.onDownload(newDownload(bt, url, suggestedFilename, artifact))
 	})
 	bt.channel.On("video", func(params map[string]interface{}) {
 		bt.Video().(*videoImpl).setArtifact(fromChannel(params["artifact"]).(*artifactImpl))
@@ -601,6 +1140,109 @@ func (p *pageImpl) onBinding(binding *bindingCallImpl) {
 	go binding.Call(function)
 }
 
+func (p *pageImpl) onDownload(download *downloadImpl) {
+	p.RLock()
+	acceptDownloads := p.acceptDownloads
+	p.RUnlock()
+	if acceptDownloads != nil && !*acceptDownloads {
+		go func() {
+			if err := download.Cancel(); err != nil {
+				log.Printf("could not cancel blocked download: %v", err)
+			}
+		}()
+		p.Emit("downloadblocked", download, &DownloadBlockedEvent{
+			Download: download,
+			Reason:   DownloadBlockedByPage,
+		})
+		return
+	}
+	if p.browserContext != nil && !p.browserContext.acceptDownloads {
+		// The browser cancels the download itself, it keeps getting emitted as
+		// a failed download for compatibility.
+		p.Emit("downloadblocked", download, &DownloadBlockedEvent{
+			Download: download,
+			Reason:   DownloadBlockedByContext,
+		})
+	}
+	p.Emit("download", download)
+}
+
+func (p *pageImpl) SetAcceptDownloads(accept bool) error {
+	if accept && !p.browserContext.acceptDownloads {
+		return errors.New("downloads can only be accepted if the browser context accepts them, create it with AcceptDownloads")
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.acceptDownloads = Bool(accept)
+	return nil
+}
+
+func (p *pageImpl) AcceptDownloads() bool {
+	p.RLock()
+	defer p.RUnlock()
+	if p.acceptDownloads != nil {
+		return *p.acceptDownloads
+	}
+	return p.browserContext.acceptDownloads
+}
+
+func (p *pageImpl) SetInheritToPopups(inherit bool) {
+	p.Lock()
+	defer p.Unlock()
+	p.inheritToPopups = inherit
+}
+
+// inheritsToPopups reports whether the routes, init scripts and bindings of
+// the page get applied to its popups.
+func (p *pageImpl) inheritsToPopups() bool {
+	p.RLock()
+	defer p.RUnlock()
+	return p.inheritToPopups
+}
+
+// applyToPopup registers the routes, init scripts and bindings of the page on
+// popup, which inherits them to its own popups as well. The popup may have
+// started loading its initial document already, which escapes them.
+func (p *pageImpl) applyToPopup(popup *pageImpl) error {
+	p.RLock()
+	routes := make([]*routeHandlerEntry, len(p.routes))
+	copy(routes, p.routes)
+	initScripts := make([]string, len(p.initScripts))
+	copy(initScripts, p.initScripts)
+	bindings := make(map[string]BindingCallFunction, len(p.bindings))
+	bindingHandles := make(map[string]bool, len(p.bindings))
+	for name, binding := range p.bindings {
+		bindings[name] = binding
+		bindingHandles[name] = p.bindingHandles[name]
+	}
+	p.RUnlock()
+	popup.SetInheritToPopups(true)
+	for _, route := range routes {
+		times, ok := route.remainingTimes()
+		if !ok {
+			continue
+		}
+		if err := popup.Route(route.matcher.urlOrPredicate, route.handler, PageRouteOptions{
+			Times: times,
+		}); err != nil {
+			return fmt.Errorf("could not add route: %w", err)
+		}
+	}
+	for _, script := range initScripts {
+		if err := popup.AddInitScript(PageAddInitScriptOptions{
+			Script: String(script),
+		}); err != nil {
+			return fmt.Errorf("could not add init script: %w", err)
+		}
+	}
+	for name, binding := range bindings {
+		if err := popup.ExposeBinding(name, binding, bindingHandles[name]); err != nil {
+			return fmt.Errorf("could not expose binding: %w", err)
+		}
+	}
+	return nil
+}
+
 func (p *pageImpl) onFrameAttached(frame *frameImpl) {
 	frame.page = p
 	p.frames = append(p.frames, frame)
@@ -623,12 +1265,24 @@ func (p *pageImpl) onFrameDetached(frame *frameImpl) {
 
 func (p *pageImpl) onRoute(route *routeImpl, request *requestImpl) {
 	go func() {
-		for _, handlerEntry := range p.routes {
-			if handlerEntry.matcher.Matches(request.URL()) {
-				handlerEntry.handler(route, request)
-				return
+		if p.browserContext.abortIfBlocked(route, request) {
+			return
+		}
+		route.extraHeaders = p.browserContext.scopedHeadersFor(request)
+		p.Lock()
+		routes := append([]*routeHandlerEntry{}, p.routes...)
+		p.Unlock()
+		handled, expired := dispatchRoute(routes, route, request)
+		if expired != nil {
+			if err := p.updateInterception(func() {
+				p.routes = removeRouteHandlerEntry(p.routes, expired)
+			}); err != nil {
+				log.Printf("could not update network interception: %v", err)
 			}
 		}
+		if handled {
+			return
+		}
 		p.browserContext.onRoute(route, request)
 	}()
 }
@@ -649,6 +1303,19 @@ func (p *pageImpl) onClose() {
 		}
 	}
 	p.browserContext.pages = newPages
+	if p.browserContext.activePage == p {
+		p.browserContext.activePage = nil
+		if len(newPages) > 0 {
+			p.browserContext.activePage = newPages[len(newPages)-1]
+		}
+	}
+	backgroundPages := []BackgroundPage{}
+	for _, page := range p.browserContext.backgroundPages {
+		if page.(*backgroundPageImpl).pageImpl != p {
+			backgroundPages = append(backgroundPages, page)
+		}
+	}
+	p.browserContext.backgroundPages = backgroundPages
 	p.browserContext.Unlock()
 	p.Emit("close")
 }
@@ -665,6 +1332,10 @@ func (p *pageImpl) Uncheck(selector string, options ...FrameUncheckOptions) erro
 	return p.mainFrame.Uncheck(selector, options...)
 }
 
+func (p *pageImpl) SetChecked(selector string, checked bool, options ...FrameSetCheckedOptions) error {
+	return p.mainFrame.SetChecked(selector, checked, options...)
+}
+
 func (p *pageImpl) WaitForTimeout(timeout float64) {
 	p.mainFrame.WaitForTimeout(timeout)
 }
@@ -713,6 +1384,7 @@ func (p *pageImpl) ExposeBinding(name string, binding BindingCallFunction, handl
 		return fmt.Errorf("Function '%s' has been already registered in the browser context", name)
 	}
 	p.bindings[name] = binding
+	p.bindingHandles[name] = needsHandle
 	_, err := p.channel.Send("exposeBinding", map[string]interface{}{
 		"name":        name,
 		"needsHandle": needsHandle,
@@ -748,6 +1420,10 @@ func (p *pageImpl) IsVisible(selector string, options ...FrameIsVisibleOptions)
 	return p.mainFrame.IsVisible(selector, options...)
 }
 
+func (p *pageImpl) Locator(selector string) Locator {
+	return p.mainFrame.Locator(selector)
+}
+
 func (p *pageImpl) DragAndDrop(source, target string, options ...FrameDragAndDropOptions) error {
 	return p.mainFrame.DragAndDrop(source, target, options...)
 }
@@ -760,6 +1436,6 @@ func (p *pageImpl) InputValue(selector string, options ...FrameInputValueOptions
 	return p.mainFrame.InputValue(selector, options...)
 }
 
-func (p *pageImpl) WaitForURL(url string, options ...FrameWaitForURLOptions) error {
+func (p *pageImpl) WaitForURL(url interface{}, options ...FrameWaitForURLOptions) error {
 	return p.mainFrame.WaitForURL(url, options...)
 }
@@ -0,0 +1,144 @@
+package playwright
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// PageSnapshot is the state of a page which got taken with Page.Snapshot(),
+// it can be serialized to JSON to restore it in a later run.
+type PageSnapshot struct {
+	// URL of the main frame.
+	URL string `json:"url"`
+	// All cookies of the browser context.
+	Cookies []*NetworkCookie `json:"cookies"`
+	// localStorage of the origin of the main frame.
+	LocalStorage map[string]string `json:"localStorage"`
+	// sessionStorage of the origin of the main frame.
+	SessionStorage map[string]string `json:"sessionStorage"`
+}
+
+const snapshotStorageScript = `() => {
+  const read = storage => {
+    const result = {};
+    for (let i = 0; i < storage.length; i++) {
+      const key = storage.key(i);
+      result[key] = storage.getItem(key);
+    }
+    return result;
+  };
+  return { localStorage: read(localStorage), sessionStorage: read(sessionStorage) };
+}`
+
+const restoreStorageScript = `snapshot => {
+  const write = (storage, items) => {
+    storage.clear();
+    for (const [key, value] of Object.entries(items || {}))
+      storage.setItem(key, value);
+  };
+  write(localStorage, snapshot.localStorage);
+  write(sessionStorage, snapshot.sessionStorage);
+}`
+
+func (p *pageImpl) Snapshot() (*PageSnapshot, error) {
+	cookies, err := p.browserContext.Cookies()
+	if err != nil {
+		return nil, fmt.Errorf("could not get cookies: %w", err)
+	}
+	result, err := p.mainFrame.Evaluate(snapshotStorageScript)
+	if err != nil {
+		return nil, fmt.Errorf("could not read storage: %w", err)
+	}
+	storage := result.(map[string]interface{})
+	return &PageSnapshot{
+		URL:            p.URL(),
+		Cookies:        cookies,
+		LocalStorage:   toStringMap(storage["localStorage"]),
+		SessionStorage: toStringMap(storage["sessionStorage"]),
+	}, nil
+}
+
+func (p *pageImpl) RestoreSnapshot(snapshot *PageSnapshot) error {
+	cookies := make([]SetNetworkCookieParam, 0, len(snapshot.Cookies))
+	for _, cookie := range snapshot.Cookies {
+		cookies = append(cookies, SetNetworkCookieParam{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   String(cookie.Domain),
+			Path:     String(cookie.Path),
+			Expires:  Int(cookie.Expires),
+			HttpOnly: Bool(cookie.HttpOnly),
+			Secure:   Bool(cookie.Secure),
+			SameSite: String(cookie.SameSite),
+		})
+	}
+	if len(cookies) > 0 {
+		if err := p.browserContext.AddCookies(cookies...); err != nil {
+			return fmt.Errorf("could not restore cookies: %w", err)
+		}
+	}
+	// The storage has to be written before the scripts of the page run, so
+	// the document gets loaded as a blank one first.
+	if err := p.withBlankDocument(snapshot.URL, func() error {
+		if _, err := p.Goto(snapshot.URL); err != nil {
+			return err
+		}
+		_, err := p.mainFrame.Evaluate(restoreStorageScript, map[string]interface{}{
+			"localStorage":   snapshot.LocalStorage,
+			"sessionStorage": snapshot.SessionStorage,
+		})
+		return err
+	}); err != nil {
+		return fmt.Errorf("could not restore storage: %w", err)
+	}
+	if _, err := p.Goto(snapshot.URL); err != nil {
+		return fmt.Errorf("could not navigate to %s: %w", snapshot.URL, err)
+	}
+	return nil
+}
+
+// withBlankDocument runs fn while navigations to url get fulfilled with an
+// empty document, ahead of all routes registered by the user.
+func (p *pageImpl) withBlankDocument(url string, fn func() error) error {
+	// Requests do not carry the fragment of the URL.
+	url = strings.SplitN(url, "#", 2)[0]
+	entry := newRouteHandlerEntry(newURLMatcher(func(requestURL string) bool {
+		return requestURL == url
+	}), func(route Route, request Request) {
+		if err := route.Fulfill(RouteFulfillOptions{
+			Body:        "<html></html>",
+			ContentType: String("text/html"),
+		}); err != nil {
+			log.Printf("could not fulfill blank document: %v", err)
+		}
+	})
+	if err := p.updateInterception(func() {
+		p.routes = append([]*routeHandlerEntry{entry}, p.routes...)
+	}); err != nil {
+		return err
+	}
+	fnErr := fn()
+	if err := p.updateInterception(func() {
+		routes := make([]*routeHandlerEntry, 0, len(p.routes))
+		for _, route := range p.routes {
+			if route != entry {
+				routes = append(routes, route)
+			}
+		}
+		p.routes = routes
+	}); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+func toStringMap(in interface{}) map[string]string {
+	out := make(map[string]string)
+	if m, ok := in.(map[string]interface{}); ok {
+		for key, value := range m {
+			out[key] = fmt.Sprint(value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package playwright
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// defaultSubscriptionBufferSize is the capacity of the channel of a
+// subscription when BufferSize is not set.
+const defaultSubscriptionBufferSize = 100
+
+// eventSubscription is the untyped part of a channel based subscription.
+// Events which arrive while the channel is full get dropped, blocking would
+// stall the connection.
+type eventSubscription struct {
+	// dropped is accessed atomically and comes first to be 64-bit aligned.
+	dropped int64
+	hub     *eventSubscriptions
+	event   string
+	send    func(payload interface{}) bool
+	close   func()
+	closed  bool
+}
+
+// Dropped returns the number of events which got dropped because the channel
+// was full.
+func (s *eventSubscription) Dropped() int {
+	return int(atomic.LoadInt64(&s.dropped))
+}
+
+// Close stops the subscription and closes its channel. Subscriptions get
+// closed automatically when the page closes.
+func (s *eventSubscription) Close() {
+	s.hub.Lock()
+	defer s.hub.Unlock()
+	s.closeLocked()
+}
+
+func (s *eventSubscription) closeLocked() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(s.hub.subscriptions[s.event], s)
+	s.close()
+}
+
+// eventSubscriptions dispatches the events of an emitter to the channel based
+// subscriptions. A single listener gets registered per event, the listeners of
+// the emitter can not be removed individually for closures.
+type eventSubscriptions struct {
+	sync.Mutex
+	subscriptions map[string]map[*eventSubscription]bool
+	closed        bool
+}
+
+// subscribe adds the subscription, the listeners get registered without
+// holding the lock as the emitter calls them with its own lock held.
+func (h *eventSubscriptions) subscribe(emitter EventEmitter, event string, subscription *eventSubscription) {
+	h.Lock()
+	subscription.hub = h
+	subscription.event = event
+	if h.closed {
+		subscription.closeLocked()
+		h.Unlock()
+		return
+	}
+	watchClose := h.subscriptions == nil
+	if watchClose {
+		h.subscriptions = make(map[string]map[*eventSubscription]bool)
+	}
+	_, watching := h.subscriptions[event]
+	if !watching {
+		h.subscriptions[event] = make(map[*eventSubscription]bool)
+	}
+	h.subscriptions[event][subscription] = true
+	h.Unlock()
+	if watchClose {
+		emitter.Once("close", h.closeAll)
+	}
+	if !watching {
+		emitter.On(event, func(payload interface{}) {
+			h.dispatch(event, payload)
+		})
+	}
+}
+
+func (h *eventSubscriptions) dispatch(event string, payload interface{}) {
+	h.Lock()
+	defer h.Unlock()
+	for subscription := range h.subscriptions[event] {
+		if !subscription.send(payload) {
+			atomic.AddInt64(&subscription.dropped, 1)
+		}
+	}
+}
+
+func (h *eventSubscriptions) closeAll() {
+	h.Lock()
+	defer h.Unlock()
+	h.closed = true
+	for _, subscriptions := range h.subscriptions {
+		for subscription := range subscriptions {
+			subscription.closeLocked()
+		}
+	}
+}
+
+func subscriptionBufferSize(options []PageSubscriptionOptions) int {
+	if len(options) == 1 && options[0].BufferSize != nil {
+		return *options[0].BufferSize
+	}
+	return defaultSubscriptionBufferSize
+}
+
+// subscribe adds a subscription to the events of the page, subscriptions to
+// a closed page get closed right away.
+func (p *pageImpl) subscribe(event string, subscription *eventSubscription) {
+	p.subscriptions.subscribe(p, event, subscription)
+	if p.IsClosed() {
+		p.subscriptions.closeAll()
+	}
+}
+
+// ConsoleMessageSubscription delivers the console messages of a page on C.
+type ConsoleMessageSubscription struct {
+	C <-chan ConsoleMessage
+	*eventSubscription
+}
+
+// PageErrorSubscription delivers the uncaught exceptions of a page on C.
+type PageErrorSubscription struct {
+	C <-chan error
+	*eventSubscription
+}
+
+// RequestSubscription delivers the requests of a page on C.
+type RequestSubscription struct {
+	C <-chan Request
+	*eventSubscription
+}
+
+// ResponseSubscription delivers the responses of a page on C.
+type ResponseSubscription struct {
+	C <-chan Response
+	*eventSubscription
+}
+
+func (p *pageImpl) ConsoleMessages(options ...PageSubscriptionOptions) *ConsoleMessageSubscription {
+	messages := make(chan ConsoleMessage, subscriptionBufferSize(options))
+	subscription := &eventSubscription{
+		send: func(payload interface{}) bool {
+			select {
+			case messages <- payload.(ConsoleMessage):
+				return true
+			default:
+				return false
+			}
+		},
+		close: func() { close(messages) },
+	}
+	p.subscribe("console", subscription)
+	return &ConsoleMessageSubscription{C: messages, eventSubscription: subscription}
+}
+
+func (p *pageImpl) PageErrors(options ...PageSubscriptionOptions) *PageErrorSubscription {
+	errs := make(chan error, subscriptionBufferSize(options))
+	subscription := &eventSubscription{
+		send: func(payload interface{}) bool {
+			select {
+			case errs <- payload.(error):
+				return true
+			default:
+				return false
+			}
+		},
+		close: func() { close(errs) },
+	}
+	p.subscribe("pageerror", subscription)
+	return &PageErrorSubscription{C: errs, eventSubscription: subscription}
+}
+
+func (p *pageImpl) Requests(options ...PageSubscriptionOptions) *RequestSubscription {
+	requests := make(chan Request, subscriptionBufferSize(options))
+	subscription := &eventSubscription{
+		send: func(payload interface{}) bool {
+			select {
+			case requests <- payload.(Request):
+				return true
+			default:
+				return false
+			}
+		},
+		close: func() { close(requests) },
+	}
+	p.subscribe("request", subscription)
+	return &RequestSubscription{C: requests, eventSubscription: subscription}
+}
+
+func (p *pageImpl) Responses(options ...PageSubscriptionOptions) *ResponseSubscription {
+	responses := make(chan Response, subscriptionBufferSize(options))
+	subscription := &eventSubscription{
+		send: func(payload interface{}) bool {
+			select {
+			case responses <- payload.(Response):
+				return true
+			default:
+				return false
+			}
+		},
+		close: func() { close(responses) },
+	}
+	p.subscribe("response", subscription)
+	return &ResponseSubscription{C: responses, eventSubscription: subscription}
+}
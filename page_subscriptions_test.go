@@ -0,0 +1,57 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestErrorSubscription(hub *eventSubscriptions, emitter EventEmitter, size int) (<-chan error, *eventSubscription) {
+	errs := make(chan error, size)
+	subscription := &eventSubscription{
+		send: func(payload interface{}) bool {
+			select {
+			case errs <- payload.(error):
+				return true
+			default:
+				return false
+			}
+		},
+		close: func() { close(errs) },
+	}
+	hub.subscribe(emitter, "pageerror", subscription)
+	return errs, subscription
+}
+
+func TestEventSubscriptions(t *testing.T) {
+	emitter := &eventEmitter{}
+	emitter.initEventEmitter()
+	hub := &eventSubscriptions{}
+	first, firstSubscription := newTestErrorSubscription(hub, emitter, 1)
+	second, secondSubscription := newTestErrorSubscription(hub, emitter, 2)
+	require.Len(t, emitter.events["pageerror"].on, 1)
+
+	emitter.Emit("pageerror", errors.New("first"))
+	emitter.Emit("pageerror", errors.New("second"))
+	require.EqualError(t, <-first, "first")
+	require.Equal(t, 1, firstSubscription.Dropped())
+	require.EqualError(t, <-second, "first")
+	require.EqualError(t, <-second, "second")
+
+	firstSubscription.Close()
+	firstSubscription.Close()
+	_, ok := <-first
+	require.False(t, ok)
+	emitter.Emit("pageerror", errors.New("third"))
+	require.EqualError(t, <-second, "third")
+
+	emitter.Emit("close")
+	_, ok = <-second
+	require.False(t, ok)
+	require.Equal(t, 0, secondSubscription.Dropped())
+
+	third, _ := newTestErrorSubscription(hub, emitter, 1)
+	_, ok = <-third
+	require.False(t, ok)
+}
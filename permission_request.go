@@ -0,0 +1,170 @@
+package playwright
+
+import (
+	"fmt"
+)
+
+const permissionRequestBinding = "__playwrightPermissionRequest"
+
+// permissionPromptScript wraps the APIs which prompt the user for a permission
+// so that the request gets reported to the permissionRequestBinding first. The
+// binding resolves with "denied" when the request got denied, otherwise the
+// original API gets called.
+const permissionPromptScript = `() => {
+  if (window.` + permissionRequestBinding + `Installed)
+    return;
+  window.` + permissionRequestBinding + `Installed = true;
+  const request = name => window.` + permissionRequestBinding + `(name, location.origin);
+  const permissionDenied = () => new DOMException('Permission denied', 'NotAllowedError');
+
+  const geolocation = navigator.geolocation;
+  if (geolocation) {
+    const getCurrentPosition = geolocation.getCurrentPosition.bind(geolocation);
+    const watchPosition = geolocation.watchPosition.bind(geolocation);
+    const clearWatch = geolocation.clearWatch.bind(geolocation);
+    const positionError = { code: 1, message: 'User denied Geolocation', PERMISSION_DENIED: 1, POSITION_UNAVAILABLE: 2, TIMEOUT: 3 };
+    const watches = new Map();
+    let lastWatchId = 0;
+    geolocation.getCurrentPosition = (success, error, options) => {
+      request('geolocation').then(decision => {
+        if (decision === 'denied')
+          error && error(positionError);
+        else
+          getCurrentPosition(success, error, options);
+      });
+    };
+    geolocation.watchPosition = (success, error, options) => {
+      const watchId = ++lastWatchId;
+      watches.set(watchId, undefined);
+      request('geolocation').then(decision => {
+        if (!watches.has(watchId))
+          return;
+        if (decision === 'denied')
+          error && error(positionError);
+        else
+          watches.set(watchId, watchPosition(success, error, options));
+      });
+      return watchId;
+    };
+    geolocation.clearWatch = watchId => {
+      if (watches.get(watchId) !== undefined)
+        clearWatch(watches.get(watchId));
+      watches.delete(watchId);
+    };
+  }
+
+  if (window.Notification) {
+    const requestPermission = window.Notification.requestPermission.bind(window.Notification);
+    window.Notification.requestPermission = callback => request('notifications').then(decision => {
+      const result = decision === 'denied' ? Promise.resolve('denied') : requestPermission();
+      return result.then(permission => {
+        callback && callback(permission);
+        return permission;
+      });
+    });
+  }
+
+  const mediaDevices = navigator.mediaDevices;
+  if (mediaDevices && mediaDevices.getUserMedia) {
+    const getUserMedia = mediaDevices.getUserMedia.bind(mediaDevices);
+    mediaDevices.getUserMedia = async constraints => {
+      const names = [];
+      if (constraints && constraints.video)
+        names.push('camera');
+      if (constraints && constraints.audio)
+        names.push('microphone');
+      for (const name of names) {
+        if (await request(name) === 'denied')
+          throw permissionDenied();
+      }
+      return getUserMedia(constraints);
+    };
+  }
+
+  const clipboard = navigator.clipboard;
+  if (clipboard) {
+    for (const method of ['read', 'readText']) {
+      if (!clipboard[method])
+        continue;
+      const original = clipboard[method].bind(clipboard);
+      clipboard[method] = async () => {
+        if (await request('clipboard-read') === 'denied')
+          throw permissionDenied();
+        return original();
+      };
+    }
+  }
+}`
+
+type permissionRequestImpl struct {
+	frame    Frame
+	name     string
+	origin   string
+	decision string
+}
+
+func (r *permissionRequestImpl) Name() string {
+	return r.name
+}
+
+func (r *permissionRequestImpl) Origin() string {
+	return r.origin
+}
+
+func (r *permissionRequestImpl) Page() Page {
+	return r.frame.Page()
+}
+
+func (r *permissionRequestImpl) Frame() Frame {
+	return r.frame
+}
+
+func (r *permissionRequestImpl) Grant() error {
+	if err := r.frame.Page().Context().GrantPermissions([]string{r.name}, BrowserContextGrantPermissionsOptions{
+		Origin: String(r.origin),
+	}); err != nil {
+		return fmt.Errorf("could not grant permission: %w", err)
+	}
+	r.decision = "granted"
+	return nil
+}
+
+func (r *permissionRequestImpl) Deny() {
+	r.decision = "denied"
+}
+
+func (b *browserContextImpl) EnablePermissionPrompts() error {
+	b.permissionPromptsLock.Lock()
+	defer b.permissionPromptsLock.Unlock()
+	b.Lock()
+	enabled := b.permissionPromptsEnabled
+	_, exposed := b.bindings[permissionRequestBinding]
+	b.Unlock()
+	if enabled {
+		return nil
+	}
+	// The binding stays registered when a previous call failed afterwards.
+	if !exposed {
+		if err := b.ExposeBinding(permissionRequestBinding, b.onPermissionRequest); err != nil {
+			return fmt.Errorf("could not enable permission prompts: %w", err)
+		}
+	}
+	if err := b.addInitFunction(permissionPromptScript, nil); err != nil {
+		return fmt.Errorf("could not enable permission prompts: %w", err)
+	}
+	b.Lock()
+	b.permissionPromptsEnabled = true
+	b.Unlock()
+	return nil
+}
+
+func (b *browserContextImpl) onPermissionRequest(source *BindingSource, args ...interface{}) interface{} {
+	request := &permissionRequestImpl{
+		frame:  source.Frame,
+		name:   args[0].(string),
+		origin: args[1].(string),
+	}
+	source.Page.(*pageImpl).Emit("permissionrequest", request)
+	b.Emit("permissionrequest", request)
+	return request.decision
+}
@@ -3,6 +3,8 @@
 // is ever-green, capable, reliable and fast.
 package playwright
 
+import "fmt"
+
 // DeviceDescriptor represents a single device
 type DeviceDescriptor struct {
 	UserAgent          string                            `json:"userAgent"`
@@ -16,10 +18,79 @@ type DeviceDescriptor struct {
 // Playwright represents a Playwright instance
 type Playwright struct {
 	channelOwner
-	Chromium BrowserType
-	Firefox  BrowserType
-	WebKit   BrowserType
-	Devices  map[string]*DeviceDescriptor
+	Chromium  BrowserType
+	Firefox   BrowserType
+	WebKit    BrowserType
+	Selectors Selectors
+	Devices   map[string]*DeviceDescriptor
+	Presets   map[string]*ContextPreset
+	Request   APIRequest
+	hooks     *lifecycleHooks
+}
+
+// RegisterDevice adds a custom device descriptor, or replaces an existing one,
+// so it can be emulated via the `Device` option of Browser.NewContext() and
+// BrowserType.LaunchPersistentContext().
+func (p *Playwright) RegisterDevice(name string, descriptor *DeviceDescriptor) {
+	p.Lock()
+	defer p.Unlock()
+	p.Devices[name] = descriptor
+}
+
+// OverrideDevice changes the fields of a registered device descriptor, e.g. the
+// user agent of one of the built-in devices.
+func (p *Playwright) OverrideDevice(name string, override func(descriptor *DeviceDescriptor)) error {
+	p.Lock()
+	defer p.Unlock()
+	descriptor, ok := p.Devices[name]
+	if !ok {
+		return fmt.Errorf("unknown device: %s", name)
+	}
+	override(descriptor)
+	return nil
+}
+
+// Clone returns a deep copy of the device descriptor, which can be used as a
+// base for registering a custom device.
+func (d *DeviceDescriptor) Clone() *DeviceDescriptor {
+	clone := *d
+	if d.Viewport != nil {
+		clone.Viewport = &BrowserNewContextOptionsViewport{}
+		if d.Viewport.Width != nil {
+			clone.Viewport.Width = Int(*d.Viewport.Width)
+		}
+		if d.Viewport.Height != nil {
+			clone.Viewport.Height = Int(*d.Viewport.Height)
+		}
+	}
+	return &clone
+}
+
+func (p *Playwright) deviceOverrides(name string) (map[string]interface{}, error) {
+	p.RLock()
+	defer p.RUnlock()
+	descriptor, ok := p.Devices[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown device: %s", name)
+	}
+	overrides := map[string]interface{}{
+		"deviceScaleFactor": descriptor.DeviceScaleFactor,
+		"isMobile":          descriptor.IsMobile,
+		"hasTouch":          descriptor.HasTouch,
+	}
+	if descriptor.UserAgent != "" {
+		overrides["userAgent"] = descriptor.UserAgent
+	}
+	if descriptor.Viewport != nil {
+		overrides["viewport"] = descriptor.Viewport
+	}
+	return overrides, nil
+}
+
+// Connection returns the connection to the driver, which allows to intercept
+// the protocol messages.
+func (p *Playwright) Connection() Connection {
+	return p.connection
 }
 
 // Stop stops the Playwright instance
@@ -33,6 +104,12 @@ func newPlaywright(parent *channelOwner, objectType string, guid string, initial
 		Firefox:  fromChannel(initializer["firefox"]).(*browserTypeImpl),
 		WebKit:   fromChannel(initializer["webkit"]).(*browserTypeImpl),
 		Devices:  make(map[string]*DeviceDescriptor),
+		Presets:  make(map[string]*ContextPreset),
+		Request:  &apiRequestImpl{},
+		hooks:    &lifecycleHooks{},
+	}
+	if selectors, ok := fromNullableChannel(initializer["selectors"]).(*selectorsImpl); ok {
+		pw.Selectors = selectors
 	}
 	for _, dd := range initializer["deviceDescriptors"].([]interface{}) {
 		entry := dd.(map[string]interface{})
@@ -42,5 +119,6 @@ func newPlaywright(parent *channelOwner, objectType string, guid string, initial
 		remapMapToStruct(entry["descriptor"], pw.Devices[entry["name"].(string)])
 	}
 	pw.createChannelOwner(pw, parent, objectType, guid, initializer)
+	pw.connection.playwright = pw
 	return pw
 }
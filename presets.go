@@ -0,0 +1,110 @@
+package playwright
+
+import "fmt"
+
+// ContextPreset is a named set of context options, e.g. the locale, timezone,
+// location, headers and cookies of a market or of an A/B test group. Presets
+// get registered with Playwright.RegisterPreset() and applied with the
+// `Preset` option of Browser.NewContext() and
+// BrowserType.LaunchPersistentContext(). Explicitly passed options take
+// precedence over the values of the preset, extra HTTP headers get merged.
+type ContextPreset struct {
+	// Locale of the context, for example `en-GB` or `de-DE`.
+	Locale *string
+	// Timezone of the context, for example `Europe/Berlin`.
+	TimezoneId *string
+	// Geolocation of the context. The `geolocation` permission needs to be
+	// granted via Permissions for the pages to read it.
+	Geolocation *BrowserContextGeolocation
+	// Permissions which get granted to all pages of the context.
+	Permissions []string
+	// Additional HTTP headers which get sent with every request.
+	ExtraHttpHeaders map[string]string
+	// Cookies which get added to the context after it got created. Either
+	// URL or Domain and Path need to be set.
+	Cookies []SetNetworkCookieParam
+}
+
+// Clone returns a copy of the preset, which can be used as a base for
+// registering a derived preset.
+func (p *ContextPreset) Clone() *ContextPreset {
+	clone := *p
+	if p.Geolocation != nil {
+		geolocation := *p.Geolocation
+		clone.Geolocation = &geolocation
+	}
+	if p.Permissions != nil {
+		clone.Permissions = append([]string{}, p.Permissions...)
+	}
+	if p.ExtraHttpHeaders != nil {
+		clone.ExtraHttpHeaders = make(map[string]string, len(p.ExtraHttpHeaders))
+		for name, value := range p.ExtraHttpHeaders {
+			clone.ExtraHttpHeaders[name] = value
+		}
+	}
+	if p.Cookies != nil {
+		clone.Cookies = append([]SetNetworkCookieParam{}, p.Cookies...)
+	}
+	return &clone
+}
+
+// RegisterPreset adds a context preset, or replaces an existing one, so it can
+// be applied via the `Preset` option of Browser.NewContext() and
+// BrowserType.LaunchPersistentContext().
+func (p *Playwright) RegisterPreset(name string, preset *ContextPreset) {
+	p.Lock()
+	defer p.Unlock()
+	p.Presets[name] = preset
+}
+
+func (p *Playwright) preset(name string) (*ContextPreset, error) {
+	p.RLock()
+	defer p.RUnlock()
+	preset, ok := p.Presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset: %s", name)
+	}
+	return preset.Clone(), nil
+}
+
+// applyDefaults sets the options of the preset which are not set explicitly.
+// It runs before the defaults of the deterministic rendering mode get applied,
+// so the values of the preset win over them.
+func (p *ContextPreset) applyDefaults(locale, timezoneID **string, permissions *[]string) {
+	if *locale == nil && p.Locale != nil {
+		*locale = String(*p.Locale)
+	}
+	if *timezoneID == nil && p.TimezoneId != nil {
+		*timezoneID = String(*p.TimezoneId)
+	}
+	if *permissions == nil && p.Permissions != nil {
+		*permissions = p.Permissions
+	}
+}
+
+// extraHTTPHeaders returns the extra HTTP headers of the preset combined with the
+// explicitly passed ones, which win on conflicts.
+func (p *ContextPreset) extraHTTPHeaders(headers map[string]string) map[string]string {
+	if len(p.ExtraHttpHeaders) == 0 {
+		return headers
+	}
+	merged := make(map[string]string, len(p.ExtraHttpHeaders)+len(headers))
+	for name, value := range p.ExtraHttpHeaders {
+		merged[name] = value
+	}
+	for name, value := range headers {
+		merged[name] = value
+	}
+	return merged
+}
+
+// applyPreset adds the cookies of the preset to a newly created context.
+func (b *browserContextImpl) applyPreset(preset *ContextPreset) error {
+	if len(preset.Cookies) == 0 {
+		return nil
+	}
+	if err := b.AddCookies(preset.Cookies...); err != nil {
+		return fmt.Errorf("could not add cookies of preset: %w", err)
+	}
+	return nil
+}
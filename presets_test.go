@@ -0,0 +1,50 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextPresetApplyDefaults(t *testing.T) {
+	preset := &ContextPreset{
+		Locale:      String("de-DE"),
+		TimezoneId:  String("Europe/Berlin"),
+		Permissions: []string{"geolocation"},
+	}
+	options := BrowserNewContextOptions{Locale: String("fr-FR")}
+	preset.applyDefaults(&options.Locale, &options.TimezoneId, &options.Permissions)
+	require.Equal(t, "fr-FR", *options.Locale)
+	require.Equal(t, "Europe/Berlin", *options.TimezoneId)
+	require.Equal(t, []string{"geolocation"}, options.Permissions)
+
+	applyDeterministicDefaults(&options.TimezoneId, &options.Locale, &options.ReducedMotion)
+	require.Equal(t, "Europe/Berlin", *options.TimezoneId)
+}
+
+func TestContextPresetExtraHTTPHeaders(t *testing.T) {
+	preset := &ContextPreset{
+		ExtraHttpHeaders: map[string]string{"X-Market": "de", "X-Variant": "a"},
+	}
+	require.Equal(t, map[string]string{"X-Market": "de", "X-Variant": "b"},
+		preset.extraHTTPHeaders(map[string]string{"X-Variant": "b"}))
+	require.Nil(t, (&ContextPreset{}).extraHTTPHeaders(nil))
+}
+
+func TestContextPresetClone(t *testing.T) {
+	preset := &ContextPreset{
+		Geolocation:      &BrowserContextGeolocation{Latitude: Float(52.52), Longitude: Float(13.40)},
+		Permissions:      []string{"geolocation"},
+		ExtraHttpHeaders: map[string]string{"X-Market": "de"},
+		Cookies:          []SetNetworkCookieParam{{Name: "variant", Value: "a", URL: String("https://example.com")}},
+	}
+	clone := preset.Clone()
+	clone.Geolocation.Latitude = Float(48.14)
+	clone.Permissions[0] = "notifications"
+	clone.ExtraHttpHeaders["X-Market"] = "at"
+	clone.Cookies[0].Value = "b"
+	require.Equal(t, 52.52, *preset.Geolocation.Latitude)
+	require.Equal(t, []string{"geolocation"}, preset.Permissions)
+	require.Equal(t, "de", preset.ExtraHttpHeaders["X-Market"])
+	require.Equal(t, "a", preset.Cookies[0].Value)
+}
@@ -0,0 +1,180 @@
+package playwright
+
+import (
+	"errors"
+	"log"
+)
+
+// ProtocolDirection is the direction of a ProtocolMessage
+type ProtocolDirection string
+
+const (
+	// ProtocolDirectionSend is used for messages which get sent to the driver.
+	ProtocolDirectionSend ProtocolDirection = "send"
+	// ProtocolDirectionReceive is used for messages which get received from the driver.
+	ProtocolDirectionReceive ProtocolDirection = "receive"
+)
+
+// ProtocolError is the error of a ProtocolMessage which answers a failed call
+type ProtocolError struct {
+	Name    string
+	Message string
+	Stack   string
+}
+
+// ProtocolMessage is a message of the protocol between the client and the
+// driver. Calls have an ID, a GUID of the object they get sent to, a method
+// and params. Their responses have the same ID and either a result or an
+// error. Events have no ID.
+type ProtocolMessage struct {
+	Direction ProtocolDirection
+	ID        int
+	GUID      string
+	Method    string
+	Params    map[string]interface{}
+	Result    interface{}
+	Error     *ProtocolError
+}
+
+// ProtocolMiddleware intercepts the messages of a Connection. It can inspect
+// and modify the message before it passes it on by calling next. A message
+// gets dropped if next does not get called. The returned error fails the call
+// of a sent message. The call of a dropped response fails with an error.
+type ProtocolMiddleware func(message *ProtocolMessage, next func(message *ProtocolMessage) error) error
+
+var errMessageDropped = errors.New("message got dropped by a middleware")
+
+func (c *connection) OnMessageSent(handler func(message ProtocolMessage)) {
+	c.middlewaresLock.Lock()
+	defer c.middlewaresLock.Unlock()
+	c.sentHandlers = append(c.sentHandlers, handler)
+}
+
+func (c *connection) OnMessageReceived(handler func(message ProtocolMessage)) {
+	c.middlewaresLock.Lock()
+	defer c.middlewaresLock.Unlock()
+	c.receivedHandlers = append(c.receivedHandlers, handler)
+}
+
+func (c *connection) Use(middleware ProtocolMiddleware) {
+	c.middlewaresLock.Lock()
+	defer c.middlewaresLock.Unlock()
+	c.middlewares = append(c.middlewares, middleware)
+}
+
+// intercept runs the message through the middlewares and passes it on to
+// the handlers and the final func, unless a middleware dropped it.
+func (c *connection) intercept(message *ProtocolMessage, handlers []func(message ProtocolMessage), final func(message *ProtocolMessage) error) error {
+	c.middlewaresLock.RLock()
+	middlewares := c.middlewares
+	c.middlewaresLock.RUnlock()
+	var next func(index int) func(message *ProtocolMessage) error
+	next = func(index int) func(message *ProtocolMessage) error {
+		return func(message *ProtocolMessage) error {
+			if index < len(middlewares) {
+				return middlewares[index](message, next(index+1))
+			}
+			for _, handler := range handlers {
+				handler(*message)
+			}
+			return final(message)
+		}
+	}
+	return next(0)(message)
+}
+
+func (c *connection) hasInterceptors() bool {
+	c.middlewaresLock.RLock()
+	defer c.middlewaresLock.RUnlock()
+	return len(c.middlewares) > 0 || len(c.sentHandlers) > 0 || len(c.receivedHandlers) > 0
+}
+
+func (c *connection) send(message map[string]interface{}) error {
+	if !c.hasInterceptors() {
+		return c.transport.Send(message)
+	}
+	protocolMessage := &ProtocolMessage{
+		Direction: ProtocolDirectionSend,
+		ID:        message["id"].(int),
+		GUID:      message["guid"].(string),
+		Method:    message["method"].(string),
+	}
+	if params, ok := message["params"].(map[string]interface{}); ok {
+		protocolMessage.Params = params
+	}
+	c.middlewaresLock.RLock()
+	handlers := c.sentHandlers
+	c.middlewaresLock.RUnlock()
+	sent := false
+	if err := c.intercept(protocolMessage, handlers, func(m *ProtocolMessage) error {
+		sent = true
+		out := map[string]interface{}{
+			"id":     m.ID,
+			"guid":   m.GUID,
+			"method": m.Method,
+			"params": m.Params,
+		}
+		if metadata, ok := message["metadata"]; ok {
+			out["metadata"] = metadata
+		}
+		return c.transport.Send(out)
+	}); err != nil {
+		return err
+	}
+	if !sent {
+		return errMessageDropped
+	}
+	return nil
+}
+
+// receive returns the message which should be dispatched or nil if it got
+// dropped by a middleware or a middleware failed.
+func (c *connection) receive(msg *message) *message {
+	if !c.hasInterceptors() {
+		return msg
+	}
+	protocolMessage := &ProtocolMessage{
+		Direction: ProtocolDirectionReceive,
+		ID:        msg.ID,
+		GUID:      msg.GUID,
+		Method:    msg.Method,
+		Params:    msg.Params,
+		Result:    msg.Result,
+	}
+	if msg.Error != nil {
+		protocolMessage.Error = &ProtocolError{
+			Name:    msg.Error.Error.Name,
+			Message: msg.Error.Error.Message,
+			Stack:   msg.Error.Error.Stack,
+		}
+	}
+	c.middlewaresLock.RLock()
+	handlers := c.receivedHandlers
+	c.middlewaresLock.RUnlock()
+	var result *message
+	if err := c.intercept(protocolMessage, handlers, func(m *ProtocolMessage) error {
+		result = &message{
+			ID:     m.ID,
+			GUID:   m.GUID,
+			Method: m.Method,
+			Params: m.Params,
+			Result: m.Result,
+		}
+		if m.Error != nil {
+			result.Error = &struct {
+				Error errorPayload `json:"error"`
+			}{
+				Error: errorPayload{
+					Name:    m.Error.Name,
+					Message: m.Error.Message,
+					Stack:   m.Error.Stack,
+				},
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Printf("could not receive message: %v", err)
+		return nil
+	}
+	return result
+}
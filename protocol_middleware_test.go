@@ -0,0 +1,133 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransport struct {
+	dispatch func(msg *message)
+	sent     chan map[string]interface{}
+}
+
+func (t *fakeTransport) Start() error {
+	return nil
+}
+
+func (t *fakeTransport) Stop() error {
+	return nil
+}
+
+func (t *fakeTransport) Send(message map[string]interface{}) error {
+	t.sent <- message
+	return nil
+}
+
+func (t *fakeTransport) SetDispatch(dispatch func(msg *message)) {
+	t.dispatch = dispatch
+}
+
+func newFakeConnection() (*connection, *fakeTransport) {
+	transport := &fakeTransport{
+		sent: make(chan map[string]interface{}, 10),
+	}
+	return newConnection(transport, func() error { return nil }), transport
+}
+
+func TestConnectionMiddlewareShouldModifySentMessages(t *testing.T) {
+	connection, transport := newFakeConnection()
+	sent := make(chan ProtocolMessage, 1)
+	connection.OnMessageSent(func(message ProtocolMessage) {
+		sent <- message
+	})
+	connection.Use(func(message *ProtocolMessage, next func(message *ProtocolMessage) error) error {
+		if message.Direction == ProtocolDirectionSend {
+			message.Params["foo"] = "baz"
+		}
+		return next(message)
+	})
+	result := make(chan interface{}, 1)
+	go func() {
+		data, err := connection.SendMessageToServer("", "ping", map[string]interface{}{
+			"foo": "bar",
+		})
+		require.NoError(t, err)
+		result <- data
+	}()
+	sentMessage := <-transport.sent
+	require.Equal(t, "ping", sentMessage["method"])
+	require.Equal(t, map[string]interface{}{"foo": "baz"}, sentMessage["params"])
+	observed := <-sent
+	require.Equal(t, ProtocolDirectionSend, observed.Direction)
+	require.Equal(t, "ping", observed.Method)
+	transport.dispatch(&message{
+		ID:     sentMessage["id"].(int),
+		Result: "pong",
+	})
+	require.Equal(t, "pong", <-result)
+}
+
+func TestConnectionMiddlewareShouldDropSentMessages(t *testing.T) {
+	connection, _ := newFakeConnection()
+	connection.Use(func(message *ProtocolMessage, next func(message *ProtocolMessage) error) error {
+		return nil
+	})
+	_, err := connection.SendMessageToServer("", "ping", nil)
+	require.Error(t, err)
+	require.ErrorIs(t, err, errMessageDropped)
+}
+
+func TestConnectionMiddlewareShouldModifyReceivedMessages(t *testing.T) {
+	connection, transport := newFakeConnection()
+	received := make([]ProtocolMessage, 0)
+	connection.OnMessageReceived(func(message ProtocolMessage) {
+		received = append(received, message)
+	})
+	connection.Use(func(message *ProtocolMessage, next func(message *ProtocolMessage) error) error {
+		if message.Direction == ProtocolDirectionReceive && message.Method == "dropped" {
+			return nil
+		}
+		if message.Direction == ProtocolDirectionReceive {
+			message.Params["foo"] = "baz"
+		}
+		return next(message)
+	})
+	events := make(chan interface{}, 2)
+	connection.rootObject.channel.On("event", func(params map[string]interface{}) {
+		events <- params["foo"]
+	})
+	transport.dispatch(&message{
+		Method: "dropped",
+		Params: map[string]interface{}{},
+	})
+	transport.dispatch(&message{
+		Method: "event",
+		Params: map[string]interface{}{"foo": "bar"},
+	})
+	require.Equal(t, "baz", <-events)
+	require.Len(t, received, 1)
+	require.Equal(t, ProtocolDirectionReceive, received[0].Direction)
+	require.Equal(t, "event", received[0].Method)
+}
+
+func TestConnectionMiddlewareShouldFailCallsOfDroppedResponses(t *testing.T) {
+	connection, transport := newFakeConnection()
+	connection.Use(func(message *ProtocolMessage, next func(message *ProtocolMessage) error) error {
+		if message.Direction == ProtocolDirectionReceive {
+			return nil
+		}
+		return next(message)
+	})
+	result := make(chan error, 1)
+	go func() {
+		_, err := connection.SendMessageToServer("", "ping", nil)
+		result <- err
+	}()
+	sentMessage := <-transport.sent
+	transport.dispatch(&message{
+		ID:     sentMessage["id"].(int),
+		Result: "pong",
+	})
+	require.ErrorIs(t, <-result, errMessageDropped)
+}
@@ -0,0 +1,97 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// validateProxy checks the proxy settings before they get passed to the
+// browser, which otherwise ignores settings it does not support and sends
+// the requests without the proxy or fails them with generic network errors.
+func validateProxy(browserName string, server, username, password *string) error {
+	if server == nil || strings.TrimSpace(*server) == "" {
+		return errors.New("proxy server is required")
+	}
+	scheme := "http"
+	if strings.Contains(*server, "://") {
+		parsed, err := url.Parse(*server)
+		if err != nil {
+			return fmt.Errorf("invalid proxy server '%s': %w", *server, err)
+		}
+		scheme = strings.ToLower(parsed.Scheme)
+		if parsed.Host == "" {
+			return fmt.Errorf("invalid proxy server '%s': missing host", *server)
+		}
+	}
+	switch scheme {
+	case "http", "https", "socks4", "socks5":
+	default:
+		return fmt.Errorf("proxy scheme '%s' is not supported, use http, https, socks4 or socks5", scheme)
+	}
+	hasUsername := username != nil && *username != ""
+	hasPassword := password != nil && *password != ""
+	if hasPassword && !hasUsername {
+		return errors.New("proxy password requires a username")
+	}
+	if hasUsername && strings.HasPrefix(scheme, "socks") {
+		// The browsers only answer authentication challenges of HTTP proxies,
+		// the credentials of SOCKS proxies get dropped silently.
+		return fmt.Errorf("%s does not support authentication for %s proxies, use an HTTP proxy or a SOCKS proxy without credentials", browserName, scheme)
+	}
+	return nil
+}
+
+// proxySettings is the proxy of a browser or browser context, which requests
+// sent by the client on behalf of the browser use as well.
+type proxySettings struct {
+	server   string
+	bypass   []string
+	username string
+	password string
+}
+
+func newProxySettings(server, bypass, username, password *string) *proxySettings {
+	if server == nil || strings.TrimSpace(*server) == "" {
+		return nil
+	}
+	settings := &proxySettings{server: strings.TrimSpace(*server)}
+	if !strings.Contains(settings.server, "://") {
+		settings.server = "http://" + settings.server
+	}
+	if bypass != nil {
+		for _, domain := range strings.Split(*bypass, ",") {
+			if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+				settings.bypass = append(settings.bypass, domain)
+			}
+		}
+	}
+	if username != nil {
+		settings.username = *username
+	}
+	if password != nil {
+		settings.password = *password
+	}
+	return settings
+}
+
+// proxyURL returns the proxy for req, nil when its host gets bypassed, like
+// http.Transport.Proxy expects it.
+func (p *proxySettings) proxyURL(req *http.Request) (*url.URL, error) {
+	host := strings.ToLower(req.URL.Hostname())
+	for _, domain := range p.bypass {
+		if domain == "*" || host == strings.TrimPrefix(domain, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(domain, ".")) {
+			return nil, nil
+		}
+	}
+	proxy, err := url.Parse(p.server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy server '%s': %w", p.server, err)
+	}
+	if p.username != "" {
+		proxy.User = url.UserPassword(p.username, p.password)
+	}
+	return proxy, nil
+}
@@ -0,0 +1,147 @@
+package playwright
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redactedPlaceholder replaces redacted values.
+const redactedPlaceholder = "[REDACTED]"
+
+// redactions is the registry of the values and header names which get masked
+// in protocol logs, traces, HAR files and error messages.
+var redactions = &redactionRegistry{}
+
+type redactionRegistry struct {
+	sync.RWMutex
+	// values sorted by length, longest first, so a secret containing another
+	// one gets masked as a whole
+	values  []string
+	headers []string
+}
+
+// RedactValue registers a secret, e.g. a password or token used by a test.
+// It gets replaced with "[REDACTED]" in the protocol log (DEBUGP), traces, HAR
+// files and the messages of errors returned by Playwright. Empty values are
+// ignored.
+func RedactValue(secret string) {
+	if secret == "" {
+		return
+	}
+	redactions.Lock()
+	defer redactions.Unlock()
+	for _, value := range redactions.values {
+		if value == secret {
+			return
+		}
+	}
+	redactions.values = append(redactions.values, secret)
+	sort.SliceStable(redactions.values, func(i, j int) bool {
+		return len(redactions.values[i]) > len(redactions.values[j])
+	})
+}
+
+// RedactHeader registers a header name pattern, e.g. "authorization" or
+// "x-*-token", whose values get redacted wherever RedactValue() secrets get
+// redacted. Patterns are matched case-insensitively with path.Match syntax.
+func RedactHeader(pattern string) {
+	redactions.Lock()
+	defer redactions.Unlock()
+	redactions.headers = append(redactions.headers, strings.ToLower(pattern))
+}
+
+// ResetRedactions removes all registered secrets and header patterns.
+func ResetRedactions() {
+	redactions.Lock()
+	defer redactions.Unlock()
+	redactions.values = nil
+	redactions.headers = nil
+}
+
+func (r *redactionRegistry) enabled() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return len(r.values) > 0 || len(r.headers) > 0
+}
+
+func (r *redactionRegistry) isSecretHeader(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	name = strings.ToLower(name)
+	for _, pattern := range r.headers {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString masks the registered secrets in s.
+func (r *redactionRegistry) redactString(s string) string {
+	r.RLock()
+	defer r.RUnlock()
+	for _, value := range r.values {
+		s = strings.ReplaceAll(s, value, redactedPlaceholder)
+	}
+	return s
+}
+
+// redactBytes masks the registered secrets in content, e.g. a resource of a
+// trace.
+func (r *redactionRegistry) redactBytes(content []byte) []byte {
+	r.RLock()
+	defer r.RUnlock()
+	for _, value := range r.values {
+		content = bytes.ReplaceAll(content, []byte(value), []byte(redactedPlaceholder))
+	}
+	return content
+}
+
+// redactValue masks the secrets in the strings of a decoded JSON value in
+// place and returns it. Headers are either {name, value} objects, as used by
+// the protocol and HAR, or keys of objects.
+func (r *redactionRegistry) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.redactString(v)
+	case map[string]interface{}:
+		name, isHeader := v["name"].(string)
+		if _, ok := v["value"].(string); ok && isHeader && r.isSecretHeader(name) {
+			v["value"] = redactedPlaceholder
+		}
+		for key, child := range v {
+			if _, ok := child.(string); ok && r.isSecretHeader(key) {
+				v[key] = redactedPlaceholder
+				continue
+			}
+			v[key] = r.redactValue(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = r.redactValue(child)
+		}
+		return v
+	}
+	return value
+}
+
+// redactJSON masks the secrets in the JSON encoding of value, for logging.
+func (r *redactionRegistry) redactJSON(value interface{}) interface{} {
+	if !r.enabled() {
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return value
+	}
+	return r.redactValue(decoded)
+}
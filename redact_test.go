@@ -0,0 +1,80 @@
+package playwright
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactValue(t *testing.T) {
+	defer ResetRedactions()
+	require.False(t, redactions.enabled())
+	RedactValue("s3cret")
+	RedactValue("s3cret-token")
+	RedactValue("")
+	require.True(t, redactions.enabled())
+	require.Equal(t, "password [REDACTED], token [REDACTED]", redactions.redactString("password s3cret, token s3cret-token"))
+
+	err := parseError(errorPayload{Name: "Error", Message: "could not log in as admin:s3cret", Stack: "at login(s3cret)"})
+	require.EqualError(t, err, "could not log in as admin:[REDACTED]")
+	require.Equal(t, "at login([REDACTED])", err.(*Error).Stack)
+
+	ResetRedactions()
+	require.False(t, redactions.enabled())
+	require.Equal(t, "s3cret", redactions.redactString("s3cret"))
+}
+
+func TestRedactHeader(t *testing.T) {
+	defer ResetRedactions()
+	RedactHeader("Authorization")
+	RedactHeader("x-*-token")
+	value := redactions.redactJSON(map[string]interface{}{
+		"headers": []map[string]string{
+			{"name": "authorization", "value": "Bearer abc"},
+			{"name": "X-Api-Token", "value": "abc"},
+			{"name": "accept", "value": "*/*"},
+		},
+		"extraHTTPHeaders": map[string]string{"AUTHORIZATION": "Basic abc"},
+	})
+	require.Equal(t, map[string]interface{}{
+		"headers": []interface{}{
+			map[string]interface{}{"name": "authorization", "value": "[REDACTED]"},
+			map[string]interface{}{"name": "X-Api-Token", "value": "[REDACTED]"},
+			map[string]interface{}{"name": "accept", "value": "*/*"},
+		},
+		"extraHTTPHeaders": map[string]interface{}{"AUTHORIZATION": "[REDACTED]"},
+	}, value)
+}
+
+func TestHarRecorderRedacts(t *testing.T) {
+	defer ResetRedactions()
+	RedactValue("users")
+	path := filepath.Join(t.TempDir(), "network.har")
+	recorder, _, err := newHarRecorder(path, nil, nil, nil, nil)
+	require.NoError(t, err)
+	writeTestHar(t, recorder)
+	require.NoError(t, recorder.finish())
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "users")
+	entries := readTestHar(t, data)["entries"].([]interface{})
+	request := entries[0].(map[string]interface{})["request"].(map[string]interface{})
+	require.Equal(t, "https://example.com/api/[REDACTED]", request["url"])
+}
+
+func TestFinalizeTraceRedacts(t *testing.T) {
+	defer ResetRedactions()
+	RedactValue(`pa"ss`)
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	writeTestZip(t, tracePath, map[string]string{
+		"trace.trace":      `{"type":"action","metadata":{"method":"fill","params":{"value":"pa\"ss"}}}` + "\n",
+		"resources/abcdef": `{"password":"pa"ss"}`,
+	})
+	require.NoError(t, finalizeTrace(tracePath, traceChunk{}))
+	entries := readTestZip(t, tracePath)
+	require.Equal(t, `{"password":"[REDACTED]"}`, entries["resources/abcdef"])
+	require.Equal(t, `{"metadata":{"method":"fill","params":{"value":"[REDACTED]"}},"type":"action"}`, strings.TrimSpace(entries["trace.trace"]))
+}
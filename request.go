@@ -99,6 +99,18 @@ func (r *requestImpl) RedirectedTo() Request {
 	return r.redirectedTo
 }
 
+func (r *requestImpl) Replay(options ...RequestReplayOptions) (APIResponse, error) {
+	option := RouteFetchOptions{}
+	if len(options) == 1 {
+		option = RouteFetchOptions(options[0])
+	}
+	headers := r.Headers()
+	if option.Headers != nil {
+		headers = option.Headers
+	}
+	return fetchRequest(r, headers, option)
+}
+
 func (r *requestImpl) Failure() *RequestFailure {
 	if r.failureText == "" {
 		return nil
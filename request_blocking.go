@@ -0,0 +1,75 @@
+package playwright
+
+import (
+	"log"
+	"net/url"
+	"strings"
+)
+
+// requestBlocker decides which requests get aborted by BrowserContext.BlockRequests().
+// It gets consulted before the route handlers, so blocked requests never
+// reach the URL matchers.
+type requestBlocker struct {
+	options       BrowserContextBlockRequestsOptions
+	resourceTypes map[string]bool
+	domains       []string
+}
+
+func newRequestBlocker(options BrowserContextBlockRequestsOptions) *requestBlocker {
+	if len(options.ResourceTypes) == 0 && len(options.Domains) == 0 {
+		return nil
+	}
+	blocker := &requestBlocker{
+		options:       options,
+		resourceTypes: make(map[string]bool, len(options.ResourceTypes)),
+		domains:       make([]string, 0, len(options.Domains)),
+	}
+	for _, resourceType := range options.ResourceTypes {
+		blocker.resourceTypes[strings.ToLower(resourceType)] = true
+	}
+	for _, domain := range options.Domains {
+		blocker.domains = append(blocker.domains, strings.ToLower(strings.TrimPrefix(domain, ".")))
+	}
+	return blocker
+}
+
+func (r *requestBlocker) blocks(request *requestImpl) bool {
+	if r.resourceTypes[request.ResourceType()] {
+		return true
+	}
+	if len(r.domains) == 0 {
+		return false
+	}
+	u, err := url.Parse(request.URL())
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range r.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *browserContextImpl) BlockRequests(options BrowserContextBlockRequestsOptions) error {
+	return b.updateInterception(func() {
+		b.blocker = newRequestBlocker(options)
+	})
+}
+
+// abortIfBlocked aborts the request and returns true when it matches the
+// rules set with BlockRequests().
+func (b *browserContextImpl) abortIfBlocked(route *routeImpl, request *requestImpl) bool {
+	b.Lock()
+	blocker := b.blocker
+	b.Unlock()
+	if blocker == nil || !blocker.blocks(request) {
+		return false
+	}
+	if err := route.Abort("blockedbyclient"); err != nil {
+		log.Printf("could not abort blocked request: %v", err)
+	}
+	return true
+}
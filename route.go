@@ -1,16 +1,29 @@
 package playwright
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 type routeImpl struct {
 	channelOwner
+	// extraHeaders are the URL scoped extra HTTP headers for the request,
+	// see SetExtraHTTPHeaders().
+	extraHeaders map[string]string
+	// errorsIgnored is set by UnrouteAll() with the ignoreErrors behavior
+	errorsIgnored int32
 }
 
 func (r *routeImpl) Request() Request {
@@ -32,10 +45,36 @@ func (r *routeImpl) Abort(errorCode ...string) error {
 	_, err := r.channel.Send("abort", map[string]interface{}{
 		"errorCode": unpackOptionalArgument(errorCode),
 	})
+	return r.checkError(err)
+}
+
+// ignoreErrors makes the route ignore the errors of handling the request,
+// e.g. when the page got closed in the meantime.
+func (r *routeImpl) ignoreErrors() {
+	atomic.StoreInt32(&r.errorsIgnored, 1)
+}
+
+func (r *routeImpl) checkError(err error) error {
+	if err != nil && atomic.LoadInt32(&r.errorsIgnored) == 1 {
+		return nil
+	}
 	return err
 }
 
 func (r *routeImpl) Fulfill(options RouteFulfillOptions) error {
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+	if options.Response != nil {
+		if err := fulfillOptionsFromResponse(&options); err != nil {
+			return err
+		}
+	}
+	if options.JSON != nil {
+		if err := fulfillOptionsFromJSON(&options); err != nil {
+			return err
+		}
+	}
 	length := 0
 	isBase64 := false
 	var fileContentType string
@@ -77,7 +116,7 @@ func (r *routeImpl) Fulfill(options RouteFulfillOptions) error {
 		"isBase64": isBase64,
 		"headers":  serializeMapToNameAndValue(headers),
 	})
-	return err
+	return r.checkError(err)
 }
 
 func (r *routeImpl) Continue(options ...RouteContinueOptions) error {
@@ -91,7 +130,7 @@ func (r *routeImpl) Continue(options ...RouteContinueOptions) error {
 			overrides["method"] = option.Method
 		}
 		if option.Headers != nil {
-			overrides["headers"] = serializeMapToNameAndValue(option.Headers)
+			overrides["headers"] = serializeMapToNameAndValue(mergeHeaders(r.extraHeaders, option.Headers))
 		}
 		if option.PostData != nil {
 			switch v := option.PostData.(type) {
@@ -102,8 +141,349 @@ func (r *routeImpl) Continue(options ...RouteContinueOptions) error {
 			}
 		}
 	}
+	if _, ok := overrides["headers"]; !ok && len(r.extraHeaders) > 0 {
+		overrides["headers"] = serializeMapToNameAndValue(r.requestHeaders())
+	}
 	_, err := r.channel.Send("continue", overrides)
-	return err
+	return r.checkError(err)
+}
+
+func (r *routeImpl) Fetch(options ...RouteFetchOptions) (APIResponse, error) {
+	option := RouteFetchOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	headers := r.requestHeaders()
+	if option.Headers != nil {
+		headers = mergeHeaders(r.extraHeaders, option.Headers)
+	}
+	return fetchRequest(r.Request().(*requestImpl), headers, option)
+}
+
+// fetchRequest issues request with the overrides of option through the
+// request context of its browser context.
+func fetchRequest(request *requestImpl, headers map[string]string, option RouteFetchOptions) (APIResponse, error) {
+	url := request.URL()
+	if option.URL != nil {
+		url = *option.URL
+	}
+	method := request.Method()
+	if option.Method != nil {
+		method = *option.Method
+	}
+	fetchHeaders := make(map[string]string, len(headers))
+	for name, value := range headers {
+		// Cookies come from the browser context, the body gets decompressed
+		// by the HTTP client so that it can be patched.
+		switch strings.ToLower(name) {
+		case "cookie", "accept-encoding", "content-length", "host":
+			continue
+		}
+		fetchHeaders[name] = value
+	}
+	var data interface{}
+	switch postData := option.PostData.(type) {
+	case string, []byte:
+		data = postData
+	default:
+		body, err := request.PostDataBuffer()
+		if err != nil {
+			return nil, fmt.Errorf("could not get post data: %w", err)
+		}
+		if body != nil {
+			data = body
+		}
+	}
+	requestContext, err := request.requestContext()
+	if err != nil {
+		return nil, err
+	}
+	return requestContext.Fetch(url, APIRequestContextFetchOptions{
+		Method:       String(method),
+		Headers:      fetchHeaders,
+		Data:         data,
+		MaxRedirects: option.MaxRedirects,
+		Timeout:      option.Timeout,
+	})
+}
+
+// requestContext returns the request context of the browser context the
+// request belongs to, so that fetching it shares its cookies. Requests
+// without a frame, e.g. of service workers, use a standalone request context.
+func (r *requestImpl) requestContext() (APIRequestContext, error) {
+	if frame, ok := r.initializer["frame"]; ok && frame != nil {
+		if page := fromChannel(frame).(*frameImpl).page; page != nil && page.browserContext != nil {
+			return page.browserContext.Request(), nil
+		}
+	}
+	return (&apiRequestImpl{}).NewContext()
+}
+
+// fulfillOptionsFromJSON sets the body to the JSON encoding of the JSON
+// option and defaults the content type to `application/json`.
+func fulfillOptionsFromJSON(options *RouteFulfillOptions) error {
+	if options.Body != nil || options.Path != nil {
+		return errors.New("only one of Body, Path or JSON can be specified")
+	}
+	body, err := json.Marshal(options.JSON)
+	if err != nil {
+		return fmt.Errorf("could not serialize JSON: %w", err)
+	}
+	options.Body = string(body)
+	options.JSON = nil
+	if options.ContentType != nil {
+		return nil
+	}
+	for name := range options.Headers {
+		if strings.ToLower(name) == "content-type" {
+			return nil
+		}
+	}
+	options.ContentType = String("application/json")
+	return nil
+}
+
+// fulfillOptionsFromResponse fills the status, headers and body which are not
+// set explicitly from the response, e.g. one returned by Route.Fetch().
+func fulfillOptionsFromResponse(options *RouteFulfillOptions) error {
+	response := options.Response
+	options.Response = nil
+	if options.Status == nil {
+		options.Status = Int(response.Status())
+	}
+	bodyChanged := options.Body != nil || options.Path != nil || options.JSON != nil
+	if !bodyChanged {
+		body, err := response.Body()
+		if err != nil {
+			return fmt.Errorf("could not read response body: %w", err)
+		}
+		options.Body = body
+	}
+	if options.Headers == nil {
+		options.Headers = make(map[string]string)
+		for name, value := range response.Headers() {
+			switch strings.ToLower(name) {
+			case "content-encoding", "transfer-encoding":
+				continue
+			case "content-length":
+				// The length gets recalculated for the new body.
+				if bodyChanged {
+					continue
+				}
+			}
+			options.Headers[name] = value
+		}
+	}
+	return nil
+}
+
+// requestHeaders returns the headers of the request together with the URL
+// scoped extra HTTP headers.
+func (r *routeImpl) requestHeaders() map[string]string {
+	return mergeHeaders(r.Request().Headers(), r.extraHeaders)
+}
+
+// ResponseTransform transforms the body of an intercepted response. The
+// returned reader gets read chunk-wise, so transforms should process their
+// input incrementally instead of reading it at once. Route.Fulfill() needs
+// the complete body though, so the transformed body is held in memory before
+// it is sent to the browser.
+type ResponseTransform func(body io.Reader) io.Reader
+
+// routeHTTPClient fetches the original responses for FulfillWithTransform and
+// ContinueWithRedirects of requests which do not belong to a browser context,
+// e.g. the ones of service workers. Redirects are returned to the browser,
+// which follows them itself.
+var routeHTTPClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// originalClient returns the client which fetches the original response of
+// the route like the browser would, with the cookies, the proxy and the HTTPS
+// settings of the browser context.
+func (r *routeImpl) originalClient() *http.Client {
+	frame, ok := r.Request().(*requestImpl).initializer["frame"]
+	if !ok || frame == nil {
+		return routeHTTPClient
+	}
+	page := fromChannel(frame).(*frameImpl).page
+	if page == nil || page.browserContext == nil {
+		return routeHTTPClient
+	}
+	return page.browserContext.originalClient()
+}
+
+func (b *browserContextImpl) originalClient() *http.Client {
+	b.Lock()
+	defer b.Unlock()
+	if b.routeClient != nil {
+		return b.routeClient
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if b.options != nil && b.options.IgnoreHttpsErrors != nil && *b.options.IgnoreHttpsErrors {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if b.proxy != nil {
+		transport.Proxy = b.proxy.proxyURL
+	}
+	b.routeClient = &http.Client{
+		Transport:     transport,
+		Jar:           &contextCookieJar{context: b},
+		CheckRedirect: routeHTTPClient.CheckRedirect,
+	}
+	return b.routeClient
+}
+
+func (r *routeImpl) FulfillWithTransform(transforms ...ResponseTransform) error {
+	resp, err := r.fetchOriginal(r.originalClient())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var body io.Reader = resp.Body
+	for _, transform := range transforms {
+		body = transform(body)
+	}
+	transformed, err := ioutil.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("could not transform response body: %w", err)
+	}
+	return r.Fulfill(RouteFulfillOptions{
+		Status:  Int(resp.StatusCode),
+		Headers: responseHeadersToFulfill(resp),
+		Body:    transformed,
+	})
+}
+
+// RedirectHop is a single redirect of a redirect chain which gets passed to
+// the handler of Route.ContinueWithRedirects().
+type RedirectHop struct {
+	// Status code of the redirect, e.g. 301 or 302.
+	Status int
+	// URL of the request which got redirected.
+	URL string
+	// Target of the redirect, the handler can change it to redirect somewhere
+	// else.
+	Location string
+}
+
+func (r *routeImpl) ContinueWithRedirects(handler func(hop *RedirectHop)) error {
+	resp, err := r.fetchOriginal(r.originalClient())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	headers := responseHeadersToFulfill(resp)
+	if location, err := resp.Location(); err == nil {
+		hop := &RedirectHop{
+			Status:   resp.StatusCode,
+			URL:      r.Request().URL(),
+			Location: location.String(),
+		}
+		handler(hop)
+		for name := range headers {
+			if strings.ToLower(name) == "location" {
+				delete(headers, name)
+			}
+		}
+		headers["Location"] = hop.Location
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response body: %w", err)
+	}
+	return r.Fulfill(RouteFulfillOptions{
+		Status:  Int(resp.StatusCode),
+		Headers: headers,
+		Body:    body,
+	})
+}
+
+func (r *routeImpl) Forward(transport http.RoundTripper) error {
+	resp, err := r.fetchOriginal(&http.Client{
+		Transport:     transport,
+		CheckRedirect: routeHTTPClient.CheckRedirect,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response body: %w", err)
+	}
+	return r.Fulfill(RouteFulfillOptions{
+		Status:  Int(resp.StatusCode),
+		Headers: responseHeadersToFulfill(resp),
+		Body:    body,
+	})
+}
+
+// ForwardTo returns a route handler which forwards the requests through
+// transport, see Route.Forward(). Requests which can not be forwarded get
+// aborted:
+//
+//	proxyURL, _ := url.Parse("http://proxy.internal:3128")
+//	err := page.Route("https://api.example.com/**", playwright.ForwardTo(&http.Transport{
+//		Proxy: http.ProxyURL(proxyURL),
+//	}))
+func ForwardTo(transport http.RoundTripper) func(route Route, request Request) {
+	return func(route Route, request Request) {
+		if err := route.Forward(transport); err != nil {
+			log.Printf("could not forward request to %s: %v", request.URL(), err)
+			if err := route.Abort("failed"); err != nil {
+				log.Printf("could not abort request: %v", err)
+			}
+		}
+	}
+}
+
+// fetchOriginal sends the request of the route with client, which must not
+// follow redirects.
+func (r *routeImpl) fetchOriginal(client *http.Client) (*http.Response, error) {
+	request := r.Request()
+	postData, err := request.PostDataBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("could not get post data: %w", err)
+	}
+	req, err := http.NewRequest(request.Method(), request.URL(), bytes.NewReader(postData))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	for name, value := range r.requestHeaders() {
+		// The response body gets decompressed by the HTTP client so that the
+		// transforms operate on the plain content.
+		// The cookies are added by the cookie jar of the client.
+		if name == "accept-encoding" || name == "content-length" || name == "host" || (name == "cookie" && client.Jar != nil) {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch original response: %w", err)
+	}
+	return resp, nil
+}
+
+// responseHeadersToFulfill converts the headers of resp for Route.Fulfill(),
+// the ones which describe the encoding of the original body get dropped.
+func responseHeadersToFulfill(resp *http.Response) map[string]string {
+	headers := make(map[string]string)
+	for name, values := range resp.Header {
+		switch strings.ToLower(name) {
+		case "content-length", "content-encoding", "transfer-encoding":
+			continue
+		}
+		separator := ", "
+		if strings.ToLower(name) == "set-cookie" {
+			separator = "\n"
+		}
+		headers[name] = strings.Join(values, separator)
+	}
+	return headers
 }
 
 func newRoute(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *routeImpl {
@@ -0,0 +1,94 @@
+package playwright
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFulfillOptionsFromResponse(t *testing.T) {
+	response := &apiResponseImpl{
+		status: 404,
+		headers: map[string]string{
+			"content-type":     "text/plain",
+			"content-length":   "5",
+			"content-encoding": "gzip",
+		},
+		body: []byte("hello"),
+	}
+	options := RouteFulfillOptions{Response: response}
+	require.NoError(t, fulfillOptionsFromResponse(&options))
+	require.Nil(t, options.Response)
+	require.Equal(t, 404, *options.Status)
+	require.Equal(t, []byte("hello"), options.Body)
+	require.Equal(t, map[string]string{"content-type": "text/plain", "content-length": "5"}, options.Headers)
+
+	options = RouteFulfillOptions{Response: response, Status: Int(200), Body: "patched"}
+	require.NoError(t, fulfillOptionsFromResponse(&options))
+	require.Equal(t, 200, *options.Status)
+	require.Equal(t, "patched", options.Body)
+	require.Equal(t, map[string]string{"content-type": "text/plain"}, options.Headers)
+}
+
+func TestFulfillOptionsFromJSON(t *testing.T) {
+	options := RouteFulfillOptions{JSON: map[string]interface{}{"items": []int{1, 2}}}
+	require.NoError(t, fulfillOptionsFromJSON(&options))
+	require.Nil(t, options.JSON)
+	require.Equal(t, `{"items":[1,2]}`, options.Body)
+	require.Equal(t, "application/json", *options.ContentType)
+
+	options = RouteFulfillOptions{
+		JSON:    []string{"a"},
+		Headers: map[string]string{"Content-Type": "application/vnd.api+json"},
+	}
+	require.NoError(t, fulfillOptionsFromJSON(&options))
+	require.Nil(t, options.ContentType)
+
+	options = RouteFulfillOptions{JSON: 1, Body: "1"}
+	require.EqualError(t, fulfillOptionsFromJSON(&options), "only one of Body, Path or JSON can be specified")
+
+	options = RouteFulfillOptions{JSON: make(chan int)}
+	require.Error(t, fulfillOptionsFromJSON(&options))
+}
+
+func TestRequestReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Token", r.Header.Get("X-Token"))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	request := &requestImpl{
+		headers: map[string]string{"x-token": "abc", "cookie": "a=b"},
+	}
+	request.initializer = map[string]interface{}{
+		"url":      server.URL + "/api",
+		"method":   "POST",
+		"postData": base64.StdEncoding.EncodeToString([]byte("original")),
+	}
+
+	response, err := request.Replay()
+	require.NoError(t, err)
+	body, err := response.Body()
+	require.NoError(t, err)
+	require.Equal(t, "original", string(body))
+	require.Equal(t, "POST", response.Headers()["x-method"])
+	require.Equal(t, "abc", response.Headers()["x-token"])
+
+	response, err = request.Replay(RequestReplayOptions{
+		Method:   String("PUT"),
+		PostData: "patched",
+		Headers:  map[string]string{"X-Token": "xyz"},
+	})
+	require.NoError(t, err)
+	body, err = response.Body()
+	require.NoError(t, err)
+	require.Equal(t, "patched", string(body))
+	require.Equal(t, "PUT", response.Headers()["x-method"])
+	require.Equal(t, "xyz", response.Headers()["x-token"])
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 const playwrightCliVersion = "1.14.0"
@@ -202,6 +203,10 @@ type RunOptions struct {
 	DriverDirectory     string
 	SkipInstallBrowsers bool
 	Browsers            []string
+	// Interval in which the driver gets pinged to detect if it hangs. Disabled by default.
+	HealthCheckInterval time.Duration
+	// Time after which the driver is considered unresponsive if it did not answer a health check. Defaults to 30 seconds.
+	HealthCheckTimeout time.Duration
 }
 
 // Install does download the driver and the browsers. If not called manually
@@ -240,7 +245,16 @@ func Run(options ...*RunOptions) (*Playwright, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not call object: %w", err)
 	}
-	return obj.(*Playwright), nil
+	pw := obj.(*Playwright)
+	if pw.Selectors != nil {
+		if err := registerGetBySelectorEngines(pw.Selectors); err != nil {
+			return nil, err
+		}
+	}
+	if driver.options.HealthCheckInterval > 0 {
+		connection.startHealthCheck(driver.options.HealthCheckInterval, driver.options.HealthCheckTimeout)
+	}
+	return pw, nil
 }
 
 func transformRunOptions(options []*RunOptions) *RunOptions {
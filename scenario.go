@@ -0,0 +1,267 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scenario runs the actors of a multi-actor test, e.g. a buyer and a seller,
+// concurrently, each in its own browser context. Actors coordinate via step
+// barriers and signals, a scenario in which all remaining actors wait for each
+// other fails with a deadlock error instead of hanging.
+type Scenario struct {
+	browser Browser
+	actors  []*scenarioActorEntry
+}
+
+type scenarioActorEntry struct {
+	name    string
+	fn      func(actor *ScenarioActor) error
+	options []BrowserNewContextOptions
+}
+
+// ScenarioActor is passed to the function of an actor. Context and Page belong
+// to the actor alone and get closed when the scenario finishes.
+type ScenarioActor struct {
+	Name        string
+	Context     BrowserContext
+	Page        Page
+	coordinator *scenarioCoordinator
+}
+
+// NewScenario returns a scenario whose actors get their contexts from browser.
+func NewScenario(browser Browser) *Scenario {
+	return &Scenario{
+		browser: browser,
+	}
+}
+
+// AddActor adds an actor which runs fn in a new context created with options.
+func (s *Scenario) AddActor(name string, fn func(actor *ScenarioActor) error, options ...BrowserNewContextOptions) {
+	s.actors = append(s.actors, &scenarioActorEntry{
+		name:    name,
+		fn:      fn,
+		options: options,
+	})
+}
+
+// Run runs all actors concurrently and waits for them to finish. It returns
+// the first error of an actor, the other actors get aborted when they reach
+// their next step or wait. Contexts get closed before Run returns.
+func (s *Scenario) Run() error {
+	names := map[string]bool{}
+	for _, entry := range s.actors {
+		if names[entry.name] {
+			return fmt.Errorf("duplicate actor: %s", entry.name)
+		}
+		names[entry.name] = true
+	}
+	coordinator := newScenarioCoordinator(len(s.actors))
+	var wg sync.WaitGroup
+	wg.Add(len(s.actors))
+	for _, entry := range s.actors {
+		go func(entry *scenarioActorEntry) {
+			defer wg.Done()
+			coordinator.finish(entry.name, s.runActor(entry, coordinator))
+		}(entry)
+	}
+	wg.Wait()
+	return coordinator.err
+}
+
+func (s *Scenario) runActor(entry *scenarioActorEntry, coordinator *scenarioCoordinator) error {
+	context, err := s.browser.NewContext(entry.options...)
+	if err != nil {
+		return fmt.Errorf("could not create context: %w", err)
+	}
+	defer context.Close()
+	page, err := context.NewPage()
+	if err != nil {
+		return fmt.Errorf("could not create page: %w", err)
+	}
+	return entry.fn(&ScenarioActor{
+		Name:        entry.name,
+		Context:     context,
+		Page:        page,
+		coordinator: coordinator,
+	})
+}
+
+// Step waits until all actors which are still running reached the step with
+// the same name. Actors which finished do not hold back the others.
+func (a *ScenarioActor) Step(name string) error {
+	return a.coordinator.step(a.Name, name)
+}
+
+// Signal marks the event as happened and releases the actors waiting for it.
+func (a *ScenarioActor) Signal(event string) {
+	a.coordinator.signal(event)
+}
+
+// WaitFor waits until another actor signaled the event, it returns
+// immediately if the event already happened.
+func (a *ScenarioActor) WaitFor(event string) error {
+	return a.coordinator.waitFor(a.Name, event)
+}
+
+// errScenarioAborted gets returned to actors which got stopped because
+// another actor failed, it does not get reported by Scenario.Run().
+var errScenarioAborted = errors.New("scenario aborted")
+
+// scenarioCoordinator keeps track of which actors are blocked on what, a
+// deadlock is detected when all running actors are blocked.
+type scenarioCoordinator struct {
+	sync.Mutex
+	running  int
+	blocked  map[string]string
+	barriers map[string]*scenarioBarrier
+	signals  map[string]*scenarioSignal
+	aborted  chan struct{}
+	err      error
+}
+
+type scenarioBarrier struct {
+	actors  []string
+	release chan struct{}
+}
+
+type scenarioSignal struct {
+	fired   chan struct{}
+	waiters []string
+}
+
+func newScenarioCoordinator(actors int) *scenarioCoordinator {
+	return &scenarioCoordinator{
+		running:  actors,
+		blocked:  map[string]string{},
+		barriers: map[string]*scenarioBarrier{},
+		signals:  map[string]*scenarioSignal{},
+		aborted:  make(chan struct{}),
+	}
+}
+
+func (c *scenarioCoordinator) step(actor, name string) error {
+	c.Lock()
+	if c.err != nil {
+		c.Unlock()
+		return errScenarioAborted
+	}
+	barrier, ok := c.barriers[name]
+	if !ok {
+		barrier = &scenarioBarrier{release: make(chan struct{})}
+		c.barriers[name] = barrier
+	}
+	barrier.actors = append(barrier.actors, actor)
+	c.blocked[actor] = fmt.Sprintf("is at step %q", name)
+	c.releaseBarriers()
+	c.detectDeadlock()
+	c.Unlock()
+	return c.wait(barrier.release)
+}
+
+func (c *scenarioCoordinator) signal(event string) {
+	c.Lock()
+	defer c.Unlock()
+	signal := c.getSignal(event)
+	select {
+	case <-signal.fired:
+		return
+	default:
+	}
+	for _, actor := range signal.waiters {
+		delete(c.blocked, actor)
+	}
+	signal.waiters = nil
+	close(signal.fired)
+}
+
+func (c *scenarioCoordinator) waitFor(actor, event string) error {
+	c.Lock()
+	if c.err != nil {
+		c.Unlock()
+		return errScenarioAborted
+	}
+	signal := c.getSignal(event)
+	select {
+	case <-signal.fired:
+		c.Unlock()
+		return nil
+	default:
+	}
+	signal.waiters = append(signal.waiters, actor)
+	c.blocked[actor] = fmt.Sprintf("waits for %q", event)
+	c.detectDeadlock()
+	c.Unlock()
+	return c.wait(signal.fired)
+}
+
+// finish records the result of an actor, the first error aborts the scenario.
+func (c *scenarioCoordinator) finish(actor string, err error) {
+	c.Lock()
+	defer c.Unlock()
+	c.running--
+	if err != nil && err != errScenarioAborted {
+		c.abort(fmt.Errorf("actor %s: %w", actor, err))
+	}
+	c.releaseBarriers()
+	c.detectDeadlock()
+}
+
+func (c *scenarioCoordinator) wait(done chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-c.aborted:
+		return errScenarioAborted
+	}
+}
+
+func (c *scenarioCoordinator) getSignal(event string) *scenarioSignal {
+	signal, ok := c.signals[event]
+	if !ok {
+		signal = &scenarioSignal{fired: make(chan struct{})}
+		c.signals[event] = signal
+	}
+	return signal
+}
+
+// releaseBarriers opens the barriers which all running actors reached.
+func (c *scenarioCoordinator) releaseBarriers() {
+	for name, barrier := range c.barriers {
+		if len(barrier.actors) < c.running {
+			continue
+		}
+		for _, actor := range barrier.actors {
+			delete(c.blocked, actor)
+		}
+		delete(c.barriers, name)
+		close(barrier.release)
+	}
+}
+
+func (c *scenarioCoordinator) detectDeadlock() {
+	if c.err != nil || c.running == 0 || len(c.blocked) < c.running {
+		return
+	}
+	actors := make([]string, 0, len(c.blocked))
+	for actor := range c.blocked {
+		actors = append(actors, actor)
+	}
+	sort.Strings(actors)
+	reasons := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		reasons = append(reasons, actor+" "+c.blocked[actor])
+	}
+	c.abort(fmt.Errorf("scenario deadlocked: %s", strings.Join(reasons, ", ")))
+}
+
+func (c *scenarioCoordinator) abort(err error) {
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.aborted)
+}
@@ -0,0 +1,96 @@
+package playwright
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runCoordinated(coordinator *scenarioCoordinator, actors map[string]func() error) {
+	var wg sync.WaitGroup
+	wg.Add(len(actors))
+	for name, fn := range actors {
+		go func(name string, fn func() error) {
+			defer wg.Done()
+			coordinator.finish(name, fn())
+		}(name, fn)
+	}
+	wg.Wait()
+}
+
+func TestScenarioCoordinatorInterleaving(t *testing.T) {
+	coordinator := newScenarioCoordinator(2)
+	var mu sync.Mutex
+	order := []string{}
+	record := func(entry string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, entry)
+	}
+	runCoordinated(coordinator, map[string]func() error{
+		"buyer": func() error {
+			if err := coordinator.step("buyer", "ready"); err != nil {
+				return err
+			}
+			record("order")
+			coordinator.signal("ordered")
+			return coordinator.waitFor("buyer", "shipped")
+		},
+		"seller": func() error {
+			if err := coordinator.step("seller", "ready"); err != nil {
+				return err
+			}
+			if err := coordinator.waitFor("seller", "ordered"); err != nil {
+				return err
+			}
+			record("ship")
+			coordinator.signal("shipped")
+			return nil
+		},
+	})
+	require.NoError(t, coordinator.err)
+	require.Equal(t, []string{"order", "ship"}, order)
+}
+
+func TestScenarioCoordinatorFinishedActorReleasesStep(t *testing.T) {
+	coordinator := newScenarioCoordinator(2)
+	runCoordinated(coordinator, map[string]func() error{
+		"buyer": func() error {
+			return coordinator.step("buyer", "done")
+		},
+		"seller": func() error {
+			return nil
+		},
+	})
+	require.NoError(t, coordinator.err)
+}
+
+func TestScenarioCoordinatorDeadlock(t *testing.T) {
+	coordinator := newScenarioCoordinator(2)
+	runCoordinated(coordinator, map[string]func() error{
+		"buyer": func() error {
+			return coordinator.waitFor("buyer", "shipped")
+		},
+		"seller": func() error {
+			return coordinator.step("seller", "paid")
+		},
+	})
+	require.EqualError(t, coordinator.err, `scenario deadlocked: buyer waits for "shipped", seller is at step "paid"`)
+}
+
+func TestScenarioCoordinatorActorError(t *testing.T) {
+	coordinator := newScenarioCoordinator(2)
+	failure := errors.New("checkout failed")
+	runCoordinated(coordinator, map[string]func() error{
+		"buyer": func() error {
+			return failure
+		},
+		"seller": func() error {
+			return coordinator.waitFor("seller", "ordered")
+		},
+	})
+	require.True(t, errors.Is(coordinator.err, failure))
+	require.EqualError(t, coordinator.err, "actor buyer: checkout failed")
+}
@@ -0,0 +1,123 @@
+#!/usr/bin/env node
+// Adds the structs, struct fields and enums which are not part of the API
+// docs of Playwright to the files generated by generateGoApi.js.
+const fs = require("fs")
+const path = require("path")
+
+const structData = require("./data/structs.json")
+const enumData = require("./data/enums.json")
+
+const root = path.join(__dirname, "..")
+
+/**
+ * @param {string|undefined} comment
+ * @param {string} indent
+ */
+const commentLines = (comment, indent) => {
+  if (!comment) return []
+  return comment.split("\n").map(line => {
+    if (!line) return `${indent}//`
+    if (line.startsWith("\t")) return `${indent}//${line}`
+    return `${indent}// ${line}`
+  })
+}
+
+const fieldLines = (field) => [
+  ...commentLines(field.comment, "\t"),
+  `\t${field.name} ${field.type} \`json:"${field.json}"\``,
+]
+
+/**
+ * @param {string[]} lines
+ * @param {string} name
+ */
+const findStruct = (lines, name) => {
+  const index = lines.indexOf(`type ${name} struct {`)
+  if (index === -1) throw new Error(`struct ${name} not found`)
+  return index
+}
+
+const addStructs = () => {
+  const file = path.join(root, "generated-structs.go")
+  let lines = fs.readFileSync(file).toString().split("\n")
+  // drop the package clause, the import block gets written below
+  let start = lines.findIndex(line => line.startsWith("type ") || line.startsWith("//"))
+  lines = lines.slice(start)
+  for (const [name, fields] of Object.entries(structData.fields)) {
+    for (const field of fields) {
+      const structIndex = findStruct(lines, name)
+      let index = structIndex + 1
+      if (field.after) {
+        while (!new RegExp(`^\\t${field.after}\\s`).test(lines[index])) {
+          if (lines[index] === "}") throw new Error(`field ${name}.${field.after} not found`)
+          index++
+        }
+        index++
+      }
+      lines.splice(index, 0, ...fieldLines(field))
+    }
+  }
+  for (const struct of structData.structs) {
+    let index = 0
+    if (struct.after) {
+      index = findStruct(lines, struct.after)
+      while (lines[index] !== "}")
+        index++
+      index++
+    }
+    lines.splice(index, 0,
+      ...(struct.blankLine ? [""] : []),
+      ...commentLines(struct.comment, ""),
+      `type ${struct.name} struct {`,
+      ...struct.fields.flatMap(fieldLines),
+      "}",
+    )
+  }
+  // see contextFromOptions() for how the Context option gets applied
+  for (const name of structData.contextOptions) {
+    let index = findStruct(lines, name)
+    while (lines[index] !== "}")
+      index++
+    lines.splice(index, 0, ...fieldLines({
+      name: "Context",
+      type: "context.Context",
+      json: "-",
+      comment: "Context of the call, overrides Timeout when it has a deadline.",
+    }))
+  }
+  const header = [
+    "package playwright",
+    "",
+    "import (",
+    ...structData.imports.map(name => `\t"${name}"`),
+    ")",
+    "",
+  ]
+  fs.writeFileSync(file, [...header, ...lines].join("\n"))
+}
+
+const addEnums = () => {
+  const file = path.join(root, "generated-enums.go")
+  const out = [fs.readFileSync(file).toString().replace(/\n+$/, "\n")]
+  for (const [name, values] of Object.entries(enumData)) {
+    const entries = Object.entries(values).map(([suffix, value], i) =>
+      `\t${name}${suffix}${i === 0 ? ` *${name}` : ""} = get${name}("${value}")`)
+    out.push(`
+func get${name}(in string) *${name} {
+\tv := ${name}(in)
+\tregisterEnumValue(v)
+\treturn &v
+}
+
+type ${name} string
+
+var (
+${entries.join("\n")}
+)
+`)
+  }
+  fs.writeFileSync(file, out.join(""))
+}
+
+addStructs()
+addEnums()
@@ -16,6 +16,7 @@ PLAYWRIGHT_DIR="playwright"
 node $PLAYWRIGHT_DIR/utils/doclint/generateGoApi.js
 mv $PLAYWRIGHT_DIR/utils/doclint/generate_types/go/generated-{enums,structs}.go .
 rm $PLAYWRIGHT_DIR/utils/doclint/generate_types/go/generated-interfaces.go
+node scripts/generate-additions.js
 go fmt generated-{enums,structs}.go > /dev/null
 
 # echo "Validating API"
@@ -2,6 +2,9 @@
 const { transformMethodNamesToGo, getAPIDocs } = require("./helpers")
 
 const interfaceData = require("./data/interfaces.json")
+// Docs of the classes and methods which are not part of the API docs of
+// Playwright or differ from them, they take precedence.
+const commentData = require("./data/comments.json")
 const api = getAPIDocs()
 
 const transformInputParameters = (input) => {
@@ -46,11 +49,26 @@ const writeComment = (comment) => {
     console.log(`// ${line}`)
 }
 
+/**
+ * @param {string} comment
+ */
+const writeDataComment = (comment) => {
+  if (!comment) return
+  for (const line of comment.split("\n"))
+    console.log(line ? `// ${line}` : "//")
+}
+
+const imports = ["io", "net/http", "time"]
+
 console.log("package playwright")
+console.log(`import (\n${imports.map(i => `"${i}"`).join("\n")}\n)`)
 
 for (const [className, methods] of Object.entries(interfaceData)) {
   const apiClass = api.find(classes => classes.name === className)
-  if (apiClass)
+  const classComments = commentData[className] || {}
+  if ("comment" in classComments)
+    writeDataComment(classComments.comment)
+  else if (apiClass)
     writeComment(apiClass.comment)
   console.log(`type ${className} interface {`)
   for (const [funcName, funcData] of Object.entries(methods)) {
@@ -59,7 +77,9 @@ for (const [className, methods] of Object.entries(interfaceData)) {
         console.log(inheritedInterface)
     } else {
       const apiFunc = apiClass?.members.find(member => member.kind === "method" && funcName === transformMethodNamesToGo(member.name))
-      if (apiFunc && apiFunc.comment)
+      if (funcName in classComments)
+        writeDataComment(classComments[funcName])
+      else if (apiFunc && apiFunc.comment)
         writeComment(apiFunc.comment)
 
       const [inputTypes, returnTypes] = funcData
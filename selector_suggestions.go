@@ -0,0 +1,307 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// selectorActions are the frame methods which act on the element matching
+// their selector.
+var selectorActions = map[string]bool{
+	"check":         true,
+	"click":         true,
+	"dblclick":      true,
+	"dispatchEvent": true,
+	"fill":          true,
+	"focus":         true,
+	"getAttribute":  true,
+	"hover":         true,
+	"innerHTML":     true,
+	"innerText":     true,
+	"inputValue":    true,
+	"press":         true,
+	"selectOption":  true,
+	"setInputFiles": true,
+	"tap":           true,
+	"textContent":   true,
+	"type":          true,
+	"uncheck":       true,
+}
+
+const (
+	maxSelectorSuggestions     = 3
+	minSelectorSuggestionScore = 0.5
+)
+
+// selectorCandidatesScript collects the values by which the visible elements
+// of the document can be located, like the selector generator of codegen
+// does.
+const selectorCandidatesScript = `testIdAttribute => {
+  const implicitRoles = { A: 'link', BUTTON: 'button', SELECT: 'combobox', TEXTAREA: 'textbox', H1: 'heading', H2: 'heading', H3: 'heading', H4: 'heading', H5: 'heading', H6: 'heading' };
+  const inputRoles = { button: 'button', submit: 'button', reset: 'button', checkbox: 'checkbox', radio: 'radio', range: 'slider' };
+  const roleOf = element => {
+    if (element.getAttribute('role'))
+      return element.getAttribute('role');
+    if (element.tagName === 'INPUT')
+      return inputRoles[element.type] || 'textbox';
+    if (element.tagName === 'A' && !element.hasAttribute('href'))
+      return '';
+    return implicitRoles[element.tagName] || '';
+  };
+  const text = value => (value || '').replace(/\s+/g, ' ').trim().substring(0, 80);
+  const candidates = [];
+  const elements = document.querySelectorAll('a, button, input, select, textarea, summary, h1, h2, h3, h4, h5, h6, [role], [id], [name], [aria-label], [placeholder], [' + testIdAttribute + ']');
+  for (const element of Array.from(elements).slice(0, 2000)) {
+    if (!element.getClientRects().length)
+      continue;
+    const testId = element.getAttribute(testIdAttribute);
+    if (testId)
+      candidates.push({ kind: 'testId', value: testId });
+    if (element.id)
+      candidates.push({ kind: 'id', value: element.id });
+    if (element.getAttribute('placeholder'))
+      candidates.push({ kind: 'placeholder', value: element.getAttribute('placeholder') });
+    if (element.getAttribute('aria-label'))
+      candidates.push({ kind: 'label', value: element.getAttribute('aria-label') });
+    const role = roleOf(element);
+    const name = text(element.getAttribute('aria-label') || (element.tagName === 'INPUT' ? element.value : element.textContent));
+    if (role && name)
+      candidates.push({ kind: 'role', role, value: name });
+    else if (name && element.tagName !== 'INPUT')
+      candidates.push({ kind: 'text', value: name });
+    if (element.getAttribute('name'))
+      candidates.push({ kind: 'name', value: element.getAttribute('name') });
+  }
+  return candidates;
+}`
+
+// selectorCandidate is a way to locate an element of the page.
+type selectorCandidate struct {
+	kind  string
+	role  string
+	value string
+}
+
+// String renders the candidate as the call which creates its locator.
+func (c selectorCandidate) String() string {
+	switch c.kind {
+	case "testId":
+		return fmt.Sprintf("GetByTestId(%q)", c.value)
+	case "id":
+		return fmt.Sprintf("Locator(%q)", "#"+cssEscapeIdentifier(c.value))
+	case "placeholder":
+		return fmt.Sprintf("GetByPlaceholder(%q)", c.value)
+	case "label":
+		return fmt.Sprintf("GetByLabel(%q)", c.value)
+	case "role":
+		return fmt.Sprintf("GetByRole(%q, GetByRoleOptions{Name: %q})", c.role, c.value)
+	case "name":
+		return fmt.Sprintf("Locator(%q)", "[name="+cssString(c.value)+"]")
+	}
+	return fmt.Sprintf("GetByText(%q)", c.value)
+}
+
+var cssIdentifierSpecialPattern = regexp.MustCompile(`([^a-zA-Z0-9_\-])`)
+
+// cssEscapeIdentifier escapes the characters of an ID which are not valid in a
+// CSS identifier.
+func cssEscapeIdentifier(id string) string {
+	return cssIdentifierSpecialPattern.ReplaceAllString(id, `\$1`)
+}
+
+// EnableSelectorSuggestions makes the actions of the pages of the context
+// which time out because their selector did not match any element list
+// similar selectors of the page in the error message.
+func (b *browserContextImpl) EnableSelectorSuggestions() {
+	b.Lock()
+	defer b.Unlock()
+	b.selectorSuggestions = true
+}
+
+func (b *browserContextImpl) selectorSuggestionsEnabled() bool {
+	b.Lock()
+	defer b.Unlock()
+	return b.selectorSuggestions
+}
+
+// suggestSelectors adds the selectors of similar elements to the timeout error
+// of an action whose selector does not match any element.
+func (f *frameImpl) suggestSelectors(method string, params map[string]interface{}, err error) error {
+	timeoutErr, ok := err.(*TimeoutError)
+	if !ok || !selectorActions[method] || f.page == nil || f.page.browserContext == nil || !f.page.browserContext.selectorSuggestionsEnabled() {
+		return err
+	}
+	selector, _ := params["selector"].(string)
+	if selector == "" {
+		return err
+	}
+	element, queryErr := f.QuerySelector(selector)
+	if queryErr != nil {
+		return err
+	}
+	if element != nil {
+		_ = element.Dispose()
+		return err
+	}
+	result, evalErr := f.Evaluate(selectorCandidatesScript, getTestIdAttribute())
+	if evalErr != nil {
+		return err
+	}
+	candidates := []selectorCandidate{}
+	entries, _ := result.([]interface{})
+	for _, entry := range entries {
+		if entry, ok := entry.(map[string]interface{}); ok {
+			candidate := selectorCandidate{}
+			candidate.kind, _ = entry["kind"].(string)
+			candidate.role, _ = entry["role"].(string)
+			candidate.value, _ = entry["value"].(string)
+			candidates = append(candidates, candidate)
+		}
+	}
+	suggestions := rankSelectorCandidates(selectorTerms(selector), candidates)
+	if len(suggestions) == 0 {
+		return err
+	}
+	return &TimeoutError{
+		Name:    timeoutErr.Name,
+		Message: timeoutErr.Message + "\nSimilar elements on the page:\n  " + strings.Join(suggestions, "\n  "),
+		Stack:   timeoutErr.Stack,
+	}
+}
+
+var (
+	selectorQuotedPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'((?:[^'\\]|\\.)*)'`)
+	selectorAttrPattern   = regexp.MustCompile(`=\s*([^\]\s"']+)\s*\]`)
+	selectorTokenPattern  = regexp.MustCompile(`[#.]?[a-zA-Z0-9_\-]+`)
+)
+
+// selectorTerms returns the values which a selector is looking for, e.g. the
+// test ID or the text, of its last part.
+func selectorTerms(selector string) []string {
+	parts := strings.Split(selector, ">>")
+	last := strings.TrimSpace(parts[len(parts)-1])
+	if len(parts) > 1 && strings.HasPrefix(last, "nth=") {
+		last = strings.TrimSpace(parts[len(parts)-2])
+	}
+	for _, engine := range []string{getByRoleEngine, getByLabelEngine, getByAttributeEngine} {
+		if !strings.HasPrefix(last, engine+"=") {
+			continue
+		}
+		var body map[string]interface{}
+		if json.Unmarshal([]byte(strings.TrimPrefix(last, engine+"=")), &body) != nil {
+			return nil
+		}
+		if name, ok := body["name"].(map[string]interface{}); ok {
+			body = name
+		}
+		if text, ok := body["text"].(string); ok {
+			return []string{text}
+		}
+		return nil
+	}
+	terms := []string{}
+	for _, match := range selectorQuotedPattern.FindAllStringSubmatch(last, -1) {
+		terms = append(terms, match[1]+match[2])
+	}
+	for _, match := range selectorAttrPattern.FindAllStringSubmatch(last, -1) {
+		terms = append(terms, match[1])
+	}
+	if len(terms) > 0 {
+		return terms
+	}
+	if index := strings.Index(last, "="); index > 0 && !strings.ContainsAny(last[:index], "[ ") {
+		return []string{strings.TrimSpace(last[index+1:])}
+	}
+	for _, token := range selectorTokenPattern.FindAllString(last, -1) {
+		if strings.HasPrefix(token, "#") || strings.HasPrefix(token, ".") {
+			terms = append(terms, token[1:])
+		}
+	}
+	if len(terms) == 0 {
+		terms = append(terms, last)
+	}
+	return terms
+}
+
+// rankSelectorCandidates returns the candidates whose value is most similar to
+// one of the terms, the most similar first.
+func rankSelectorCandidates(terms []string, candidates []selectorCandidate) []string {
+	type scored struct {
+		rendered string
+		score    float64
+	}
+	best := map[string]float64{}
+	for _, candidate := range candidates {
+		score := 0.0
+		for _, term := range terms {
+			if s := similarity(term, candidate.value); s > score {
+				score = s
+			}
+		}
+		if score < minSelectorSuggestionScore {
+			continue
+		}
+		if rendered := candidate.String(); score > best[rendered] {
+			best[rendered] = score
+		}
+	}
+	ranked := make([]scored, 0, len(best))
+	for rendered, score := range best {
+		ranked = append(ranked, scored{rendered, score})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].rendered < ranked[j].rendered
+	})
+	suggestions := []string{}
+	for i := 0; i < len(ranked) && i < maxSelectorSuggestions; i++ {
+		suggestions = append(suggestions, ranked[i].rendered)
+	}
+	return suggestions
+}
+
+// similarity is 1 for equal strings and 0 for completely different ones,
+// based on the case insensitive edit distance.
+func similarity(a, b string) float64 {
+	x := []rune(strings.ToLower(a))
+	y := []rune(strings.ToLower(b))
+	longest := len(x)
+	if len(y) > longest {
+		longest = len(y)
+	}
+	if longest == 0 {
+		return 1
+	}
+	previous := make([]int, len(y)+1)
+	current := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return 1 - float64(previous[len(y)])/float64(longest)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,43 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorTerms(t *testing.T) {
+	require.Equal(t, []string{"submit"}, selectorTerms(getByTestIdSelector("submit")))
+	require.Equal(t, []string{"Sign in"}, selectorTerms(getByRoleSelector("button", GetByRoleOptions{Name: "Sign in"})))
+	require.Equal(t, []string{"Email"}, selectorTerms(getByLabelSelector("Email")))
+	require.Equal(t, []string{"submit"}, selectorTerms(`[data-testid=submit]`))
+	require.Equal(t, []string{"submit"}, selectorTerms(`button[data-testid="submit"]`))
+	require.Equal(t, []string{"Sign in"}, selectorTerms(`text=Sign in`))
+	require.Equal(t, []string{"login", "primary"}, selectorTerms(`form >> #login.primary >> nth=0`))
+	require.Equal(t, []string{"button"}, selectorTerms(`button`))
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, similarity("Submit", "submit"))
+	require.Equal(t, 0.0, similarity("abc", "xyz"))
+	require.InDelta(t, 0.75, similarity("test", "tent"), 0.001)
+	require.Equal(t, 1.0, similarity("", ""))
+}
+
+func TestRankSelectorCandidates(t *testing.T) {
+	candidates := []selectorCandidate{
+		{kind: "testId", value: "submit-button"},
+		{kind: "testId", value: "cancel-button"},
+		{kind: "role", role: "button", value: "Submit"},
+		{kind: "id", value: "submit:main"},
+		{kind: "name", value: "submitted"},
+		{kind: "text", value: "Sign up for the newsletter"},
+	}
+	require.Equal(t, []string{
+		`GetByRole("button", GetByRoleOptions{Name: "Submit"})`,
+		`Locator("[name=\"submitted\"]")`,
+		`Locator("#submit\\:main")`,
+	}, rankSelectorCandidates([]string{"submit"}, candidates))
+	require.Equal(t, []string{`GetByTestId("submit-button")`}, rankSelectorCandidates([]string{"submit-btn"}, candidates)[:1])
+	require.Empty(t, rankSelectorCandidates([]string{"unrelated"}, candidates))
+}
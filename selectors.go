@@ -0,0 +1,23 @@
+package playwright
+
+type selectorsImpl struct {
+	channelOwner
+}
+
+func (s *selectorsImpl) Register(name string, script string, options ...SelectorsRegisterOptions) error {
+	_, err := s.channel.Send("register", map[string]interface{}{
+		"name":   name,
+		"source": script,
+	}, options)
+	return err
+}
+
+func (s *selectorsImpl) SetTestIdAttribute(name string) {
+	SetTestIdAttribute(name)
+}
+
+func newSelectors(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *selectorsImpl {
+	bt := &selectorsImpl{}
+	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
+	return bt
+}
@@ -0,0 +1,126 @@
+package playwright
+
+import "fmt"
+
+// StabilizeOptions configures what Page.Stabilize() and the `Stabilize`
+// option of the screenshot methods wait for.
+type StabilizeOptions struct {
+	// Whether to wait until all fonts of the document are loaded. Defaults to `true`.
+	Fonts *bool `json:"fonts"`
+	// Whether to wait until all `<img>` elements are loaded and decoded, images with `loading="lazy"` which did not start
+	// loading are skipped. Defaults to `true`.
+	Images *bool `json:"images"`
+	// Whether to wait until all finite CSS animations, CSS transitions and Web Animations finished, infinite ones are
+	// ignored. Defaults to `true`.
+	Animations *bool `json:"animations"`
+	// Maximum time in milliseconds, defaults to the timeout of the screenshot or to the default timeout of the page.
+	Timeout *float64 `json:"timeout"`
+}
+
+const stabilizeScript = `async options => {
+  const waitUntilStable = async () => {
+    if (options.fonts && document.fonts)
+      await document.fonts.ready;
+    if (options.images) {
+      const inViewport = image => {
+        const rect = image.getBoundingClientRect();
+        return rect.bottom > 0 && rect.right > 0 && rect.top < innerHeight && rect.left < innerWidth;
+      };
+      const images = Array.from(document.images).filter(image => (!image.complete || image.naturalWidth) &&
+          (!options.viewportOnly || inViewport(image)));
+      await Promise.all(images.map(async image => {
+        if (!image.complete) {
+          if (image.loading === 'lazy' && !image.currentSrc)
+            return;
+          await new Promise(resolve => {
+            image.addEventListener('load', resolve, { once: true });
+            image.addEventListener('error', resolve, { once: true });
+          });
+        }
+        if (image.decode)
+          await image.decode().catch(() => {});
+      }));
+    }
+    if (options.animations && document.getAnimations) {
+      for (;;) {
+        const running = document.getAnimations().filter(animation => {
+          if (animation.playState !== 'running' || !animation.effect)
+            return false;
+          return isFinite(animation.effect.getComputedTiming().endTime);
+        });
+        if (!running.length)
+          break;
+        await Promise.all(running.map(animation => animation.finished.catch(() => {})));
+      }
+    }
+    // Let the browser paint the final state.
+    await new Promise(resolve => requestAnimationFrame(() => requestAnimationFrame(resolve)));
+  };
+  let timer;
+  const timeout = new Promise((_, reject) => {
+    if (options.timeout)
+      timer = setTimeout(() => reject(new Error('Timeout ' + options.timeout + 'ms exceeded while stabilizing the page.')), options.timeout);
+  });
+  try {
+    await Promise.race([waitUntilStable(), timeout]);
+  } finally {
+    clearTimeout(timer);
+  }
+}`
+
+// stabilizeArgument returns the argument of stabilizeScript, every check is
+// enabled unless it got disabled explicitly.
+func stabilizeArgument(options StabilizeOptions, timeout float64) map[string]interface{} {
+	enabled := func(v *bool) bool {
+		return v == nil || *v
+	}
+	if options.Timeout != nil {
+		timeout = *options.Timeout
+	}
+	return map[string]interface{}{
+		"fonts":      enabled(options.Fonts),
+		"images":     enabled(options.Images),
+		"animations": enabled(options.Animations),
+		"timeout":    timeout,
+	}
+}
+
+func (p *pageImpl) WaitForAssetsLoaded(options ...PageWaitForAssetsLoadedOptions) error {
+	option := PageWaitForAssetsLoadedOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	argument := stabilizeArgument(StabilizeOptions{
+		Animations: Bool(false),
+		Timeout:    option.Timeout,
+	}, p.timeoutSettings.Timeout())
+	argument["viewportOnly"] = option.AllImages == nil || !*option.AllImages
+	if _, err := p.mainFrame.Evaluate(stabilizeScript, argument); err != nil {
+		return fmt.Errorf("could not wait for assets: %w", err)
+	}
+	return nil
+}
+
+func stabilizeFrame(frame Frame, options StabilizeOptions, timeout float64) error {
+	if _, err := frame.Evaluate(stabilizeScript, stabilizeArgument(options, timeout)); err != nil {
+		return fmt.Errorf("could not stabilize page: %w", err)
+	}
+	return nil
+}
+
+func (p *pageImpl) Stabilize(options ...StabilizeOptions) error {
+	option := StabilizeOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	return stabilizeFrame(p.mainFrame, option, p.timeoutSettings.Timeout())
+}
+
+// screenshotTimeout returns the timeout of the stabilization before a
+// screenshot, which falls back to the timeout of the screenshot itself.
+func screenshotTimeout(timeout *float64, defaultTimeout float64) float64 {
+	if timeout != nil {
+		return *timeout
+	}
+	return defaultTimeout
+}
@@ -0,0 +1,235 @@
+package playwright
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StepResult is a finished step recorded by Step().
+type StepResult struct {
+	Title    string
+	Start    time.Time
+	Duration time.Duration
+	// Error returned by the function of the step, nil if it passed.
+	Error error
+	// Steps which ran inside of this step.
+	Steps []*StepResult
+}
+
+// StepRecorderOptions are the options of NewStepRecorder().
+type StepRecorderOptions struct {
+	// Tracing to group the actions of a step in, see Tracing.Group(). Steps
+	// only get grouped while tracing is started.
+	Tracing Tracing
+}
+
+// StepRecorder records the steps which run with a context returned by
+// ContextWithStepRecorder(). Steps nest in the order they get called, so a
+// recorder should be used by one goroutine at a time, e.g. one per test.
+type StepRecorder struct {
+	sync.Mutex
+	tracing Tracing
+	steps   []*StepResult
+	stack   []*StepResult
+}
+
+// NewStepRecorder returns a recorder for Step().
+func NewStepRecorder(options ...StepRecorderOptions) *StepRecorder {
+	recorder := &StepRecorder{}
+	if len(options) == 1 {
+		recorder.tracing = options[0].Tracing
+	}
+	return recorder
+}
+
+type stepRecorderKey struct{}
+
+// ContextWithStepRecorder returns a context which makes Step() record into
+// recorder.
+func ContextWithStepRecorder(ctx context.Context, recorder *StepRecorder) context.Context {
+	return context.WithValue(ctx, stepRecorderKey{}, recorder)
+}
+
+// StepRecorderFromContext returns the recorder of ctx or nil.
+func StepRecorderFromContext(ctx context.Context) *StepRecorder {
+	recorder, _ := ctx.Value(stepRecorderKey{}).(*StepRecorder)
+	return recorder
+}
+
+// Step runs fn as a business-level step, e.g. "add item to cart", and
+// returns its error. The step gets recorded with its timing and result by the
+// recorder of ctx, if any, which can write the steps as JUnit or HTML report.
+// Steps called by fn get nested into the step. If ctx is done already fn does
+// not run and the error of ctx gets returned.
+func Step(ctx context.Context, title string, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	recorder := StepRecorderFromContext(ctx)
+	if recorder == nil {
+		return fn()
+	}
+	step, grouped := recorder.begin(title)
+	var err error
+	defer func() {
+		recorder.end(step, grouped, err)
+	}()
+	err = fn()
+	return err
+}
+
+// begin starts a step, grouped reports whether a tracing group got started
+// for it.
+func (r *StepRecorder) begin(title string) (step *StepResult, grouped bool) {
+	step = &StepResult{
+		Title: title,
+		Start: time.Now(),
+	}
+	r.Lock()
+	if len(r.stack) > 0 {
+		parent := r.stack[len(r.stack)-1]
+		parent.Steps = append(parent.Steps, step)
+	} else {
+		r.steps = append(r.steps, step)
+	}
+	r.stack = append(r.stack, step)
+	r.Unlock()
+	if r.tracing != nil {
+		// The step gets recorded even if tracing has not been started.
+		grouped = r.tracing.Group(title) == nil
+	}
+	return step, grouped
+}
+
+func (r *StepRecorder) end(step *StepResult, grouped bool, err error) {
+	if grouped {
+		_ = r.tracing.GroupEnd()
+	}
+	r.Lock()
+	defer r.Unlock()
+	step.Duration = time.Since(step.Start)
+	step.Error = err
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		if r.stack[i] == step {
+			r.stack = r.stack[:i]
+			break
+		}
+	}
+}
+
+// Steps returns the recorded top-level steps.
+func (r *StepRecorder) Steps() []*StepResult {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*StepResult{}, r.steps...)
+}
+
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the steps as JUnit XML test suite. Every step becomes a
+// test case, nested steps are named by the titles of their parents joined by
+// " › ".
+func (r *StepRecorder) WriteJUnit(w io.Writer, suite string) error {
+	report := junitTestSuite{Name: suite}
+	var total time.Duration
+	var add func(steps []*StepResult, parents []string)
+	add = func(steps []*StepResult, parents []string) {
+		for _, step := range steps {
+			path := append(append([]string{}, parents...), step.Title)
+			testCase := junitTestCase{
+				Name:      strings.Join(path, " › "),
+				ClassName: suite,
+				Time:      formatSeconds(step.Duration),
+			}
+			if step.Error != nil {
+				testCase.Failure = &junitFailure{Message: step.Error.Error()}
+				report.Failures++
+			}
+			report.Cases = append(report.Cases, testCase)
+			add(step.Steps, path)
+		}
+	}
+	r.Lock()
+	for _, step := range r.steps {
+		total += step.Duration
+	}
+	add(r.steps, nil)
+	r.Unlock()
+	report.Tests = len(report.Cases)
+	report.Time = formatSeconds(total)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("could not write JUnit report: %w", err)
+	}
+	return nil
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+var stepReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+li { margin: 4px 0; }
+.passed::before { content: "✓ "; color: green; }
+.failed::before { content: "✗ "; color: red; }
+.duration { color: gray; }
+.error { color: red; white-space: pre-wrap; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{template "steps" .Steps}}
+</body>
+</html>
+{{define "steps"}}{{if .}}<ul>
+{{range .}}<li><span class="{{if .Error}}failed{{else}}passed{{end}}">{{.Title}}</span> <span class="duration">{{.Duration}}</span>{{if .Error}}
+<div class="error">{{.Error}}</div>{{end}}
+{{template "steps" .Steps}}</li>
+{{end}}</ul>{{end}}{{end}}`))
+
+// WriteHTML writes the steps as HTML report with the given title.
+func (r *StepRecorder) WriteHTML(w io.Writer, title string) error {
+	r.Lock()
+	defer r.Unlock()
+	if err := stepReportTemplate.Execute(w, map[string]interface{}{
+		"Title": title,
+		"Steps": r.steps,
+	}); err != nil {
+		return fmt.Errorf("could not write HTML report: %w", err)
+	}
+	return nil
+}
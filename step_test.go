@@ -0,0 +1,62 @@
+package playwright
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepRecordsNestedSteps(t *testing.T) {
+	recorder := NewStepRecorder()
+	ctx := ContextWithStepRecorder(context.Background(), recorder)
+	failure := errors.New("out of stock")
+	err := Step(ctx, "checkout", func() error {
+		if err := Step(ctx, "add item to cart", func() error { return nil }); err != nil {
+			return err
+		}
+		return Step(ctx, "pay", func() error { return failure })
+	})
+	require.Equal(t, failure, err)
+	require.NoError(t, Step(ctx, "logout", func() error { return nil }))
+
+	steps := recorder.Steps()
+	require.Len(t, steps, 2)
+	require.Equal(t, "checkout", steps[0].Title)
+	require.Equal(t, failure, steps[0].Error)
+	require.Len(t, steps[0].Steps, 2)
+	require.Equal(t, "add item to cart", steps[0].Steps[0].Title)
+	require.NoError(t, steps[0].Steps[0].Error)
+	require.Equal(t, failure, steps[0].Steps[1].Error)
+	require.Equal(t, "logout", steps[1].Title)
+
+	var junit bytes.Buffer
+	require.NoError(t, recorder.WriteJUnit(&junit, "shop"))
+	require.Contains(t, junit.String(), `<testsuite name="shop" tests="4" failures="2"`)
+	require.Contains(t, junit.String(), `name="checkout › add item to cart" classname="shop"`)
+	require.Contains(t, junit.String(), `<failure message="out of stock"></failure>`)
+
+	var html bytes.Buffer
+	require.NoError(t, recorder.WriteHTML(&html, "Shop <report>"))
+	require.Contains(t, html.String(), "<title>Shop &lt;report&gt;</title>")
+	require.Contains(t, html.String(), `<span class="failed">pay</span>`)
+	require.Contains(t, html.String(), `<span class="passed">add item to cart</span>`)
+}
+
+func TestStepWithoutRecorder(t *testing.T) {
+	called := false
+	require.NoError(t, Step(context.Background(), "step", func() error {
+		called = true
+		return nil
+	}))
+	require.True(t, called)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, context.Canceled, Step(ctx, "step", func() error {
+		t.Fatal("step of a done context must not run")
+		return nil
+	}))
+}
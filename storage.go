@@ -0,0 +1,199 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type webStorageImpl struct {
+	page   *pageImpl
+	name   string
+	origin []string
+}
+
+func (w *webStorageImpl) Items() (map[string]string, error) {
+	frame, err := w.page.frameForOrigin(w.origin...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := frame.Evaluate(`name => {
+  const storage = window[name];
+  const items = {};
+  for (let i = 0; i < storage.length; i++) {
+    const key = storage.key(i);
+    items[key] = storage.getItem(key);
+  }
+  return items;
+}`, w.name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", w.name, err)
+	}
+	return toStringMap(result), nil
+}
+
+func (w *webStorageImpl) GetItem(key string) (string, bool, error) {
+	frame, err := w.page.frameForOrigin(w.origin...)
+	if err != nil {
+		return "", false, err
+	}
+	result, err := frame.Evaluate(`([name, key]) => window[name].getItem(key)`, []interface{}{w.name, key})
+	if err != nil {
+		return "", false, fmt.Errorf("could not read %s: %w", w.name, err)
+	}
+	if result == nil {
+		return "", false, nil
+	}
+	return result.(string), true, nil
+}
+
+func (w *webStorageImpl) SetItem(key, value string) error {
+	return w.evaluate(`([name, key, value]) => window[name].setItem(key, value)`, key, value)
+}
+
+func (w *webStorageImpl) RemoveItem(key string) error {
+	return w.evaluate(`([name, key]) => window[name].removeItem(key)`, key)
+}
+
+func (w *webStorageImpl) Clear() error {
+	return w.evaluate(`([name]) => window[name].clear()`)
+}
+
+func (w *webStorageImpl) evaluate(expression string, args ...interface{}) error {
+	frame, err := w.page.frameForOrigin(w.origin...)
+	if err != nil {
+		return err
+	}
+	if _, err := frame.Evaluate(expression, append([]interface{}{w.name}, args...)); err != nil {
+		return fmt.Errorf("could not write %s: %w", w.name, err)
+	}
+	return nil
+}
+
+func (p *pageImpl) LocalStorage(origin ...string) WebStorage {
+	return &webStorageImpl{page: p, name: "localStorage", origin: origin}
+}
+
+func (p *pageImpl) SessionStorage(origin ...string) WebStorage {
+	return &webStorageImpl{page: p, name: "sessionStorage", origin: origin}
+}
+
+// IndexedDBDatabase is the content of an IndexedDB database, see Page.DumpIndexedDB().
+type IndexedDBDatabase struct {
+	// Name of the database.
+	Name string `json:"name"`
+	// Version of the database.
+	Version int `json:"version"`
+	// Object stores of the database.
+	Stores []IndexedDBObjectStore `json:"stores"`
+}
+
+// IndexedDBObjectStore is an object store of an IndexedDBDatabase.
+type IndexedDBObjectStore struct {
+	// Name of the object store.
+	Name string `json:"name"`
+	// Key path of the object store, either `nil`, a string or a slice of strings.
+	KeyPath interface{} `json:"keyPath"`
+	// Whether the keys get generated.
+	AutoIncrement bool `json:"autoIncrement"`
+	// Indexes of the object store.
+	Indexes []IndexedDBIndex `json:"indexes"`
+	// Records of the object store, ordered by their keys.
+	Records []IndexedDBRecord `json:"records"`
+}
+
+// IndexedDBIndex is an index of an IndexedDBObjectStore.
+type IndexedDBIndex struct {
+	// Name of the index.
+	Name string `json:"name"`
+	// Key path of the index, either a string or a slice of strings.
+	KeyPath interface{} `json:"keyPath"`
+	// Whether the index does not allow duplicate keys.
+	Unique bool `json:"unique"`
+	// Whether the index has an entry for each element of array keys.
+	MultiEntry bool `json:"multiEntry"`
+}
+
+// IndexedDBRecord is a record of an IndexedDBObjectStore.
+type IndexedDBRecord struct {
+	Key   interface{} `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+const dumpIndexedDBScript = `async names => {
+  const promisify = request => new Promise((resolve, reject) => {
+    request.onsuccess = () => resolve(request.result);
+    request.onerror = () => reject(request.error);
+  });
+  if (!names) {
+    if (!indexedDB.databases)
+      throw new Error('indexedDB.databases() is not supported, pass the names of the databases');
+    names = (await indexedDB.databases()).map(database => database.name);
+  }
+  const result = [];
+  for (const name of names) {
+    const open = indexedDB.open(name);
+    // Do not create databases which do not exist.
+    let created = false;
+    open.onupgradeneeded = () => {
+      created = true;
+      open.transaction.abort();
+    };
+    const db = await promisify(open).catch(error => {
+      if (created)
+        return null;
+      throw error;
+    });
+    if (!db)
+      continue;
+    const stores = [];
+    for (const storeName of Array.from(db.objectStoreNames)) {
+      const store = db.transaction(storeName, 'readonly').objectStore(storeName);
+      // The indexes can only be read while the transaction is active.
+      const indexes = Array.from(store.indexNames).map(indexName => {
+        const index = store.index(indexName);
+        return { name: index.name, keyPath: index.keyPath, unique: index.unique, multiEntry: index.multiEntry };
+      });
+      const [keys, values] = await Promise.all([promisify(store.getAllKeys()), promisify(store.getAll())]);
+      stores.push({
+        name: store.name,
+        keyPath: store.keyPath,
+        autoIncrement: store.autoIncrement,
+        indexes,
+        records: keys.map((key, i) => ({ key, value: values[i] })),
+      });
+    }
+    result.push({ name: db.name, version: db.version, stores });
+    db.close();
+  }
+  return result;
+}`
+
+func (p *pageImpl) DumpIndexedDB(options ...PageDumpIndexedDBOptions) ([]IndexedDBDatabase, error) {
+	var origin []string
+	var names interface{}
+	if len(options) == 1 {
+		if options[0].Origin != nil {
+			origin = []string{*options[0].Origin}
+		}
+		if options[0].Databases != nil {
+			names = options[0].Databases
+		}
+	}
+	frame, err := p.frameForOrigin(origin...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := frame.Evaluate(dumpIndexedDBScript, names)
+	if err != nil {
+		return nil, fmt.Errorf("could not dump IndexedDB: %w", err)
+	}
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize IndexedDB: %w", err)
+	}
+	databases := make([]IndexedDBDatabase, 0)
+	if err := json.Unmarshal(serialized, &databases); err != nil {
+		return nil, fmt.Errorf("could not parse IndexedDB: %w", err)
+	}
+	return databases, nil
+}
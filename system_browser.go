@@ -0,0 +1,132 @@
+package playwright
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// SystemBrowser represents a browser installation which was found on the host
+// system and can be launched via the `Channel` launch option.
+type SystemBrowser struct {
+	// Browser type name which is able to launch it, e.g. `chromium`.
+	BrowserName string
+	// Distribution channel, e.g. `chrome` or `msedge-beta`.
+	Channel string
+	// Absolute path to the browser executable.
+	ExecutablePath string
+}
+
+// systemBrowserChannels are the channels in the order they get reported by
+// BrowserType.SystemBrowsers().
+var systemBrowserChannels = map[string][]string{
+	"chromium": {
+		"chrome", "chrome-beta", "chrome-dev", "chrome-canary",
+		"msedge", "msedge-beta", "msedge-dev", "msedge-canary",
+	},
+}
+
+// systemBrowserLocations returns the well known install locations of a
+// browser channel on the current platform.
+func systemBrowserLocations(channel string) []string {
+	switch runtime.GOOS {
+	case "linux":
+		return map[string][]string{
+			"chrome":      {"/opt/google/chrome/chrome"},
+			"chrome-beta": {"/opt/google/chrome-beta/chrome"},
+			"chrome-dev":  {"/opt/google/chrome-unstable/chrome"},
+			"msedge":      {"/opt/microsoft/msedge/msedge"},
+			"msedge-beta": {"/opt/microsoft/msedge-beta/msedge"},
+			"msedge-dev":  {"/opt/microsoft/msedge-dev/msedge"},
+		}[channel]
+	case "darwin":
+		return map[string][]string{
+			"chrome":        {"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome"},
+			"chrome-beta":   {"/Applications/Google Chrome Beta.app/Contents/MacOS/Google Chrome Beta"},
+			"chrome-dev":    {"/Applications/Google Chrome Dev.app/Contents/MacOS/Google Chrome Dev"},
+			"chrome-canary": {"/Applications/Google Chrome Canary.app/Contents/MacOS/Google Chrome Canary"},
+			"msedge":        {"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge"},
+			"msedge-beta":   {"/Applications/Microsoft Edge Beta.app/Contents/MacOS/Microsoft Edge Beta"},
+			"msedge-dev":    {"/Applications/Microsoft Edge Dev.app/Contents/MacOS/Microsoft Edge Dev"},
+			"msedge-canary": {"/Applications/Microsoft Edge Canary.app/Contents/MacOS/Microsoft Edge Canary"},
+		}[channel]
+	case "windows":
+		suffix := map[string]string{
+			"chrome":        `Google\Chrome\Application\chrome.exe`,
+			"chrome-beta":   `Google\Chrome Beta\Application\chrome.exe`,
+			"chrome-dev":    `Google\Chrome Dev\Application\chrome.exe`,
+			"chrome-canary": `Google\Chrome SxS\Application\chrome.exe`,
+			"msedge":        `Microsoft\Edge\Application\msedge.exe`,
+			"msedge-beta":   `Microsoft\Edge Beta\Application\msedge.exe`,
+			"msedge-dev":    `Microsoft\Edge Dev\Application\msedge.exe`,
+			"msedge-canary": `Microsoft\Edge SxS\Application\msedge.exe`,
+		}[channel]
+		if suffix == "" {
+			return nil
+		}
+		locations := make([]string, 0)
+		for _, env := range []string{"LOCALAPPDATA", "PROGRAMFILES", "PROGRAMFILES(X86)"} {
+			if prefix := os.Getenv(env); prefix != "" {
+				locations = append(locations, filepath.Join(prefix, suffix))
+			}
+		}
+		return locations
+	}
+	return nil
+}
+
+// findSystemBrowsers returns all the channels of the given browser type which
+// are installed on the host system.
+func findSystemBrowsers(browserName string) []SystemBrowser {
+	browsers := make([]SystemBrowser, 0)
+	for _, channel := range systemBrowserChannels[browserName] {
+		for _, location := range systemBrowserLocations(channel) {
+			if validateExecutablePath(location) == nil {
+				browsers = append(browsers, SystemBrowser{
+					BrowserName:    browserName,
+					Channel:        channel,
+					ExecutablePath: location,
+				})
+				break
+			}
+		}
+	}
+	return browsers
+}
+
+// validateExecutablePath checks that the given path points to a file which
+// can get executed by the driver.
+func validateExecutablePath(path string) error {
+	stats, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("executable does not exist at %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not stat executable: %w", err)
+	}
+	if stats.IsDir() {
+		return fmt.Errorf("executable path %s is a directory", path)
+	}
+	if runtime.GOOS != "windows" && stats.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("executable at %s is not executable", path)
+	}
+	return nil
+}
+
+// validateLaunchTarget makes sure that the given executable path can be
+// launched by the browser type before sending it to the driver. Channels are
+// validated by the driver, which knows the ones it supports.
+func validateLaunchTarget(browserName string, executablePath *string) error {
+	if executablePath == nil {
+		return nil
+	}
+	path, err := filepath.Abs(*executablePath)
+	if err != nil {
+		return fmt.Errorf("could not resolve executable path: %w", err)
+	}
+	if err := validateExecutablePath(path); err != nil {
+		return fmt.Errorf("invalid executablePath for %s: %w", browserName, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package playwright_test
+
+import (
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessibilitySnapshot(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<head><title>Accessibility Test</title></head>
+		<body>
+			<h1>Inputs</h1>
+			<input placeholder="Empty input" autofocus />
+			<input type="checkbox" aria-label="Agree" checked />
+			<button aria-pressed="true">Bold</button>
+		</body>`))
+	snapshot, err := page.Accessibility().Snapshot()
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	heading := snapshot.Find(func(node *playwright.AccessibilityNode) bool {
+		return node.Role == "heading"
+	})
+	require.NotNil(t, heading)
+	require.Equal(t, "Inputs", heading.Name)
+	require.Equal(t, 1, heading.Level)
+	checkbox := snapshot.Find(func(node *playwright.AccessibilityNode) bool {
+		return node.Role == "checkbox"
+	})
+	require.NotNil(t, checkbox)
+	require.Equal(t, "Agree", checkbox.Name)
+	require.Equal(t, playwright.MixedStateOn, checkbox.Checked)
+	textbox := snapshot.Find(func(node *playwright.AccessibilityNode) bool {
+		return node.Role == "textbox"
+	})
+	require.NotNil(t, textbox)
+	require.True(t, textbox.Focused)
+}
+
+func TestAccessibilitySnapshotRoot(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<div role="tablist">
+			<div role="tab" aria-selected="true"><b>Tab1</b></div>
+			<div role="tab">Tab2</div>
+		</div>
+		<div role="menu"><div role="menuitem">First Item</div></div>`))
+	menu, err := page.QuerySelector(`div[role="menu"]`)
+	require.NoError(t, err)
+	snapshot, err := page.Accessibility().Snapshot(playwright.AccessibilitySnapshotOptions{
+		Root: menu,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "menu", snapshot.Role)
+	require.Len(t, snapshot.Children, 1)
+	require.Equal(t, "First Item", snapshot.Children[0].Name)
+
+	snapshot, err = page.Accessibility().Snapshot(playwright.AccessibilitySnapshotOptions{
+		InterestingOnly: playwright.Bool(false),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, snapshot.Find(func(node *playwright.AccessibilityNode) bool {
+		return node.Role == "tab" && node.Selected
+	}))
+}
@@ -0,0 +1,248 @@
+package playwright_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRequestContextFetch(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+			"method":      r.Method,
+			"query":       r.URL.RawQuery,
+			"body":        string(body),
+			"contentType": r.Header.Get("Content-Type"),
+			"foo":         r.Header.Get("Foo"),
+		}))
+	})
+	request, err := pw.Request.NewContext(playwright.APIRequestNewContextOptions{
+		BaseURL:          playwright.String(server.PREFIX),
+		ExtraHttpHeaders: map[string]string{"Foo": "bar"},
+	})
+	require.NoError(t, err)
+	defer request.Dispose()
+
+	response, err := request.Post("/echo", playwright.APIRequestContextFetchOptions{
+		Params: map[string]interface{}{"id": 42},
+		Data:   map[string]string{"name": "foo"},
+	})
+	require.NoError(t, err)
+	require.True(t, response.Ok())
+	require.Equal(t, "application/json", response.Headers()["content-type"])
+	var echo map[string]string
+	require.NoError(t, response.JSON(&echo))
+	require.Equal(t, map[string]string{
+		"method":      "POST",
+		"query":       "id=42",
+		"body":        `{"name":"foo"}`,
+		"contentType": "application/json",
+		"foo":         "bar",
+	}, echo)
+
+	response, err = request.Fetch(server.PREFIX+"/echo", playwright.APIRequestContextFetchOptions{
+		Method:  playwright.String("put"),
+		Form:    map[string]interface{}{"a": "b"},
+		Headers: map[string]string{"foo": "baz"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, response.JSON(&echo))
+	require.Equal(t, "PUT", echo["method"])
+	require.Equal(t, "a=b", echo["body"])
+	require.Equal(t, "application/x-www-form-urlencoded", echo["contentType"])
+	require.Equal(t, "baz", echo["foo"])
+
+	response, err = request.Get("/does-not-exist.html")
+	require.NoError(t, err)
+	require.Equal(t, 404, response.Status())
+	require.NoError(t, playwright.NewPlaywrightAssertions().APIResponse(response).ToHaveStatus(404))
+	_, err = request.Get("/does-not-exist.html", playwright.APIRequestContextFetchOptions{
+		FailOnStatusCode: playwright.Bool(true),
+	})
+	require.EqualError(t, err, "404 Not Found")
+
+	require.NoError(t, request.Dispose())
+	_, err = request.Get("/echo")
+	require.EqualError(t, err, "request context has been disposed")
+}
+
+func TestAPIRequestContextStorageState(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret", Path: "/"})
+		http.Redirect(w, r, "/whoami", http.StatusFound)
+	})
+	server.SetRoute("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(cookie.Value))
+	})
+	request, err := pw.Request.NewContext()
+	require.NoError(t, err)
+	defer request.Dispose()
+	response, err := request.Get(server.PREFIX + "/login")
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/whoami", response.URL())
+	text, err := response.Text()
+	require.NoError(t, err)
+	require.Equal(t, "secret", text)
+
+	state, err := request.StorageState()
+	require.NoError(t, err)
+	require.Len(t, state.Cookies, 1)
+	require.Equal(t, "session", state.Cookies[0].Name)
+
+	other, err := pw.Request.NewContext(playwright.APIRequestNewContextOptions{
+		StorageState: state,
+	})
+	require.NoError(t, err)
+	defer other.Dispose()
+	response, err = other.Get(server.PREFIX + "/whoami")
+	require.NoError(t, err)
+	require.Equal(t, 200, response.Status())
+
+	response, err = request.Get(server.PREFIX+"/login", playwright.APIRequestContextFetchOptions{
+		MaxRedirects: playwright.Int(0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 302, response.Status())
+}
+
+func TestBrowserContextRequestShouldShareCookies(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "from-api", Path: "/"})
+	})
+	server.SetRoute("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("page")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(cookie.Value))
+	})
+	_, err := context.Request().Post(server.PREFIX + "/login")
+	require.NoError(t, err)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	cookie, err := page.Evaluate(`document.cookie`)
+	require.NoError(t, err)
+	require.Equal(t, "session=from-api", cookie)
+
+	_, err = page.Evaluate(`document.cookie = "page=from-page"`)
+	require.NoError(t, err)
+	response, err := page.Request().Get(server.PREFIX + "/whoami")
+	require.NoError(t, err)
+	text, err := response.Text()
+	require.NoError(t, err)
+	require.Equal(t, "from-page", text)
+}
+
+func TestAPIRequestContextMultipart(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/upload", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		result := map[string]string{"name": r.FormValue("name")}
+		for field, headers := range r.MultipartForm.File {
+			file, err := headers[0].Open()
+			require.NoError(t, err)
+			content, err := ioutil.ReadAll(file)
+			require.NoError(t, err)
+			result[field] = headers[0].Filename + ":" + string(content)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(result))
+	})
+	file, err := os.Open(Asset("file-to-upload.txt"))
+	require.NoError(t, err)
+	defer file.Close()
+	response, err := context.Request().Post(server.PREFIX+"/upload", playwright.APIRequestContextFetchOptions{
+		Multipart: map[string]interface{}{
+			"name":   "foo",
+			"buffer": playwright.InputFile{Name: "data.json", MimeType: "application/json", Buffer: []byte(`{"a":1}`)},
+			"path":   file,
+		},
+	})
+	require.NoError(t, err)
+	var result map[string]string
+	require.NoError(t, response.JSON(&result))
+	content, err := ioutil.ReadFile(Asset("file-to-upload.txt"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"name":   "foo",
+		"buffer": `data.json:{"a":1}`,
+		"path":   "file-to-upload.txt:" + string(content),
+	}, result)
+}
+
+func TestAPIRequestContextStorageStateShouldBeReusedByBrowser(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "from-api", Path: "/"})
+	})
+	request, err := pw.Request.NewContext()
+	require.NoError(t, err)
+	defer request.Dispose()
+	_, err = request.Post(server.PREFIX + "/login")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), ".auth", "user.json")
+	_, err = request.StorageState(path)
+	require.NoError(t, err)
+
+	browserContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		StorageStatePath: playwright.String(path),
+	})
+	require.NoError(t, err)
+	defer browserContext.Close()
+	browserPage, err := browserContext.NewPage()
+	require.NoError(t, err)
+	_, err = browserPage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	cookie, err := browserPage.Evaluate(`document.cookie`)
+	require.NoError(t, err)
+	require.Equal(t, "session=from-api", cookie)
+
+	_, err = browserPage.Evaluate(`() => {
+		document.cookie = "session=from-browser";
+		localStorage.setItem("foo", "bar");
+	}`)
+	require.NoError(t, err)
+	_, err = browserContext.StorageState(path)
+	require.NoError(t, err)
+	server.SetRoute("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(cookie.Value))
+	})
+	reused, err := pw.Request.NewContext(playwright.APIRequestNewContextOptions{
+		StorageStatePath: playwright.String(path),
+	})
+	require.NoError(t, err)
+	defer reused.Dispose()
+	response, err := reused.Get(server.PREFIX + "/whoami")
+	require.NoError(t, err)
+	text, err := response.Text()
+	require.NoError(t, err)
+	require.Equal(t, "from-browser", text)
+	state, err := reused.StorageState()
+	require.NoError(t, err)
+	require.Len(t, state.Origins, 1)
+	require.Equal(t, []playwright.LocalStorageEntry{{Name: "foo", Value: "bar"}}, state.Origins[0].LocalStorage)
+}
@@ -0,0 +1,309 @@
+package playwright_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocatorAssertionsToHaveAccessibleName(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button aria-label="Close dialog">x</button>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("button"))
+	require.NoError(t, assertions.ToHaveAccessibleName("Close dialog"))
+	require.NoError(t, assertions.ToHaveAccessibleName("close DIALOG", playwright.LocatorAssertionsToHaveAccessibleNameOptions{
+		IgnoreCase: playwright.Bool(true),
+	}))
+	require.NoError(t, assertions.ToHaveAccessibleName(regexp.MustCompile(`^Close`)))
+	require.NoError(t, assertions.Not().ToHaveAccessibleName("x"))
+	err := assertions.ToHaveAccessibleName("Open dialog", playwright.LocatorAssertionsToHaveAccessibleNameOptions{
+		Timeout: playwright.Float(300),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got 'Close dialog'")
+}
+
+func TestLocatorAssertionsToHaveRole(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div role="checkbox" aria-checked="false">Subscribe</div>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("div"))
+	require.NoError(t, assertions.ToHaveRole("checkbox"))
+	require.NoError(t, assertions.Not().ToHaveRole("button"))
+}
+
+func TestLocatorAssertionsToMatchAriaSnapshot(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<main>
+			<h1>Todos</h1>
+			<ul>
+				<li>Milk</li>
+				<li>Eggs</li>
+			</ul>
+			<button>Add</button>
+		</main>`))
+	snapshot, err := page.Locator("ul").AriaSnapshot()
+	require.NoError(t, err)
+	require.Equal(t, "- list:\n  - listitem: Milk\n  - listitem: Eggs", snapshot)
+
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("main"))
+	require.NoError(t, assertions.ToMatchAriaSnapshot(`
+		- heading "Todos" [level=1]
+		- list:
+		  - listitem: /Milk|Butter/
+		- button "Add"`))
+	require.NoError(t, assertions.Not().ToMatchAriaSnapshot(`- button "Remove"`))
+	_, err = page.Evaluate(`() => setTimeout(() => document.querySelector('button').textContent = 'Remove', 100)`)
+	require.NoError(t, err)
+	require.NoError(t, assertions.ToMatchAriaSnapshot(`- button "Remove"`))
+	err = assertions.ToMatchAriaSnapshot(`- button "Add"`, playwright.LocatorAssertionsToMatchAriaSnapshotOptions{
+		Timeout: playwright.Float(300),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `button "Remove"`)
+}
+
+func TestLocatorAssertionsShouldRetry(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button>Save</button>`))
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('button').setAttribute('aria-label', 'Saved'), 200)`)
+	require.NoError(t, err)
+	require.NoError(t, playwright.NewPlaywrightAssertions().Locator(page.Locator("button")).ToHaveAccessibleName("Saved"))
+}
+
+func TestLocatorAssertionsToHaveAccessibleDescription(t *testing.T) {
+	if !isChromium {
+		t.Skip("accessible descriptions are only exposed in Chromium")
+	}
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button aria-describedby="hint">Delete</button><div id="hint">Removes the file permanently</div>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("button"))
+	require.NoError(t, assertions.ToHaveAccessibleDescription("Removes the file permanently"))
+}
+
+func TestLocatorAssertionsToHaveCSS(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div style="display: flex; color: rgb(255, 0, 0)">text</div>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("div"))
+	require.NoError(t, assertions.ToHaveCSS("display", "flex"))
+	require.NoError(t, assertions.ToHaveCSS("color", regexp.MustCompile(`^rgb\(255`)))
+	require.NoError(t, assertions.Not().ToHaveCSS("display", "block"))
+}
+
+func TestLocatorAssertionsToHaveClass(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div class="card selected">text</div>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("div"))
+	require.NoError(t, assertions.ToHaveClass("card selected"))
+	require.NoError(t, assertions.ToHaveClass(regexp.MustCompile(`\bselected\b`)))
+	require.NoError(t, assertions.Not().ToHaveClass("card"))
+}
+
+func TestLocatorAssertionsToHaveValue(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input value="initial">`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("input"))
+	require.NoError(t, assertions.ToHaveValue("initial"))
+	require.NoError(t, page.Fill("input", "changed"))
+	require.NoError(t, assertions.ToHaveValue(regexp.MustCompile(`^chan`)))
+}
+
+func TestLocatorAssertionsToHaveValues(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<select multiple>
+			<option value="red">Red</option>
+			<option value="green" selected>Green</option>
+			<option value="blue" selected>Blue</option>
+		</select>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("select"))
+	require.NoError(t, assertions.ToHaveValues([]interface{}{"green", regexp.MustCompile(`^bl`)}))
+	require.NoError(t, assertions.Not().ToHaveValues([]interface{}{"green"}))
+	err := assertions.ToHaveValues([]interface{}{"red"}, playwright.LocatorAssertionsToHaveValuesOptions{
+		Timeout: playwright.Float(300),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got '[green blue]'")
+}
+
+func TestLocatorAssertionsToBeInViewport(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	require.NoError(t, page.SetContent(`
+		<div id="big" style="height: 1000px; width: 100px"></div>
+		<div id="below" style="height: 50px; width: 100px"></div>`))
+	require.NoError(t, playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("#big")).ToBeInViewport())
+	require.NoError(t, playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("#big")).ToBeInViewport(playwright.LocatorAssertionsToBeInViewportOptions{
+		Ratio: playwright.Float(0.4),
+	}))
+	require.NoError(t, playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("#big")).Not().ToBeInViewport(playwright.LocatorAssertionsToBeInViewportOptions{
+		Ratio: playwright.Float(0.6),
+	}))
+	require.NoError(t, playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("#below")).Not().ToBeInViewport())
+}
+
+func TestLocatorAssertionsToHaveText(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<ul><li>One</li><li>  Two
+		items</li></ul>`))
+	assertions := playwright.NewPlaywrightAssertions(1000)
+	require.NoError(t, assertions.Locator(page.Locator("li").Last()).ToHaveText("Two items"))
+	require.NoError(t, assertions.Locator(page.Locator("li").Last()).ToContainText("two", playwright.LocatorAssertionsToContainTextOptions{
+		IgnoreCase: playwright.Bool(true),
+	}))
+	require.NoError(t, assertions.Locator(page.Locator("li")).ToHaveText([]interface{}{"One", regexp.MustCompile(`^\s*Two`)}))
+	require.NoError(t, assertions.Locator(page.Locator("ul")).ToContainText("One"))
+	require.NoError(t, assertions.Locator(page.Locator("ul")).Not().ToHaveText("One"))
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('li').textContent = 'Three', 200)`)
+	require.NoError(t, err)
+	require.NoError(t, assertions.Locator(page.Locator("li").First()).ToHaveText("Three"))
+}
+
+func TestLocatorAssertionsToHaveCount(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<ul><li>One</li></ul>`))
+	assertions := playwright.NewPlaywrightAssertions(1000).Locator(page.Locator("li"))
+	require.NoError(t, assertions.ToHaveCount(1))
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('ul').innerHTML += '<li>Two</li>', 200)`)
+	require.NoError(t, err)
+	require.NoError(t, assertions.ToHaveCount(2))
+	err = assertions.ToHaveCount(3, playwright.LocatorAssertionsToHaveCountOptions{
+		Timeout: playwright.Float(300),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got '2'")
+}
+
+func TestLocatorAssertionsVisibility(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div style="display: none">Hidden</div>`))
+	assertions := playwright.NewPlaywrightAssertions(1000)
+	require.NoError(t, assertions.Locator(page.Locator("div")).ToBeHidden())
+	require.NoError(t, assertions.Locator(page.Locator("div")).ToBeAttached())
+	require.NoError(t, assertions.Locator(page.Locator("span")).ToBeHidden())
+	require.NoError(t, assertions.Locator(page.Locator("span")).Not().ToBeAttached())
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('div').style.display = 'block', 200)`)
+	require.NoError(t, err)
+	require.NoError(t, assertions.Locator(page.Locator("div")).ToBeVisible())
+}
+
+func TestLocatorAssertionsStates(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input id="name" type="text">
+		<input type="checkbox" checked>
+		<button disabled>Save</button>
+		<input readonly value="fixed">
+	`))
+	assertions := playwright.NewPlaywrightAssertions(1000)
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToBeEmpty())
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToBeEditable())
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToBeEnabled())
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToHaveId("name"))
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToHaveAttribute("type", "text"))
+	require.NoError(t, page.Locator("#name").Focus())
+	require.NoError(t, assertions.Locator(page.Locator("#name")).ToBeFocused())
+	require.NoError(t, assertions.Locator(page.Locator("[type=checkbox]")).ToBeChecked())
+	require.NoError(t, page.Locator("[type=checkbox]").Uncheck())
+	require.NoError(t, assertions.Locator(page.Locator("[type=checkbox]")).ToBeChecked(playwright.LocatorAssertionsToBeCheckedOptions{
+		Checked: playwright.Bool(false),
+	}))
+	require.NoError(t, assertions.Locator(page.Locator("button")).ToBeDisabled())
+	require.NoError(t, assertions.Locator(page.Locator("[readonly]")).Not().ToBeEditable())
+	require.NoError(t, assertions.Locator(page.Locator("[readonly]")).Not().ToBeEmpty())
+}
+
+func TestPageAssertionsToHaveURL(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`url => setTimeout(() => window.location.href = url, 200)`, server.PREFIX+"/grid.html")
+	require.NoError(t, err)
+	assertions := playwright.NewPlaywrightAssertions().Page(page)
+	require.NoError(t, assertions.ToHaveURL(server.PREFIX+"/grid.html"))
+	require.NoError(t, assertions.ToHaveURL(regexp.MustCompile(`/grid\.html$`)))
+	require.NoError(t, assertions.ToHaveURL(func(url string) bool {
+		return url != server.EMPTY_PAGE
+	}))
+	require.NoError(t, assertions.Not().ToHaveURL(server.EMPTY_PAGE))
+	err = assertions.ToHaveURL(server.EMPTY_PAGE, playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(300),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got '"+server.PREFIX+"/grid.html'")
+}
+
+func TestPageAssertionsToHaveURLWithBaseURL(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		BaseURL: playwright.String(server.PREFIX),
+	})
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	_, err = page.Goto("/empty.html")
+	require.NoError(t, err)
+	require.NoError(t, playwright.NewPlaywrightAssertions(1000).Page(page).ToHaveURL("/empty.html"))
+}
+
+func TestPageAssertionsToHaveTitle(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<title>Loading</title>`))
+	_, err := page.Evaluate(`() => setTimeout(() => document.title = 'Dashboard', 200)`)
+	require.NoError(t, err)
+	assertions := playwright.NewPlaywrightAssertions().Page(page)
+	require.NoError(t, assertions.ToHaveTitle("Dashboard"))
+	require.NoError(t, assertions.ToHaveTitle(regexp.MustCompile(`^Dash`)))
+	require.NoError(t, assertions.Not().ToHaveTitle("Loading"))
+}
+
+func TestAPIResponseAssertions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/api/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "Alice", "age": 30}`))
+	})
+	response, err := page.Goto(server.PREFIX + "/api/user")
+	require.NoError(t, err)
+	assertions := playwright.NewPlaywrightAssertions().APIResponse(response)
+	require.NoError(t, assertions.ToBeOK())
+	require.NoError(t, assertions.ToHaveStatus(200))
+	require.NoError(t, assertions.ToHaveHeader("content-type", regexp.MustCompile(`^application/json`)))
+	require.NoError(t, assertions.ToHaveBody(regexp.MustCompile(`"Alice"`)))
+	require.NoError(t, assertions.ToHaveJSON(map[string]interface{}{"name": "Alice", "age": 30}))
+	require.NoError(t, assertions.Not().ToHaveStatus(404))
+	err = assertions.ToHaveJSON(struct {
+		Name string `json:"name"`
+	}{Name: "Alice"})
+	require.Error(t, err)
+
+	response, err = page.Goto(server.PREFIX + "/does-not-exist")
+	require.NoError(t, err)
+	assertions = playwright.NewPlaywrightAssertions().APIResponse(response)
+	require.NoError(t, assertions.Not().ToBeOK())
+	err = assertions.ToBeOK()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got '404'")
+}
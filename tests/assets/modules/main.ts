@@ -0,0 +1,14 @@
+import { add } from './math';
+
+interface Input {
+  a: number;
+  b: number;
+}
+
+export const name: string = 'main';
+
+export default function sum(input: Input): number {
+  return add(input.a, input.b);
+}
+
+(window as any).__sum = sum;
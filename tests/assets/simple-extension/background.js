@@ -0,0 +1,4 @@
+window.MAGIC = 42;
+chrome.runtime.onMessage.addListener((message, sender, sendResponse) => {
+  sendResponse({ echo: message });
+});
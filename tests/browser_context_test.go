@@ -1,7 +1,14 @@
 package playwright_test
 
 import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/mxschmitt/playwright-go"
 	"github.com/stretchr/testify/require"
@@ -13,6 +20,41 @@ func TestBrowserContextNewPage(t *testing.T) {
 	require.Equal(t, context.Browser(), browser)
 }
 
+func TestBrowserContextNewPageWithOptions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	otherPage, err := context.NewPage(playwright.BrowserNewPageOptions{
+		Viewport: &playwright.BrowserNewPageOptionsViewport{
+			Width:  playwright.Int(400),
+			Height: playwright.Int(300),
+		},
+		UserAgent:   playwright.String("foobar"),
+		ColorScheme: playwright.ColorSchemeDark,
+		ExtraHttpHeaders: map[string]string{
+			"foo": "bar",
+		},
+	})
+	require.NoError(t, err)
+	utils.VerifyViewport(t, otherPage, 400, 300)
+	utils.AssertEval(t, otherPage, "matchMedia('(prefers-color-scheme: dark)').matches", true)
+	request, err := otherPage.ExpectRequest(server.EMPTY_PAGE, func() error {
+		_, err := otherPage.Goto(server.EMPTY_PAGE)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foobar", request.Headers()["user-agent"])
+	require.Equal(t, "bar", request.Headers()["foo"])
+	utils.AssertEval(t, otherPage, "navigator.userAgent", "foobar")
+
+	utils.VerifyViewport(t, page, 1280, 720)
+	utils.AssertEval(t, page, "matchMedia('(prefers-color-scheme: dark)').matches", false)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	userAgent, err := page.Evaluate("navigator.userAgent")
+	require.NoError(t, err)
+	require.NotEqual(t, "foobar", userAgent)
+}
+
 func TestBrowserContextNewContext(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -77,6 +119,50 @@ func TestBrowserContextSetExtraHTTPHeaders(t *testing.T) {
 	<-intercepted
 }
 
+func TestBrowserContextSetExtraHTTPHeadersMergeAndScope(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.SetExtraHTTPHeaders(map[string]string{"x-one": "1"}))
+	require.NoError(t, context.SetExtraHTTPHeaders(map[string]string{"x-two": "2"}, playwright.BrowserContextSetExtraHTTPHeadersOptions{
+		Merge: playwright.Bool(true),
+	}))
+	require.NoError(t, context.SetExtraHTTPHeaders(map[string]string{"authorization": "Bearer secret"}, playwright.BrowserContextSetExtraHTTPHeadersOptions{
+		URL: server.PREFIX + "/**",
+	}))
+	request := server.WaitForRequestChan("/empty.html")
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	req := <-request
+	require.Equal(t, "1", req.Header.Get("x-one"))
+	require.Equal(t, "2", req.Header.Get("x-two"))
+	require.Equal(t, "Bearer secret", req.Header.Get("authorization"))
+
+	request = server.WaitForRequestChan("/empty.html")
+	_, err = page.Goto(server.CROSS_PROCESS_PREFIX + "/empty.html")
+	require.NoError(t, err)
+	req = <-request
+	require.Equal(t, "1", req.Header.Get("x-one"))
+	require.Empty(t, req.Header.Get("authorization"))
+}
+
+func TestPageSetExtraHTTPHeadersScope(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetExtraHTTPHeaders(map[string]string{"x-api": "page"}, playwright.PageSetExtraHTTPHeadersOptions{
+		URL: regexp.MustCompile(`/api/`),
+	}))
+	server.SetRoute("/api/data", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	request := server.WaitForRequestChan("/api/data")
+	_, err = page.Evaluate(`url => fetch(url)`, server.PREFIX+"/api/data")
+	require.NoError(t, err)
+	require.Equal(t, "page", (<-request).Header.Get("x-api"))
+}
+
 func TestBrowserContextNewCDPSession(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -110,6 +196,119 @@ func TestBrowserContextSetGeolocation(t *testing.T) {
 	require.NoError(t, context.ClearPermissions())
 }
 
+func TestBrowserContextGeolocationJourney(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.GrantPermissions([]string{"geolocation"}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	journey := playwright.NewGeolocationJourney(context,
+		playwright.GeolocationWaypoint{Latitude: 10, Longitude: 10},
+		playwright.GeolocationWaypoint{Latitude: 20, Longitude: 30, Duration: 10 * time.Second},
+	)
+	getPosition := func() interface{} {
+		geolocation, err := page.Evaluate(`() => new Promise(resolve => navigator.geolocation.getCurrentPosition(position => {
+      resolve({latitude: position.coords.latitude, longitude: position.coords.longitude});
+    }))`)
+		require.NoError(t, err)
+		return geolocation
+	}
+	require.NoError(t, journey.Start())
+	require.Equal(t, map[string]interface{}{"latitude": 10, "longitude": 10}, getPosition())
+	require.NoError(t, journey.Advance(5*time.Second))
+	require.Equal(t, map[string]interface{}{"latitude": 15, "longitude": 20}, getPosition())
+	require.NoError(t, journey.Advance(time.Minute))
+	require.Equal(t, map[string]interface{}{"latitude": 20, "longitude": 30}, getPosition())
+}
+
+func TestBrowserContextGeolocationJourneyPlay(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.GrantPermissions([]string{"geolocation"}))
+	journey := playwright.NewGeolocationJourney(context,
+		playwright.GeolocationWaypoint{Latitude: 10, Longitude: 10},
+		playwright.GeolocationWaypoint{Latitude: 20, Longitude: 30, Duration: 200 * time.Millisecond},
+	)
+	require.NoError(t, journey.Play(50*time.Millisecond))
+	require.EqualError(t, journey.Play(50*time.Millisecond), "journey is already playing")
+	require.NoError(t, journey.Wait())
+	require.GreaterOrEqual(t, journey.Elapsed(), 200*time.Millisecond)
+	journey.Stop()
+}
+
+func TestBrowserContextPermissionRequestDeny(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.EnablePermissionPrompts())
+	requests := make(chan playwright.PermissionRequest, 1)
+	page.On("permissionrequest", func(request playwright.PermissionRequest) {
+		request.Deny()
+		requests <- request
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	code, err := page.Evaluate(`() => new Promise(resolve => navigator.geolocation.getCurrentPosition(
+      () => resolve(0),
+      error => resolve(error.code)
+    ))`)
+	require.NoError(t, err)
+	require.Equal(t, 1, code)
+	request := <-requests
+	require.Equal(t, "geolocation", request.Name())
+	require.Equal(t, server.PREFIX, request.Origin())
+	require.Equal(t, page, request.Page())
+	require.Equal(t, page.MainFrame(), request.Frame())
+}
+
+func TestBrowserContextPermissionRequestInCurrentDocument(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, context.EnablePermissionPrompts())
+	require.NoError(t, context.EnablePermissionPrompts())
+	requests := make(chan playwright.PermissionRequest, 2)
+	page.On("permissionrequest", func(request playwright.PermissionRequest) {
+		request.Deny()
+		requests <- request
+	})
+	code, err := page.Evaluate(`() => new Promise(resolve => navigator.geolocation.getCurrentPosition(
+      () => resolve(0),
+      error => resolve(error.code)
+    ))`)
+	require.NoError(t, err)
+	require.Equal(t, 1, code)
+	require.Equal(t, "geolocation", (<-requests).Name())
+	require.Len(t, requests, 0)
+}
+
+func TestBrowserContextPermissionRequestGrant(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.EnablePermissionPrompts())
+	require.NoError(t, context.SetGeolocation(&playwright.SetGeolocationOptions{
+		Longitude: 10,
+		Latitude:  10,
+	}))
+	names := make(chan string, 1)
+	context.On("permissionrequest", func(request playwright.PermissionRequest) {
+		require.NoError(t, request.Grant())
+		names <- request.Name()
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	geolocation, err := page.Evaluate(`() => new Promise(resolve => navigator.geolocation.getCurrentPosition(position => {
+      resolve({latitude: position.coords.latitude, longitude: position.coords.longitude});
+    }))`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"latitude":  10,
+		"longitude": 10,
+	}, geolocation)
+	require.Equal(t, "geolocation", <-names)
+	require.NoError(t, context.ClearPermissions())
+}
+
 func TestBrowserContextAddCookies(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -230,3 +429,268 @@ func TestBrowserContextUnrouteShouldWork(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []int{4}, intercepted)
 }
+
+func TestBrowserContextActivePage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	require.Nil(t, context.ActivePage())
+	page1, err := context.NewPage()
+	require.NoError(t, err)
+	page2, err := context.NewPage()
+	require.NoError(t, err)
+	require.Equal(t, []playwright.Page{page1, page2}, context.Pages())
+	require.Equal(t, page2, context.ActivePage())
+	require.NoError(t, page1.BringToFront())
+	require.Equal(t, page1, context.ActivePage())
+	require.NoError(t, page1.Close())
+	require.Equal(t, page2, context.ActivePage())
+}
+
+func TestBrowserContextWaitForPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	existing, err := context.WaitForPage()
+	require.NoError(t, err)
+	require.Equal(t, page, existing)
+	_, err = page.Evaluate("url => setTimeout(() => window.open(url), 100)", server.PREFIX+"/grid.html")
+	require.NoError(t, err)
+	popup, err := context.WaitForPage(playwright.BrowserContextWaitForPageOptions{
+		Predicate: func(page playwright.Page) bool {
+			return page != existing
+		},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, page, popup)
+	_, err = context.WaitForPage(playwright.BrowserContextWaitForPageOptions{
+		Predicate: func(page playwright.Page) bool {
+			return false
+		},
+		Timeout: playwright.Float(100),
+	})
+	require.EqualError(t, err, "Timeout 100.00ms exceeded while waiting for page.")
+	_, err = page.Evaluate("url => setTimeout(() => window.open(url), 100)", server.PREFIX+"/one-style.html")
+	require.NoError(t, err)
+	second, err := context.WaitForPage(playwright.BrowserContextWaitForPageOptions{
+		Predicate: func(page playwright.Page) bool {
+			return page != existing && page != popup
+		},
+		Timeout: playwright.Float(0),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, popup, second)
+}
+
+func TestBrowserContextCloseAllPages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	for i := 0; i < 3; i++ {
+		_, err := context.NewPage()
+		require.NoError(t, err)
+	}
+	require.Len(t, context.Pages(), 3)
+	require.NoError(t, context.CloseAllPages())
+	require.Len(t, context.Pages(), 0)
+	require.Nil(t, context.ActivePage())
+	_, err = context.NewPage()
+	require.NoError(t, err)
+}
+
+func TestBrowserContextClone(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	source, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		UserAgent: playwright.String("cloned-agent"),
+	})
+	require.NoError(t, err)
+	defer source.Close()
+	require.NoError(t, source.AddInitScript(playwright.BrowserContextAddInitScriptOptions{
+		Script: playwright.String("window.injected = 123"),
+	}))
+	require.NoError(t, source.Route("**/mocked.html", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{
+			Body:        "<title>mocked</title>",
+			ContentType: playwright.String("text/html"),
+		}))
+	}))
+	sourcePage, err := source.NewPage()
+	require.NoError(t, err)
+	_, err = sourcePage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = sourcePage.Evaluate(`() => {
+		document.cookie = 'session=secret';
+		localStorage.setItem('token', 'abc');
+	}`)
+	require.NoError(t, err)
+
+	clone, err := source.Clone(playwright.BrowserNewContextOptions{
+		Locale: playwright.String("de-DE"),
+	})
+	require.NoError(t, err)
+	defer clone.Close()
+	page, err := clone.NewPage()
+	require.NoError(t, err)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	state, err := page.Evaluate(`() => [document.cookie, localStorage.getItem('token'), window.injected, navigator.userAgent, navigator.language]`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"session=secret", "abc", 123, "cloned-agent", "de-DE"}, state)
+	_, err = page.Goto(server.PREFIX + "/mocked.html")
+	require.NoError(t, err)
+	title, err := page.Title()
+	require.NoError(t, err)
+	require.Equal(t, "mocked", title)
+}
+
+func TestBrowserContextBlockRequests(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.BlockRequests(playwright.BrowserContextBlockRequestsOptions{
+		ResourceTypes: []string{"image"},
+		Domains:       []string{"localhost"},
+	}))
+	failed := make(chan playwright.Request, 1)
+	page.Once("requestfailed", func(request playwright.Request) {
+		failed <- request
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(`<img src="`+server.PREFIX+`/pptr.png">`))
+	require.Equal(t, server.PREFIX+"/pptr.png", (<-failed).URL())
+	_, err = page.Evaluate(`url => fetch(url)`, server.CROSS_PROCESS_PREFIX+"/empty.html")
+	require.Error(t, err)
+	_, err = page.Evaluate(`url => fetch(url).then(r => r.status)`, server.PREFIX+"/empty.html")
+	require.NoError(t, err)
+
+	require.NoError(t, context.BlockRequests(playwright.BrowserContextBlockRequestsOptions{}))
+	_, err = page.Evaluate(`url => fetch(url, { mode: 'no-cors' }).then(r => r.type)`, server.CROSS_PROCESS_PREFIX+"/empty.html")
+	require.NoError(t, err)
+}
+
+func TestBrowserContextSetHTTPCacheDisabled(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	hits := 0
+	server.SetRoute("/cached.css", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body { color: red; }"))
+	})
+	server.SetRoute("/cached.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<link rel="stylesheet" href="/cached.css">`))
+	})
+	require.NoError(t, context.SetHTTPCacheDisabled(true))
+	for i := 0; i < 2; i++ {
+		_, err := page.Goto(server.PREFIX + "/cached.html")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, hits)
+}
+
+func TestBrowserContextRecordHar(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	harPath := filepath.Join(t.TempDir(), "network.har")
+	harContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		RecordHarPath:      playwright.String(harPath),
+		RecordHarUrlFilter: "**/*.css",
+	})
+	require.NoError(t, err)
+	harPage, err := harContext.NewPage()
+	require.NoError(t, err)
+	_, err = harPage.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	require.NoError(t, harContext.Close())
+
+	data, err := ioutil.ReadFile(harPath)
+	require.NoError(t, err)
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					URL string `json:"url"`
+				} `json:"request"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	require.NoError(t, json.Unmarshal(data, &har))
+	require.Len(t, har.Log.Entries, 1)
+	require.Equal(t, server.PREFIX+"/one-style.css", har.Log.Entries[0].Request.URL)
+}
+
+func TestBrowserContextLimitsMaxPages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context2, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Limits: &playwright.ContextLimits{MaxPages: playwright.Int(1)},
+	})
+	require.NoError(t, err)
+	defer context2.Close()
+	_, err = context2.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, context2.LimitError())
+	_, err = context2.NewPage()
+	var limitErr *playwright.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, *playwright.ContextLimitPages, limitErr.Limit)
+	require.Equal(t, limitErr, context2.LimitError())
+}
+
+func TestBrowserContextLimitsMaxNavigations(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context2, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Limits: &playwright.ContextLimits{MaxNavigations: playwright.Int(2)},
+	})
+	require.NoError(t, err)
+	defer context2.Close()
+	page2, err := context2.NewPage()
+	require.NoError(t, err)
+	_, err = page2.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page2.Goto(server.PREFIX + "/grid.html")
+	require.NoError(t, err)
+	_, _ = page2.Goto(server.PREFIX + "/dom.html")
+	require.Eventually(t, func() bool {
+		return context2.LimitError() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	var limitErr *playwright.LimitExceededError
+	require.True(t, errors.As(context2.LimitError(), &limitErr))
+	require.Equal(t, *playwright.ContextLimitNavigations, limitErr.Limit)
+}
+
+func TestBrowserContextOnBeforeClose(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newContext, err := browser.NewContext()
+	require.NoError(t, err)
+	newPage, err := newContext.NewPage()
+	require.NoError(t, err)
+	calls := []string{}
+	newContext.OnBeforeClose(func(context playwright.BrowserContext) error {
+		title, err := newPage.Evaluate(`() => "still open"`)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, title.(string))
+		return errors.New("flush failed")
+	})
+	newContext.OnBeforeClose(func(context playwright.BrowserContext) error {
+		calls = append(calls, "second")
+		return nil
+	})
+	err = newContext.Close()
+	require.EqualError(t, err, "before close hook failed: flush failed")
+	require.Equal(t, []string{"still open", "second"}, calls)
+	require.NoError(t, newContext.Close())
+	require.Equal(t, []string{"still open", "second"}, calls)
+}
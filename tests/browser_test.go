@@ -45,6 +45,20 @@ func TestBrowserNewContextWithExtraHTTPHeaders(t *testing.T) {
 	<-intercepted
 }
 
+func TestBrowserNewContextShouldRejectSocksProxyCredentials(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Proxy: &playwright.BrowserNewContextOptionsProxy{
+			Server:   playwright.String("socks5://127.0.0.1:1080"),
+			Username: playwright.String("user"),
+			Password: playwright.String("secret"),
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not support authentication for socks5 proxies")
+}
+
 func TestBrowserNewPage(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -128,3 +142,141 @@ func TestBrowserClose(t *testing.T) {
 	require.NoError(t, pw.Stop())
 	require.False(t, browser.IsConnected())
 }
+
+func TestBrowserNewContextDeterministic(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	render := func() []interface{} {
+		deterministicContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+			Deterministic: &playwright.DeterministicRenderingOptions{
+				Seed: playwright.Int(42),
+			},
+		})
+		require.NoError(t, err)
+		defer deterministicContext.Close()
+		deterministicPage, err := deterministicContext.NewPage()
+		require.NoError(t, err)
+		_, err = deterministicPage.Goto(server.EMPTY_PAGE)
+		require.NoError(t, err)
+		result, err := deterministicPage.Evaluate(`() => {
+			const input = document.createElement('input');
+			input.style.transition = 'opacity 10s';
+			document.body.appendChild(input);
+			return [
+				Math.random(),
+				new Date().toISOString(),
+				Intl.DateTimeFormat().resolvedOptions().timeZone,
+				navigator.language,
+				getComputedStyle(input).caretColor,
+				getComputedStyle(input).transitionDuration,
+				matchMedia('(prefers-reduced-motion: reduce)').matches,
+			];
+		}`)
+		require.NoError(t, err)
+		return result.([]interface{})
+	}
+	first := render()
+	require.Equal(t, first, render())
+	require.Equal(t, []interface{}{
+		"2020-01-01T00:00:00.000Z", "UTC", "en-US", "rgba(0, 0, 0, 0)", "0s", true,
+	}, first[1:])
+}
+
+func TestBrowserNewContextDemoMode(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	demoContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		DemoMode: &playwright.DemoModeOptions{
+			Captions: playwright.Bool(false),
+		},
+	})
+	require.NoError(t, err)
+	defer demoContext.Close()
+	demoPage, err := demoContext.NewPage()
+	require.NoError(t, err)
+	_, err = demoPage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, demoPage.SetContent(`<button onclick="window.clicked = true">Click</button>`))
+	require.NoError(t, demoPage.Click("button"))
+	result, err := demoPage.Evaluate(`() => {
+		const overlay = document.querySelector('x-pw-demo');
+		return [window.clicked, !!overlay, overlay && getComputedStyle(overlay).pointerEvents];
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{true, true, "none"}, result)
+}
+
+func TestBrowserNewContextPreset(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	pw.RegisterPreset("de-variant-b", &playwright.ContextPreset{
+		Locale:      playwright.String("de-DE"),
+		TimezoneId:  playwright.String("Europe/Berlin"),
+		Geolocation: &playwright.BrowserContextGeolocation{Latitude: playwright.Float(52.52), Longitude: playwright.Float(13.40)},
+		Permissions: []string{"geolocation"},
+		ExtraHttpHeaders: map[string]string{
+			"X-Market": "de",
+		},
+		Cookies: []playwright.SetNetworkCookieParam{
+			{Name: "variant", Value: "b", URL: playwright.String(server.PREFIX)},
+		},
+	})
+	presetContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Preset: playwright.String("de-variant-b"),
+		Locale: playwright.String("de-AT"),
+	})
+	require.NoError(t, err)
+	defer presetContext.Close()
+	presetPage, err := presetContext.NewPage()
+	require.NoError(t, err)
+	request, err := presetPage.ExpectRequest("**/empty.html", func() error {
+		_, err := presetPage.Goto(server.EMPTY_PAGE)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, "de", request.Headers()["x-market"])
+	result, err := presetPage.Evaluate(`() => new Promise(resolve => navigator.geolocation.getCurrentPosition(position => resolve([
+		navigator.language,
+		Intl.DateTimeFormat().resolvedOptions().timeZone,
+		document.cookie,
+		position.coords.latitude,
+	])))`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"de-AT", "Europe/Berlin", "variant=b", 52.52}, result)
+
+	_, err = browser.NewContext(playwright.BrowserNewContextOptions{
+		Preset: playwright.String("unknown"),
+	})
+	require.EqualError(t, err, "unknown preset: unknown")
+}
+
+func TestBrowserSubscribeEvents(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	subscription := browser.SubscribeEvents(playwright.BrowserSubscribeEventsOptions{
+		Events: []string{"console", "pageerror"},
+	})
+	defer subscription.Close()
+	otherContext, err := browser.NewContext()
+	require.NoError(t, err)
+	defer otherContext.Close()
+	otherPage, err := otherContext.NewPage()
+	require.NoError(t, err)
+
+	_, err = page.Evaluate(`() => console.log("from page")`)
+	require.NoError(t, err)
+	event := <-subscription.C
+	require.Equal(t, "console", event.Name)
+	require.Equal(t, page, event.Page)
+	require.Equal(t, context, event.Context)
+	require.Equal(t, "from page", event.Payload.(playwright.ConsoleMessage).Text())
+
+	_, err = otherPage.Evaluate(`() => setTimeout(() => { throw new Error("boom") }, 0)`)
+	require.NoError(t, err)
+	event = <-subscription.C
+	require.Equal(t, "pageerror", event.Name)
+	require.Equal(t, otherPage, event.Page)
+	require.NotEqual(t, "", event.ContextID)
+	require.Contains(t, event.Payload.(error).Error(), "boom")
+	require.Equal(t, 0, subscription.Dropped())
+}
@@ -1,8 +1,14 @@
 package playwright_test
 
 import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/mxschmitt/playwright-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -147,3 +153,132 @@ func TestBrowserTypeConnectShouldEmitDisconnectedEvent(t *testing.T) {
 	require.False(t, browser2.IsConnected())
 	require.Len(t, disconnected2.Get(), 1)
 }
+
+func TestBrowserTypeLaunchShouldRejectMissingExecutablePath(t *testing.T) {
+	_, err := browserType.Launch(playwright.BrowserTypeLaunchOptions{
+		ExecutablePath: playwright.String(filepath.Join(t.TempDir(), "does-not-exist")),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "executable does not exist")
+}
+
+func TestBrowserTypeLaunchShouldRejectDirectoryAsExecutablePath(t *testing.T) {
+	_, err := browserType.LaunchPersistentContext(t.TempDir(), playwright.BrowserTypeLaunchPersistentContextOptions{
+		ExecutablePath: playwright.String(t.TempDir()),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is a directory")
+}
+
+func TestBrowserTypeSystemBrowsers(t *testing.T) {
+	for _, systemBrowser := range browserType.SystemBrowsers() {
+		require.Equal(t, browserType.Name(), systemBrowser.BrowserName)
+		require.NotEmpty(t, systemBrowser.Channel)
+		require.FileExists(t, systemBrowser.ExecutablePath)
+	}
+	if !isChromium {
+		require.Len(t, browserType.SystemBrowsers(), 0)
+	}
+}
+
+func TestBrowserTypeConnectShouldRetry(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	url := fmt.Sprintf("ws://%s/", listener.Addr().String())
+	require.NoError(t, listener.Close())
+	start := time.Now()
+	_, err = browserType.Connect(url, playwright.BrowserTypeConnectOptions{
+		Retries:      playwright.Int(2),
+		RetryBackoff: playwright.Float(50),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not connect to websocket")
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestBrowserTypeConnectWithRetries(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	remote_server := newRemoteServer()
+	defer remote_server.Close()
+	browser, err := browserType.Connect(remote_server.url, playwright.BrowserTypeConnectOptions{
+		Retries: playwright.Int(3),
+		Timeout: playwright.Float(10000),
+	})
+	require.NoError(t, err)
+	require.True(t, browser.IsConnected())
+	require.NoError(t, browser.Close())
+}
+
+func TestBrowserTypeCapabilities(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.True(t, browserType.SupportsVideo())
+	require.Equal(t, isChromium, browserType.SupportsPDF())
+	require.Equal(t, isChromium, browserType.SupportsCDP())
+	require.Equal(t, isChromium, browserType.SupportsExtensions())
+	if isChromium {
+		return
+	}
+	_, err := page.PDF()
+	require.ErrorIs(t, err, playwright.ErrNotSupported)
+	var notSupportedErr *playwright.NotSupportedError
+	require.ErrorAs(t, err, &notSupportedErr)
+	require.Equal(t, browserType.Name(), notSupportedErr.Browser)
+	_, err = context.NewCDPSession(page)
+	require.ErrorIs(t, err, playwright.ErrNotSupported)
+	_, err = browser.NewBrowserCDPSession()
+	require.ErrorIs(t, err, playwright.ErrNotSupported)
+}
+
+func TestPlaywrightLifecycleHooks(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	// The hooks stay registered on the shared instance, so they only record
+	// while this test runs.
+	var mu sync.Mutex
+	active := true
+	browsers := []playwright.Browser{}
+	contexts := []playwright.BrowserContext{}
+	pw.OnBrowserLaunched(func(browser playwright.Browser) {
+		mu.Lock()
+		defer mu.Unlock()
+		if active {
+			browsers = append(browsers, browser)
+		}
+	})
+	pw.OnContextCreated(func(context playwright.BrowserContext) {
+		mu.Lock()
+		defer mu.Unlock()
+		if active {
+			contexts = append(contexts, context)
+		}
+	})
+	defer func() {
+		mu.Lock()
+		active = false
+		mu.Unlock()
+	}()
+
+	launched, err := browserType.Launch()
+	require.NoError(t, err)
+	defer launched.Close()
+	created, err := launched.NewContext()
+	require.NoError(t, err)
+	mu.Lock()
+	require.Equal(t, []playwright.Browser{launched}, browsers)
+	require.Equal(t, []playwright.BrowserContext{created}, contexts)
+	mu.Unlock()
+
+	closedURL := ""
+	created.OnBeforeClose(func(context playwright.BrowserContext) error {
+		closedURL = context.Pages()[0].URL()
+		return nil
+	})
+	createdPage, err := created.NewPage()
+	require.NoError(t, err)
+	_, err = createdPage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, launched.Close())
+	require.Equal(t, server.EMPTY_PAGE, closedURL)
+}
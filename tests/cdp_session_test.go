@@ -3,6 +3,7 @@ package playwright_test
 import (
 	"testing"
 
+	"github.com/mxschmitt/playwright-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,105 @@ func TestCDPSessionDetach(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestCDPSessionTypedNetworkDomain(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("CDP is only supported in Chromium")
+	}
+	cdpSession, err := page.Context().NewCDPSession(page)
+	require.NoError(t, err)
+	defer cdpSession.Detach()
+	require.NoError(t, cdpSession.Network().Enable())
+	requests := make(chan *playwright.CDPNetworkRequestWillBeSentEvent, 1)
+	cdpSession.Network().OnRequestWillBeSent(func(ev *playwright.CDPNetworkRequestWillBeSentEvent) {
+		requests <- ev
+	})
+	responses := make(chan *playwright.CDPNetworkResponseReceivedEvent, 1)
+	cdpSession.Network().OnResponseReceived(func(ev *playwright.CDPNetworkResponseReceivedEvent) {
+		responses <- ev
+	})
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	request := <-requests
+	require.Equal(t, server.EMPTY_PAGE, request.Request.URL)
+	require.Equal(t, "GET", request.Request.Method)
+	response := <-responses
+	require.Equal(t, request.RequestID, response.RequestID)
+	require.Equal(t, 200, response.Response.Status)
+	body, err := cdpSession.Network().GetResponseBody(response.RequestID)
+	require.NoError(t, err)
+	require.False(t, body.Base64Encoded)
+}
+
+func TestCDPSessionTypedEmulationDomain(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("CDP is only supported in Chromium")
+	}
+	cdpSession, err := page.Context().NewCDPSession(page)
+	require.NoError(t, err)
+	defer cdpSession.Detach()
+	require.NoError(t, cdpSession.Emulation().SetDeviceMetricsOverride(playwright.CDPEmulationDeviceMetrics{
+		Width:             400,
+		Height:            300,
+		DeviceScaleFactor: 2,
+	}))
+	utils.AssertEval(t, page, "window.innerWidth", 400)
+	utils.AssertEval(t, page, "window.devicePixelRatio", 2)
+	require.NoError(t, cdpSession.Emulation().SetTimezoneOverride("Europe/Berlin"))
+	utils.AssertEval(t, page, "Intl.DateTimeFormat().resolvedOptions().timeZone", "Europe/Berlin")
+}
+
+func TestCDPSessionTypedPerformanceAndStorageDomains(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("CDP is only supported in Chromium")
+	}
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	cdpSession, err := page.Context().NewCDPSession(page)
+	require.NoError(t, err)
+	defer cdpSession.Detach()
+	require.NoError(t, cdpSession.Performance().Enable())
+	metrics, err := cdpSession.Performance().GetMetrics()
+	require.NoError(t, err)
+	require.Greater(t, len(metrics), 0)
+	usage, err := cdpSession.Storage().GetUsageAndQuota(server.PREFIX)
+	require.NoError(t, err)
+	require.Greater(t, usage.Quota, float64(0))
+	require.NoError(t, cdpSession.Storage().ClearDataForOrigin(server.PREFIX, "all"))
+}
+
+func TestPageNewCDPSession(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	cdpSession, err := page.NewCDPSession()
+	if !isChromium {
+		require.ErrorIs(t, err, playwright.ErrNotSupported)
+		return
+	}
+	require.NoError(t, err)
+	_, err = cdpSession.Send("Runtime.enable", nil)
+	require.NoError(t, err)
+	contexts := make(chan map[string]interface{}, 1)
+	cdpSession.On("Runtime.executionContextCreated", func(params map[string]interface{}) {
+		select {
+		case contexts <- params:
+		default:
+		}
+	})
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NotNil(t, <-contexts)
+	result, err := cdpSession.Send("Runtime.evaluate", map[string]interface{}{
+		"expression":    "7 * 3",
+		"returnByValue": true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(21), result.(map[string]interface{})["result"].(map[string]interface{})["value"])
+	require.NoError(t, cdpSession.Detach())
+}
@@ -0,0 +1,224 @@
+package playwright_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockInstallShouldFreezeTime(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, page.Clock().Install(playwright.ClockInstallOptions{
+		Time: &startTime,
+	}))
+	now, err := page.Evaluate("() => Date.now()")
+	require.NoError(t, err)
+	require.Equal(t, 1577836800000, now)
+	require.NoError(t, page.Clock().RunFor(1500*time.Millisecond))
+	now, err = page.Evaluate("() => new Date().toISOString()")
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-01T00:00:01.500Z", now)
+}
+
+func TestClockInstallOnNavigatedPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, page.Clock().Install(playwright.ClockInstallOptions{
+		Time: &startTime,
+	}))
+	now, err := page.Evaluate("() => Date.now()")
+	require.NoError(t, err)
+	require.Equal(t, 1577836800000, now)
+	_, err = page.Reload()
+	require.NoError(t, err)
+	now, err = page.Evaluate("() => Date.now()")
+	require.NoError(t, err)
+	require.Equal(t, 1577836800000, now)
+}
+
+func TestClockRunForShouldFireTimers(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Clock().Install())
+	_, err := page.Evaluate(`() => {
+		window.calls = [];
+		setTimeout(() => window.calls.push('timeout'), 100);
+		setInterval(() => window.calls.push('interval'), 40);
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Clock().RunFor(99*time.Millisecond))
+	calls, err := page.Evaluate("() => window.calls")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"interval", "interval"}, calls)
+	require.NoError(t, page.Clock().RunFor(time.Millisecond))
+	calls, err = page.Evaluate("() => window.calls")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"interval", "interval", "timeout"}, calls)
+}
+
+func TestClockAdvanceAnimationFrames(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Clock().Install())
+	_, err := page.Evaluate(`() => {
+		window.frameCount = 0;
+		const step = () => {
+			window.frameCount++;
+			requestAnimationFrame(step);
+		};
+		requestAnimationFrame(step);
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Clock().AdvanceAnimationFrames(3))
+	frames, err := page.Evaluate("() => window.frameCount")
+	require.NoError(t, err)
+	require.Equal(t, 3, frames)
+	now, err := page.Evaluate("() => performance.now()")
+	require.NoError(t, err)
+	require.Equal(t, 48, now)
+}
+
+func TestClockShouldDriveCSSAnimations(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<style>
+			@keyframes grow { from { width: 0px; } to { width: 100px; } }
+			div { animation: grow 1s linear forwards; height: 10px; }
+		</style>
+		<div></div>
+	`))
+	require.NoError(t, page.Clock().Install())
+	require.NoError(t, page.Clock().RunFor(250*time.Millisecond))
+	width, err := page.EvalOnSelector("div", "div => div.getBoundingClientRect().width")
+	require.NoError(t, err)
+	require.InDelta(t, 25, width, 1)
+	require.NoError(t, page.Clock().RunFor(time.Second))
+	width, err = page.EvalOnSelector("div", "div => div.getBoundingClientRect().width")
+	require.NoError(t, err)
+	require.Equal(t, 100, width)
+}
+
+func TestClockFlushTimers(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Clock().Install())
+	_, err := page.Evaluate(`() => {
+		window.done = false;
+		setTimeout(() => setTimeout(() => window.done = true, 5000), 1000);
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Clock().FlushTimers())
+	done, err := page.Evaluate("() => window.done")
+	require.NoError(t, err)
+	require.Equal(t, true, done)
+	_, err = page.Evaluate("() => setInterval(() => {}, 10)")
+	require.NoError(t, err)
+	err = page.Clock().FlushTimers()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "assuming an infinite loop")
+}
+
+func TestClockShouldThrowWhenNotInstalled(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	err := page.Clock().RunFor(time.Second)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Clock is not installed")
+}
+
+func TestClockSetFixedTime(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	fixedTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, page.Clock().SetFixedTime(fixedTime))
+	now, err := page.Evaluate(`() => new Promise(f => setTimeout(() => f(new Date().toISOString()), 50))`)
+	require.NoError(t, err)
+	require.Equal(t, "2021-06-01T12:00:00.000Z", now)
+	_, err = page.Reload()
+	require.NoError(t, err)
+	now, err = page.Evaluate("() => Date.now()")
+	require.NoError(t, err)
+	require.Equal(t, 1622548800000, now)
+}
+
+func TestClockSetFixedTimeTwice(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Clock().SetFixedTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, page.Clock().SetFixedTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)))
+	now, err := page.Evaluate("() => new Date().toISOString()")
+	require.NoError(t, err)
+	require.Equal(t, "2022-06-01T12:00:00.000Z", now)
+	_, err = page.Reload()
+	require.NoError(t, err)
+	now, err = page.Evaluate(`() => new Promise(f => setTimeout(() => f(new Date().toISOString()), 50))`)
+	require.NoError(t, err)
+	require.Equal(t, "2022-06-01T12:00:00.000Z", now)
+}
+
+func TestClockFastForwardShouldFireDueTimersOnce(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Clock().Install())
+	_, err := page.Evaluate(`() => {
+		window.ticks = 0;
+		window.timeouts = 0;
+		setInterval(() => window.ticks++, 1000);
+		setTimeout(() => window.timeouts++, 30000);
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Clock().FastForward(time.Hour))
+	counts, err := page.Evaluate("() => [window.ticks, window.timeouts]")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{1, 1}, counts)
+}
+
+func TestClockPauseAtAndResume(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, page.Clock().Install(playwright.ClockInstallOptions{
+		Time: &startTime,
+	}))
+	require.NoError(t, page.Clock().PauseAt(startTime.Add(time.Minute)))
+	now, err := page.Evaluate("() => new Date().toISOString()")
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-01T00:01:00.000Z", now)
+	err = page.Clock().PauseAt(startTime)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cannot pause at")
+
+	require.NoError(t, page.Clock().Resume())
+	fired, err := page.Evaluate(`() => new Promise(f => setTimeout(() => f(true), 100))`)
+	require.NoError(t, err)
+	require.Equal(t, true, fired)
+	require.NoError(t, page.Clock().PauseAt(startTime.Add(time.Hour)))
+	now, err = page.Evaluate("() => new Date().toISOString()")
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-01T01:00:00.000Z", now)
+}
+
+func TestBrowserContextClockShouldApplyToAllPages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, context.Clock().Install(playwright.ClockInstallOptions{
+		Time: &startTime,
+	}))
+	page2, err := context.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, context.Clock().RunFor(time.Second))
+	for _, p := range []playwright.Page{page, page2} {
+		now, err := p.Evaluate("() => Date.now()")
+		require.NoError(t, err)
+		require.Equal(t, 1577836801000, now)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/mxschmitt/playwright-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -76,3 +78,134 @@ func TestDownloadCancel(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "canceled", failure)
 }
+
+func TestDownloadBlockedPerPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/downloadWithFilename", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", "attachment; filename=file.txt")
+		if _, err := w.Write([]byte("foobar")); err != nil {
+			log.Printf("could not write: %v", err)
+		}
+	})
+	content := fmt.Sprintf(`<a href="%s/downloadWithFilename">download</a>`, server.PREFIX)
+	require.True(t, page.AcceptDownloads())
+	require.NoError(t, page.SetAcceptDownloads(false))
+	require.False(t, page.AcceptDownloads())
+	page.On("download", func() {
+		t.Error("download should have been blocked")
+	})
+	reasons := make(chan string, 1)
+	page.On("downloadblocked", func(download playwright.Download, event *playwright.DownloadBlockedEvent) {
+		require.Equal(t, download, event.Download)
+		reasons <- event.Reason
+	})
+	require.NoError(t, page.SetContent(content))
+	blocked, err := page.ExpectEvent("downloadblocked", func() error {
+		return page.Click("a")
+	})
+	require.NoError(t, err)
+	download := blocked.(playwright.Download)
+	require.Equal(t, "file.txt", download.SuggestedFilename())
+	require.Equal(t, page, download.Page())
+	require.Equal(t, playwright.DownloadBlockedByPage, <-reasons)
+
+	otherPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.True(t, otherPage.AcceptDownloads())
+	require.NoError(t, otherPage.SetContent(content))
+	download, err = otherPage.ExpectDownload(func() error {
+		return otherPage.Click("a")
+	})
+	require.NoError(t, err)
+	failure, err := download.Failure()
+	require.NoError(t, err)
+	require.Equal(t, "", failure)
+}
+
+func TestDownloadSetAcceptDownloadsShouldRequireContextOption(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	otherContext, err := browser.NewContext()
+	require.NoError(t, err)
+	defer otherContext.Close()
+	otherPage, err := otherContext.NewPage()
+	require.NoError(t, err)
+	require.False(t, otherPage.AcceptDownloads())
+	require.Error(t, otherPage.SetAcceptDownloads(true))
+	require.NoError(t, otherPage.SetAcceptDownloads(false))
+}
+
+func TestPageExpectDownloadMatching(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/export.csv", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Add("Content-Disposition", "attachment; filename=export.csv")
+		if _, err := w.Write([]byte("a,b\n1,2\n")); err != nil {
+			log.Printf("could not write: %v", err)
+		}
+	})
+	require.NoError(t, page.SetContent(
+		fmt.Sprintf(`<a href="%s/export.csv">download</a>`, server.PREFIX),
+	))
+	match, err := page.ExpectDownloadMatching(func() error {
+		return page.Click("a")
+	}, playwright.PageExpectDownloadMatchingOptions{
+		SuggestedFilename: regexp.MustCompile(`\.csv$`),
+		MimeType:          playwright.String("text/csv"),
+		MinSize:           playwright.Int(8),
+		SHA256:            playwright.String("2f5a5b3f3b0ba0d6a4ebd1a4a0b7e2ae7a39a2d8ac1ca6d0bdb3a4c3c38e5a6b"),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SHA-256")
+	require.Equal(t, "export.csv", match.Download.SuggestedFilename())
+	require.Equal(t, "text/csv", match.MimeType)
+	require.Equal(t, int64(8), match.Size)
+
+	match, err = page.ExpectDownloadMatching(func() error {
+		return page.Click("a")
+	}, playwright.PageExpectDownloadMatchingOptions{
+		SuggestedFilename: "export.csv",
+		SHA256:            playwright.String(match.SHA256),
+	})
+	require.NoError(t, err)
+	require.NoError(t, match.Download.Delete())
+
+	_, err = page.ExpectDownloadMatching(func() error {
+		return page.Click("a")
+	}, playwright.PageExpectDownloadMatchingOptions{
+		MinSize: playwright.Int(1024),
+	})
+	require.Error(t, err)
+}
+
+func TestDownloadBlockedPerContext(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/downloadWithFilename", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", "attachment; filename=file.txt")
+		if _, err := w.Write([]byte("foobar")); err != nil {
+			log.Printf("could not write: %v", err)
+		}
+	})
+	otherContext, err := browser.NewContext()
+	require.NoError(t, err)
+	defer otherContext.Close()
+	otherPage, err := otherContext.NewPage()
+	require.NoError(t, err)
+	events := make(chan *playwright.DownloadBlockedEvent, 1)
+	otherPage.On("downloadblocked", func(download playwright.Download, event *playwright.DownloadBlockedEvent) {
+		events <- event
+	})
+	require.NoError(t, otherPage.SetContent(fmt.Sprintf(`<a href="%s/downloadWithFilename">download</a>`, server.PREFIX)))
+	download, err := otherPage.ExpectDownload(func() error {
+		return otherPage.Click("a")
+	})
+	require.NoError(t, err)
+	event := <-events
+	require.Equal(t, playwright.DownloadBlockedByContext, event.Reason)
+	require.Equal(t, download, event.Download)
+}
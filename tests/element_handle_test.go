@@ -161,6 +161,153 @@ func TestElementBoundingBox(t *testing.T) {
 	require.Equal(t, 50, box.Height)
 }
 
+func TestElementBoundingBoxSpace(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	require.NoError(t, page.SetContent(`<div style="height: 2000px"></div><div id="box" style="width: 50px; height: 50px"></div>`))
+	_, err := page.Evaluate("() => window.scrollTo(0, 1000)")
+	require.NoError(t, err)
+	element_handle, err := page.QuerySelector("#box")
+	require.NoError(t, err)
+	box, err := element_handle.BoundingBox()
+	require.NoError(t, err)
+	require.Equal(t, 1008, box.Y)
+	box, err = element_handle.BoundingBox(playwright.ElementHandleBoundingBoxOptions{
+		Space: playwright.BoundingBoxSpacePage,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2008, box.Y)
+	require.Equal(t, 50, box.Width)
+}
+
+func TestLocatorBoundingBox(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	_, err := page.Goto(server.PREFIX + "/grid.html")
+	require.NoError(t, err)
+	box, err := page.Locator(".box:nth-of-type(13)").BoundingBox()
+	require.NoError(t, err)
+	require.Equal(t, 100, box.X)
+	require.Equal(t, 50, box.Y)
+	require.Equal(t, 50, box.Width)
+	require.Equal(t, 50, box.Height)
+}
+
+func TestLocatorBoundingBoxInIframe(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	require.NoError(t, page.SetContent(`<iframe style="position: absolute; left: 20px; top: 30px; border: 0" srcdoc="<div style='margin: 10px; width: 40px; height: 40px'></div><style>body { margin: 0 }</style>"></iframe>`))
+	frame := page.Frames()[1]
+	_, err := frame.WaitForSelector("div")
+	require.NoError(t, err)
+	box, err := frame.Locator("div").BoundingBox()
+	require.NoError(t, err)
+	require.Equal(t, 30, box.X)
+	require.Equal(t, 40, box.Y)
+	box, err = frame.Locator("div").BoundingBox(playwright.LocatorBoundingBoxOptions{
+		Space: playwright.BoundingBoxSpaceFrame,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 10, box.X)
+	require.Equal(t, 10, box.Y)
+	require.Equal(t, 40, box.Width)
+}
+
+func TestLocatorSelectText(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<textarea>some value</textarea>`))
+	require.NoError(t, page.Locator("textarea").SelectText())
+	selection, err := page.EvalOnSelector("textarea", "t => t.value.substring(t.selectionStart, t.selectionEnd)")
+	require.NoError(t, err)
+	require.Equal(t, "some value", selection)
+}
+
+func TestLocatorPressSequentially(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input>`))
+	_, err := page.EvalOnSelector("input", `input => {
+		window.keys = [];
+		input.addEventListener('keydown', event => window.keys.push(event.key));
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("input").PressSequentially("abc", playwright.LocatorPressSequentiallyOptions{
+		Delay: playwright.Float(10),
+	}))
+	value, err := page.EvalOnSelector("input", "input => input.value")
+	require.NoError(t, err)
+	require.Equal(t, "abc", value)
+	keys, err := page.Evaluate("() => window.keys")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b", "c"}, keys)
+}
+
+func TestLocatorStateGetters(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input id="text" value="hello" data-kind="greeting">
+		<input id="checkbox" type="checkbox" checked disabled>
+		<div id="hidden" style="display: none">hidden <b>text</b></div>`))
+	value, err := page.Locator("#text").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "hello", value)
+	attribute, err := page.Locator("#text").GetAttribute("data-kind")
+	require.NoError(t, err)
+	require.Equal(t, "greeting", attribute)
+	editable, err := page.Locator("#text").IsEditable()
+	require.NoError(t, err)
+	require.True(t, editable)
+	checked, err := page.Locator("#checkbox").IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	enabled, err := page.Locator("#checkbox").IsEnabled()
+	require.NoError(t, err)
+	require.False(t, enabled)
+	disabled, err := page.Locator("#checkbox").IsDisabled()
+	require.NoError(t, err)
+	require.True(t, disabled)
+	visible, err := page.Locator("#hidden").IsVisible()
+	require.NoError(t, err)
+	require.False(t, visible)
+	hidden, err := page.Locator("#hidden").IsHidden()
+	require.NoError(t, err)
+	require.True(t, hidden)
+	textContent, err := page.Locator("#hidden").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "hidden text", textContent)
+	innerHTML, err := page.Locator("#hidden").InnerHTML()
+	require.NoError(t, err)
+	require.Equal(t, "hidden <b>text</b>", innerHTML)
+}
+
+func TestLocatorInputValueShouldWaitForElement(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Evaluate(`() => setTimeout(() => {
+		const input = document.createElement('input');
+		input.value = 'late';
+		document.body.appendChild(input);
+	}, 100)`)
+	require.NoError(t, err)
+	value, err := page.Locator("input").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "late", value)
+}
+
+func TestLocatorStateGettersShouldBeStrict(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>a</div><div>b</div>`))
+	_, err := page.Locator("div").TextContent()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "strict mode violation")
+}
+
 func TestElementHandleTap(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -0,0 +1,149 @@
+package playwright_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func launchContextWithExtension(t *testing.T) playwright.BrowserContext {
+	if !isChromium {
+		t.Skip("extensions are only supported in Chromium")
+	}
+	if os.Getenv("HEADFUL") == "" {
+		t.Skip("extensions are only supported in headful mode")
+	}
+	extensionPath := Asset("simple-extension")
+	context, err := browserType.LaunchPersistentContext(t.TempDir(), playwright.BrowserTypeLaunchPersistentContextOptions{
+		Headless: playwright.Bool(false),
+		Args: []string{
+			fmt.Sprintf("--disable-extensions-except=%s", extensionPath),
+			fmt.Sprintf("--load-extension=%s", extensionPath),
+		},
+	})
+	require.NoError(t, err)
+	return context
+}
+
+func TestBrowserContextWaitForExtension(t *testing.T) {
+	context := launchContextWithExtension(t)
+	defer context.Close()
+	extension, err := context.WaitForExtension()
+	require.NoError(t, err)
+	require.Len(t, extension.ID(), 32)
+	require.NotNil(t, extension.BackgroundPage())
+	require.Nil(t, extension.ServiceWorker())
+	require.Len(t, context.BackgroundPages(), 1)
+	require.Len(t, context.Extensions(), 1)
+	require.Equal(t, fmt.Sprintf("chrome-extension://%s/popup.html", extension.ID()), extension.URL("/popup.html"))
+	magic, err := extension.Evaluate("() => window.MAGIC")
+	require.NoError(t, err)
+	require.Equal(t, 42, magic)
+	manifest, err := extension.Manifest()
+	require.NoError(t, err)
+	require.Equal(t, "Simple extension", manifest["name"])
+}
+
+func TestBrowserContextWaitForExtensionShouldTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := context.WaitForExtension(playwright.BrowserContextWaitForExtensionOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Timeout 100.00ms exceeded")
+}
+
+func TestExtensionOpenPopupAndOptions(t *testing.T) {
+	context := launchContextWithExtension(t)
+	defer context.Close()
+	extension, err := context.WaitForExtension()
+	require.NoError(t, err)
+	popup, err := extension.OpenPopup()
+	require.NoError(t, err)
+	title, err := popup.Title()
+	require.NoError(t, err)
+	require.Equal(t, "Popup", title)
+	options, err := extension.OpenOptions()
+	require.NoError(t, err)
+	title, err = options.Title()
+	require.NoError(t, err)
+	require.Equal(t, "Options", title)
+}
+
+func TestExtensionSendMessage(t *testing.T) {
+	context := launchContextWithExtension(t)
+	defer context.Close()
+	extension, err := context.WaitForExtension()
+	require.NoError(t, err)
+	response, err := extension.SendMessage(map[string]interface{}{
+		"foo": "bar",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"echo": map[string]interface{}{
+			"foo": "bar",
+		},
+	}, response)
+}
+
+func TestBrowserTypeLaunchPersistentContextExtensions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		_, err := browserType.LaunchPersistentContext(t.TempDir(), playwright.BrowserTypeLaunchPersistentContextOptions{
+			Extensions: []string{Asset("simple-extension")},
+		})
+		require.ErrorIs(t, err, playwright.ErrNotSupported)
+		return
+	}
+	if os.Getenv("HEADFUL") == "" {
+		t.Skip("extensions are only supported in headful mode")
+	}
+	userDataDir := t.TempDir()
+	context, err := browserType.LaunchPersistentContext(userDataDir, playwright.BrowserTypeLaunchPersistentContextOptions{
+		Extensions: []string{Asset("simple-extension")},
+	})
+	require.NoError(t, err)
+	extension, err := context.WaitForExtension()
+	require.NoError(t, err)
+	id := extension.ID()
+	require.NoError(t, context.AddCookies(playwright.SetNetworkCookieParam{
+		Name:    "profile",
+		Value:   "reused",
+		URL:     playwright.String(server.EMPTY_PAGE),
+		Expires: playwright.Int(int(time.Now().Add(time.Hour).Unix())),
+	}))
+	require.NoError(t, context.Close())
+
+	// The profile keeps the extension and the cookies.
+	context, err = browserType.LaunchPersistentContext(userDataDir, playwright.BrowserTypeLaunchPersistentContextOptions{
+		Extensions: []string{Asset("simple-extension")},
+	})
+	require.NoError(t, err)
+	defer context.Close()
+	extension, err = context.WaitForExtension()
+	require.NoError(t, err)
+	require.Equal(t, id, extension.ID())
+	cookies, err := context.Cookies(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Len(t, cookies, 1)
+	require.Equal(t, "reused", cookies[0].Value)
+}
+
+func TestBrowserTypeLaunchPersistentContextExtensionsWithoutManifest(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("extensions are only supported in Chromium")
+	}
+	_, err := browserType.LaunchPersistentContext(t.TempDir(), playwright.BrowserTypeLaunchPersistentContextOptions{
+		Extensions: []string{t.TempDir()},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not have a manifest.json")
+}
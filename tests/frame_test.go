@@ -1,6 +1,9 @@
 package playwright_test
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/mxschmitt/playwright-go"
@@ -70,3 +73,88 @@ func TestFrameSetInputFiles(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "file-to-upload.txt", fileName)
 }
+
+func TestFrameExpectNavigationInIframe(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(fmt.Sprintf(`<iframe src="%s"></iframe>`, server.EMPTY_PAGE)))
+	frame := page.Frames()[1]
+	frame.WaitForLoadState()
+	response, err := frame.ExpectNavigation(func() error {
+		_, err := frame.Evaluate("url => window.location.href = url", server.PREFIX+"/grid.html")
+		return err
+	})
+	require.NoError(t, err)
+	require.True(t, response.Ok())
+	require.Equal(t, server.PREFIX+"/grid.html", frame.URL())
+	require.Equal(t, server.EMPTY_PAGE, page.URL())
+}
+
+func TestFrameWaitForURL(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.MainFrame().WaitForURL(regexp.MustCompile(`/empty\.html$`)))
+	_, err = page.Evaluate("url => setTimeout(() => window.location.href = url, 100)", server.PREFIX+"/grid.html")
+	require.NoError(t, err)
+	require.NoError(t, page.MainFrame().WaitForURL(func(url string) bool {
+		return strings.HasSuffix(url, "/grid.html")
+	}, playwright.FrameWaitForURLOptions{
+		WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+	}))
+	require.Equal(t, server.PREFIX+"/grid.html", page.URL())
+}
+
+func TestPageFrameNavigatedEvent(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	events := make(chan *playwright.FrameNavigatedEvent, 1)
+	page.On("framenavigated", func(frame playwright.Frame, event *playwright.FrameNavigatedEvent) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	_, err = page.Goto(server.PREFIX + "/grid.html")
+	require.NoError(t, err)
+	event := <-events
+	require.Equal(t, page.MainFrame(), event.Frame)
+	require.Equal(t, server.EMPTY_PAGE, event.OldURL)
+	require.Equal(t, server.PREFIX+"/grid.html", event.NewURL)
+	require.True(t, event.NewDocument)
+}
+
+func TestPageWaitForFrame(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`url => setTimeout(() => {
+		const frame = document.createElement('iframe');
+		frame.src = url;
+		document.body.appendChild(frame);
+	}, 100)`, server.PREFIX+"/grid.html")
+	require.NoError(t, err)
+	frame, err := page.WaitForFrame("**/grid.html")
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/grid.html", frame.URL())
+	require.Equal(t, page.MainFrame(), frame.ParentFrame())
+
+	frame, err = page.WaitForFrame(func(frame playwright.Frame) bool {
+		return frame.ParentFrame() != nil
+	}, playwright.PageWaitForFrameOptions{
+		WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/grid.html", frame.URL())
+
+	_, err = page.WaitForFrame(regexp.MustCompile(`/missing\.html$`), playwright.PageWaitForFrameOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.EqualError(t, err, "Timeout 100.00ms exceeded while waiting for frame.")
+}
@@ -1,6 +1,7 @@
 package playwright_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/mxschmitt/playwright-go"
@@ -122,6 +123,32 @@ func TestKeyboardInsertText(t *testing.T) {
 	require.True(t, result.(bool))
 }
 
+func TestKeyboardInsertTextBypassesKeyEvents(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input>`))
+	_, err := page.EvalOnSelector("input", `input => {
+		window.keys = [];
+		input.addEventListener('keydown', event => window.keys.push(event.key));
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Focus("input"))
+	require.NoError(t, page.Keyboard().InsertText("嗨"))
+	value, err := page.InputValue("input")
+	require.NoError(t, err)
+	require.Equal(t, "嗨", value)
+	keys, err := page.Evaluate("() => window.keys")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{}, keys)
+
+	require.NoError(t, page.Keyboard().Type("ab", playwright.KeyboardTypeOptions{
+		Delay: playwright.Float(10),
+	}))
+	keys, err = page.Evaluate("() => window.keys")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b"}, keys)
+}
+
 func TestKeyboardType(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -204,3 +231,38 @@ func TestTouchscreenTap(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, result.(bool))
 }
+
+func TestInputRecordingReplay(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<textarea style="position:absolute;left:0;top:0;width:100px;height:50px"></textarea>`))
+	recording, err := playwright.RecordInput(page)
+	require.NoError(t, err)
+	require.NoError(t, page.Mouse().Click(20, 20))
+	require.NoError(t, page.Keyboard().Type("hi"))
+	require.NoError(t, recording.Stop())
+	require.NoError(t, page.Keyboard().Type("ignored"))
+
+	types := []string{}
+	for _, event := range recording.Events() {
+		if event.Type != "mousemove" {
+			types = append(types, event.Type)
+		}
+	}
+	require.Equal(t, []string{"mousedown", "mouseup", "keydown", "keyup", "keydown", "keyup"}, types)
+
+	path := filepath.Join(t.TempDir(), "input.json")
+	require.NoError(t, recording.Save(path))
+	loaded, err := playwright.LoadInputRecording(path)
+	require.NoError(t, err)
+
+	replayPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, replayPage.SetContent(`<textarea style="position:absolute;left:0;top:0;width:100px;height:50px"></textarea>`))
+	require.NoError(t, loaded.Replay(replayPage, playwright.InputRecordingReplayOptions{
+		Speed: playwright.Float(0),
+	}))
+	value, err := replayPage.InputValue("textarea")
+	require.NoError(t, err)
+	require.Equal(t, "hi", value)
+}
@@ -120,3 +120,41 @@ func TestJSHandleTypeParsing(t *testing.T) {
 	_, ok = stringV.(int)
 	require.False(t, ok)
 }
+
+func TestJSHandleTraverseWindowObject(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="app">hello</div>`))
+	windowHandle, err := page.EvaluateHandle(`() => {
+		window.__state = { root: document.querySelector('#app'), nested: { count: 3 } };
+		return window;
+	}`)
+	require.NoError(t, err)
+	require.Nil(t, windowHandle.AsElement())
+	stateHandle, err := windowHandle.GetProperty("__state")
+	require.NoError(t, err)
+	require.Nil(t, stateHandle.AsElement())
+
+	properties, err := stateHandle.GetProperties()
+	require.NoError(t, err)
+	require.Len(t, properties, 2)
+	root := properties["root"].AsElement()
+	require.NotNil(t, root)
+	text, err := root.TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "hello", text)
+	require.Nil(t, properties["nested"].AsElement())
+
+	rootHandle, err := stateHandle.GetProperty("root")
+	require.NoError(t, err)
+	require.NotNil(t, rootHandle.AsElement())
+	countHandle, err := properties["nested"].GetProperty("count")
+	require.NoError(t, err)
+	count, err := countHandle.JSONValue()
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	elementHandle, err := stateHandle.EvaluateHandle(`state => state.root`)
+	require.NoError(t, err)
+	require.NotNil(t, elementHandle.AsElement())
+}
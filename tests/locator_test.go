@@ -0,0 +1,384 @@
+package playwright_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocatorClickAndFill(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input id="name">
+		<button onclick="window.clicked = document.querySelector('#name').value">Submit</button>`))
+	require.NoError(t, page.Locator("#name").Fill("playwright"))
+	require.NoError(t, page.Locator("button").Click())
+	clicked, err := page.Evaluate("() => window.clicked")
+	require.NoError(t, err)
+	require.Equal(t, "playwright", clicked)
+}
+
+func TestLocatorShouldResolveLazily(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	button := page.Locator("button")
+	require.NoError(t, page.SetContent(`<button onclick="window.clicked = 1">first</button>`))
+	require.NoError(t, button.Click())
+	require.NoError(t, page.SetContent(`<button onclick="window.clicked = 2">second</button>`))
+	require.NoError(t, button.Click())
+	clicked, err := page.Evaluate("() => window.clicked")
+	require.NoError(t, err)
+	require.Equal(t, 2, clicked)
+}
+
+func TestLocatorShouldBeStrict(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button>a</button><button>b</button>`))
+	err := page.Locator("button").Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(1000),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "strict mode violation")
+}
+
+func TestLocatorCountNthFirstLast(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<ul><li>one</li><li>two</li><li>three</li></ul>`))
+	items := page.Locator("li")
+	count, err := items.Count()
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	text, err := items.First().TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "one", text)
+	text, err = items.Nth(1).TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "two", text)
+	text, err = items.Last().TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "three", text)
+	texts, err := items.AllTextContents()
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two", "three"}, texts)
+	texts, err = items.AllInnerTexts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two", "three"}, texts)
+	handles, err := items.ElementHandles()
+	require.NoError(t, err)
+	require.Len(t, handles, 3)
+	count, err = page.Locator("li.missing").Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestLocatorChaining(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<div id="a"><span>in a</span></div>
+		<div id="b"><span>in b</span></div>`))
+	text, err := page.Locator("#b").Locator("span").InnerText()
+	require.NoError(t, err)
+	require.Equal(t, "in b", text)
+	require.Equal(t, page, page.Locator("#b").Page())
+}
+
+func TestLocatorEvaluate(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div class="item">1</div><div class="item">2</div><p>text</p>`))
+	result, err := page.Locator("p").Evaluate("(p, suffix) => p.textContent + suffix", "!")
+	require.NoError(t, err)
+	require.Equal(t, "text!", result)
+	result, err = page.Locator(".item").EvaluateAll("items => items.map(item => item.textContent).join(',')")
+	require.NoError(t, err)
+	require.Equal(t, "1,2", result)
+	handle, err := page.Locator("p").EvaluateHandle("p => p.parentElement", nil)
+	require.NoError(t, err)
+	tagName, err := handle.Evaluate("e => e.tagName")
+	require.NoError(t, err)
+	require.Equal(t, "BODY", tagName)
+}
+
+func TestLocatorFormActions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input id="checkbox" type="checkbox">
+		<select id="select"><option value="a">A</option><option value="b">B</option></select>
+		<input id="text">`))
+	require.NoError(t, page.Locator("#checkbox").Check())
+	checked, err := page.Locator("#checkbox").IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	require.NoError(t, page.Locator("#checkbox").Uncheck())
+	checked, err = page.Locator("#checkbox").IsChecked()
+	require.NoError(t, err)
+	require.False(t, checked)
+	require.NoError(t, page.Locator("#checkbox").SetChecked(true))
+	checked, err = page.Locator("#checkbox").IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	require.NoError(t, page.Locator("#checkbox").SetChecked(false))
+	checked, err = page.Locator("#checkbox").IsChecked()
+	require.NoError(t, err)
+	require.False(t, checked)
+	selected, err := page.Locator("#select").SelectOption(playwright.SelectOptionValues{
+		Values: playwright.StringSlice("b"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, selected)
+	require.NoError(t, page.Locator("#text").Type("ab"))
+	require.NoError(t, page.Locator("#text").Press("Backspace"))
+	value, err := page.Locator("#text").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "a", value)
+	require.NoError(t, page.Locator("#text").Focus())
+	focused, err := page.Evaluate("() => document.activeElement.id")
+	require.NoError(t, err)
+	require.Equal(t, "text", focused)
+}
+
+func TestLocatorDispatchEventAndHover(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button onclick="window.clicked = true" onmouseover="window.hovered = true">Click</button>`))
+	require.NoError(t, page.Locator("button").DispatchEvent("click", nil))
+	clicked, err := page.Evaluate("() => window.clicked")
+	require.NoError(t, err)
+	require.Equal(t, true, clicked)
+	require.NoError(t, page.Locator("button").Hover())
+	hovered, err := page.Evaluate("() => window.hovered")
+	require.NoError(t, err)
+	require.Equal(t, true, hovered)
+}
+
+func TestLocatorWaitFor(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div></div>`))
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('div').innerHTML = '<span>ready</span>', 100)`)
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("span").WaitFor())
+	require.NoError(t, page.Locator("p").WaitFor(playwright.LocatorWaitForOptions{
+		State: playwright.WaitForSelectorStateDetached,
+	}))
+	err = page.Locator("p").WaitFor(playwright.LocatorWaitForOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.Error(t, err)
+}
+
+func TestLocatorScreenshot(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	_, err := page.Goto(server.PREFIX + "/grid.html")
+	require.NoError(t, err)
+	require.NoError(t, page.Locator(".box:nth-of-type(3)").ScrollIntoViewIfNeeded())
+	screenshot, err := page.Locator(".box:nth-of-type(3)").Screenshot()
+	require.NoError(t, err)
+	require.NotEmpty(t, screenshot)
+}
+
+func TestLocatorGetByRole(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<h1>Title</h1>
+		<h2 aria-hidden="true">Hidden</h2>
+		<button>Save</button>
+		<div role="button" aria-pressed="true">Bold</div>
+		<input type="checkbox" checked aria-label="Subscribe">
+		<input type="submit" value="Send">
+	`))
+	count, err := page.GetByRole("button").Count()
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	text, err := page.GetByRole("button", playwright.GetByRoleOptions{Name: "save"}).TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Save", text)
+	count, err = page.GetByRole("button", playwright.GetByRoleOptions{Name: "save", Exact: playwright.Bool(true)}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	text, err = page.GetByRole("button", playwright.GetByRoleOptions{Pressed: playwright.Bool(true)}).TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Bold", text)
+	value, err := page.GetByRole("button", playwright.GetByRoleOptions{Name: regexp.MustCompile(`^Se`)}).Last().GetAttribute("value")
+	require.NoError(t, err)
+	require.Equal(t, "Send", value)
+	count, err = page.GetByRole("checkbox", playwright.GetByRoleOptions{Name: "Subscribe", Checked: playwright.Bool(true)}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	count, err = page.GetByRole("heading").Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	count, err = page.GetByRole("heading", playwright.GetByRoleOptions{IncludeHidden: playwright.Bool(true)}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	count, err = page.GetByRole("heading", playwright.GetByRoleOptions{Level: playwright.Int(1)}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestLocatorGetByText(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="outer"><span>Hello  world</span><span>Hello</span></div><span>Hello</span>`))
+	count, err := page.GetByText("hello").Count()
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	count, err = page.GetByText("Hello", playwright.GetByTextOptions{Exact: playwright.Bool(true)}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	count, err = page.Locator("#outer").GetByText(regexp.MustCompile(`world$`)).Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestLocatorGetByLabelAndAttributes(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<label for="email">Email address</label><input id="email">
+		<input aria-label="Password" type="password">
+		<input placeholder="Search the site">
+		<img alt="Company logo" src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">
+		<span title="Tooltip">?</span>
+	`))
+	require.NoError(t, page.GetByLabel("email").Fill("user@example.com"))
+	value, err := page.Locator("#email").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "user@example.com", value)
+	typ, err := page.GetByLabel("Password", playwright.GetByTextOptions{Exact: playwright.Bool(true)}).GetAttribute("type")
+	require.NoError(t, err)
+	require.Equal(t, "password", typ)
+	count, err := page.GetByPlaceholder("search").Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	count, err = page.GetByAltText(regexp.MustCompile(`(?i)LOGO`)).Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	text, err := page.MainFrame().GetByTitle("Tooltip").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "?", text)
+}
+
+func TestLocatorGetByTestId(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<div data-testid="form"><button data-testid="submit">Send</button></div>
+		<button data-testid="submit-later" data-qa="cancel">Cancel</button>
+	`))
+	text, err := page.GetByTestId("submit").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Send", text)
+	count, err := page.GetByTestId(regexp.MustCompile(`^submit`)).Count()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	count, err = page.GetByTestId("form").GetByTestId("submit").Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	pw.Selectors.SetTestIdAttribute("data-qa")
+	defer playwright.SetTestIdAttribute("data-testid")
+	text, err = page.MainFrame().GetByTestId("cancel").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Cancel", text)
+}
+
+func TestLocatorSelectorSuggestions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button data-testid="submit-button">Send</button>`))
+	err := page.GetByTestId("submit").Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "Similar elements")
+
+	context.EnableSelectorSuggestions()
+	err = page.GetByTestId("submit-btn").Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(500),
+	})
+	var timeoutErr *playwright.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Contains(t, err.Error(), "Similar elements on the page:\n  GetByTestId(\"submit-button\")")
+
+	require.NoError(t, page.SetContent(`<button data-testid="submit-button" disabled>Send</button>`))
+	err = page.GetByTestId("submit-button").Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "Similar elements")
+}
+
+func TestLocatorFillTime(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		TimezoneId: playwright.String("America/New_York"),
+	})
+	require.NoError(t, err)
+	defer newContext.Close()
+	newPage, err := newContext.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, newPage.SetContent(`
+		<input id="date" type="date">
+		<input id="time" type="time">
+		<input id="datetime" type="datetime-local">
+		<input id="month" type="month">
+		<input id="text">
+	`))
+	// 2021-01-01 03:30 UTC is still New Year's Eve in New York.
+	value := time.Date(2021, time.January, 1, 3, 30, 0, 0, time.UTC)
+	for selector, expected := range map[string]string{
+		"#date":     "2020-12-31",
+		"#time":     "22:30",
+		"#datetime": "2020-12-31T22:30",
+		"#month":    "2020-12",
+	} {
+		require.NoError(t, newPage.Locator(selector).FillTime(value))
+		actual, err := newPage.InputValue(selector)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	require.NoError(t, newPage.FillTime("#date", value.Add(24*time.Hour)))
+	actual, err := newPage.InputValue("#date")
+	require.NoError(t, err)
+	require.Equal(t, "2021-01-01", actual)
+	require.Error(t, newPage.FillTime("#text", value))
+}
+
+func TestLocatorExtractAll(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<table id="first">
+		<tr><td class="name">foo</td><td class="price">1</td></tr>
+		<tr><td class="name">bar</td><td class="price">2</td></tr>
+	</table>
+	<table id="second">
+		<tr><td class="name">baz</td><td class="price">3</td></tr>
+	</table>`))
+	type row struct {
+		Name  string
+		Price int
+	}
+	rows := []row{}
+	require.NoError(t, page.Locator("#second").Locator("tr").ExtractAll(&rows, playwright.FieldMap{
+		"Name":  {Selector: ".name"},
+		"Price": {Selector: ".price"},
+	}))
+	require.Equal(t, []row{{Name: "baz", Price: 3}}, rows)
+	require.NoError(t, page.Locator("#first tr").ExtractAll(&rows, playwright.FieldMap{
+		"Name": {Selector: ".name"},
+	}))
+	require.Equal(t, []row{{Name: "foo"}, {Name: "bar"}}, rows)
+}
@@ -1,11 +1,14 @@
 package playwright_test
 
 import (
+	goContext "context"
+	"embed"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -41,6 +44,148 @@ func TestPageSetContent(t *testing.T) {
 	require.Equal(t, content, "<html><head></head><body><h1>foo</h1></body></html>")
 }
 
+func TestPageSetContentFromReader(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	html := "<ul>" + strings.Repeat("<li>🎉 item</li>", 200000) + "</ul>"
+	require.NoError(t, page.SetContentFromReader(strings.NewReader(html)))
+	count, err := page.Evaluate("() => document.querySelectorAll('li').length")
+	require.NoError(t, err)
+	require.Equal(t, 200000, count)
+	var content strings.Builder
+	require.NoError(t, page.ContentTo(&content))
+	expected, err := page.Content()
+	require.NoError(t, err)
+	require.Equal(t, expected, content.String())
+	require.Contains(t, content.String(), "<li>🎉 item</li>")
+}
+
+func TestPageSetContentFromReaderShouldRunScripts(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContentFromReader(strings.NewReader(`<!DOCTYPE html><script>window.foo = 42</script><div>bar</div>`), playwright.PageSetContentOptions{
+		WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+	}))
+	result, err := page.Evaluate("() => window.foo")
+	require.NoError(t, err)
+	require.Equal(t, 42, result)
+	var content strings.Builder
+	require.NoError(t, page.MainFrame().ContentTo(&content))
+	require.True(t, strings.HasPrefix(content.String(), "<!DOCTYPE html>"))
+}
+
+func TestPageExtractAll(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<table>
+		<tr><td class="name">foo</td><td class="price">1,200.50</td><td><a href="/foo">link</a></td></tr>
+		<tr><td class="name">bar</td><td class="price">3</td><td></td></tr>
+	</table>`))
+	type row struct {
+		Name  string
+		Price float64
+		Link  *string
+	}
+	rows := []row{}
+	require.NoError(t, page.ExtractAll("tr", &rows, playwright.FieldMap{
+		"Name":  {Selector: ".name"},
+		"Price": {Selector: ".price"},
+		"Link":  {Selector: "a", Attribute: "href"},
+	}))
+	require.Equal(t, []row{
+		{Name: "foo", Price: 1200.5, Link: playwright.String("/foo")},
+		{Name: "bar", Price: 3},
+	}, rows)
+	require.Error(t, page.ExtractAll("tr", &rows, playwright.FieldMap{
+		"Unknown": {Selector: ".name"},
+	}))
+	require.Error(t, page.ExtractAll("tr", rows, playwright.FieldMap{}))
+}
+
+func TestPageExtractText(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<h1>Title</h1>
+		<p>Hello <a href="https://example.com/">world</a>, <strong>welcome</strong>.</p>
+		<ul><li>one<ul><li>nested</li></ul></li><li>two</li></ul>
+		<div style="display: none">hidden</div>
+		<script>var ignored = true;</script>
+		<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>
+		<section id="footer">Foo<br>Bar</section>`))
+	text, err := page.ExtractText()
+	require.NoError(t, err)
+	require.Equal(t, "Title\n\nHello world, welcome.\n\none\nnested\ntwo\n\nA\tB\n1\t2\n\nFoo\nBar", text)
+	markdown, err := page.ExtractText(playwright.PageExtractTextOptions{
+		Format: playwright.TextFormatMarkdown,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "# Title\n\nHello [world](https://example.com/), **welcome**.\n\n- one\n  - nested\n- two\n\n| A | B |\n| --- | --- |\n| 1 | 2 |\n\nFoo\nBar", markdown)
+	text, err = page.ExtractText(playwright.PageExtractTextOptions{
+		Selector: playwright.String("#footer"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Foo\nBar", text)
+}
+
+func TestPageInnerTextAll(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<p>main</p><iframe srcdoc="<p>child</p>"></iframe>`))
+	require.Eventually(t, func() bool {
+		return len(page.Frames()) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	_, err := page.Frames()[1].WaitForSelector("p")
+	require.NoError(t, err)
+	text, err := page.InnerTextAll()
+	require.NoError(t, err)
+	require.Equal(t, "main\n\nchild", text)
+}
+
+func TestPageDOMSnapshot(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="app" class="b a" data-reactid="1">
+		<h1>Title</h1>
+		<script>var ignored = true;</script>
+		<p>Hello   <b>world</b></p>
+		<span class="timestamp">12:00</span>
+	</div>`))
+	options := playwright.PageDOMSnapshotOptions{
+		Selector:         playwright.String("#app"),
+		Styles:           []string{"display"},
+		IgnoreAttributes: []string{"data-reactid"},
+		IgnoreSelectors:  []string{".timestamp"},
+	}
+	before, err := page.DOMSnapshot(options)
+	require.NoError(t, err)
+	require.Equal(t, `<div class="a b" id="app"> {display: block}
+  <h1> {display: block}
+    "Title"
+  <p> {display: block}
+    "Hello"
+    <b> {display: inline}
+      "world"
+`, before.String())
+
+	_, err = page.Evaluate(`() => {
+		document.querySelector('h1').textContent = 'New title';
+		document.querySelector('b').style.display = 'none';
+		document.querySelector('#app').appendChild(document.createElement('footer'));
+		document.querySelector('.timestamp').textContent = '12:01';
+	}`)
+	require.NoError(t, err)
+	after, err := page.DOMSnapshot(options)
+	require.NoError(t, err)
+	require.Equal(t, []playwright.DOMChange{
+		{Type: playwright.DOMChangeText, Path: "div > h1 > #text", Before: "Title", After: "New title"},
+		{Type: playwright.DOMChangeStyle, Path: "div > p > b", Name: "display", Before: "inline", After: "none"},
+		{Type: playwright.DOMChangeAdded, Path: "div > footer", After: "<footer> {display: block}"},
+	}, playwright.DiffDOMSnapshots(before, after))
+
+	_, err = page.DOMSnapshot(playwright.PageDOMSnapshotOptions{Selector: playwright.String("#missing")})
+	require.Error(t, err)
+}
+
 func TestPageScreenshot(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -65,6 +210,81 @@ func TestPageScreenshot(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPageWaitForAssetsLoaded(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/never", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`([visible, pending]) => {
+		const image = document.createElement('img');
+		image.src = visible;
+		document.body.appendChild(image);
+		const below = document.createElement('img');
+		below.src = pending;
+		below.style.marginTop = '5000px';
+		document.body.appendChild(below);
+	}`, []interface{}{server.PREFIX + "/pptr.png", server.PREFIX + "/never"})
+	require.NoError(t, err)
+	require.NoError(t, page.WaitForAssetsLoaded())
+	complete, err := page.Evaluate(`() => document.images[0].complete && document.images[0].naturalWidth > 0`)
+	require.NoError(t, err)
+	require.True(t, complete.(bool))
+
+	err = page.WaitForAssetsLoaded(playwright.PageWaitForAssetsLoadedOptions{
+		AllImages: playwright.Bool(true),
+		Timeout:   playwright.Float(200),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not wait for assets")
+}
+
+func TestPageScreenshotStabilize(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<style>
+			@keyframes grow { from { width: 0 } to { width: 100px } }
+			div { height: 10px; background: red; animation: grow 300ms forwards; }
+		</style>
+		<div></div>
+	`))
+	screenshot, err := page.Screenshot(playwright.PageScreenshotOptions{
+		Stabilize: &playwright.StabilizeOptions{},
+	})
+	require.NoError(t, err)
+	require.True(t, filetype.IsImage(screenshot))
+	width, err := page.Evaluate(`() => document.querySelector('div').getBoundingClientRect().width`)
+	require.NoError(t, err)
+	require.Equal(t, 100, width)
+
+	screenshot, err = page.Locator("div").Screenshot(playwright.LocatorScreenshotOptions{
+		Stabilize: &playwright.StabilizeOptions{Animations: playwright.Bool(false)},
+	})
+	require.NoError(t, err)
+	require.True(t, filetype.IsImage(screenshot))
+}
+
+func TestPageStabilizeTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/never", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	_, err := page.Evaluate(`url => {
+		const image = document.createElement('img');
+		image.src = url;
+		document.body.appendChild(image);
+	}`, server.PREFIX+"/never")
+	require.NoError(t, err)
+	require.NoError(t, page.Stabilize(playwright.StabilizeOptions{Images: playwright.Bool(false)}))
+	err = page.Stabilize(playwright.StabilizeOptions{Timeout: playwright.Float(200)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stabilizing")
+}
+
 func TestPagePDF(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -188,6 +408,22 @@ func TestPageExpectWorker(t *testing.T) {
 	require.Equal(t, 0, len(page.Workers()))
 }
 
+func TestPageExpectWorkerWithPredicate(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.ExpectWorker(func() error {
+		_, err := page.Goto(server.PREFIX + "/worker/worker.html")
+		return err
+	}, playwright.PageExpectWorkerOptions{
+		Predicate: func(worker playwright.Worker) bool {
+			return strings.HasSuffix(worker.URL(), "other.js")
+		},
+		Timeout: playwright.Float(500),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Timeout 500.00ms exceeded while waiting for worker.")
+}
+
 func TestPageExpectRequest(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -214,6 +450,36 @@ func TestPageExpectRequestRegexp(t *testing.T) {
 	require.Equal(t, "GET", request.Method())
 }
 
+func TestPageExpectRequestFinished(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	request, err := page.ExpectRequestFinished(func() error {
+		_, err := page.Goto(server.PREFIX + "/one-style.html")
+		return err
+	}, playwright.PageExpectRequestFinishedOptions{
+		Predicate: func(request playwright.Request) bool {
+			return strings.HasSuffix(request.URL(), ".css")
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/one-style.css", request.URL())
+	require.Equal(t, "stylesheet", request.ResourceType())
+}
+
+func TestPageExpectRequestAndResponse(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	request, response, err := page.ExpectRequestAndResponse("**/empty.html", func() error {
+		_, err := page.Goto(server.EMPTY_PAGE)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, request.URL())
+	require.Equal(t, "GET", request.Method())
+	require.Equal(t, 200, response.Status())
+	require.Equal(t, request, response.Request())
+}
+
 func TestPageExpectRequestFunc(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -255,6 +521,138 @@ func TestPageExpectPopup(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, popup.URL(), server.EMPTY_PAGE)
 }
+
+func TestPageExpectPopupWithoutTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	popup, err := page.ExpectPopup(func() error {
+		_, err := page.Evaluate(`setTimeout(() => window.open(document.location.href), 100)`)
+		return err
+	}, playwright.PageExpectPopupOptions{
+		Timeout: playwright.Float(0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, popup.URL(), server.EMPTY_PAGE)
+}
+
+func TestPageExpectPopupWithURLAndPredicate(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	openPopups := func() error {
+		_, err := page.Evaluate(`() => {
+			window.open('/one-style.html');
+			window.open('/grid.html');
+		}`)
+		return err
+	}
+	popup, err := page.ExpectPopup(openPopups, playwright.PageExpectPopupOptions{
+		URL: "**/grid.html",
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/grid.html", popup.URL())
+	popup, err = page.ExpectPopup(openPopups, playwright.PageExpectPopupOptions{
+		Predicate: func(popup playwright.Page) bool {
+			return strings.HasSuffix(popup.URL(), "/one-style.html")
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/one-style.html", popup.URL())
+}
+
+func TestPageSetInheritToPopups(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.Route("**/mocked.html", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{
+			Body:        "<title>mocked</title>",
+			ContentType: playwright.String("text/html"),
+		}))
+	}))
+	require.NoError(t, page.AddInitScript(playwright.PageAddInitScriptOptions{
+		Script: playwright.String("window.injected = 123"),
+	}))
+	require.NoError(t, page.ExposeFunction("compute", func(args ...interface{}) interface{} {
+		return args[0].(int) * 2
+	}))
+	page.SetInheritToPopups(true)
+	popup, err := page.ExpectPopup(func() error {
+		_, err := page.Evaluate(`() => { window.open('about:blank') }`)
+		return err
+	})
+	require.NoError(t, err)
+	_, err = popup.Goto(server.PREFIX + "/mocked.html")
+	require.NoError(t, err)
+	title, err := popup.Title()
+	require.NoError(t, err)
+	require.Equal(t, "mocked", title)
+	injected, err := popup.Evaluate("() => window.injected")
+	require.NoError(t, err)
+	require.Equal(t, 123, injected)
+	result, err := popup.Evaluate("() => window.compute(21)")
+	require.NoError(t, err)
+	require.Equal(t, 42, result)
+}
+
+func TestPageSetInheritToPopupsShouldCoverLaterNavigations(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	fulfill := func(title string) func(playwright.Route, playwright.Request) {
+		return func(route playwright.Route, request playwright.Request) {
+			require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{
+				Body:        "<title>" + title + "</title>",
+				ContentType: playwright.String("text/html"),
+			}))
+		}
+	}
+	require.NoError(t, context.Route("**/mocked.html", fulfill("context")))
+	require.NoError(t, page.Route("**/mocked.html", fulfill("page")))
+	require.NoError(t, page.AddInitScript(playwright.PageAddInitScriptOptions{
+		Script: playwright.String("window.injected = 123"),
+	}))
+	page.SetInheritToPopups(true)
+	popup, err := page.ExpectPopup(func() error {
+		_, err := page.Evaluate(`() => { window.open('/mocked.html') }`)
+		return err
+	})
+	require.NoError(t, err)
+	popup.WaitForLoadState()
+	title, err := popup.Title()
+	require.NoError(t, err)
+	require.Contains(t, []string{"context", "page"}, title)
+	_, err = popup.Reload()
+	require.NoError(t, err)
+	title, err = popup.Title()
+	require.NoError(t, err)
+	require.Equal(t, "page", title)
+	injected, err := popup.Evaluate("() => window.injected")
+	require.NoError(t, err)
+	require.Equal(t, 123, injected)
+}
+
+func TestPageExpectPopupShouldTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	popup, err := page.ExpectPopup(func() error {
+		_, err := page.Evaluate(`window.open('/one-style.html')`)
+		return err
+	}, playwright.PageExpectPopupOptions{
+		URL:     regexp.MustCompile(`grid\.html$`),
+		Timeout: playwright.Float(500),
+	})
+	require.Nil(t, popup)
+	require.EqualError(t, err, "Timeout 500.00ms exceeded while waiting for popup.")
+}
+
 func TestPageExpectNavigation(t *testing.T) {
 	t.Skip()
 	BeforeEach(t)
@@ -274,9 +672,19 @@ func TestPageExpectFileChooser(t *testing.T) {
 }
 
 func TestPageExpectDialog(t *testing.T) {
-	t.Skip()
 	BeforeEach(t)
 	defer AfterEach(t)
+	dialog, err := page.ExpectDialog(func() error {
+		_, err := page.Evaluate(`() => setTimeout(() => confirm("delete?"), 0)`)
+		return err
+	}, playwright.PageExpectDialogOptions{
+		Predicate: func(dialog playwright.Dialog) bool {
+			return dialog.Type() == "confirm"
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "delete?", dialog.Message())
+	require.NoError(t, dialog.Accept())
 }
 
 func TestPageExpectConsoleMessage(t *testing.T) {
@@ -452,6 +860,61 @@ func TestPageAddStyleTagFile(t *testing.T) {
 	require.Equal(t, "rgb(255, 0, 0)", v)
 }
 
+//go:embed assets/injectedfile.js assets/injectedstyle.css
+var embeddedAssets embed.FS
+
+func TestPageAddScriptTagFS(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	_, err = page.AddScriptTag(playwright.PageAddScriptTagOptions{
+		FS:   embeddedAssets,
+		Path: playwright.String("assets/injectedfile.js"),
+	})
+	require.NoError(t, err)
+	v, err := page.Evaluate("__injected")
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+
+	_, err = page.AddScriptTag(playwright.PageAddScriptTagOptions{
+		FS:   embeddedAssets,
+		Path: playwright.String("assets/missing.js"),
+	})
+	require.Error(t, err)
+}
+
+func TestPageAddStyleTagFS(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	_, err = page.AddStyleTag(playwright.PageAddStyleTagOptions{
+		FS:   embeddedAssets,
+		Path: playwright.String("assets/injectedstyle.css"),
+	})
+	require.NoError(t, err)
+	v, err := page.Evaluate("window.getComputedStyle(document.querySelector('body')).getPropertyValue('background-color')")
+	require.NoError(t, err)
+	require.Equal(t, "rgb(255, 0, 0)", v)
+}
+
+func TestPageAddInitScriptFS(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.AddInitScript(playwright.PageAddInitScriptOptions{
+		FS:   embeddedAssets,
+		Path: playwright.String("assets/injectedfile.js"),
+	}))
+	_, err := page.Goto(server.PREFIX + "/tamperable.html")
+	require.NoError(t, err)
+	result, err := page.Evaluate(`() => window['result']`)
+	require.NoError(t, err)
+	require.Equal(t, 123, result)
+}
+
 func TestPageWaitForLoadState(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -474,6 +937,47 @@ func TestPlaywrightDevices(t *testing.T) {
 	}
 }
 
+func TestPlaywrightRegisterDevice(t *testing.T) {
+	device := pw.Devices["iPad Pro 11"].Clone()
+	device.UserAgent = "OurKioskTablet/1.0"
+	device.Viewport.Width = playwright.Int(600)
+	pw.RegisterDevice("OurKioskTablet", device)
+	require.NotEqual(t, "OurKioskTablet/1.0", pw.Devices["iPad Pro 11"].UserAgent)
+	context, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Device: playwright.String("OurKioskTablet"),
+	})
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	utils.AssertEval(t, page, "navigator.userAgent", "OurKioskTablet/1.0")
+	utils.AssertEval(t, page, "window.innerWidth", 600)
+}
+
+func TestPlaywrightOverrideDevice(t *testing.T) {
+	pw.RegisterDevice("OverriddenDevice", pw.Devices["Pixel 5"].Clone())
+	require.NoError(t, pw.OverrideDevice("OverriddenDevice", func(device *playwright.DeviceDescriptor) {
+		device.UserAgent = "Overridden"
+	}))
+	require.Error(t, pw.OverrideDevice("does-not-exist", func(device *playwright.DeviceDescriptor) {}))
+	context, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Device:    playwright.String("OverriddenDevice"),
+		UserAgent: playwright.String("Explicit"),
+	})
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	utils.AssertEval(t, page, "navigator.userAgent", "Explicit")
+}
+
+func TestBrowserNewContextWithUnknownDevice(t *testing.T) {
+	_, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Device: playwright.String("does-not-exist"),
+	})
+	require.EqualError(t, err, "unknown device: does-not-exist")
+}
+
 func TestPageAddInitScript(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -550,6 +1054,44 @@ func TestPageUncheck(t *testing.T) {
 	require.Equal(t, false, value)
 }
 
+func TestPageSetChecked(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input id='checkbox' type='checkbox' onclick='window.clicks = (window.clicks || 0) + 1'></input>`))
+
+	for _, checked := range []bool{true, true, false, false} {
+		require.NoError(t, page.SetChecked("input", checked))
+		value, err := page.IsChecked("input")
+		require.NoError(t, err)
+		require.Equal(t, checked, value)
+	}
+	clicks, err := page.Evaluate("window.clicks")
+	require.NoError(t, err)
+	require.Equal(t, 2, clicks)
+
+	require.NoError(t, page.SetContent(`<div>no checkbox</div>`))
+	require.Error(t, page.SetChecked("div", true, playwright.FrameSetCheckedOptions{
+		Timeout: playwright.Float(1000),
+	}))
+}
+
+func TestPageInvalidOptions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	waitUntil := playwright.WaitUntilState("networkIdle")
+	_, err := page.Goto(server.EMPTY_PAGE, playwright.PageGotoOptions{
+		WaitUntil: &waitUntil,
+	})
+	var optionsErr *playwright.OptionsError
+	require.ErrorAs(t, err, &optionsErr)
+	require.Equal(t, "WaitUntil", optionsErr.Field)
+	_, err = page.Screenshot(playwright.PageScreenshotOptions{
+		FullPage: playwright.Bool(true),
+		Clip:     &playwright.PageScreenshotOptionsClip{Width: playwright.Float(10), Height: playwright.Float(10)},
+	})
+	require.ErrorAs(t, err, &optionsErr)
+}
+
 func TestPageWaitForTimeout(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -570,6 +1112,70 @@ func TestPageWaitForFunction(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPageWaitForFunctionReturnsHandle(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	handle, err := page.WaitForFunction(`() => window.innerWidth`, nil)
+	require.NoError(t, err)
+	value, err := handle.JSONValue()
+	require.NoError(t, err)
+	require.NotZero(t, value)
+}
+
+func TestPageWaitForFunctionTerminatesBlockingPredicate(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("terminating scripts is only supported by Chromium")
+	}
+	_, err := page.WaitForFunction(`() => { while (true) {} }`, nil, playwright.FrameWaitForFunctionOptions{
+		Timeout: playwright.Float(500),
+	})
+	var timeoutErr *playwright.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	result, err := page.Evaluate(`1 + 2`)
+	require.NoError(t, err)
+	require.Equal(t, 3, result)
+}
+
+func TestPageEvaluateTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Evaluate(`() => new Promise(() => {})`, nil, playwright.EvaluateOptions{
+		Timeout: playwright.Float(200),
+	})
+	var timeoutErr *playwright.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Contains(t, err.Error(), "Timeout 200ms exceeded while evaluating.")
+
+	result, err := page.Evaluate(`a => a * 2`, 21, playwright.EvaluateOptions{
+		Timeout: playwright.Float(1000),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, result)
+	result, err = page.Evaluate(`1 + 2`, nil, playwright.EvaluateOptions{
+		Timeout: playwright.Float(1000),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, result)
+}
+
+func TestPageEvaluateTimeoutTerminatesBlockingScript(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("terminating scripts is only supported by Chromium")
+	}
+	_, err := page.Evaluate(`() => { while (true) {} }`, nil, playwright.EvaluateOptions{
+		Timeout: playwright.Float(200),
+	})
+	var timeoutErr *playwright.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	result, err := page.Evaluate(`1 + 2`)
+	require.NoError(t, err)
+	require.Equal(t, 3, result)
+}
+
 func TestPageDblclick(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -661,6 +1267,111 @@ func TestPageSetViewport(t *testing.T) {
 	utils.VerifyViewport(t, page, 123, 456)
 }
 
+func TestPageSetViewportWithDeviceScaleFactorAndOrientation(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("device scale factor and orientation are only supported in Chromium")
+	}
+	_, err := page.Evaluate(`() => {
+		window.events = [];
+		window.addEventListener('resize', () => window.events.push('resize'));
+		window.addEventListener('orientationchange', () => window.events.push('orientationchange'));
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.SetViewportSize(800, 400, playwright.PageSetViewportSizeOptions{
+		DeviceScaleFactor: playwright.Float(2),
+		Orientation:       playwright.String("landscape"),
+	}))
+	utils.VerifyViewport(t, page, 800, 400)
+	utils.AssertEval(t, page, "window.devicePixelRatio", 2)
+	utils.AssertEval(t, page, "screen.orientation.type", "landscape-primary")
+	utils.AssertEval(t, page, "matchMedia('(orientation: landscape)').matches", true)
+	require.NoError(t, page.SetViewportSize(400, 800, playwright.PageSetViewportSizeOptions{
+		Orientation: playwright.String("portrait"),
+	}))
+	utils.VerifyViewport(t, page, 400, 800)
+	utils.AssertEval(t, page, "window.devicePixelRatio", 2)
+	utils.AssertEval(t, page, "screen.orientation.type", "portrait-primary")
+	events, err := page.Evaluate("() => window.events")
+	require.NoError(t, err)
+	require.Contains(t, events, "resize")
+	require.Contains(t, events, "orientationchange")
+	require.NoError(t, page.SetViewportSize(500, 600))
+	utils.VerifyViewport(t, page, 500, 600)
+	utils.AssertEval(t, page, "window.devicePixelRatio", 2)
+	utils.AssertEval(t, page, "screen.orientation.type", "portrait-primary")
+}
+
+func TestPageSetViewportShouldValidateOrientation(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	err := page.SetViewportSize(800, 400, playwright.PageSetViewportSizeOptions{
+		Orientation: playwright.String("diagonal"),
+	})
+	require.EqualError(t, err, "orientation: expected one of (portrait|landscape), got diagonal")
+}
+
+func TestPageSetWindowBounds(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("window bounds are only supported in Chromium")
+	}
+	require.NoError(t, page.SetWindowBounds(playwright.WindowBounds{
+		Left:   playwright.Int(10),
+		Top:    playwright.Int(20),
+		Width:  playwright.Int(800),
+		Height: playwright.Int(600),
+	}))
+	bounds, err := page.WindowBounds()
+	require.NoError(t, err)
+	require.Equal(t, 800, *bounds.Width)
+	require.Equal(t, 600, *bounds.Height)
+	require.Equal(t, "normal", *bounds.WindowState)
+	err = page.SetWindowBounds(playwright.WindowBounds{
+		Width:       playwright.Int(800),
+		WindowState: playwright.String("maximized"),
+	})
+	require.EqualError(t, err, "window state 'maximized' can not be combined with left, top, width or height")
+}
+
+func TestPageSetUserAgent(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetUserAgent("foobar"))
+	utils.AssertEval(t, page, "navigator.userAgent", "foobar")
+	request, err := page.ExpectRequest(server.EMPTY_PAGE, func() error {
+		_, err := page.Reload()
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foobar", request.Headers()["user-agent"])
+	utils.AssertEval(t, page, "navigator.userAgent", "foobar")
+}
+
+func TestPageSetUserAgentWithMetadata(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("user agent metadata is only supported in Chromium")
+	}
+	require.NoError(t, page.SetUserAgent("foobar", playwright.UserAgentMetadata{
+		Brands: []playwright.UserAgentBrandVersion{
+			{Brand: "Foo", Version: "42"},
+		},
+		Platform: "Foo OS",
+		Mobile:   true,
+	}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	utils.AssertEval(t, page, "navigator.userAgent", "foobar")
+	utils.AssertEval(t, page, "navigator.userAgentData.platform", "Foo OS")
+	utils.AssertEval(t, page, "navigator.userAgentData.mobile", true)
+}
+
 func TestPageEmulateMedia(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -681,6 +1392,24 @@ func TestPageEmulateMedia(t *testing.T) {
 	utils.AssertEval(t, page, "matchMedia('print').matches", false)
 }
 
+func TestPageEmulateMediaFeatures(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		require.Error(t, page.EmulateMediaFeatures([]playwright.MediaFeature{{Name: "forced-colors", Value: "active"}}))
+		return
+	}
+	utils.AssertEval(t, page, "matchMedia('(forced-colors: active)').matches", false)
+	require.NoError(t, page.EmulateMediaFeatures([]playwright.MediaFeature{
+		{Name: "forced-colors", Value: "active"},
+		{Name: "prefers-contrast", Value: "more"},
+	}))
+	utils.AssertEval(t, page, "matchMedia('(forced-colors: active)').matches", true)
+	utils.AssertEval(t, page, "matchMedia('(prefers-contrast: more)').matches", true)
+	require.NoError(t, page.EmulateMediaFeatures(nil))
+	utils.AssertEval(t, page, "matchMedia('(forced-colors: active)').matches", false)
+}
+
 func TestPageBringToFront(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -856,3 +1585,371 @@ func TestPageInputValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "", value)
 }
+
+func TestPageClipboard(t *testing.T) {
+	if !isChromium {
+		t.Skip("clipboard permissions are only supported in Chromium")
+	}
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.Clipboard().WriteText("copied text"))
+	text, err := page.Clipboard().ReadText()
+	require.NoError(t, err)
+	require.Equal(t, "copied text", text)
+}
+
+func TestPageSnapshotAndRestore(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => {
+		document.cookie = 'step=3';
+		localStorage.setItem('cart', 'apples');
+		sessionStorage.setItem('wizard', 'payment');
+	}`)
+	require.NoError(t, err)
+	snapshot, err := page.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, snapshot.URL)
+	require.Equal(t, map[string]string{"cart": "apples"}, snapshot.LocalStorage)
+	require.Equal(t, map[string]string{"wizard": "payment"}, snapshot.SessionStorage)
+	require.Len(t, snapshot.Cookies, 1)
+
+	otherContext, err := browser.NewContext()
+	require.NoError(t, err)
+	defer otherContext.Close()
+	otherPage, err := otherContext.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, otherPage.AddInitScript(playwright.PageAddInitScriptOptions{
+		Script: playwright.String("window.cartOnLoad = localStorage.getItem('cart')"),
+	}))
+	require.NoError(t, otherPage.RestoreSnapshot(snapshot))
+	require.Equal(t, server.EMPTY_PAGE, otherPage.URL())
+	state, err := otherPage.Evaluate(`() => [document.cookie, window.cartOnLoad, sessionStorage.getItem('wizard')]`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"step=3", "apples", "payment"}, state)
+}
+
+func TestPageNetworkStats(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	_, err = page.Evaluate(`url => fetch(url, { method: 'POST', body: 'hello' })`, server.EMPTY_PAGE)
+	require.NoError(t, err)
+	stats := page.NetworkStats()
+	require.Equal(t, 3, stats.Requests)
+	require.Equal(t, 1, stats.RequestsByType["document"])
+	require.Equal(t, 1, stats.RequestsByType["stylesheet"])
+	require.Equal(t, 1, stats.RequestsByType["fetch"])
+	require.Equal(t, 3, stats.Responses)
+	require.Equal(t, 0, stats.FailedRequests)
+	require.Equal(t, int64(5), stats.BytesSent)
+	require.Greater(t, stats.BytesReceived, int64(0))
+
+	otherPage, err := context.NewPage()
+	require.NoError(t, err)
+	_, err = otherPage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, 1, otherPage.NetworkStats().Requests)
+	require.Equal(t, 3, page.NetworkStats().Requests)
+	require.Equal(t, 4, context.NetworkStats().Requests)
+}
+
+func TestPageClearBrowserCache(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("clearing the browser cache is only supported in Chromium")
+	}
+	hits := 0
+	server.SetRoute("/cached.css", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body { color: red; }"))
+	})
+	server.SetRoute("/cached.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<link rel="stylesheet" href="/cached.css">`))
+	})
+	loadStylesheet := func() playwright.Response {
+		response, err := page.ExpectResponse("**/cached.css", func() error {
+			_, err := page.Goto(server.PREFIX + "/cached.html")
+			return err
+		})
+		require.NoError(t, err)
+		return response
+	}
+	fromCache, err := loadStylesheet().FromCache()
+	require.NoError(t, err)
+	require.False(t, fromCache)
+	fromCache, err = loadStylesheet().FromCache()
+	require.NoError(t, err)
+	require.True(t, fromCache)
+	require.Equal(t, 1, hits)
+
+	require.NoError(t, page.ClearBrowserCache())
+	fromCache, err = loadStylesheet().FromCache()
+	require.NoError(t, err)
+	require.False(t, fromCache)
+	require.Equal(t, 2, hits)
+}
+
+func TestPageLocalAndSessionStorage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	localStorage := page.LocalStorage()
+	require.NoError(t, localStorage.SetItem("token", "abc"))
+	require.NoError(t, localStorage.SetItem("theme", "dark"))
+	value, ok, err := localStorage.GetItem("token")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "abc", value)
+	_, ok, err = localStorage.GetItem("missing")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoError(t, localStorage.RemoveItem("theme"))
+	items, err := localStorage.Items()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"token": "abc"}, items)
+	stored, err := page.Evaluate(`() => localStorage.getItem('token')`)
+	require.NoError(t, err)
+	require.Equal(t, "abc", stored)
+
+	sessionStorage := page.SessionStorage(server.PREFIX)
+	require.NoError(t, sessionStorage.SetItem("step", "2"))
+	items, err = sessionStorage.Items()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"step": "2"}, items)
+	require.NoError(t, sessionStorage.Clear())
+	items, err = sessionStorage.Items()
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	_, err = page.LocalStorage("https://example.com").Items()
+	require.Error(t, err)
+}
+
+func TestPageDumpIndexedDB(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => new Promise((resolve, reject) => {
+		const request = indexedDB.open('shop', 2);
+		request.onupgradeneeded = () => {
+			const store = request.result.createObjectStore('products', { keyPath: 'id' });
+			store.createIndex('byName', 'name', { unique: true });
+			store.put({ id: 1, name: 'apple' });
+			store.put({ id: 2, name: 'pear' });
+		};
+		request.onsuccess = () => {
+			request.result.close();
+			resolve();
+		};
+		request.onerror = () => reject(request.error);
+	})`)
+	require.NoError(t, err)
+	databases, err := page.DumpIndexedDB(playwright.PageDumpIndexedDBOptions{
+		Databases: []string{"shop", "missing"},
+	})
+	require.NoError(t, err)
+	require.Len(t, databases, 1)
+	require.Equal(t, "shop", databases[0].Name)
+	require.Equal(t, 2, databases[0].Version)
+	require.Len(t, databases[0].Stores, 1)
+	store := databases[0].Stores[0]
+	require.Equal(t, "products", store.Name)
+	require.Equal(t, "id", store.KeyPath)
+	require.False(t, store.AutoIncrement)
+	require.Equal(t, []playwright.IndexedDBIndex{{Name: "byName", KeyPath: "name", Unique: true}}, store.Indexes)
+	require.Len(t, store.Records, 2)
+	require.Equal(t, float64(1), store.Records[0].Key)
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "apple"}, store.Records[0].Value)
+
+	missing, err := page.Evaluate(`() => indexedDB.databases ? indexedDB.databases().then(dbs => dbs.some(db => db.name === 'missing')) : false`)
+	require.NoError(t, err)
+	require.Equal(t, false, missing)
+}
+
+func TestPageAddInitScriptForOrigins(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.AddInitScript(playwright.PageAddInitScriptOptions{
+		Script:  playwright.String("window.patched = 'origin'"),
+		Origins: []string{server.PREFIX},
+	}))
+	require.NoError(t, page.AddInitScript(playwright.PageAddInitScriptOptions{
+		Script:        playwright.String("window.top_only = true"),
+		MainFrameOnly: playwright.Bool(true),
+	}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = utils.AttachFrame(page, "same-origin", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = utils.AttachFrame(page, "cross-origin", server.CROSS_PROCESS_PREFIX+"/empty.html")
+	require.NoError(t, err)
+	frames := page.Frames()
+	require.Len(t, frames, 3)
+
+	results, err := page.EvaluateInOrigin(server.PREFIX, "() => [window.patched, !!window.top_only]")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{"origin", true},
+		[]interface{}{"origin", false},
+	}, results)
+	results, err = page.EvaluateInOrigin(server.CROSS_PROCESS_PREFIX+"/", "() => [typeof window.patched, !!window.top_only]")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{"undefined", false},
+	}, results)
+	results, err = page.EvaluateInOrigin("https://example.com", "() => 1")
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestPageEvaluateModule(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if _, err := exec.LookPath("esbuild"); err != nil && os.Getenv("PLAYWRIGHT_ESBUILD_PATH") == "" {
+		t.Skip("esbuild is not installed")
+	}
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	result, err := page.EvaluateModule(playwright.PageEvaluateModuleOptions{
+		Path: playwright.String(Asset("modules/main.ts")),
+		Arg:  map[string]interface{}{"a": 2, "b": 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, result)
+	result, err = page.EvaluateModule(playwright.PageEvaluateModuleOptions{
+		Path:   playwright.String(Asset("modules/main.ts")),
+		Export: playwright.String("name"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "main", result)
+	result, err = page.EvaluateModule(playwright.PageEvaluateModuleOptions{
+		Content: playwright.String("export default (value: string): string => value.toUpperCase()"),
+		Arg:     "hello",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", result)
+	_, err = page.EvaluateModule(playwright.PageEvaluateModuleOptions{
+		Path:   playwright.String(Asset("modules/main.ts")),
+		Export: playwright.String("missing"),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "module has no export named missing")
+}
+
+func TestPageAddScriptTagTypeScript(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if _, err := exec.LookPath("esbuild"); err != nil && os.Getenv("PLAYWRIGHT_ESBUILD_PATH") == "" {
+		t.Skip("esbuild is not installed")
+	}
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.AddScriptTag(playwright.PageAddScriptTagOptions{
+		Path: playwright.String(Asset("modules/main.ts")),
+	})
+	require.NoError(t, err)
+	result, err := page.Evaluate(`() => window.__sum({ a: 1, b: 2 })`)
+	require.NoError(t, err)
+	require.Equal(t, 3, result)
+}
+
+func TestPageConsoleMessagesSubscription(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	messages := page.ConsoleMessages()
+	defer messages.Close()
+	errs := page.PageErrors()
+	_, err := page.Evaluate(`() => {
+		console.log("first");
+		console.log("second");
+		setTimeout(() => { throw new Error("boom") }, 0);
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, "first", (<-messages.C).Text())
+	require.Equal(t, "second", (<-messages.C).Text())
+	select {
+	case err := <-errs.C:
+		require.Contains(t, err.Error(), "boom")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no page error received")
+	}
+	errs.Close()
+	_, ok := <-errs.C
+	require.False(t, ok)
+	require.Equal(t, 0, messages.Dropped())
+}
+
+func TestPageRequestsSubscriptionClosesWithPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	requests := newPage.Requests()
+	responses := newPage.Responses()
+	_, err = newPage.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, (<-requests.C).URL())
+	require.Equal(t, server.EMPTY_PAGE, (<-responses.C).URL())
+	require.NoError(t, newPage.Close())
+	for range requests.C {
+	}
+	for range responses.C {
+	}
+}
+
+func TestPageNavigationRetries(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context.SetNavigationRetries(5)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		// Reload the page while the action gets performed.
+		_, err := page.Evaluate(`() => { setTimeout(() => location.reload(), 5) }`)
+		require.NoError(t, err)
+		require.NoError(t, page.Hover("body"))
+	}
+}
+
+func TestPageGotoContext(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/never", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := goContext.WithTimeout(goContext.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := page.Goto(server.PREFIX+"/never", playwright.PageGotoOptions{
+		Context: ctx,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, goContext.DeadlineExceeded) || strings.Contains(err.Error(), "Timeout"))
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	canceled, cancel := goContext.WithCancel(goContext.Background())
+	cancel()
+	err = page.Click("body", playwright.PageClickOptions{
+		Context: canceled,
+	})
+	require.True(t, errors.Is(err, goContext.Canceled))
+
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.WaitForFunction("() => false", nil, playwright.FrameWaitForFunctionOptions{
+		Context: ctx,
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package playwright_test
+
+import (
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactValueMasksErrorMessages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	playwright.RedactValue("s3cret")
+	defer playwright.ResetRedactions()
+	_, err := page.Goto("http://localhost:1/?token=s3cret")
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "s3cret")
+	require.Contains(t, err.Error(), "token=[REDACTED]")
+}
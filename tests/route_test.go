@@ -1,10 +1,16 @@
 package playwright_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mxschmitt/playwright-go"
 	"github.com/stretchr/testify/require"
@@ -150,6 +156,83 @@ func TestRouteFulfillByteSlice(t *testing.T) {
 	require.Equal(t, "text/plain", response.Headers()["content-type"])
 }
 
+func TestRouteFulfillWithTransform(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/script.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Header().Set("X-Original", "yes")
+		_, err := w.Write([]byte(strings.Repeat("var a = 1;\n", 100000)))
+		require.NoError(t, err)
+	})
+	intercepted := make(chan bool, 1)
+	err := page.Route("**/script.js", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.FulfillWithTransform(func(body io.Reader) io.Reader {
+			return io.MultiReader(strings.NewReader("window.instrumented = true;\n"), body)
+		}, func(body io.Reader) io.Reader {
+			pr, pw := io.Pipe()
+			go func() {
+				buf := make([]byte, 4096)
+				for {
+					n, err := body.Read(buf)
+					if n > 0 {
+						if _, werr := pw.Write(bytes.ReplaceAll(buf[:n], []byte("1"), []byte("2"))); werr != nil {
+							return
+						}
+					}
+					if err != nil {
+						if err == io.EOF {
+							err = nil
+						}
+						pw.CloseWithError(err)
+						return
+					}
+				}
+			}()
+			return pr
+		}))
+		intercepted <- true
+	})
+	require.NoError(t, err)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	response, err := page.Goto(server.PREFIX + "/script.js")
+	require.NoError(t, err)
+	<-intercepted
+	require.Equal(t, 200, response.Status())
+	require.Equal(t, "yes", response.Headers()["x-original"])
+	text, err := response.Text()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(text, "window.instrumented = true;\nvar a = 2;"))
+	require.NotContains(t, text, "1")
+}
+
+func TestRouteFulfillWithTransformSendsContextCookies(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	cookies := make(chan string, 1)
+	server.SetRoute("/script.js", func(w http.ResponseWriter, r *http.Request) {
+		cookies <- r.Header.Get("Cookie")
+		w.Header().Set("Content-Type", "application/javascript")
+		_, err := w.Write([]byte("var a = 1;"))
+		require.NoError(t, err)
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, context.AddCookies(playwright.SetNetworkCookieParam{
+		Name:  "session",
+		Value: "secret",
+		URL:   playwright.String(server.EMPTY_PAGE),
+	}))
+	err = page.Route("**/script.js", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.FulfillWithTransform())
+	})
+	require.NoError(t, err)
+	_, err = page.Goto(server.PREFIX + "/script.js")
+	require.NoError(t, err)
+	require.Equal(t, "session=secret", <-cookies)
+}
+
 func TestRouteFulfillPath(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -258,3 +341,239 @@ func TestRequestPostData(t *testing.T) {
 	})`, server.PREFIX+"/foobar")
 	require.NoError(t, err)
 }
+
+func TestRouteContinueWithRedirects(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRedirect("/short", "/hop")
+	server.SetRedirect("/hop", "/grid.html")
+	hops := make(chan playwright.RedirectHop, 2)
+	err := page.Route("**/*", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.ContinueWithRedirects(func(hop *playwright.RedirectHop) {
+			hops <- *hop
+			if strings.HasSuffix(hop.URL, "/hop") {
+				hop.Location = server.EMPTY_PAGE
+			}
+		}))
+	})
+	require.NoError(t, err)
+	response, err := page.Goto(server.PREFIX + "/short")
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, response.URL())
+	require.Equal(t, server.EMPTY_PAGE, page.URL())
+	first := <-hops
+	require.Equal(t, 302, first.Status)
+	require.Equal(t, server.PREFIX+"/short", first.URL)
+	require.Equal(t, "/hop", first.Location)
+	second := <-hops
+	require.Equal(t, server.PREFIX+"/hop", second.URL)
+	require.Equal(t, server.EMPTY_PAGE, second.Location)
+}
+
+type recordingTransport struct {
+	urls chan string
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.urls <- req.URL.String()
+	req.Header.Set("x-forwarded-by", "test")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRouteForward(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	transport := &recordingTransport{urls: make(chan string, 10)}
+	require.NoError(t, page.Route("**/empty.html", playwright.ForwardTo(transport)))
+	request := server.WaitForRequestChan("/empty.html")
+	response, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.Status())
+	require.Equal(t, server.EMPTY_PAGE, <-transport.urls)
+	require.Equal(t, "test", (<-request).Header.Get("x-forwarded-by"))
+
+	failing := playwright.ForwardTo(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, io.ErrUnexpectedEOF
+	}))
+	require.NoError(t, page.Route("**/grid.html", failing))
+	_, err = page.Goto(server.PREFIX + "/grid.html")
+	require.Error(t, err)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPageRouteFromHAR(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	harPath := filepath.Join(t.TempDir(), "network.har")
+
+	recordPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, recordPage.RouteFromHAR(harPath, playwright.PageRouteFromHAROptions{
+		Update: playwright.Bool(true),
+		URL:    "**/one-style.*",
+	}))
+	_, err = recordPage.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	require.NoError(t, recordPage.Close())
+	require.FileExists(t, harPath)
+
+	require.NoError(t, page.RouteFromHAR(harPath))
+	response, err := page.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	require.Equal(t, 200, response.Status())
+	color, err := page.Evaluate(`() => getComputedStyle(document.body).backgroundColor`)
+	require.NoError(t, err)
+	require.Equal(t, "rgb(255, 192, 203)", color)
+	_, err = page.Goto(server.PREFIX + "/empty.html")
+	require.Error(t, err)
+}
+
+func TestBrowserContextRouteFromHARFallback(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	harPath := filepath.Join(t.TempDir(), "network.zip")
+	recordContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		RecordHarPath: playwright.String(harPath),
+	})
+	require.NoError(t, err)
+	recordPage, err := recordContext.NewPage()
+	require.NoError(t, err)
+	server.SetRoute("/recorded.html", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "recorded")
+	})
+	_, err = recordPage.Goto(server.PREFIX + "/recorded.html")
+	require.NoError(t, err)
+	require.NoError(t, recordContext.Close())
+
+	server.SetRoute("/recorded.html", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "live")
+	})
+	require.NoError(t, context.RouteFromHAR(harPath, playwright.BrowserContextRouteFromHAROptions{
+		NotFound: playwright.HarNotFoundFallback,
+	}))
+	_, err = page.Goto(server.PREFIX + "/recorded.html")
+	require.NoError(t, err)
+	content, err := page.TextContent("body")
+	require.NoError(t, err)
+	require.Equal(t, "recorded", content)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+}
+
+func TestRouteFetch(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.Route("**/empty.html", func(route playwright.Route, request playwright.Request) {
+		response, err := route.Fetch()
+		require.NoError(t, err)
+		body, err := response.Text()
+		require.NoError(t, err)
+		require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{
+			Response: response,
+			Body:     body + "<div>patched</div>",
+		}))
+	}))
+	response, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.Status())
+	content, err := page.TextContent("div")
+	require.NoError(t, err)
+	require.Equal(t, "patched", content)
+}
+
+func TestRouteFulfillJSON(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	type item struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+	require.NoError(t, page.Route("**/api/items", func(route playwright.Route, request playwright.Request) {
+		require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{
+			Status: playwright.Int(201),
+			JSON:   []item{{Name: "book", Price: 12}},
+		}))
+	}))
+	response, err := page.Goto(server.PREFIX + "/api/items")
+	require.NoError(t, err)
+	require.Equal(t, 201, response.Status())
+	require.Equal(t, "application/json", response.Headers()["content-type"])
+	var items []item
+	require.NoError(t, response.JSON(&items))
+	require.Equal(t, []item{{Name: "book", Price: 12}}, items)
+}
+
+func TestPageRouteTimes(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	intercepted := 0
+	require.NoError(t, page.Route("**/empty.html", func(route playwright.Route, request playwright.Request) {
+		intercepted++
+		require.NoError(t, route.Fulfill(playwright.RouteFulfillOptions{Body: "mocked"}))
+	}, playwright.PageRouteOptions{Times: playwright.Int(1)}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	content, err := page.TextContent("body")
+	require.NoError(t, err)
+	require.Equal(t, "mocked", content)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	content, err = page.TextContent("body")
+	require.NoError(t, err)
+	require.Equal(t, "", content)
+	require.Equal(t, 1, intercepted)
+}
+
+func TestBrowserContextUnrouteAllWait(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	started := make(chan bool)
+	finished := make(chan bool, 1)
+	require.NoError(t, context.Route("**/empty.html", func(route playwright.Route, request playwright.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, route.Continue())
+		finished <- true
+	}))
+	go func() {
+		_, _ = page.Goto(server.EMPTY_PAGE)
+	}()
+	<-started
+	require.NoError(t, context.UnrouteAll(playwright.BrowserContextUnrouteAllOptions{
+		Behavior: playwright.UnrouteBehaviorWait,
+	}))
+	select {
+	case <-finished:
+	default:
+		t.Fatal("UnrouteAll returned before the handler finished")
+	}
+}
+
+func TestRequestReplay(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	calls := 0
+	server.SetRoute("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Method", r.Method)
+		fmt.Fprintf(w, "call %d", calls)
+	})
+	response, err := page.Goto(server.PREFIX + "/flaky")
+	require.NoError(t, err)
+	replayed, err := response.Request().Replay()
+	require.NoError(t, err)
+	body, err := replayed.Text()
+	require.NoError(t, err)
+	require.Equal(t, "call 2", body)
+
+	replayed, err = response.Request().Replay(playwright.RequestReplayOptions{
+		Method: playwright.String("HEAD"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "HEAD", replayed.Headers()["x-method"])
+}
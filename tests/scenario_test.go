@@ -0,0 +1,54 @@
+package playwright_test
+
+import (
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioActorsUseSeparateContexts(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	scenario := playwright.NewScenario(browser)
+	var sellerCookie interface{}
+	scenario.AddActor("buyer", func(actor *playwright.ScenarioActor) error {
+		if _, err := actor.Page.Goto(server.EMPTY_PAGE); err != nil {
+			return err
+		}
+		if _, err := actor.Page.Evaluate(`() => document.cookie = "cart=1"`); err != nil {
+			return err
+		}
+		actor.Signal("ordered")
+		return actor.Step("done")
+	})
+	scenario.AddActor("seller", func(actor *playwright.ScenarioActor) error {
+		if err := actor.WaitFor("ordered"); err != nil {
+			return err
+		}
+		if _, err := actor.Page.Goto(server.EMPTY_PAGE); err != nil {
+			return err
+		}
+		cookie, err := actor.Page.Evaluate(`() => document.cookie`)
+		if err != nil {
+			return err
+		}
+		sellerCookie = cookie
+		return actor.Step("done")
+	})
+	require.NoError(t, scenario.Run())
+	require.Equal(t, "", sellerCookie)
+}
+
+func TestScenarioDeadlock(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	scenario := playwright.NewScenario(browser)
+	scenario.AddActor("buyer", func(actor *playwright.ScenarioActor) error {
+		return actor.WaitFor("shipped")
+	})
+	scenario.AddActor("seller", func(actor *playwright.ScenarioActor) error {
+		return actor.WaitFor("paid")
+	})
+	require.EqualError(t, scenario.Run(), `scenario deadlocked: buyer waits for "shipped", seller waits for "paid"`)
+}
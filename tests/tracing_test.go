@@ -1,7 +1,11 @@
 package playwright_test
 
 import (
+	"archive/zip"
+	goContext "context"
+	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mxschmitt/playwright-go"
@@ -31,3 +35,212 @@ func TestBrowserContextOutputTrace(t *testing.T) {
 	require.NoError(t, err)
 	require.FileExists(t, filepath.Join(dir, "trace.zip"))
 }
+
+func TestBrowserContextTraceWithSourcesAndTitle(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	err = context.Tracing().Start(playwright.TracingStartOptions{
+		Screenshots: playwright.Bool(true),
+		Snapshots:   playwright.Bool(true),
+		Sources:     playwright.Bool(true),
+		Title:       playwright.String("my trace"),
+	})
+	require.NoError(t, err)
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	defer page.Close()
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	tracePath := filepath.Join(t.TempDir(), "nested", "trace.zip")
+	err = context.Tracing().Stop(playwright.TracingStopOptions{
+		Path: playwright.String(tracePath),
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.OpenReader(tracePath)
+	require.NoError(t, err)
+	defer reader.Close()
+	var trace string
+	var sources []string
+	for _, entry := range reader.File {
+		if strings.HasPrefix(entry.Name, "resources/src@") {
+			sources = append(sources, entry.Name)
+		}
+		if entry.Name == "trace.trace" {
+			file, err := entry.Open()
+			require.NoError(t, err)
+			content, err := ioutil.ReadAll(file)
+			require.NoError(t, err)
+			file.Close()
+			trace = string(content)
+		}
+	}
+	require.Contains(t, trace, `"title":"my trace"`)
+	require.Contains(t, trace, "tracing_test.go")
+	require.NotEmpty(t, sources)
+}
+
+func TestBrowserContextTraceChunks(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	defer page.Close()
+	require.NoError(t, context.Tracing().Start(playwright.TracingStartOptions{
+		Snapshots: playwright.Bool(true),
+	}))
+	require.EqualError(t, context.Tracing().StartChunk(), "a trace chunk is already being recorded")
+	_, err = page.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, context.Tracing().StopChunk(playwright.TracingStopChunkOptions{
+		Path: playwright.String(filepath.Join(dir, "trace1.zip")),
+	}))
+
+	require.NoError(t, context.Tracing().StartChunk(playwright.TracingStartChunkOptions{
+		Title: playwright.String("second"),
+	}))
+	_, err = page.Goto(server.PREFIX + "/grid.html")
+	require.NoError(t, err)
+	require.NoError(t, context.Tracing().StopChunk(playwright.TracingStopChunkOptions{
+		Path: playwright.String(filepath.Join(dir, "trace2.zip")),
+	}))
+	require.NoError(t, context.Tracing().Stop())
+
+	readTrace := func(name string) string {
+		reader, err := zip.OpenReader(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer reader.Close()
+		for _, entry := range reader.File {
+			if entry.Name == "trace.trace" {
+				file, err := entry.Open()
+				require.NoError(t, err)
+				defer file.Close()
+				content, err := ioutil.ReadAll(file)
+				require.NoError(t, err)
+				return string(content)
+			}
+		}
+		return ""
+	}
+	first := readTrace("trace1.zip")
+	require.Contains(t, first, "one-style.html")
+	require.NotContains(t, first, "grid.html")
+	second := readTrace("trace2.zip")
+	require.Contains(t, second, "grid.html")
+	require.NotContains(t, second, "one-style.html")
+	require.Contains(t, second, `"title":"second"`)
+}
+
+func TestBrowserContextTraceGroups(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	defer page.Close()
+	require.NoError(t, context.Tracing().Start())
+	require.NoError(t, context.Tracing().Group("navigation"))
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, context.Tracing().GroupEnd())
+	_, err = page.Evaluate("() => 1")
+	require.NoError(t, err)
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	require.NoError(t, context.Tracing().Stop(playwright.TracingStopOptions{
+		Path: playwright.String(tracePath),
+	}))
+
+	reader, err := zip.OpenReader(tracePath)
+	require.NoError(t, err)
+	defer reader.Close()
+	var trace string
+	for _, entry := range reader.File {
+		if entry.Name == "trace.trace" {
+			file, err := entry.Open()
+			require.NoError(t, err)
+			content, err := ioutil.ReadAll(file)
+			require.NoError(t, err)
+			file.Close()
+			trace = string(content)
+		}
+	}
+	require.Contains(t, trace, `"apiName":"navigation › frame.goto"`)
+	require.NotContains(t, trace, "navigation › frame.evaluateExpression")
+}
+
+func TestStepsGroupTraceActions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext()
+	require.NoError(t, err)
+	defer context.Close()
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	defer page.Close()
+	require.NoError(t, context.Tracing().Start())
+	recorder := playwright.NewStepRecorder(playwright.StepRecorderOptions{
+		Tracing: context.Tracing(),
+	})
+	ctx := playwright.ContextWithStepRecorder(goContext.Background(), recorder)
+	require.NoError(t, playwright.Step(ctx, "open shop", func() error {
+		_, err := page.Goto(server.EMPTY_PAGE)
+		return err
+	}))
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	require.NoError(t, context.Tracing().Stop(playwright.TracingStopOptions{
+		Path: playwright.String(tracePath),
+	}))
+	steps := recorder.Steps()
+	require.Len(t, steps, 1)
+	require.Equal(t, "open shop", steps[0].Title)
+	require.NoError(t, steps[0].Error)
+
+	reader, err := zip.OpenReader(tracePath)
+	require.NoError(t, err)
+	defer reader.Close()
+	var trace string
+	for _, entry := range reader.File {
+		if entry.Name == "trace.trace" {
+			file, err := entry.Open()
+			require.NoError(t, err)
+			content, err := ioutil.ReadAll(file)
+			require.NoError(t, err)
+			file.Close()
+			trace = string(content)
+		}
+	}
+	require.Contains(t, trace, `"apiName":"open shop › frame.goto"`)
+}
+
+func TestBrowserContextTracePathWithMetadata(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Metadata: map[string]string{"test": t.Name()},
+	})
+	require.NoError(t, err)
+	defer context.Close()
+	context.SetMetadata("ticket", "SHOP-123")
+	require.Equal(t, map[string]string{"test": t.Name(), "ticket": "SHOP-123"}, context.Metadata())
+	require.NoError(t, context.Tracing().Start())
+	page, err := context.NewPage()
+	require.NoError(t, err)
+	page.SetMetadata("ticket", "SHOP-456")
+	require.Equal(t, "SHOP-456", page.Metadata()["ticket"])
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, context.Tracing().Stop(playwright.TracingStopOptions{
+		Path: playwright.String(filepath.Join(dir, "{test}-{ticket}.zip")),
+	}))
+	require.FileExists(t, filepath.Join(dir, t.Name()+"-SHOP-123.zip"))
+}
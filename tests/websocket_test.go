@@ -198,3 +198,89 @@ func TestWebSocketShouldEmitBinaryFrameEvents(t *testing.T) {
 	require.Equal(t, sent, [][]byte{{0, 1, 2, 3, 4}, []byte("echo-bin")})
 	require.Equal(t, received, [][]byte{[]byte("incoming"), {4, 2}})
 }
+
+func TestPageRouteWebSocketShouldMockServer(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.RouteWebSocket("**/mock", func(ws playwright.WebSocketRoute) {
+		ws.OnMessage(func(message interface{}) {
+			require.NoError(t, ws.Send(fmt.Sprintf("echo: %v", message)))
+		})
+	}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	value, err := page.Evaluate(`() => {
+        let cb;
+        const result = new Promise(f => cb = f);
+        const ws = new WebSocket('ws://localhost:1/mock');
+        ws.addEventListener('open', () => ws.send('hello'));
+        ws.addEventListener('message', event => { ws.close(); cb(event.data); });
+        return result;
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, "echo: hello", value)
+}
+
+func TestBrowserContextRouteWebSocketShouldProxyToServer(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	wsServer := newWebsocketServer()
+	defer wsServer.Stop()
+	require.NoError(t, context.RouteWebSocket("**/ws", func(ws playwright.WebSocketRoute) {
+		serverWS, err := ws.ConnectToServer()
+		require.NoError(t, err)
+		serverWS.OnMessage(func(message interface{}) {
+			require.NoError(t, ws.Send(fmt.Sprintf("%v (proxied)", message)))
+		})
+	}))
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	value, err := page.Evaluate(`port => {
+        let cb;
+        const result = new Promise(f => cb = f);
+        const ws = new WebSocket('ws://localhost:' + port + '/ws');
+        ws.addEventListener('message', event => { ws.close(); cb(event.data); });
+        return result;
+	}`, wsServer.PORT)
+	require.NoError(t, err)
+	require.Equal(t, "incoming (proxied)", value)
+}
+
+func TestWebSocketShouldPassFrameDetails(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	wsServer := newWebsocketServer()
+	defer wsServer.Stop()
+
+	frames := make(chan *playwright.WebSocketFrame, 10)
+	page.Once("websocket", func(ws playwright.WebSocket) {
+		ws.On("framereceived", func(payload []byte, frame *playwright.WebSocketFrame) {
+			frames <- frame
+		})
+	})
+	_, err := page.Evaluate(`port => {
+        const ws = new WebSocket('ws://localhost:' + port + '/ws');
+        ws.addEventListener('open', () => ws.send('echo-bin'));
+    }`, wsServer.PORT)
+	require.NoError(t, err)
+	text := <-frames
+	require.False(t, text.IsBinary())
+	require.Equal(t, "incoming", text.Text())
+	binary := <-frames
+	require.True(t, binary.IsBinary())
+	require.Equal(t, []byte{4, 2}, binary.Payload)
+}
+
+func TestWebSocketShouldEmitSocketError(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	wsEvent, err := page.ExpectEvent("websocket", func() error {
+		_, err := page.Evaluate(`() => { new WebSocket('ws://localhost:1/bogus-ws'); }`)
+		return err
+	})
+	require.NoError(t, err)
+	ws := wsEvent.(playwright.WebSocket)
+	message := ws.WaitForEvent("socketerror")
+	require.IsType(t, "", message)
+	require.NotEmpty(t, message)
+}
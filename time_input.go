@@ -0,0 +1,97 @@
+package playwright
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeInputScript returns the type attribute of the input, which browsers
+// without support for e.g. month inputs report as `text` via the property,
+// and the offset of the emulated timezone at the given instant in minutes.
+const timeInputScript = `(element, timestamp) => {
+  if (element.nodeName !== 'INPUT')
+    throw new Error('Element is not an <input> element');
+  return {
+    type: (element.getAttribute('type') || 'text').toLowerCase(),
+    offset: -new Date(timestamp).getTimezoneOffset(),
+  };
+}`
+
+// formatTimeInput formats value as the value of a date, time, datetime-local,
+// month or week input. Seconds and milliseconds only get included when they
+// are set.
+func formatTimeInput(value time.Time, inputType string) (string, error) {
+	clock := value.Format("15:04")
+	if value.Second() != 0 || value.Nanosecond() >= int(time.Millisecond) {
+		clock = value.Format("15:04:05")
+	}
+	if value.Nanosecond() >= int(time.Millisecond) {
+		clock += fmt.Sprintf(".%03d", value.Nanosecond()/int(time.Millisecond))
+	}
+	switch inputType {
+	case "date":
+		return value.Format("2006-01-02"), nil
+	case "time":
+		return clock, nil
+	case "datetime-local":
+		return value.Format("2006-01-02") + "T" + clock, nil
+	case "month":
+		return value.Format("2006-01"), nil
+	case "week":
+		year, week := value.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week), nil
+	}
+	return "", fmt.Errorf("element is a %s input, expected one of date, time, datetime-local, month or week", inputType)
+}
+
+// timeInputValue returns the value for a time input which shows the instant
+// in the timezone of the page.
+func timeInputValue(value time.Time, result interface{}) (string, error) {
+	info, _ := result.(map[string]interface{})
+	inputType, _ := info["type"].(string)
+	offset := 0
+	switch v := info["offset"].(type) {
+	case int:
+		offset = v
+	case float64:
+		offset = int(v)
+	}
+	return formatTimeInput(value.In(time.FixedZone("", offset*60)), strings.ToLower(inputType))
+}
+
+func (l *locatorImpl) FillTime(value time.Time, options ...LocatorFillTimeOptions) error {
+	option := LocatorFillTimeOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	result, err := l.Evaluate(timeInputScript, int(value.UnixNano()/int64(time.Millisecond)), LocatorEvaluateOptions{
+		Timeout: option.Timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("could not fill time: %w", err)
+	}
+	formatted, err := timeInputValue(value, result)
+	if err != nil {
+		return fmt.Errorf("could not fill time: %w", err)
+	}
+	return l.Fill(formatted, LocatorFillOptions{
+		Force:       option.Force,
+		NoWaitAfter: option.NoWaitAfter,
+		Timeout:     option.Timeout,
+	})
+}
+
+func (f *frameImpl) FillTime(selector string, value time.Time, options ...FrameFillTimeOptions) error {
+	option := LocatorFillTimeOptions{}
+	if len(options) == 1 {
+		option.Force = options[0].Force
+		option.NoWaitAfter = options[0].NoWaitAfter
+		option.Timeout = options[0].Timeout
+	}
+	return f.Locator(selector).FillTime(value, option)
+}
+
+func (p *pageImpl) FillTime(selector string, value time.Time, options ...FrameFillTimeOptions) error {
+	return p.mainFrame.FillTime(selector, value, options...)
+}
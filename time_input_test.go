@@ -0,0 +1,41 @@
+package playwright
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTimeInput(t *testing.T) {
+	value := time.Date(2021, time.January, 3, 9, 5, 0, 0, time.UTC)
+	for inputType, expected := range map[string]string{
+		"date":           "2021-01-03",
+		"time":           "09:05",
+		"datetime-local": "2021-01-03T09:05",
+		"month":          "2021-01",
+		"week":           "2020-W53",
+	} {
+		formatted, err := formatTimeInput(value, inputType)
+		require.NoError(t, err)
+		require.Equal(t, expected, formatted, inputType)
+	}
+	formatted, err := formatTimeInput(value.Add(7*time.Second+250*time.Millisecond), "time")
+	require.NoError(t, err)
+	require.Equal(t, "09:05:07.250", formatted)
+	formatted, err = formatTimeInput(value.Add(7*time.Second), "datetime-local")
+	require.NoError(t, err)
+	require.Equal(t, "2021-01-03T09:05:07", formatted)
+	_, err = formatTimeInput(value, "text")
+	require.EqualError(t, err, "element is a text input, expected one of date, time, datetime-local, month or week")
+}
+
+func TestTimeInputValue(t *testing.T) {
+	value := time.Date(2021, time.March, 31, 23, 30, 0, 0, time.UTC)
+	formatted, err := timeInputValue(value, map[string]interface{}{"type": "datetime-local", "offset": 120})
+	require.NoError(t, err)
+	require.Equal(t, "2021-04-01T01:30", formatted)
+	formatted, err = timeInputValue(value, map[string]interface{}{"type": "DATE", "offset": -300})
+	require.NoError(t, err)
+	require.Equal(t, "2021-03-31", formatted)
+}
@@ -1,35 +1,487 @@
 package playwright
 
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
 type tracingImpl struct {
-	context *browserContextImpl
-	channel *channel
+	sync.Mutex
+	context   *browserContextImpl
+	channel   *channel
+	started   bool
+	recording bool
+	sources   bool
+	groups    []string
+	chunk     traceChunk
+}
+
+// traceChunk describes the part of the trace which gets exported. The driver
+// records a single trace per context, chunks get cut out of it by skipping
+// the events which were recorded before the chunk started.
+type traceChunk struct {
+	title   string
+	sources bool
+	// number of events per event file of the trace when the chunk started
+	offsets map[string]int
 }
 
 func (t *tracingImpl) Start(options ...TracingStartOptions) error {
-	_, err := t.channel.Send("tracingStart", options)
-	return err
+	var sources bool
+	var title string
+	if len(options) == 1 {
+		option := options[0]
+		if option.Sources != nil {
+			sources = *option.Sources
+		}
+		if option.Title != nil {
+			title = *option.Title
+		}
+		option.Sources = nil
+		option.Title = nil
+		options = []TracingStartOptions{option}
+	}
+	if _, err := t.channel.Send("tracingStart", options); err != nil {
+		return err
+	}
+	t.Lock()
+	defer t.Unlock()
+	if sources && !t.sources {
+		atomic.AddInt32(&t.channel.connection.sourceTracings, 1)
+	} else if !sources && t.sources {
+		atomic.AddInt32(&t.channel.connection.sourceTracings, -1)
+	}
+	t.started = true
+	t.recording = true
+	t.sources = sources
+	t.chunk = traceChunk{title: title, sources: sources}
+	return nil
 }
 
-func (t *tracingImpl) Stop(options ...TracingStopOptions) error {
+func (t *tracingImpl) StartChunk(options ...TracingStartChunkOptions) error {
+	t.Lock()
+	defer t.Unlock()
+	if !t.started {
+		return errors.New("tracing has not been started")
+	}
+	if t.recording {
+		return errors.New("a trace chunk is already being recorded")
+	}
+	offsets, err := t.eventCounts()
+	if err != nil {
+		return fmt.Errorf("could not start trace chunk: %w", err)
+	}
+	t.recording = true
+	t.chunk = traceChunk{sources: t.sources, offsets: offsets}
+	if len(options) == 1 && options[0].Title != nil {
+		t.chunk.title = *options[0].Title
+	}
+	return nil
+}
+
+func (t *tracingImpl) StopChunk(options ...TracingStopChunkOptions) error {
+	t.Lock()
+	defer t.Unlock()
+	if !t.recording {
+		return errors.New("no trace chunk is being recorded")
+	}
+	t.recording = false
 	if len(options) == 1 && options[0].Path != nil {
-		artifactChannel, err := t.channel.Send("tracingExport", nil)
-		if err != nil {
+		return t.exportChunk(*options[0].Path, t.chunk)
+	}
+	return nil
+}
+
+func (t *tracingImpl) Stop(options ...TracingStopOptions) error {
+	t.Lock()
+	chunk, recording := t.chunk, t.recording
+	if t.sources {
+		atomic.AddInt32(&t.channel.connection.sourceTracings, -1)
+	}
+	atomic.AddInt32(&t.channel.connection.tracingGroups, -int32(len(t.groups)))
+	t.started = false
+	t.recording = false
+	t.sources = false
+	t.groups = nil
+	t.chunk = traceChunk{}
+	t.Unlock()
+	if recording && len(options) == 1 && options[0].Path != nil {
+		if err := t.exportChunk(*options[0].Path, chunk); err != nil {
 			return err
 		}
-		artifact := fromChannel(artifactChannel).(*artifactImpl)
-		if err = artifact.SaveAs(*options[0].Path); err != nil {
+	}
+	if _, err := t.channel.Send("tracingStop", nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *tracingImpl) Group(name string) error {
+	t.Lock()
+	defer t.Unlock()
+	if !t.started {
+		return errors.New("tracing has not been started")
+	}
+	t.groups = append(t.groups, name)
+	atomic.AddInt32(&t.channel.connection.tracingGroups, 1)
+	return nil
+}
+
+func (t *tracingImpl) GroupEnd() error {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.groups) == 0 {
+		return errors.New("no tracing group has been started")
+	}
+	t.groups = t.groups[:len(t.groups)-1]
+	atomic.AddInt32(&t.channel.connection.tracingGroups, -1)
+	return nil
+}
+
+func (t *tracingImpl) openGroups() []string {
+	t.Lock()
+	defer t.Unlock()
+	return append([]string{}, t.groups...)
+}
+
+func (t *tracingImpl) export(path string) error {
+	artifactChannel, err := t.channel.Send("tracingExport", nil)
+	if err != nil {
+		return err
+	}
+	artifact := fromChannel(artifactChannel).(*artifactImpl)
+	if err = artifact.SaveAs(path); err != nil {
+		return err
+	}
+	return artifact.Delete()
+}
+
+func (t *tracingImpl) exportChunk(path string, chunk traceChunk) error {
+	path = expandArtifactPath(path, t.context.Metadata())
+	if err := t.export(path); err != nil {
+		return err
+	}
+	if chunk.sources || chunk.title != "" || chunk.offsets != nil || redactions.enabled() {
+		if err := finalizeTrace(path, chunk); err != nil {
+			return fmt.Errorf("could not finalize trace: %w", err)
+		}
+	}
+	return nil
+}
+
+// eventCounts returns the number of events which got recorded so far.
+func (t *tracingImpl) eventCounts() (map[string]int, error) {
+	dir, err := ioutil.TempDir("", "playwright-trace-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trace.zip")
+	if err := t.export(path); err != nil {
+		return nil, err
+	}
+	return countTraceEvents(path)
+}
+
+func newTracing(context *browserContextImpl) *tracingImpl {
+	return &tracingImpl{
+		context: context,
+		channel: context.channel,
+	}
+}
+
+var packagePath = reflect.TypeOf(tracingImpl{}).PkgPath()
+
+// callMetadata returns the metadata of a call, which the driver records in the
+// trace: the stack of the caller while sources are traced and, inside of
+// tracing groups, the name of the call prefixed with the names of the groups.
+func (c *connection) callMetadata(guid, method string) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	if atomic.LoadInt32(&c.sourceTracings) > 0 {
+		metadata["stack"] = callerStack()
+	}
+	if atomic.LoadInt32(&c.tracingGroups) > 0 {
+		if owner, ok := c.objects[guid]; ok {
+			if groups := tracingGroups(owner); len(groups) > 0 {
+				metadata["apiName"] = groupedAPIName(groups, owner.objectType, method)
+			}
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// tracingGroups returns the open tracing groups of the browser context the
+// object belongs to.
+func tracingGroups(owner *channelOwner) []string {
+	for ; owner != nil; owner = owner.parent {
+		if owner.channel == nil {
+			return nil
+		}
+		if context, ok := owner.channel.object.(*browserContextImpl); ok {
+			if context.tracing == nil {
+				return nil
+			}
+			return context.tracing.openGroups()
+		}
+	}
+	return nil
+}
+
+// groupedAPIName returns the title of a call in the trace viewer, e.g.
+// "login › fill credentials › page.fill".
+func groupedAPIName(groups []string, objectType, method string) string {
+	object := objectType
+	if object != "" {
+		object = strings.ToLower(object[:1]) + object[1:]
+	}
+	return strings.Join(append(groups, object+"."+method), " › ")
+}
+
+// callerStack returns the frames of the user code which called into the
+// library, they get attached to the protocol messages so that the trace
+// viewer can link its actions to the sources.
+func callerStack() []map[string]interface{} {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := []map[string]interface{}{}
+	for {
+		frame, more := frames.Next()
+		if !isLibraryFrame(frame.Function) && frame.File != "" {
+			function := frame.Function
+			if i := strings.LastIndex(function, "/"); i != -1 {
+				function = function[i+1:]
+			}
+			stack = append(stack, map[string]interface{}{
+				"file":     frame.File,
+				"line":     frame.Line,
+				"column":   0,
+				"function": function,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+func isLibraryFrame(function string) bool {
+	for _, prefix := range []string{packagePath + ".", "runtime.", "testing.", "sync."} {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// finalizeTrace rewrites the exported trace. It drops the events which were
+// recorded before the chunk started, together with the resources only they
+// referenced, records the title in the context options event, masks the
+// secrets registered with RedactValue() and bundles the source files
+// referenced by the stacks of the actions, which the trace viewer shows in
+// its source tab.
+func finalizeTrace(tracePath string, chunk traceChunk) error {
+	reader, err := zip.OpenReader(tracePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	entries := map[string][]byte{}
+	names := []string{}
+	files := map[string]bool{}
+	var events bytes.Buffer
+	for _, entry := range reader.File {
+		content, err := readZipEntry(entry)
+		if err != nil {
 			return err
 		}
-		if err = artifact.Delete(); err != nil {
+		if isTraceEventFile(entry.Name) {
+			if content, err = rewriteTraceEvents(content, chunk.offsets[entry.Name], chunk.title, files); err != nil {
+				return fmt.Errorf("could not parse %s: %w", entry.Name, err)
+			}
+			events.Write(content)
+		} else if strings.HasPrefix(entry.Name, "resources/") {
+			content = redactions.redactBytes(content)
+		}
+		entries[entry.Name] = content
+		names = append(names, entry.Name)
+	}
+	reader.Close()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, name := range names {
+		if chunk.offsets != nil && strings.HasPrefix(name, "resources/") &&
+			!bytes.Contains(events.Bytes(), []byte(path.Base(name))) {
+			continue
+		}
+		if err := writeZipEntry(writer, name, entries[name]); err != nil {
 			return err
 		}
 	}
-	if _, err := t.channel.Send("tracingStop", nil); err != nil {
+	if chunk.sources {
+		sources := make([]string, 0, len(files))
+		for file := range files {
+			sources = append(sources, file)
+		}
+		sort.Strings(sources)
+		for _, file := range sources {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				// sources which are not available anymore are left out
+				continue
+			}
+			if err := writeZipEntry(writer, sourceResourceName(file), content); err != nil {
+				return err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
 		return err
 	}
-	return nil
+	return ioutil.WriteFile(tracePath, buffer.Bytes(), 0644)
 }
 
-func newTracing(context *browserContextImpl) *tracingImpl {
-	return &tracingImpl{context, context.channel}
+func isTraceEventFile(name string) bool {
+	ext := path.Ext(name)
+	return !strings.HasPrefix(name, "resources/") && (ext == ".trace" || ext == ".network")
+}
+
+// countTraceEvents returns the number of events per event file of a trace.
+func countTraceEvents(tracePath string) (map[string]int, error) {
+	reader, err := zip.OpenReader(tracePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	counts := map[string]int{}
+	for _, entry := range reader.File {
+		if !isTraceEventFile(entry.Name) {
+			continue
+		}
+		content, err := readZipEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range bytes.Split(content, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) > 0 {
+				counts[entry.Name]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// sourceResourceName is the name under which the trace viewer looks up a
+// source file in the trace.
+func sourceResourceName(file string) string {
+	sum := sha1.Sum([]byte(file))
+	return "resources/src@" + hex.EncodeToString(sum[:]) + ".txt"
+}
+
+// rewriteTraceEvents drops the first skip events, except for the context
+// options, sets the title of the context options events, masks the secrets
+// registered with RedactValue() and collects the files of the stack frames.
+// The trace consists of one JSON event per line.
+func rewriteTraceEvents(content []byte, skip int, title string, files map[string]bool) ([]byte, error) {
+	redact := redactions.enabled()
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), len(content)+1)
+	for index := 0; scanner.Scan(); {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		index++
+		var event map[string]interface{}
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, err
+		}
+		if index <= skip && event["type"] != "context-options" {
+			continue
+		}
+		collectStackFiles(event, files)
+		retitle := title != "" && event["type"] == "context-options"
+		if retitle {
+			event["title"] = title
+		}
+		if redact {
+			redactions.redactValue(event)
+		}
+		if retitle || redact {
+			rewritten, err := json.Marshal(event)
+			if err != nil {
+				return nil, err
+			}
+			line = rewritten
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func collectStackFiles(value interface{}, files map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if frames, ok := child.([]interface{}); ok && key == "stack" {
+				for _, frame := range frames {
+					if frame, ok := frame.(map[string]interface{}); ok {
+						if file, ok := frame["file"].(string); ok && file != "" {
+							files[file] = true
+						}
+					}
+				}
+				continue
+			}
+			collectStackFiles(child, files)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectStackFiles(child, files)
+		}
+	}
+}
+
+func readZipEntry(entry *zip.File) ([]byte, error) {
+	file, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ioutil.ReadAll(file)
+}
+
+func writeZipEntry(writer *zip.Writer, name string, content []byte) error {
+	file, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, bytes.NewReader(content))
+	return err
 }
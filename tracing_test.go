@@ -0,0 +1,145 @@
+package playwright
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerStackSkipsLibraryFrames(t *testing.T) {
+	stack := callerStack()
+	// all frames of this test belong to the library package or the test runner
+	require.Empty(t, stack)
+	require.True(t, isLibraryFrame(packagePath+".(*pageImpl).Goto"))
+	require.True(t, isLibraryFrame("testing.tRunner"))
+	require.False(t, isLibraryFrame("main.main"))
+	require.False(t, isLibraryFrame(packagePath+"_test.TestFoo"))
+}
+
+func TestFinalizeTrace(t *testing.T) {
+	_, source, _, _ := runtime.Caller(0)
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	events := `{"type":"context-options","browserName":"chromium"}
+{"type":"action","metadata":{"method":"goto","stack":[{"file":"` + filepath.ToSlash(source) + `","line":12,"column":0},{"file":"/does/not/exist.go","line":1}]}}
+`
+	writeTestZip(t, tracePath, map[string]string{
+		"trace.trace":      events,
+		"resources/abcdef": "image",
+	})
+	require.NoError(t, finalizeTrace(tracePath, traceChunk{title: "My trace", sources: true}))
+
+	entries := readTestZip(t, tracePath)
+	require.Equal(t, "image", entries["resources/abcdef"])
+	lines := strings.Split(strings.TrimSpace(entries["trace.trace"]), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"title":"My trace"`)
+	require.Contains(t, lines[1], `"method":"goto"`)
+	content, err := ioutil.ReadFile(source)
+	require.NoError(t, err)
+	require.Equal(t, string(content), entries[sourceResourceName(filepath.ToSlash(source))])
+	require.Len(t, entries, 3)
+}
+
+func TestFinalizeTraceWithoutSources(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	writeTestZip(t, tracePath, map[string]string{
+		"trace.trace": `{"type":"action","metadata":{"stack":[{"file":"` + filepath.ToSlash(tracePath) + `","line":1}]}}` + "\n",
+	})
+	require.NoError(t, finalizeTrace(tracePath, traceChunk{}))
+	require.Len(t, readTestZip(t, tracePath), 1)
+}
+
+func writeTestZip(t *testing.T, path string, entries map[string]string) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for name, content := range entries {
+		require.NoError(t, writeZipEntry(writer, name, []byte(content)))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func readTestZip(t *testing.T, path string) map[string]string {
+	reader, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	entries := map[string]string{}
+	for _, entry := range reader.File {
+		content, err := readZipEntry(entry)
+		require.NoError(t, err)
+		entries[entry.Name] = string(content)
+	}
+	return entries
+}
+
+func TestFinalizeTraceChunk(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.zip")
+	writeTestZip(t, tracePath, map[string]string{
+		"trace.trace": `{"type":"context-options","browserName":"chromium"}
+{"type":"screencast-frame","sha1":"first"}
+{"type":"action","metadata":{"method":"goto"}}
+{"type":"screencast-frame","sha1":"second"}
+{"type":"action","metadata":{"method":"click"}}
+`,
+		"resources/first":  "first image",
+		"resources/second": "second image",
+	})
+	counts, err := countTraceEvents(tracePath)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"trace.trace": 5}, counts)
+
+	require.NoError(t, finalizeTrace(tracePath, traceChunk{
+		title:   "second chunk",
+		offsets: map[string]int{"trace.trace": 3},
+	}))
+	entries := readTestZip(t, tracePath)
+	require.Equal(t, `{"browserName":"chromium","title":"second chunk","type":"context-options"}
+{"type":"screencast-frame","sha1":"second"}
+{"type":"action","metadata":{"method":"click"}}
+`, entries["trace.trace"])
+	require.Equal(t, "second image", entries["resources/second"])
+	require.NotContains(t, entries, "resources/first")
+}
+
+func TestCallMetadataTracingGroups(t *testing.T) {
+	conn := &connection{objects: map[string]*channelOwner{}}
+	context := &browserContextImpl{}
+	context.channelOwner = channelOwner{
+		objectType: "BrowserContext",
+		guid:       "context",
+		connection: conn,
+		channel:    &channel{guid: "context", connection: conn, object: context},
+	}
+	context.tracing = newTracing(context)
+	page := &pageImpl{}
+	page.channelOwner = channelOwner{
+		objectType: "Page",
+		guid:       "page",
+		connection: conn,
+		channel:    &channel{guid: "page", connection: conn, object: page},
+		parent:     &context.channelOwner,
+	}
+	conn.objects["context"] = &context.channelOwner
+	conn.objects["page"] = &page.channelOwner
+
+	require.Nil(t, conn.callMetadata("page", "goto"))
+	require.EqualError(t, context.tracing.Group("login"), "tracing has not been started")
+	context.tracing.started = true
+	require.NoError(t, context.tracing.Group("login"))
+	require.NoError(t, context.tracing.Group("fill credentials"))
+	require.Equal(t, map[string]interface{}{
+		"apiName": "login › fill credentials › page.fill",
+	}, conn.callMetadata("page", "fill"))
+	require.NoError(t, context.tracing.GroupEnd())
+	require.Equal(t, "login › browserContext.newPage", conn.callMetadata("context", "newPage")["apiName"])
+	require.NoError(t, context.tracing.GroupEnd())
+	require.Nil(t, conn.callMetadata("page", "goto"))
+	require.EqualError(t, context.tracing.GroupEnd(), "no tracing group has been started")
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"gopkg.in/square/go-jose.v2/json"
@@ -37,12 +38,24 @@ type webSocketTransport struct {
 	err      error
 }
 
-func (t *webSocketTransport) Start() error {
-	conn, _, err := websocket.DefaultDialer.Dial(t.url, nil)
+// Dial connects to the websocket. A timeout of 0 disables the timeout.
+func (t *webSocketTransport) Dial(timeout time.Duration) error {
+	dialer := *websocket.DefaultDialer
+	dialer.HandshakeTimeout = timeout
+	conn, _, err := dialer.Dial(t.url, nil)
 	if err != nil {
 		return fmt.Errorf("could not connect to websocket: %w", err)
 	}
 	t.conn = conn
+	return nil
+}
+
+func (t *webSocketTransport) Start() error {
+	if t.conn == nil {
+		if err := t.Dial(0); err != nil {
+			return err
+		}
+	}
 
 	for {
 		msg := &message{}
@@ -108,7 +121,7 @@ func (t *pipeTransport) Start() error {
 		}
 		if os.Getenv("DEBUGP") != "" {
 			fmt.Print("RECV>")
-			if err := json.NewEncoder(os.Stderr).Encode(msg); err != nil {
+			if err := json.NewEncoder(os.Stderr).Encode(redactions.redactJSON(msg)); err != nil {
 				log.Printf("could not encode json: %v", err)
 			}
 		}
@@ -147,7 +160,7 @@ func (t *pipeTransport) Send(message map[string]interface{}) error {
 	}
 	if os.Getenv("DEBUGP") != "" {
 		fmt.Print("SEND>")
-		if err := json.NewEncoder(os.Stderr).Encode(message); err != nil {
+		if err := json.NewEncoder(os.Stderr).Encode(redactions.redactJSON(message)); err != nil {
 			log.Printf("could not encode json: %v", err)
 		}
 	}
@@ -0,0 +1,147 @@
+package playwright
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// userDataDirLockFiles are the files browsers create in their profile while
+// they are running. They get skipped when copying a profile, otherwise the
+// copy would be considered as in use by another browser process.
+var userDataDirLockFiles = map[string]bool{
+	"SingletonLock":   true,
+	"SingletonSocket": true,
+	"SingletonCookie": true,
+	"lockfile":        true,
+	"parent.lock":     true,
+	".parentlock":     true,
+	"lock":            true,
+}
+
+// UserDataDir is a persistent browser profile directory which can be passed
+// to BrowserType.LaunchPersistentContext(). It allows stateful profiles (e.g.
+// with installed extensions or saved passwords) to be prepared once and then
+// be cloned for every test, so tests running in parallel don't share state.
+type UserDataDir struct {
+	// Path of the profile directory on disk.
+	Path string
+}
+
+// NewUserDataDir returns a UserDataDir for the given path. The directory gets
+// created if it does not exist yet. When no path is given, a new temporary
+// directory is used.
+func NewUserDataDir(path ...string) (*UserDataDir, error) {
+	if len(path) == 1 {
+		if err := os.MkdirAll(path[0], 0700); err != nil {
+			return nil, fmt.Errorf("could not create user data dir: %w", err)
+		}
+		return &UserDataDir{Path: path[0]}, nil
+	}
+	dir, err := ioutil.TempDir("", "playwright-go-profile-")
+	if err != nil {
+		return nil, fmt.Errorf("could not create user data dir: %w", err)
+	}
+	return &UserDataDir{Path: dir}, nil
+}
+
+// Snapshot copies the current state of the profile into dest and returns it.
+// The browser using the profile should be closed before, so that all the
+// data has been flushed to disk.
+func (u *UserDataDir) Snapshot(dest string) (*UserDataDir, error) {
+	snapshot, err := NewUserDataDir(dest)
+	if err != nil {
+		return nil, err
+	}
+	if err := copyUserDataDir(u.Path, snapshot.Path); err != nil {
+		return nil, fmt.Errorf("could not snapshot user data dir: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Clone copies the profile into a new temporary directory. Use it to give
+// every test its own copy of a prepared profile.
+func (u *UserDataDir) Clone() (*UserDataDir, error) {
+	clone, err := NewUserDataDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := copyUserDataDir(u.Path, clone.Path); err != nil {
+		return nil, fmt.Errorf("could not clone user data dir: %w", err)
+	}
+	return clone, nil
+}
+
+// Reset discards all the changes made to the profile and restores the state of
+// the given snapshot. Without a snapshot the profile gets emptied.
+func (u *UserDataDir) Reset(snapshot ...*UserDataDir) error {
+	entries, err := ioutil.ReadDir(u.Path)
+	if err != nil {
+		return fmt.Errorf("could not read user data dir: %w", err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(u.Path, entry.Name())); err != nil {
+			return fmt.Errorf("could not reset user data dir: %w", err)
+		}
+	}
+	if len(snapshot) == 1 {
+		if err := copyUserDataDir(snapshot[0].Path, u.Path); err != nil {
+			return fmt.Errorf("could not restore user data dir: %w", err)
+		}
+	}
+	return nil
+}
+
+// Remove deletes the profile directory from disk.
+func (u *UserDataDir) Remove() error {
+	return os.RemoveAll(u.Path)
+}
+
+func copyUserDataDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if userDataDirLockFiles[info.Name()] {
+			return nil
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, relPath)
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		// sockets, pipes and devices can't be copied
+		return nil
+	})
+}
+
+func copyFile(src, dest string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
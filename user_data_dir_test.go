@@ -0,0 +1,47 @@
+package playwright
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDataDirCloneAndReset(t *testing.T) {
+	profile, err := NewUserDataDir(filepath.Join(t.TempDir(), "profile"))
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(profile.Path, "Default"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(profile.Path, "Default", "Preferences"), []byte("foo"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(profile.Path, "lockfile"), []byte(""), 0600))
+
+	snapshot, err := profile.Snapshot(filepath.Join(t.TempDir(), "snapshot"))
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(snapshot.Path, "lockfile"))
+
+	clone, err := snapshot.Clone()
+	require.NoError(t, err)
+	defer clone.Remove()
+	require.NotEqual(t, snapshot.Path, clone.Path)
+	content, err := ioutil.ReadFile(filepath.Join(clone.Path, "Default", "Preferences"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(content))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(clone.Path, "Default", "Preferences"), []byte("bar"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(clone.Path, "Default", "History"), []byte("baz"), 0600))
+	content, err = ioutil.ReadFile(filepath.Join(snapshot.Path, "Default", "Preferences"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(content))
+
+	require.NoError(t, clone.Reset(snapshot))
+	content, err = ioutil.ReadFile(filepath.Join(clone.Path, "Default", "Preferences"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(content))
+	require.NoFileExists(t, filepath.Join(clone.Path, "Default", "History"))
+
+	require.NoError(t, clone.Reset())
+	entries, err := ioutil.ReadDir(clone.Path)
+	require.NoError(t, err)
+	require.Len(t, entries, 0)
+}
@@ -0,0 +1,227 @@
+package playwright
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OptionsError is returned for invalid options before they are sent to the
+// driver, e.g. for an unknown enum value or mutually exclusive fields.
+type OptionsError struct {
+	// Name of the option struct, e.g. "PageScreenshotOptions".
+	Options string
+	// Name of the invalid field, empty when the error concerns several fields.
+	Field string
+	// Description of the problem.
+	Reason string
+}
+
+func (e *OptionsError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid %s: %s", e.Options, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s.%s: %s", e.Options, e.Field, e.Reason)
+}
+
+// enumValues holds the values of the enums of generated-enums.go, which
+// register themselves when they get declared.
+var enumValues = map[reflect.Type][]string{}
+
+// nullableEnums accept "null" to reset the emulation, e.g. in
+// Page.EmulateMedia().
+var nullableEnums = map[reflect.Type]bool{
+	reflect.TypeOf(ColorScheme("")):   true,
+	reflect.TypeOf(ReducedMotion("")): true,
+}
+
+func registerEnumValue(value interface{}) {
+	t := reflect.TypeOf(value)
+	enumValues[t] = append(enumValues[t], reflect.ValueOf(value).String())
+}
+
+// optionsRule checks the relation of the fields of an option struct.
+type optionsRule func(v reflect.Value) *OptionsError
+
+// optionsRules are the rules of the option structs, which the driver would
+// only reject after a round trip or silently ignore.
+var optionsRules = map[reflect.Type][]optionsRule{
+	reflect.TypeOf(PageScreenshotOptions{}): {
+		exclusiveFields("Clip", "FullPage"),
+		screenshotQuality,
+	},
+	reflect.TypeOf(ElementHandleScreenshotOptions{}): {screenshotQuality},
+	reflect.TypeOf(LocatorScreenshotOptions{}):       {screenshotQuality},
+	reflect.TypeOf(PageAddScriptTagOptions{}): {
+		requiredFields("URL", "Path", "Content"),
+		dependentField("FS", "Path"),
+	},
+	reflect.TypeOf(PageAddStyleTagOptions{}): {
+		requiredFields("URL", "Path", "Content"),
+		dependentField("FS", "Path"),
+	},
+	reflect.TypeOf(RouteFulfillOptions{}): {
+		exclusiveFields("Body", "JSON", "Path"),
+	},
+}
+
+// validateOptions checks the enum values and the optionsRules of an option
+// struct, a pointer to it or a slice of them, including nested structs.
+// Other values are valid.
+func validateOptions(options interface{}) error {
+	if options == nil {
+		return nil
+	}
+	if err := validateValue(reflect.ValueOf(options)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateValue(v reflect.Value) *OptionsError {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return validateValue(v.Elem())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Struct && v.Type().Elem().Kind() != reflect.Ptr {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		return validateStruct(v)
+	}
+	return nil
+}
+
+func validateStruct(v reflect.Value) *OptionsError {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		value := v.Field(i)
+		if err := validateEnum(value); err != nil {
+			err.Options = t.Name()
+			err.Field = field.Name
+			return err
+		}
+		if field.Type.Kind() == reflect.Struct || (field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct) ||
+			(field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct) {
+			if err := validateValue(value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, rule := range optionsRules[t] {
+		if err := rule(v); err != nil {
+			err.Options = t.Name()
+			return err
+		}
+	}
+	return nil
+}
+
+// validateEnum checks the value of an enum field or of a slice of enums.
+func validateEnum(v reflect.Value) *OptionsError {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return validateEnum(v.Elem())
+	case reflect.Slice:
+		if _, ok := enumValues[v.Type().Elem()]; !ok {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := validateEnum(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		values, ok := enumValues[v.Type()]
+		if !ok || (nullableEnums[v.Type()] && v.String() == "null") {
+			return nil
+		}
+		for _, value := range values {
+			if value == v.String() {
+				return nil
+			}
+		}
+		return &OptionsError{
+			Reason: fmt.Sprintf("expected one of %s, got %q", quoteValues(values), v.String()),
+		}
+	}
+	return nil
+}
+
+func quoteValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func isFieldSet(v reflect.Value, name string) bool {
+	field := v.FieldByName(name)
+	return field.IsValid() && !field.IsZero()
+}
+
+func setFields(v reflect.Value, names []string) []string {
+	set := []string{}
+	for _, name := range names {
+		if isFieldSet(v, name) {
+			set = append(set, name)
+		}
+	}
+	return set
+}
+
+// exclusiveFields allows at most one of the fields to be set.
+func exclusiveFields(names ...string) optionsRule {
+	return func(v reflect.Value) *OptionsError {
+		if set := setFields(v, names); len(set) > 1 {
+			return &OptionsError{Reason: fmt.Sprintf("%s can not be combined", strings.Join(set, " and "))}
+		}
+		return nil
+	}
+}
+
+// requiredFields requires at least one of the fields to be set.
+func requiredFields(names ...string) optionsRule {
+	return func(v reflect.Value) *OptionsError {
+		if len(setFields(v, names)) == 0 {
+			return &OptionsError{Reason: fmt.Sprintf("one of %s is required", strings.Join(names, ", "))}
+		}
+		return nil
+	}
+}
+
+// dependentField allows name to be set only together with other.
+func dependentField(name, other string) optionsRule {
+	return func(v reflect.Value) *OptionsError {
+		if isFieldSet(v, name) && !isFieldSet(v, other) {
+			return &OptionsError{Field: name, Reason: fmt.Sprintf("requires %s to be set", other)}
+		}
+		return nil
+	}
+}
+
+func screenshotQuality(v reflect.Value) *OptionsError {
+	screenshotType, _ := v.FieldByName("Type").Interface().(*ScreenshotType)
+	if isFieldSet(v, "Quality") && screenshotType != nil && *screenshotType == *ScreenshotTypePng {
+		return &OptionsError{Field: "Quality", Reason: "is unsupported for png screenshots"}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOptionsEnums(t *testing.T) {
+	require.NoError(t, validateOptions(PageGotoOptions{WaitUntil: WaitUntilStateNetworkidle}))
+	require.NoError(t, validateOptions([]PageGotoOptions{}))
+	require.NoError(t, validateOptions(map[string]interface{}{"waitUntil": "foo"}))
+
+	waitUntil := WaitUntilState("networkIdle")
+	err := validateOptions([]PageGotoOptions{{WaitUntil: &waitUntil}})
+	var optionsErr *OptionsError
+	require.True(t, errors.As(err, &optionsErr))
+	require.Equal(t, "PageGotoOptions", optionsErr.Options)
+	require.Equal(t, "WaitUntil", optionsErr.Field)
+	require.EqualError(t, err, `invalid PageGotoOptions.WaitUntil: expected one of "load", "domcontentloaded", "networkidle", got "networkIdle"`)
+
+	err = validateOptions(&PageClickOptions{Modifiers: []KeyboardModifier{*KeyboardModifierShift, "Ctrl"}})
+	require.EqualError(t, err, `invalid PageClickOptions.Modifiers: expected one of "Alt", "Control", "Meta", "Shift", got "Ctrl"`)
+
+	colorScheme := ColorScheme("null")
+	require.NoError(t, validateOptions(PageEmulateMediaOptions{ColorScheme: &colorScheme}))
+}
+
+func TestValidateOptionsRules(t *testing.T) {
+	err := validateOptions(PageScreenshotOptions{FullPage: Bool(true), Clip: &PageScreenshotOptionsClip{}})
+	require.EqualError(t, err, "invalid PageScreenshotOptions: Clip and FullPage can not be combined")
+	err = validateOptions(ElementHandleScreenshotOptions{Type: ScreenshotTypePng, Quality: Int(50)})
+	require.EqualError(t, err, "invalid ElementHandleScreenshotOptions.Quality: is unsupported for png screenshots")
+	require.NoError(t, validateOptions(PageScreenshotOptions{Type: ScreenshotTypeJpeg, Quality: Int(50)}))
+
+	err = validateOptions(PageAddScriptTagOptions{})
+	require.EqualError(t, err, "invalid PageAddScriptTagOptions: one of URL, Path, Content is required")
+	require.NoError(t, validateOptions(PageAddStyleTagOptions{Content: String("body {}")}))
+
+	err = validateOptions(RouteFulfillOptions{Body: "body", Path: String("index.html")})
+	require.EqualError(t, err, "invalid RouteFulfillOptions: Body and Path can not be combined")
+}
+
+func TestValidateOptionsBeforeSending(t *testing.T) {
+	waitUntil := WaitUntilState("foo")
+	_, err := (&channel{}).Send("goto", map[string]interface{}{"url": "about:blank"}, []PageGotoOptions{{WaitUntil: &waitUntil}})
+	var optionsErr *OptionsError
+	require.True(t, errors.As(err, &optionsErr))
+}
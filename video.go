@@ -14,7 +14,7 @@ func (v *videoImpl) Delete() error {
 }
 
 func (v *videoImpl) SaveAs(path string) error {
-	return v.artifact.SaveAs(path)
+	return v.artifact.SaveAs(expandArtifactPath(path, v.page.Metadata()))
 }
 func (v *videoImpl) setArtifact(artifact *artifactImpl) {
 	v.artifact = artifact
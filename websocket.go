@@ -41,33 +41,59 @@ func newWebsocket(parent *channelOwner, objectType string, guid string, initiali
 			ws.Emit("error", params["error"])
 		},
 	)
+	ws.channel.On(
+		"socketError",
+		func(params map[string]interface{}) {
+			ws.Emit("socketerror", params["error"])
+		},
+	)
 	return ws
 }
 
+// WebSocketFrame is passed as the second argument of the `framesent` and
+// `framereceived` events of the WebSocket, next to the payload.
+type WebSocketFrame struct {
+	// Payload of the frame, the text for text frames.
+	Payload []byte
+	// Opcode of the frame, 1 for text and 2 for binary frames.
+	Opcode int
+}
+
+// IsBinary returns whether the frame is a binary frame.
+func (f *WebSocketFrame) IsBinary() bool {
+	return f.Opcode == 2
+}
+
+// Text returns the payload of a text frame as string.
+func (f *WebSocketFrame) Text() string {
+	return string(f.Payload)
+}
+
 func (ws *webSocketImpl) onFrameSent(opcode float64, data string) {
-	if opcode == 2 {
-		payload, err := base64.StdEncoding.DecodeString(data)
-		if err != nil {
-			log.Printf("could not decode WebSocket.onFrameSent payload: %v", err)
-			return
-		}
-		ws.Emit("framesent", payload)
-	} else {
-		ws.Emit("framesent", []byte(data))
+	if frame := decodeWebSocketFrame(opcode, data); frame != nil {
+		ws.Emit("framesent", frame.Payload, frame)
 	}
 }
 
 func (ws *webSocketImpl) onFrameReceived(opcode float64, data string) {
-	if opcode == 2 {
+	if frame := decodeWebSocketFrame(opcode, data); frame != nil {
+		ws.Emit("framereceived", frame.Payload, frame)
+	}
+}
+
+// decodeWebSocketFrame returns the frame of a frame event, binary payloads
+// are base64 encoded by the driver.
+func decodeWebSocketFrame(opcode float64, data string) *WebSocketFrame {
+	frame := &WebSocketFrame{Opcode: int(opcode), Payload: []byte(data)}
+	if frame.IsBinary() {
 		payload, err := base64.StdEncoding.DecodeString(data)
 		if err != nil {
-			log.Printf("could not decode WebSocket.onFrameReceived payload: %v", err)
-			return
+			log.Printf("could not decode WebSocket frame payload: %v", err)
+			return nil
 		}
-		ws.Emit("framereceived", payload)
-	} else {
-		ws.Emit("framereceived", []byte(data))
+		frame.Payload = payload
 	}
+	return frame
 }
 
 func (ws *webSocketImpl) WaitForEvent(event string, predicate ...interface{}) interface{} {
@@ -0,0 +1,570 @@
+package playwright
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// webSocketRouteBinding is the binding the pages report the events of their
+// WebSockets through.
+const webSocketRouteBinding = "__playwright_websocket_route__"
+
+// webSocketRouteScript replaces the WebSocket class of the page. Sockets
+// which are not routed connect to the server natively, the others get driven
+// by the WebSocketRoute in Go. Events sent to Go carry a sequence number, the
+// binding calls get handled concurrently.
+const webSocketRouteScript = `(() => {
+  if (window.__playwrightWebSocketDispatch)
+    return;
+  const NativeWebSocket = window.WebSocket;
+  const call = message => {
+    const binding = window['` + webSocketRouteBinding + `'];
+    return binding ? binding(message) : Promise.resolve(false);
+  };
+  const sockets = new Map();
+  let lastId = 0;
+  const toBase64 = buffer => {
+    const bytes = new Uint8Array(buffer);
+    let binary = '';
+    for (let i = 0; i < bytes.length; i++)
+      binary += String.fromCharCode(bytes[i]);
+    return btoa(binary);
+  };
+  const encode = async data => {
+    if (typeof data === 'string')
+      return { data, isBase64: false };
+    if (data instanceof Blob)
+      return { data: toBase64(await data.arrayBuffer()), isBase64: true };
+    if (ArrayBuffer.isView(data))
+      return { data: toBase64(data.buffer.slice(data.byteOffset, data.byteOffset + data.byteLength)), isBase64: true };
+    return { data: toBase64(data), isBase64: true };
+  };
+  const decode = (message, binaryType) => {
+    if (!message.isBase64)
+      return message.data;
+    const bytes = Uint8Array.from(atob(message.data), c => c.charCodeAt(0));
+    return binaryType === 'arraybuffer' ? bytes.buffer : new Blob([bytes]);
+  };
+
+  class WebSocket extends EventTarget {
+    constructor(url, protocols) {
+      super();
+      this.url = new URL(url, location.href).href;
+      this.protocol = '';
+      this.extensions = '';
+      this.bufferedAmount = 0;
+      this.readyState = WebSocket.CONNECTING;
+      this.onopen = null;
+      this.onmessage = null;
+      this.onerror = null;
+      this.onclose = null;
+      this._binaryType = 'blob';
+      this._protocols = protocols;
+      this._id = ++lastId;
+      this._seq = 0;
+      sockets.set(this._id, this);
+      call({ id: this._id, type: 'connect', url: this.url }).then(routed => {
+        if (!routed)
+          this._passthrough();
+      }, () => this._passthrough());
+    }
+
+    get binaryType() {
+      return this._binaryType;
+    }
+
+    set binaryType(value) {
+      this._binaryType = value;
+      if (this._native)
+        this._native.binaryType = value;
+    }
+
+    send(data) {
+      if (this.readyState === WebSocket.CONNECTING)
+        throw new DOMException("Failed to execute 'send' on 'WebSocket': Still in CONNECTING state.", 'InvalidStateError');
+      if (this.readyState !== WebSocket.OPEN)
+        return;
+      if (this._native)
+        return this._native.send(data);
+      this._notify(encode(data).then(message => ({ type: 'message', ...message })));
+    }
+
+    close(code, reason) {
+      if (this.readyState >= WebSocket.CLOSING)
+        return;
+      if (this._native)
+        return this._native.close(code, reason);
+      this._notify(Promise.resolve({ type: 'close', code: code === undefined ? 1005 : code, reason: reason || '' }));
+      this._closed(code === undefined ? 1005 : code, reason || '', true);
+    }
+
+    _emit(type, init) {
+      let event;
+      if (type === 'message')
+        event = new MessageEvent('message', init);
+      else if (type === 'close')
+        event = new CloseEvent('close', init);
+      else
+        event = new Event(type);
+      const handler = this['on' + type];
+      if (handler)
+        handler.call(this, event);
+      this.dispatchEvent(event);
+    }
+
+    _notify(message) {
+      const seq = ++this._seq;
+      message.then(message => call({ id: this._id, seq, ...message }));
+    }
+
+    _closed(code, reason, wasClean) {
+      if (this.readyState === WebSocket.CLOSED)
+        return;
+      this.readyState = WebSocket.CLOSED;
+      if (!this._server)
+        sockets.delete(this._id);
+      setTimeout(() => this._emit('close', { code, reason, wasClean }), 0);
+    }
+
+    _passthrough() {
+      sockets.delete(this._id);
+      const native = new NativeWebSocket(this.url, this._protocols);
+      native.binaryType = this._binaryType;
+      this._native = native;
+      native.onopen = () => {
+        this.readyState = WebSocket.OPEN;
+        this.protocol = native.protocol;
+        this.extensions = native.extensions;
+        this._emit('open');
+      };
+      native.onmessage = event => this._emit('message', { data: event.data, origin: event.origin });
+      native.onerror = () => this._emit('error');
+      native.onclose = event => this._closed(event.code, event.reason, event.wasClean);
+    }
+
+    _connectServer() {
+      const server = new NativeWebSocket(this.url, this._protocols);
+      server.binaryType = 'arraybuffer';
+      this._server = server;
+      server.onopen = () => this._notify(Promise.resolve({ type: 'serverOpen' }));
+      server.onmessage = event => this._notify(encode(event.data).then(message => ({ type: 'serverMessage', ...message })));
+      server.onclose = event => {
+        this._notify(Promise.resolve({ type: 'serverClose', code: event.code, reason: event.reason }));
+        if (this.readyState === WebSocket.CLOSED)
+          sockets.delete(this._id);
+      };
+    }
+  }
+  for (const [name, value] of [['CONNECTING', 0], ['OPEN', 1], ['CLOSING', 2], ['CLOSED', 3]]) {
+    Object.defineProperty(WebSocket, name, { value });
+    Object.defineProperty(WebSocket.prototype, name, { value });
+  }
+
+  window.__playwrightWebSocketDispatch = (id, message) => {
+    const ws = sockets.get(id);
+    if (!ws)
+      return;
+    switch (message.type) {
+      case 'open':
+        if (ws.readyState === WebSocket.CONNECTING) {
+          ws.readyState = WebSocket.OPEN;
+          ws._emit('open');
+        }
+        break;
+      case 'message':
+        if (ws.readyState === WebSocket.OPEN)
+          ws._emit('message', { data: decode(message, ws.binaryType), origin: new URL(ws.url).origin });
+        break;
+      case 'close':
+        ws._closed(message.code, message.reason, true);
+        break;
+      case 'connectServer':
+        ws._connectServer();
+        break;
+      case 'serverSend':
+        if (ws._server && ws._server.readyState === NativeWebSocket.OPEN)
+          ws._server.send(decode(message, 'arraybuffer'));
+        break;
+      case 'serverClose':
+        if (ws._server)
+          message.code === 1005 ? ws._server.close() : ws._server.close(message.code, message.reason);
+        break;
+    }
+  };
+  window.WebSocket = WebSocket;
+})();`
+
+type webSocketRouteHandler struct {
+	matcher *urlMatcher
+	handler func(WebSocketRoute)
+}
+
+type webSocketKey struct {
+	frame *frameImpl
+	id    int
+}
+
+// webSocketRouter keeps the WebSocket routes of a browser context and the
+// routed sockets of its pages.
+type webSocketRouter struct {
+	sync.Mutex
+	installed bool
+	routes    []*webSocketRouteHandler
+	sockets   map[webSocketKey]*webSocketRouteImpl
+}
+
+func (b *browserContextImpl) RouteWebSocket(url interface{}, handler func(WebSocketRoute)) error {
+	b.webSocketRouter.Lock()
+	b.webSocketRouter.routes = append(b.webSocketRouter.routes, &webSocketRouteHandler{
+		matcher: newURLMatcher(url),
+		handler: handler,
+	})
+	b.webSocketRouter.Unlock()
+	return b.installWebSocketRouting()
+}
+
+func (p *pageImpl) RouteWebSocket(url interface{}, handler func(WebSocketRoute)) error {
+	p.Lock()
+	p.webSocketRoutes = append(p.webSocketRoutes, &webSocketRouteHandler{
+		matcher: newURLMatcher(url),
+		handler: handler,
+	})
+	p.Unlock()
+	return p.browserContext.installWebSocketRouting()
+}
+
+// installWebSocketRouting exposes the binding and adds the script which
+// replaces the WebSocket class to the context once. The script applies to
+// documents which get created afterwards.
+func (b *browserContextImpl) installWebSocketRouting() error {
+	b.webSocketRouter.Lock()
+	defer b.webSocketRouter.Unlock()
+	if b.webSocketRouter.installed {
+		return nil
+	}
+	if err := b.ExposeBinding(webSocketRouteBinding, b.onWebSocketRouteBinding); err != nil {
+		return fmt.Errorf("could not install WebSocket routing: %w", err)
+	}
+	if err := b.AddInitScript(BrowserContextAddInitScriptOptions{
+		Script: String(webSocketRouteScript),
+	}); err != nil {
+		return fmt.Errorf("could not install WebSocket routing: %w", err)
+	}
+	b.webSocketRouter.installed = true
+	b.webSocketRouter.sockets = make(map[webSocketKey]*webSocketRouteImpl)
+	return nil
+}
+
+// findWebSocketRoute returns the handler for url, routes of the page take
+// precedence over the ones of the context.
+func (b *browserContextImpl) findWebSocketRoute(page *pageImpl, url string) func(WebSocketRoute) {
+	page.Lock()
+	pageRoutes := page.webSocketRoutes
+	page.Unlock()
+	b.webSocketRouter.Lock()
+	contextRoutes := b.webSocketRouter.routes
+	b.webSocketRouter.Unlock()
+	for _, routes := range [][]*webSocketRouteHandler{pageRoutes, contextRoutes} {
+		for _, route := range routes {
+			if route.matcher.Matches(url) {
+				return route.handler
+			}
+		}
+	}
+	return nil
+}
+
+func (b *browserContextImpl) onWebSocketRouteBinding(source *BindingSource, args ...interface{}) interface{} {
+	if len(args) != 1 {
+		return false
+	}
+	message, ok := args[0].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	frame := source.Frame.(*frameImpl)
+	key := webSocketKey{frame: frame, id: webSocketInt(message["id"])}
+	if message["type"] == "connect" {
+		url, _ := message["url"].(string)
+		handler := b.findWebSocketRoute(source.Page.(*pageImpl), url)
+		if handler == nil {
+			return false
+		}
+		route := newWebSocketRoute(frame, key.id, url)
+		b.webSocketRouter.Lock()
+		b.webSocketRouter.sockets[key] = route
+		b.webSocketRouter.Unlock()
+		go func() {
+			handler(route)
+			route.handlerDone()
+		}()
+		return true
+	}
+	b.webSocketRouter.Lock()
+	route := b.webSocketRouter.sockets[key]
+	b.webSocketRouter.Unlock()
+	if route != nil {
+		route.receive(webSocketInt(message["seq"]), message)
+	}
+	return nil
+}
+
+// webSocketRouteImpl is one side of a routed WebSocket, either the page or,
+// after ConnectToServer(), the server.
+type webSocketRouteImpl struct {
+	sync.Mutex
+	frame     *frameImpl
+	id        int
+	url       string
+	isServer  bool
+	peer      *webSocketRouteImpl
+	onMessage func(message interface{})
+	onClose   func(code int, reason string)
+	// the state of the socket is kept by the page side
+	connected    bool
+	serverOpened bool
+	opened       bool
+	handled      bool
+	// events arrive concurrently, they get delivered in the order of their
+	// sequence numbers once the handler returned
+	deliveryMu sync.Mutex
+	nextSeq    int
+	pending    map[int]map[string]interface{}
+}
+
+func newWebSocketRoute(frame *frameImpl, id int, url string) *webSocketRouteImpl {
+	route := &webSocketRouteImpl{
+		frame:   frame,
+		id:      id,
+		url:     url,
+		nextSeq: 1,
+		pending: make(map[int]map[string]interface{}),
+	}
+	route.peer = &webSocketRouteImpl{
+		frame:    frame,
+		id:       id,
+		url:      url,
+		isServer: true,
+		peer:     route,
+	}
+	return route
+}
+
+func (r *webSocketRouteImpl) URL() string {
+	return r.url
+}
+
+func (r *webSocketRouteImpl) Send(message interface{}) error {
+	encoded, err := encodeWebSocketMessage(message)
+	if err != nil {
+		return err
+	}
+	encoded["type"] = "message"
+	if r.isServer {
+		encoded["type"] = "serverSend"
+	}
+	return r.dispatch(encoded)
+}
+
+func (r *webSocketRouteImpl) Close(options ...WebSocketRouteCloseOptions) error {
+	code := 1000
+	reason := ""
+	if len(options) == 1 {
+		if options[0].Code != nil {
+			code = *options[0].Code
+		}
+		if options[0].Reason != nil {
+			reason = *options[0].Reason
+		}
+	}
+	messageType := "close"
+	if r.isServer {
+		messageType = "serverClose"
+	}
+	return r.dispatch(map[string]interface{}{
+		"type":   messageType,
+		"code":   code,
+		"reason": reason,
+	})
+}
+
+func (r *webSocketRouteImpl) OnMessage(handler func(message interface{})) {
+	r.Lock()
+	defer r.Unlock()
+	r.onMessage = handler
+}
+
+func (r *webSocketRouteImpl) OnClose(handler func(code int, reason string)) {
+	r.Lock()
+	defer r.Unlock()
+	r.onClose = handler
+}
+
+func (r *webSocketRouteImpl) ConnectToServer() (WebSocketRoute, error) {
+	if r.isServer {
+		return nil, errors.New("ConnectToServer must be called on the page side of the route")
+	}
+	r.Lock()
+	connected := r.connected
+	r.connected = true
+	r.Unlock()
+	if connected {
+		return r.peer, nil
+	}
+	if err := r.dispatch(map[string]interface{}{"type": "connectServer"}); err != nil {
+		return nil, err
+	}
+	return r.peer, nil
+}
+
+func (r *webSocketRouteImpl) dispatch(message map[string]interface{}) error {
+	_, err := r.frame.Evaluate(`([id, message]) => window.__playwrightWebSocketDispatch && window.__playwrightWebSocketDispatch(id, message)`, []interface{}{r.id, message})
+	if err != nil {
+		return fmt.Errorf("could not dispatch WebSocket message: %w", err)
+	}
+	return nil
+}
+
+// handlerDone opens the socket of the page once the handler returned, unless
+// it waits for the server, and delivers the events which arrived meanwhile.
+func (r *webSocketRouteImpl) handlerDone() {
+	r.Lock()
+	r.handled = true
+	r.Unlock()
+	r.maybeOpen()
+	r.deliver()
+}
+
+func (r *webSocketRouteImpl) maybeOpen() {
+	r.Lock()
+	open := r.handled && !r.opened && (!r.connected || r.serverOpened)
+	if open {
+		r.opened = true
+	}
+	r.Unlock()
+	if open {
+		if err := r.dispatch(map[string]interface{}{"type": "open"}); err != nil {
+			log.Printf("could not open routed WebSocket: %v", err)
+		}
+	}
+}
+
+func (r *webSocketRouteImpl) receive(seq int, message map[string]interface{}) {
+	r.deliveryMu.Lock()
+	r.pending[seq] = message
+	r.deliveryMu.Unlock()
+	r.deliver()
+}
+
+func (r *webSocketRouteImpl) deliver() {
+	r.deliveryMu.Lock()
+	defer r.deliveryMu.Unlock()
+	r.Lock()
+	handled := r.handled
+	r.Unlock()
+	if !handled {
+		return
+	}
+	for {
+		message, ok := r.pending[r.nextSeq]
+		if !ok {
+			return
+		}
+		delete(r.pending, r.nextSeq)
+		r.nextSeq++
+		if err := r.handle(message); err != nil {
+			log.Printf("could not handle WebSocket event: %v", err)
+		}
+	}
+}
+
+// handle runs the handler for an event of the page or the server, without a
+// handler the event gets forwarded to the other side if the server is
+// connected.
+func (r *webSocketRouteImpl) handle(message map[string]interface{}) error {
+	server := r.peer
+	r.Lock()
+	connected := r.connected
+	r.Unlock()
+	switch message["type"] {
+	case "message":
+		if handler := r.messageHandler(); handler != nil {
+			handler(decodeWebSocketMessage(message))
+		} else if connected {
+			return server.Send(decodeWebSocketMessage(message))
+		}
+	case "close":
+		code, reason := webSocketInt(message["code"]), fmt.Sprint(message["reason"])
+		if handler := r.closeHandler(); handler != nil {
+			handler(code, reason)
+		} else if connected {
+			return server.dispatch(map[string]interface{}{"type": "serverClose", "code": code, "reason": reason})
+		}
+	case "serverOpen":
+		r.Lock()
+		r.serverOpened = true
+		r.Unlock()
+		r.maybeOpen()
+	case "serverMessage":
+		if handler := server.messageHandler(); handler != nil {
+			handler(decodeWebSocketMessage(message))
+		} else {
+			return r.Send(decodeWebSocketMessage(message))
+		}
+	case "serverClose":
+		code, reason := webSocketInt(message["code"]), fmt.Sprint(message["reason"])
+		if handler := server.closeHandler(); handler != nil {
+			handler(code, reason)
+		} else {
+			return r.Close(WebSocketRouteCloseOptions{Code: Int(code), Reason: String(reason)})
+		}
+	}
+	return nil
+}
+
+func (r *webSocketRouteImpl) messageHandler() func(message interface{}) {
+	r.Lock()
+	defer r.Unlock()
+	return r.onMessage
+}
+
+func (r *webSocketRouteImpl) closeHandler() func(code int, reason string) {
+	r.Lock()
+	defer r.Unlock()
+	return r.onClose
+}
+
+// encodeWebSocketMessage converts a string or []byte message for the page.
+func encodeWebSocketMessage(message interface{}) (map[string]interface{}, error) {
+	switch v := message.(type) {
+	case string:
+		return map[string]interface{}{"data": v, "isBase64": false}, nil
+	case []byte:
+		return map[string]interface{}{"data": base64.StdEncoding.EncodeToString(v), "isBase64": true}, nil
+	}
+	return nil, fmt.Errorf("WebSocket message must be a string or []byte, got %T", message)
+}
+
+// decodeWebSocketMessage returns the data of a message from the page, a
+// string for text messages and []byte for binary ones.
+func decodeWebSocketMessage(message map[string]interface{}) interface{} {
+	data, _ := message["data"].(string)
+	if isBase64, _ := message["isBase64"].(bool); isBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return []byte{}
+		}
+		return decoded
+	}
+	return data
+}
+
+// webSocketInt returns a number of a message from the page, which arrives as
+// float64.
+func webSocketInt(v interface{}) int {
+	number, _ := v.(float64)
+	return int(number)
+}
@@ -0,0 +1,44 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketMessageEncoding(t *testing.T) {
+	text, err := encodeWebSocketMessage("hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", decodeWebSocketMessage(text))
+
+	binary, err := encodeWebSocketMessage([]byte{0, 1, 2})
+	require.NoError(t, err)
+	require.Equal(t, true, binary["isBase64"])
+	require.Equal(t, []byte{0, 1, 2}, decodeWebSocketMessage(binary))
+
+	_, err = encodeWebSocketMessage(42)
+	require.EqualError(t, err, "WebSocket message must be a string or []byte, got int")
+}
+
+func TestWebSocketRouteDeliversInOrder(t *testing.T) {
+	route := newWebSocketRoute(nil, 1, "ws://localhost/ws")
+	var messages []interface{}
+	route.OnMessage(func(message interface{}) {
+		messages = append(messages, message)
+	})
+	route.receive(2, map[string]interface{}{"type": "message", "data": "second"})
+	route.receive(1, map[string]interface{}{"type": "message", "data": "first"})
+	require.Empty(t, messages, "messages get held back until the handler returned")
+
+	route.Lock()
+	route.handled = true
+	route.opened = true
+	route.Unlock()
+	route.deliver()
+	require.Equal(t, []interface{}{"first", "second"}, messages)
+
+	route.receive(4, map[string]interface{}{"type": "message", "data": "fourth"})
+	require.Len(t, messages, 2)
+	route.receive(3, map[string]interface{}{"type": "message", "data": "third"})
+	require.Equal(t, []interface{}{"first", "second", "third", "fourth"}, messages)
+}
@@ -0,0 +1,30 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketFrameEvents(t *testing.T) {
+	ws := &webSocketImpl{}
+	ws.initEventEmitter()
+	var payloads [][]byte
+	var frames []*WebSocketFrame
+	ws.On("framereceived", func(payload []byte) {
+		payloads = append(payloads, payload)
+	})
+	ws.On("framereceived", func(payload []byte, frame *WebSocketFrame) {
+		frames = append(frames, frame)
+	})
+	ws.onFrameReceived(1, "hello")
+	ws.onFrameReceived(2, "BAI=")
+	ws.onFrameReceived(2, "not base64")
+
+	require.Equal(t, [][]byte{[]byte("hello"), {4, 2}}, payloads)
+	require.Len(t, frames, 2)
+	require.False(t, frames[0].IsBinary())
+	require.Equal(t, "hello", frames[0].Text())
+	require.True(t, frames[1].IsBinary())
+	require.Equal(t, []byte{4, 2}, frames[1].Payload)
+}
@@ -53,7 +53,7 @@ func (w *workerImpl) EvaluateHandle(expression string, options ...interface{}) (
 	if err != nil {
 		return nil, err
 	}
-	return fromChannel(result).(*jsHandleImpl), nil
+	return fromChannel(result).(JSHandle), nil
 }
 
 func (w *workerImpl) onClose() {